@@ -10,8 +10,12 @@
 package aspen
 
 import (
+	"context"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/synnaxlabs/aspen/internal/cluster"
+	"github.com/synnaxlabs/aspen/internal/kv"
 	"github.com/synnaxlabs/aspen/internal/node"
 	"github.com/synnaxlabs/aspen/transport"
 	"github.com/synnaxlabs/x/address"
@@ -19,6 +23,7 @@ import (
 	xkv "github.com/synnaxlabs/x/kv"
 	"github.com/synnaxlabs/x/observe"
 	storex "github.com/synnaxlabs/x/store"
+	"github.com/synnaxlabs/x/version"
 )
 
 // Cluster represents a group of nodes that can exchange their state with each other.
@@ -39,6 +44,23 @@ type Cluster interface {
 	storex.Reader[cluster.State]
 }
 
+// Admin provides operations for diagnosing and repairing the state of the cluster.
+type Admin interface {
+	// LastSeen returns the last time the host observed the heartbeat of the node with
+	// the given key advance. Returns false if the host has not observed the node's
+	// heartbeat advance since it started.
+	LastSeen(key node.Key) (time.Time, bool)
+	// GossipNow immediately exchanges cluster state with every healthy peer instead
+	// of waiting for the next gossip interval.
+	GossipNow(ctx context.Context) error
+	// Evict marks the node with the given key as having left the cluster. Evict is
+	// intended for removing nodes that are permanently dead.
+	Evict(ctx context.Context, key node.Key) error
+	// LeaseCounts returns the number of keys in the key-value store held by each
+	// leaseholder in the cluster.
+	LeaseCounts(ctx context.Context) (map[node.Key]int, error)
+}
+
 // Resolver is used to resolve a reachable address for a node in the cluster.
 type Resolver interface {
 	// Resolve resolves the address of a node with the given key.
@@ -77,14 +99,61 @@ const (
 	Suspect      = node.StateSuspect
 )
 
-var NodeNotfound = cluster.NodeNotFound
+var (
+	NodeNotfound       = cluster.NodeNotFound
+	ErrCannotEvictHost = cluster.ErrCannotEvictHost
+)
 
 type DB struct {
 	Cluster *cluster.Cluster
 	xkv.DB
+	kv     *kv.DB
 	closer xio.MultiCloser
 }
 
+var (
+	_ xkv.Versioned = (*DB)(nil)
+	_ Admin         = (*DB)(nil)
+)
+
+// GetVersioned implements xkv.Versioned, returning the value and version of the
+// key as seen by its leaseholder.
+func (db *DB) GetVersioned(
+	ctx context.Context,
+	key []byte,
+	opts ...any,
+) ([]byte, version.Counter, error) {
+	return db.kv.GetVersioned(ctx, key, opts...)
+}
+
+// CompareAndSet implements xkv.Versioned, setting the value of the key on its
+// leaseholder if and only if the key is at the expected version.
+func (db *DB) CompareAndSet(
+	ctx context.Context,
+	key []byte,
+	expected version.Counter,
+	value []byte,
+	opts ...any,
+) error {
+	return db.kv.CompareAndSet(ctx, key, expected, value, opts...)
+}
+
+// LastSeen implements Admin.
+func (db *DB) LastSeen(key node.Key) (time.Time, bool) { return db.Cluster.LastSeen(key) }
+
+// GossipNow implements Admin.
+func (db *DB) GossipNow(ctx context.Context) error { return db.Cluster.GossipNow(ctx) }
+
+// Evict implements Admin.
+func (db *DB) Evict(ctx context.Context, key node.Key) error {
+	return db.Cluster.Evict(ctx, key)
+}
+
+// LeaseCounts implements Admin.
+func (db *DB) LeaseCounts(ctx context.Context) (map[node.Key]int, error) {
+	return db.kv.LeaseCounts(ctx)
+}
+
 // Close implements xkv.DB, shutting down the key-value store, cluster and transport.
 // Close is not safe to call concurrently with any other DB method. All DB methods
 // called after Close will panic.
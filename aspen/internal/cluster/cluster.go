@@ -182,6 +182,49 @@ func (c *Cluster) Resolve(key node.Key) (address.Address, error) {
 	return n.Address, err
 }
 
+// LastSeen returns the last time the host observed the heartbeat of the node with
+// the given key advance. Returns false if the host has not observed the node's
+// heartbeat advance since it started.
+func (c *Cluster) LastSeen(key node.Key) (time.Time, bool) { return c.gossip.LastSeen(key) }
+
+// GossipNow immediately exchanges state with every healthy peer in the Cluster
+// instead of waiting for the next gossip interval. This is useful for quickly
+// re-converging the view of the Cluster after a network partition has healed.
+// Returns a combined error for all peers that could not be reached.
+func (c *Cluster) GossipNow(ctx context.Context) error {
+	snap := c.Store.CopyState()
+	var err error
+	for _, peer := range snap.Nodes.WhereState(node.StateHealthy).WhereNot(snap.HostKey) {
+		if gErr := c.gossip.GossipOnceWith(ctx, peer.Address); gErr != nil {
+			err = errors.Combine(err, errors.Wrapf(gErr, "failed to gossip with node %d", peer.Key))
+		}
+	}
+	return err
+}
+
+// ErrCannotEvictHost is returned when attempting to evict the host node from the
+// Cluster.
+var ErrCannotEvictHost = errors.New("[cluster] - cannot evict the host node")
+
+// Evict marks the node with the given key as having left the Cluster and spreads
+// the change to the rest of the Cluster through gossip. Evict is intended for
+// removing nodes that are permanently dead. If the evicted node is still running,
+// its next heartbeat will supersede the eviction and the node will rejoin the
+// Cluster.
+func (c *Cluster) Evict(ctx context.Context, key node.Key) error {
+	if key == c.HostKey() {
+		return ErrCannotEvictHost
+	}
+	n, err := c.Node(key)
+	if err != nil {
+		return err
+	}
+	n.State = node.StateLeft
+	n.Heartbeat = n.Heartbeat.Increment()
+	c.SetNode(ctx, n)
+	return nil
+}
+
 func (c *Cluster) Close() error { return c.shutdown.Close() }
 
 func (c *Cluster) gossipInitialState(ctx context.Context) error {
@@ -81,4 +81,47 @@ var _ = Describe("Cluster", func() {
 
 	})
 
+	Describe("Evict", func() {
+
+		It("Should mark a node as having left the cluster on every member", func() {
+			c1 := MustSucceed(builder.New(clusterCtx, cluster.Config{}))
+			c2 := MustSucceed(builder.New(clusterCtx, cluster.Config{}))
+			c3 := MustSucceed(builder.New(clusterCtx, cluster.Config{}))
+			Eventually(func() int { return len(c3.Nodes()) }).Should(Equal(3))
+			Expect(c2.Close()).To(Succeed())
+			Expect(c1.Evict(ctx, c2.HostKey())).To(Succeed())
+			Eventually(func(g Gomega) {
+				n, err := c3.Node(c2.HostKey())
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(n.State).To(Equal(node.StateLeft))
+			}).Should(Succeed())
+		})
+
+		It("Should not allow the host to evict itself", func() {
+			c1 := MustSucceed(builder.New(clusterCtx, cluster.Config{}))
+			Expect(c1.Evict(ctx, c1.HostKey())).To(HaveOccurredAs(cluster.ErrCannotEvictHost))
+		})
+
+		It("Should return an error when the node does not exist", func() {
+			c1 := MustSucceed(builder.New(clusterCtx, cluster.Config{}))
+			Expect(c1.Evict(ctx, 42)).To(HaveOccurredAs(cluster.NodeNotFound))
+		})
+
+	})
+
+	Describe("GossipNow", func() {
+
+		It("Should exchange state with all peers and record their heartbeats", func() {
+			c1 := MustSucceed(builder.New(clusterCtx, cluster.Config{}))
+			c2 := MustSucceed(builder.New(clusterCtx, cluster.Config{}))
+			Eventually(func() int { return len(c1.Nodes()) }).Should(Equal(2))
+			Expect(c1.GossipNow(ctx)).To(Succeed())
+			Eventually(func() bool {
+				_, ok := c1.LastSeen(c2.HostKey())
+				return ok
+			}).Should(BeTrue())
+		})
+
+	})
+
 })
@@ -18,10 +18,19 @@ import (
 	"github.com/synnaxlabs/x/rand"
 	"github.com/synnaxlabs/x/signal"
 	"go.uber.org/zap"
+	"sync"
 	"time"
 )
 
-type Gossip struct{ Config }
+type Gossip struct {
+	Config
+	mu struct {
+		sync.RWMutex
+		// lastSeen tracks the last time the heartbeat of each node was observed
+		// to advance.
+		lastSeen map[node.Key]time.Time
+	}
+}
 
 // New opens a new Gossip that will spread cluster state to and from the given store.
 func New(cfgs ...Config) (*Gossip, error) {
@@ -30,6 +39,7 @@ func New(cfgs ...Config) (*Gossip, error) {
 		return nil, err
 	}
 	g := &Gossip{Config: cfg}
+	g.mu.lastSeen = make(map[node.Key]time.Time)
 	g.TransportServer.BindHandler(g.process)
 	return g, nil
 }
@@ -80,6 +90,33 @@ func (g *Gossip) incrementHostHeartbeat(ctx context.Context) {
 	host := g.Store.GetHost()
 	host.Heartbeat = host.Heartbeat.Increment()
 	g.Store.SetNode(ctx, host)
+	g.mu.Lock()
+	g.mu.lastSeen[host.Key] = time.Now()
+	g.mu.Unlock()
+}
+
+// LastSeen returns the last time the heartbeat of the node with the given key was
+// observed to advance. Returns false if the heartbeat has not advanced since the
+// Gossip was opened.
+func (g *Gossip) LastSeen(key node.Key) (time.Time, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	t, ok := g.mu.lastSeen[key]
+	return t, ok
+}
+
+// merge merges the given nodes into the store, recording which node heartbeats
+// advanced as a result.
+func (g *Gossip) merge(ctx context.Context, prev, nodes node.Group) {
+	g.Store.Merge(ctx, nodes)
+	now := time.Now()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for key, n := range nodes {
+		if p, ok := prev[key]; !ok || n.Heartbeat.OlderThan(p.Heartbeat) {
+			g.mu.lastSeen[key] = now
+		}
+	}
 }
 
 func (g *Gossip) process(ctx context.Context, msg Message) (Message, error) {
@@ -129,7 +166,7 @@ func (g *Gossip) sync(sync Message) (ack Message) {
 func (g *Gossip) ack(ctx context.Context, ack Message) (ack2 Message) {
 	// Take a snapshot before we merge the peer's nodes.
 	snap := g.Store.CopyState()
-	g.Store.Merge(ctx, ack.Nodes)
+	g.merge(ctx, snap.Nodes, ack.Nodes)
 	ack2 = Message{Nodes: make(node.Group)}
 	for _, dig := range ack.Digests {
 		// If we have the node, and our version is newer, return it to the
@@ -141,7 +178,9 @@ func (g *Gossip) ack(ctx context.Context, ack Message) (ack2 Message) {
 	return ack2
 }
 
-func (g *Gossip) ack2(ctx context.Context, ack2 Message) { g.Store.Merge(ctx, ack2.Nodes) }
+func (g *Gossip) ack2(ctx context.Context, ack2 Message) {
+	g.merge(ctx, g.Store.CopyState().Nodes, ack2.Nodes)
+}
 
 func RandomPeer(nodes node.Group, host node.Key) node.Node {
 	return rand.MapValue(nodes.WhereState(node.StateHealthy).WhereNot(host))
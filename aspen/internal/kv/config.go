@@ -43,6 +43,14 @@ type Config struct {
 	// LeaseTransportServer is used to send leaseAlloc NewStreamer between nodes.
 	// [Required]
 	LeaseTransportServer LeaseTransportServer
+	// ReadTransportClient is used to forward consistent reads to the leaseholder of
+	// a key.
+	// [Required]
+	ReadTransportClient ReadTransportClient
+	// ReadTransportServer is used to serve consistent reads for keys leased by the
+	// host.
+	// [Required]
+	ReadTransportServer ReadTransportServer
 	// RecoveryTransportClient is used to send recovery requests to nodes.
 	// [Required]
 	RecoveryTransportClient RecoveryTransportClient
@@ -70,6 +78,8 @@ func (cfg Config) Override(other Config) Config {
 	cfg.FeedbackTransportServer = override.Nil(cfg.FeedbackTransportServer, other.FeedbackTransportServer)
 	cfg.LeaseTransportServer = override.Nil(cfg.LeaseTransportServer, other.LeaseTransportServer)
 	cfg.LeaseTransportClient = override.Nil(cfg.LeaseTransportClient, other.LeaseTransportClient)
+	cfg.ReadTransportClient = override.Nil(cfg.ReadTransportClient, other.ReadTransportClient)
+	cfg.ReadTransportServer = override.Nil(cfg.ReadTransportServer, other.ReadTransportServer)
 	cfg.RecoveryTransportClient = override.Nil(cfg.RecoveryTransportClient, other.RecoveryTransportClient)
 	cfg.RecoveryTransportServer = override.Nil(cfg.RecoveryTransportServer, other.RecoveryTransportServer)
 	cfg.Engine = override.Nil(cfg.Engine, other.Engine)
@@ -89,6 +99,8 @@ func (cfg Config) Validate() error {
 	validate.NotNil(v, "FeedbackTransportServer", cfg.FeedbackTransportServer)
 	validate.NotNil(v, "LeaseTransportClient", cfg.LeaseTransportServer)
 	validate.NotNil(v, "LeaseTransportServer", cfg.LeaseTransportClient)
+	validate.NotNil(v, "ReadTransportClient", cfg.ReadTransportClient)
+	validate.NotNil(v, "ReadTransportServer", cfg.ReadTransportServer)
 	validate.NotNil(v, "RecoveryTransportClient", cfg.RecoveryTransportClient)
 	validate.NotNil(v, "RecoveryTransportServer", cfg.RecoveryTransportServer)
 	validate.NotNil(v, "Engine", cfg.Engine)
@@ -106,6 +118,8 @@ func (cfg Config) Report() alamos.Report {
 	report["feedback_transport_server"] = cfg.FeedbackTransportServer.Report()
 	report["lease_transport_client"] = cfg.LeaseTransportClient.Report()
 	report["lease_transport_server"] = cfg.LeaseTransportServer.Report()
+	report["read_transport_client"] = cfg.ReadTransportClient.Report()
+	report["read_transport_server"] = cfg.ReadTransportServer.Report()
 	return report
 }
 
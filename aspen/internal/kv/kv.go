@@ -252,6 +252,7 @@ func Open(ctx context.Context, cfgs ...Config) (*DB, error) {
 		Capacity:     1,
 	}.MustRoute(pipe)
 	newRecoveryServer(cfg)
+	newReadServer(cfg)
 	pipe.Flow(sCtx)
 	return db_, runRecovery(ctx, cfg)
 }
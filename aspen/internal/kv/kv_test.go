@@ -211,6 +211,76 @@ var _ = Describe("txn", func() {
 
 	})
 
+	Describe("Versioned", func() {
+		Describe("Gateway Leaseholder", func() {
+			It("Should set a key that does not exist when the expected version is zero", func() {
+				kv := MustSucceed(builder.New(ctx, kv.Config{}, cluster.Config{}))
+				Expect(kv.CompareAndSet(ctx, []byte("key"), 0, []byte("value"))).To(Succeed())
+				v, ver := MustSucceed2(kv.GetVersioned(ctx, []byte("key")))
+				Expect(v).To(Equal([]byte("value")))
+				Expect(ver).ToNot(BeZero())
+			})
+
+			It("Should reject a write against a stale version", func() {
+				kv := MustSucceed(builder.New(ctx, kv.Config{}, cluster.Config{}))
+				Expect(kv.Set(ctx, []byte("key"), []byte("value"))).To(Succeed())
+				_, ver := MustSucceed2(kv.GetVersioned(ctx, []byte("key")))
+				Expect(kv.CompareAndSet(ctx, []byte("key"), ver, []byte("value2"))).To(Succeed())
+				Expect(kv.CompareAndSet(ctx, []byte("key"), ver, []byte("value3"))).
+					To(HaveOccurredAs(xkv.VersionMismatch))
+				v, _ := MustSucceed2(kv.GetVersioned(ctx, []byte("key")))
+				Expect(v).To(Equal([]byte("value2")))
+			})
+
+			It("Should return NotFound for a deleted key", func() {
+				kv := MustSucceed(builder.New(ctx, kv.Config{}, cluster.Config{}))
+				Expect(kv.Set(ctx, []byte("key"), []byte("value"))).To(Succeed())
+				Expect(kv.Delete(ctx, []byte("key"))).To(Succeed())
+				_, _, err := kv.GetVersioned(ctx, []byte("key"))
+				Expect(err).To(HaveOccurredAs(xkv.NotFound))
+				Expect(kv.CompareAndSet(ctx, []byte("key"), 0, []byte("value"))).To(Succeed())
+			})
+		})
+
+		Describe("Peer Leaseholder", func() {
+			It("Should route reads and conditional writes to the leaseholder", func() {
+				kv1 := MustSucceed(builder.New(ctx, kv.Config{}, cluster.Config{}))
+				kv2 := MustSucceed(builder.New(ctx, kv.Config{}, cluster.Config{}))
+				waitForClusterStateToConverge(builder)
+				Expect(kv1.Set(ctx, []byte("key"), []byte("value"))).To(Succeed())
+				_, ver := MustSucceed2(kv2.GetVersioned(ctx, []byte("key"), node.Key(1)))
+				Expect(kv2.CompareAndSet(ctx, []byte("key"), ver, []byte("value2"), node.Key(1))).
+					To(Succeed())
+				Expect(kv2.CompareAndSet(ctx, []byte("key"), ver, []byte("value3"), node.Key(1))).
+					To(HaveOccurredAs(xkv.VersionMismatch))
+				v, _ := MustSucceed2(kv1.GetVersioned(ctx, []byte("key")))
+				Expect(v).To(Equal([]byte("value2")))
+				Eventually(func(g Gomega) {
+					v, _, err := kv2.GetVersioned(ctx, []byte("key"))
+					g.Expect(err).ToNot(HaveOccurred())
+					g.Expect(v).To(Equal([]byte("value2")))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	Describe("LeaseCounts", func() {
+		It("Should count the live keys held by each leaseholder", func() {
+			kv1 := MustSucceed(builder.New(ctx, kv.Config{}, cluster.Config{}))
+			kv2 := MustSucceed(builder.New(ctx, kv.Config{}, cluster.Config{}))
+			waitForClusterStateToConverge(builder)
+			Expect(kv1.Set(ctx, []byte("a"), []byte("value"))).To(Succeed())
+			Expect(kv1.Set(ctx, []byte("b"), []byte("value"))).To(Succeed())
+			Expect(kv1.Set(ctx, []byte("c"), []byte("value"), node.Key(2))).To(Succeed())
+			Expect(kv1.Delete(ctx, []byte("b"))).To(Succeed())
+			Eventually(func(g Gomega) {
+				counts, err := kv2.LeaseCounts(ctx)
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(counts).To(Equal(map[node.Key]int{1: 1, 2: 1}))
+			}).Should(Succeed())
+		})
+	})
+
 	Describe("Request Recovery", func() {
 		It("Should stop propagating an operation after a set threshold of"+
 			" redundant broadcasts", func() {
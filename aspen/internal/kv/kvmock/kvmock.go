@@ -29,6 +29,7 @@ type Builder struct {
 	FeedbackNet *fmock.Network[kv.FeedbackMessage, types.Nil]
 	LeaseNet    *fmock.Network[kv.TxRequest, types.Nil]
 	RecoveryNet *fmock.Network[kv.RecoveryRequest, kv.RecoveryResponse]
+	ReadNet     *fmock.Network[kv.ReadRequest, kv.ReadResponse]
 	KVs         map[node.Key]xkv.DB
 }
 
@@ -40,6 +41,7 @@ func NewBuilder(baseKVCfg kv.Config, baseClusterCfg cluster.Config) *Builder {
 		FeedbackNet: fmock.NewNetwork[kv.FeedbackMessage, types.Nil](),
 		LeaseNet:    fmock.NewNetwork[kv.TxRequest, types.Nil](),
 		RecoveryNet: fmock.NewNetwork[kv.RecoveryRequest, kv.RecoveryResponse](),
+		ReadNet:     fmock.NewNetwork[kv.ReadRequest, kv.ReadResponse](),
 		KVs:         make(map[node.Key]xkv.DB),
 	}
 }
@@ -63,6 +65,8 @@ func (b *Builder) New(ctx context.Context, kvCfg kv.Config, clusterCfg cluster.C
 	kvCfg.LeaseTransportClient = b.LeaseNet.UnaryClient()
 	kvCfg.RecoveryTransportServer = b.RecoveryNet.StreamServer(addr)
 	kvCfg.RecoveryTransportClient = b.RecoveryNet.StreamClient()
+	kvCfg.ReadTransportServer = b.ReadNet.UnaryServer(addr)
+	kvCfg.ReadTransportClient = b.ReadNet.UnaryClient()
 	kve, err := kv.Open(ctx, kvCfg)
 	if err != nil {
 		return nil, err
@@ -127,3 +127,23 @@ func (lr *leaseReceiver) receive(_ context.Context, br TxRequest) (types.Nil, er
 	lr.Out.Inlet() <- br
 	return types.Nil{}, bc.wait()
 }
+
+// LeaseCounts returns the number of live (i.e. non-deleted) keys held by each
+// leaseholder in the cluster, as seen by the host's replica of the key-value store.
+func (d *DB) LeaseCounts(ctx context.Context) (map[node.Key]int, error) {
+	iter, err := d.config.Engine.OpenIterator(xkv.IterPrefix([]byte(digestPrefix)))
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[node.Key]int)
+	var dig Digest
+	for iter.First(); iter.Valid(); iter.Next() {
+		if err = codec.Decode(ctx, iter.Value(), &dig); err != nil {
+			return nil, errors.Combine(err, iter.Close())
+		}
+		if dig.Variant == change.Set {
+			counts[dig.Leaseholder]++
+		}
+	}
+	return counts, iter.Close()
+}
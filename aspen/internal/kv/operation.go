@@ -35,7 +35,13 @@ type Operation struct {
 	xkv.Change
 	Version     version.Counter
 	Leaseholder node.Key
-	state       gossipState
+	// Conditional marks the operation as a compare-and-set. The leaseholder will only
+	// apply the operation if the current version of the key is equal to Expected.
+	Conditional bool
+	// Expected is the version the key must be at for a Conditional operation to be
+	// applied. A value of zero means that the key must not exist.
+	Expected version.Counter
+	state    gossipState
 }
 
 func (o Operation) Digest() Digest {
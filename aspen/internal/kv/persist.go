@@ -11,22 +11,29 @@ package kv
 
 import (
 	"context"
+
 	"github.com/synnaxlabs/x/confluence"
 	xkv "github.com/synnaxlabs/x/kv"
 )
 
 type persist struct {
-	db xkv.Atomic
+	db xkv.DB
 	confluence.LinearTransform[TxRequest, TxRequest]
 }
 
-func newPersist(bw xkv.Atomic) segment {
+func newPersist(bw xkv.DB) segment {
 	ps := &persist{db: bw}
 	ps.Transform = ps.persist
 	return ps
 }
 
 func (ps *persist) persist(_ context.Context, br TxRequest) (TxRequest, bool, error) {
+	// Conditional operations must be checked in the same stage that persists them,
+	// as this is the only place where writes to the engine are serialized.
+	if err := br.checkConditions(br.Context, ps.db); err != nil {
+		br.done(err)
+		return br, false, nil
+	}
 	err := br.commitTo(ps.db)
 	return br, err == nil, nil
 }
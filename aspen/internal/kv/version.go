@@ -77,7 +77,7 @@ func (vc *versionFilter) filter(ctx context.Context, op Operation) bool {
 	return op.Version.NewerThan(dig.Version)
 }
 
-func getDigestFromKV(ctx context.Context, kve xkv.DB, key []byte) (Digest, error) {
+func getDigestFromKV(ctx context.Context, kve xkv.Reader, key []byte) (Digest, error) {
 	dig := Digest{}
 	key, err := digestKey(key)
 	if err != nil {
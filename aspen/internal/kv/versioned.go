@@ -0,0 +1,188 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package kv
+
+import (
+	"context"
+
+	"github.com/synnaxlabs/freighter"
+	"github.com/synnaxlabs/x/binary"
+	"github.com/synnaxlabs/x/change"
+	"github.com/synnaxlabs/x/errors"
+	xkv "github.com/synnaxlabs/x/kv"
+	"github.com/synnaxlabs/x/version"
+)
+
+// ReadRequest is a request to read the value and version of a key from its
+// leaseholder.
+type ReadRequest struct {
+	Key []byte
+}
+
+// ReadResponse is the leaseholder's response to a ReadRequest.
+type ReadResponse struct {
+	Value   []byte
+	Version version.Counter
+}
+
+type (
+	ReadTransportClient = freighter.UnaryClient[ReadRequest, ReadResponse]
+	ReadTransportServer = freighter.UnaryServer[ReadRequest, ReadResponse]
+)
+
+var _ xkv.Versioned = (*DB)(nil)
+
+// GetVersioned implements xkv.Versioned. The read is served by the leaseholder of
+// the key, which is the only node that can assign new versions to it. If the
+// leaseholder of the key is not known to the host, the caller can provide it as an
+// option in the same manner as Set.
+func (d *DB) GetVersioned(
+	ctx context.Context,
+	key []byte,
+	maybeLease ...any,
+) ([]byte, version.Counter, error) {
+	lease, err := validateLeaseOption(maybeLease)
+	if err != nil {
+		return nil, 0, err
+	}
+	if lease == DefaultLeaseholder {
+		if lease, err = d.leaseAlloc.getLease(ctx, key); errors.Is(err, xkv.NotFound) {
+			lease = d.config.Cluster.HostKey()
+		} else if err != nil {
+			return nil, 0, err
+		}
+	}
+	if lease == d.config.Cluster.HostKey() {
+		return readVersioned(ctx, d.config.Engine, key)
+	}
+	addr, err := d.config.Cluster.Resolve(lease)
+	if err != nil {
+		return nil, 0, err
+	}
+	res, err := d.config.ReadTransportClient.Send(ctx, addr, ReadRequest{Key: key})
+	return res.Value, res.Version, err
+}
+
+// CompareAndSet implements xkv.Versioned. The operation is forwarded to the
+// leaseholder of the key, which checks the version of the key and persists the
+// new value in a single step of the persist stage. This guarantees that no other
+// write to the key can be applied in between the check and the write.
+func (d *DB) CompareAndSet(
+	ctx context.Context,
+	key []byte,
+	expected version.Counter,
+	value []byte,
+	maybeLease ...any,
+) error {
+	lease, err := validateLeaseOption(maybeLease)
+	if err != nil {
+		return err
+	}
+	op, err := d.leaseAlloc.allocate(ctx, Operation{
+		Change: xkv.Change{
+			Key:     binary.MakeCopy(key),
+			Value:   binary.MakeCopy(value),
+			Variant: change.Set,
+		},
+		Leaseholder: lease,
+		Conditional: true,
+		Expected:    expected,
+	})
+	if err != nil {
+		return err
+	}
+	req := TxRequest{Leaseholder: op.Leaseholder, Operations: []Operation{op}}
+	req.Context, req.span = d.config.T.Debug(ctx, "compare-and-set")
+	return d.apply([]TxRequest{req})
+}
+
+// checkConditions verifies that every conditional operation in the request matches
+// the version of its key in the given reader. Once checked, the operations are
+// marked as unconditional so that they are gossiped and applied by other nodes
+// without being re-evaluated against a (possibly stale) local state.
+func (tr TxRequest) checkConditions(ctx context.Context, r xkv.Reader) error {
+	for i, op := range tr.Operations {
+		if !op.Conditional {
+			continue
+		}
+		current, err := currentVersion(ctx, r, op.Key)
+		if err != nil {
+			return err
+		}
+		if !current.EqualTo(op.Expected) {
+			return errors.Wrapf(
+				xkv.VersionMismatch,
+				"expected version %d for key %s, but found %d",
+				op.Expected,
+				op.Key,
+				current,
+			)
+		}
+		tr.Operations[i].Conditional = false
+	}
+	return nil
+}
+
+// currentVersion returns the version of the key in the reader, or zero if the key
+// has never been set or has been deleted.
+func currentVersion(ctx context.Context, r xkv.Reader, key []byte) (version.Counter, error) {
+	dig, err := getDigestFromKV(ctx, r, key)
+	if errors.Is(err, xkv.NotFound) || dig.Variant == change.Delete {
+		return 0, nil
+	}
+	return dig.Version, err
+}
+
+func readVersioned(
+	ctx context.Context,
+	r xkv.Reader,
+	key []byte,
+) ([]byte, version.Counter, error) {
+	// The value and its digest are written in the same transaction, but are read
+	// separately. We re-check the digest after reading the value to make sure that
+	// no write was applied in between the two reads.
+	for {
+		before, err := getDigestFromKV(ctx, r, key)
+		if err != nil {
+			return nil, 0, err
+		}
+		if before.Variant == change.Delete {
+			return nil, 0, xkv.NotFound
+		}
+		v, closer, err := r.Get(ctx, key)
+		if err != nil {
+			return nil, 0, err
+		}
+		value := binary.MakeCopy(v)
+		if err = closer.Close(); err != nil {
+			return nil, 0, err
+		}
+		after, err := getDigestFromKV(ctx, r, key)
+		if err != nil {
+			return nil, 0, err
+		}
+		if after.Version.EqualTo(before.Version) {
+			return value, before.Version, nil
+		}
+	}
+}
+
+type readServer struct{ Config }
+
+func newReadServer(cfg Config) *readServer {
+	rs := &readServer{Config: cfg}
+	rs.ReadTransportServer.BindHandler(rs.read)
+	return rs
+}
+
+func (rs *readServer) read(ctx context.Context, req ReadRequest) (ReadResponse, error) {
+	v, ver, err := readVersioned(ctx, rs.Engine, req.Key)
+	return ReadResponse{Value: v, Version: ver}, err
+}
@@ -58,9 +58,11 @@ func Open(
 		return nil, err
 	}
 	o.kv.Cluster = db.Cluster
-	if db.DB, err = kv.Open(ctx, o.kv); !ok(err, db.DB) {
+	kvDB, err := kv.Open(ctx, o.kv)
+	if !ok(err, kvDB) {
 		return nil, err
 	}
+	db.DB, db.kv = kvDB, kvDB
 
 	return db, err
 }
@@ -89,6 +91,8 @@ func configureTransport(ctx context.Context, o *options) (io.Closer, error) {
 	o.kv.LeaseTransportClient = o.transport.LeaseClient()
 	o.kv.FeedbackTransportServer = o.transport.FeedbackServer()
 	o.kv.FeedbackTransportClient = o.transport.FeedbackClient()
+	o.kv.ReadTransportServer = o.transport.ReadServer()
+	o.kv.ReadTransportClient = o.transport.ReadClient()
 	o.kv.RecoveryTransportServer = o.transport.RecoveryServer()
 	o.kv.RecoveryTransportClient = o.transport.RecoveryClient()
 	return transportShutdown, nil
@@ -32,6 +32,8 @@ var (
 	_ fgrpc.Translator[kv.FeedbackMessage, *aspenv1.FeedbackMessage]   = feedbackTranslator{}
 	_ fgrpc.Translator[kv.RecoveryRequest, *aspenv1.RecoveryRequest]   = recoveryRequestTranslator{}
 	_ fgrpc.Translator[kv.RecoveryResponse, *aspenv1.RecoveryResponse] = recoveryResponseTranslator{}
+	_ fgrpc.Translator[kv.ReadRequest, *aspenv1.ReadRequest]           = readRequestTranslator{}
+	_ fgrpc.Translator[kv.ReadResponse, *aspenv1.ReadResponse]         = readResponseTranslator{}
 )
 
 type pledgeTranslator struct{}
@@ -119,11 +121,13 @@ func (bt batchTranslator) Backward(ctx context.Context, tMsg *aspenv1.TxRequest)
 
 func translateOpForward(msg kv.Operation) (tMsg *aspenv1.Operation) {
 	return &aspenv1.Operation{
-		Key:         msg.Key,
-		Value:       msg.Value,
-		Variant:     uint32(msg.Variant),
-		Leaseholder: uint32(msg.Leaseholder),
-		Version:     int64(msg.Version),
+		Key:             msg.Key,
+		Value:           msg.Value,
+		Variant:         uint32(msg.Variant),
+		Leaseholder:     uint32(msg.Leaseholder),
+		Version:         int64(msg.Version),
+		Conditional:     msg.Conditional,
+		ExpectedVersion: int64(msg.Expected),
 	}
 }
 
@@ -136,6 +140,8 @@ func translateOpBackward(msg *aspenv1.Operation) (tMsg kv.Operation) {
 		},
 		Leaseholder: node.Key(msg.Leaseholder),
 		Version:     version.Counter(msg.Version),
+		Conditional: msg.Conditional,
+		Expected:    version.Counter(msg.ExpectedVersion),
 	}
 }
 
@@ -195,3 +201,23 @@ func (r recoveryResponseTranslator) Backward(ctx context.Context, tMsg *aspenv1.
 	}
 	return msg, nil
 }
+
+type readRequestTranslator struct{}
+
+func (r readRequestTranslator) Forward(_ context.Context, msg kv.ReadRequest) (*aspenv1.ReadRequest, error) {
+	return &aspenv1.ReadRequest{Key: msg.Key}, nil
+}
+
+func (r readRequestTranslator) Backward(_ context.Context, tMsg *aspenv1.ReadRequest) (kv.ReadRequest, error) {
+	return kv.ReadRequest{Key: tMsg.Key}, nil
+}
+
+type readResponseTranslator struct{}
+
+func (r readResponseTranslator) Forward(_ context.Context, msg kv.ReadResponse) (*aspenv1.ReadResponse, error) {
+	return &aspenv1.ReadResponse{Value: msg.Value, Version: int64(msg.Version)}, nil
+}
+
+func (r readResponseTranslator) Backward(_ context.Context, tMsg *aspenv1.ReadResponse) (kv.ReadResponse, error) {
+	return kv.ReadResponse{Value: tMsg.Value, Version: version.Counter(tMsg.Version)}, nil
+}
@@ -90,6 +90,18 @@ type (
 		types.Nil,
 		*emptypb.Empty,
 	]
+	readClient = fgrpc.UnaryClient[
+		kv.ReadRequest,
+		*aspenv1.ReadRequest,
+		kv.ReadResponse,
+		*aspenv1.ReadResponse,
+	]
+	readServer = fgrpc.UnaryServer[
+		kv.ReadRequest,
+		*aspenv1.ReadRequest,
+		kv.ReadResponse,
+		*aspenv1.ReadResponse,
+	]
 	recoveryClient = fgrpc.StreamClient[
 		kv.RecoveryRequest,
 		*aspenv1.RecoveryRequest,
@@ -132,6 +144,9 @@ var (
 	_ kv.FeedbackTransportClient         = (*feedbackClient)(nil)
 	_ kv.FeedbackTransportServer         = (*feedbackServer)(nil)
 	_ aspenv1.FeedbackServiceServer      = (*feedbackServer)(nil)
+	_ kv.ReadTransportClient             = (*readClient)(nil)
+	_ kv.ReadTransportServer             = (*readServer)(nil)
+	_ aspenv1.ReadServiceServer          = (*readServer)(nil)
 	_ kv.RecoveryTransportClient         = (*recoveryClient)(nil)
 	_ kv.RecoveryTransportServer         = (*recoveryServerCore)(nil)
 	_ aspenv1.RecoveryServiceServer      = (*recoveryServer)(nil)
@@ -236,6 +251,25 @@ func New(pool *fgrpc.Pool) *Transport {
 			ResponseTranslator: fgrpc.EmptyTranslator{},
 			ServiceDesc:        &aspenv1.FeedbackService_ServiceDesc,
 		},
+		readClient: &readClient{
+			Pool:               pool,
+			RequestTranslator:  readRequestTranslator{},
+			ResponseTranslator: readResponseTranslator{},
+			Exec: func(
+				ctx context.Context,
+				conn grpc.ClientConnInterface,
+				req *aspenv1.ReadRequest,
+			) (*aspenv1.ReadResponse, error) {
+				return aspenv1.NewReadServiceClient(conn).Exec(ctx, req)
+			},
+			ServiceDesc: &aspenv1.ReadService_ServiceDesc,
+		},
+		readServer: &readServer{
+			Internal:           true,
+			RequestTranslator:  readRequestTranslator{},
+			ResponseTranslator: readResponseTranslator{},
+			ServiceDesc:        &aspenv1.ReadService_ServiceDesc,
+		},
 		recServer: &recoveryServer{
 			recoveryServerCore: recoveryServerCore{
 				RequestTranslator:  recoveryRequestTranslator{},
@@ -267,6 +301,8 @@ type Transport struct {
 	leaseClient    *leaseClient
 	feedbackServer *feedbackServer
 	feedbackClient *feedbackClient
+	readServer     *readServer
+	readClient     *readClient
 	recServer      *recoveryServer
 	recClient      *recoveryClient
 }
@@ -293,6 +329,10 @@ func (t Transport) FeedbackServer() kv.FeedbackTransportServer { return t.feedba
 
 func (t Transport) FeedbackClient() kv.FeedbackTransportClient { return t.feedbackClient }
 
+func (t Transport) ReadServer() kv.ReadTransportServer { return t.readServer }
+
+func (t Transport) ReadClient() kv.ReadTransportClient { return t.readClient }
+
 func (t Transport) RecoveryServer() kv.RecoveryTransportServer { return t.recServer }
 
 func (t Transport) RecoveryClient() kv.RecoveryTransportClient { return t.recClient }
@@ -303,6 +343,7 @@ func (t Transport) BindTo(reg grpc.ServiceRegistrar) {
 	t.txServer.BindTo(reg)
 	t.leaseServer.BindTo(reg)
 	t.feedbackServer.BindTo(reg)
+	t.readServer.BindTo(reg)
 	t.recServer.BindTo(reg)
 }
 
@@ -317,6 +358,8 @@ func (t Transport) Use(middleware ...freighter.Middleware) {
 	t.leaseClient.Use(middleware...)
 	t.feedbackServer.Use(middleware...)
 	t.feedbackClient.Use(middleware...)
+	t.readServer.Use(middleware...)
+	t.readClient.Use(middleware...)
 	t.recServer.Use(middleware...)
 	t.recClient.Use(middleware...)
 }
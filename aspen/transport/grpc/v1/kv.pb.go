@@ -195,14 +195,16 @@ func (x *TxRequest) GetOperations() []*Operation {
 }
 
 type Operation struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Variant       uint32                 `protobuf:"varint,1,opt,name=variant,proto3" json:"variant,omitempty"`
-	Leaseholder   uint32                 `protobuf:"varint,2,opt,name=leaseholder,proto3" json:"leaseholder,omitempty"`
-	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
-	Key           []byte                 `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
-	Value         []byte                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Variant         uint32                 `protobuf:"varint,1,opt,name=variant,proto3" json:"variant,omitempty"`
+	Leaseholder     uint32                 `protobuf:"varint,2,opt,name=leaseholder,proto3" json:"leaseholder,omitempty"`
+	Version         int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Key             []byte                 `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	Value           []byte                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	Conditional     bool                   `protobuf:"varint,6,opt,name=conditional,proto3" json:"conditional,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Operation) Reset() {
@@ -270,6 +272,116 @@ func (x *Operation) GetValue() []byte {
 	return nil
 }
 
+func (x *Operation) GetConditional() bool {
+	if x != nil {
+		return x.Conditional
+	}
+	return false
+}
+
+func (x *Operation) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type ReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           []byte                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadRequest) Reset() {
+	*x = ReadRequest{}
+	mi := &file_aspen_transport_grpc_v1_kv_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadRequest) ProtoMessage() {}
+
+func (x *ReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aspen_transport_grpc_v1_kv_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadRequest.ProtoReflect.Descriptor instead.
+func (*ReadRequest) Descriptor() ([]byte, []int) {
+	return file_aspen_transport_grpc_v1_kv_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReadRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type ReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         []byte                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadResponse) Reset() {
+	*x = ReadResponse{}
+	mi := &file_aspen_transport_grpc_v1_kv_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadResponse) ProtoMessage() {}
+
+func (x *ReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aspen_transport_grpc_v1_kv_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadResponse.ProtoReflect.Descriptor instead.
+func (*ReadResponse) Descriptor() ([]byte, []int) {
+	return file_aspen_transport_grpc_v1_kv_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReadResponse) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ReadResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type RecoveryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	HighWater     int64                  `protobuf:"varint,1,opt,name=high_water,json=highWater,proto3" json:"high_water,omitempty"`
@@ -279,7 +391,7 @@ type RecoveryRequest struct {
 
 func (x *RecoveryRequest) Reset() {
 	*x = RecoveryRequest{}
-	mi := &file_aspen_transport_grpc_v1_kv_proto_msgTypes[4]
+	mi := &file_aspen_transport_grpc_v1_kv_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -291,7 +403,7 @@ func (x *RecoveryRequest) String() string {
 func (*RecoveryRequest) ProtoMessage() {}
 
 func (x *RecoveryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aspen_transport_grpc_v1_kv_proto_msgTypes[4]
+	mi := &file_aspen_transport_grpc_v1_kv_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -304,7 +416,7 @@ func (x *RecoveryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecoveryRequest.ProtoReflect.Descriptor instead.
 func (*RecoveryRequest) Descriptor() ([]byte, []int) {
-	return file_aspen_transport_grpc_v1_kv_proto_rawDescGZIP(), []int{4}
+	return file_aspen_transport_grpc_v1_kv_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *RecoveryRequest) GetHighWater() int64 {
@@ -323,7 +435,7 @@ type RecoveryResponse struct {
 
 func (x *RecoveryResponse) Reset() {
 	*x = RecoveryResponse{}
-	mi := &file_aspen_transport_grpc_v1_kv_proto_msgTypes[5]
+	mi := &file_aspen_transport_grpc_v1_kv_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -335,7 +447,7 @@ func (x *RecoveryResponse) String() string {
 func (*RecoveryResponse) ProtoMessage() {}
 
 func (x *RecoveryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aspen_transport_grpc_v1_kv_proto_msgTypes[5]
+	mi := &file_aspen_transport_grpc_v1_kv_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,7 +460,7 @@ func (x *RecoveryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecoveryResponse.ProtoReflect.Descriptor instead.
 func (*RecoveryResponse) Descriptor() ([]byte, []int) {
-	return file_aspen_transport_grpc_v1_kv_proto_rawDescGZIP(), []int{5}
+	return file_aspen_transport_grpc_v1_kv_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RecoveryResponse) GetOperations() []*Operation {
@@ -375,13 +487,20 @@ const file_aspen_transport_grpc_v1_kv_proto_rawDesc = "" +
 	"\vleaseholder\x18\x02 \x01(\rR\vleaseholder\x123\n" +
 	"\n" +
 	"operations\x18\x03 \x03(\v2\x13.aspen.v1.OperationR\n" +
-	"operations\"\x89\x01\n" +
+	"operations\"\xd6\x01\n" +
 	"\tOperation\x12\x18\n" +
 	"\avariant\x18\x01 \x01(\rR\avariant\x12 \n" +
 	"\vleaseholder\x18\x02 \x01(\rR\vleaseholder\x12\x18\n" +
 	"\aversion\x18\x03 \x01(\x03R\aversion\x12\x10\n" +
 	"\x03key\x18\x04 \x01(\fR\x03key\x12\x14\n" +
-	"\x05value\x18\x05 \x01(\fR\x05value\"0\n" +
+	"\x05value\x18\x05 \x01(\fR\x05value\x12 \n" +
+	"\vconditional\x18\x06 \x01(\bR\vconditional\x12)\n" +
+	"\x10expected_version\x18\a \x01(\x03R\x0fexpectedVersion\"\x1f\n" +
+	"\vReadRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\fR\x03key\">\n" +
+	"\fReadResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\"0\n" +
 	"\x0fRecoveryRequest\x12\x1d\n" +
 	"\n" +
 	"high_water\x18\x01 \x01(\x03R\thighWater\"G\n" +
@@ -394,7 +513,9 @@ const file_aspen_transport_grpc_v1_kv_proto_rawDesc = "" +
 	"\tTxService\x120\n" +
 	"\x04Exec\x12\x13.aspen.v1.TxRequest\x1a\x13.aspen.v1.TxRequest2C\n" +
 	"\fLeaseService\x123\n" +
-	"\x04Exec\x12\x13.aspen.v1.TxRequest\x1a\x16.google.protobuf.Empty2T\n" +
+	"\x04Exec\x12\x13.aspen.v1.TxRequest\x1a\x16.google.protobuf.Empty2D\n" +
+	"\vReadService\x125\n" +
+	"\x04Exec\x12\x15.aspen.v1.ReadRequest\x1a\x16.aspen.v1.ReadResponse2T\n" +
 	"\x0fRecoveryService\x12A\n" +
 	"\x04Exec\x12\x19.aspen.v1.RecoveryRequest\x1a\x1a.aspen.v1.RecoveryResponse(\x010\x01B\x87\x01\n" +
 	"\fcom.aspen.v1B\aKvProtoP\x01Z-github.com/synnaxlabs/aspen/transport/grpc/v1\xa2\x02\x03AXX\xaa\x02\bAspen.V1\xca\x02\bAspen\\V1\xe2\x02\x14Aspen\\V1\\GPBMetadata\xea\x02\tAspen::V1b\x06proto3"
@@ -411,15 +532,17 @@ func file_aspen_transport_grpc_v1_kv_proto_rawDescGZIP() []byte {
 	return file_aspen_transport_grpc_v1_kv_proto_rawDescData
 }
 
-var file_aspen_transport_grpc_v1_kv_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_aspen_transport_grpc_v1_kv_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_aspen_transport_grpc_v1_kv_proto_goTypes = []any{
 	(*FeedbackMessage)(nil),  // 0: aspen.v1.FeedbackMessage
 	(*OperationDigest)(nil),  // 1: aspen.v1.OperationDigest
 	(*TxRequest)(nil),        // 2: aspen.v1.TxRequest
 	(*Operation)(nil),        // 3: aspen.v1.Operation
-	(*RecoveryRequest)(nil),  // 4: aspen.v1.RecoveryRequest
-	(*RecoveryResponse)(nil), // 5: aspen.v1.RecoveryResponse
-	(*emptypb.Empty)(nil),    // 6: google.protobuf.Empty
+	(*ReadRequest)(nil),      // 4: aspen.v1.ReadRequest
+	(*ReadResponse)(nil),     // 5: aspen.v1.ReadResponse
+	(*RecoveryRequest)(nil),  // 6: aspen.v1.RecoveryRequest
+	(*RecoveryResponse)(nil), // 7: aspen.v1.RecoveryResponse
+	(*emptypb.Empty)(nil),    // 8: google.protobuf.Empty
 }
 var file_aspen_transport_grpc_v1_kv_proto_depIdxs = []int32{
 	1, // 0: aspen.v1.FeedbackMessage.digests:type_name -> aspen.v1.OperationDigest
@@ -428,13 +551,15 @@ var file_aspen_transport_grpc_v1_kv_proto_depIdxs = []int32{
 	0, // 3: aspen.v1.FeedbackService.Exec:input_type -> aspen.v1.FeedbackMessage
 	2, // 4: aspen.v1.TxService.Exec:input_type -> aspen.v1.TxRequest
 	2, // 5: aspen.v1.LeaseService.Exec:input_type -> aspen.v1.TxRequest
-	4, // 6: aspen.v1.RecoveryService.Exec:input_type -> aspen.v1.RecoveryRequest
-	6, // 7: aspen.v1.FeedbackService.Exec:output_type -> google.protobuf.Empty
-	2, // 8: aspen.v1.TxService.Exec:output_type -> aspen.v1.TxRequest
-	6, // 9: aspen.v1.LeaseService.Exec:output_type -> google.protobuf.Empty
-	5, // 10: aspen.v1.RecoveryService.Exec:output_type -> aspen.v1.RecoveryResponse
-	7, // [7:11] is the sub-list for method output_type
-	3, // [3:7] is the sub-list for method input_type
+	4, // 6: aspen.v1.ReadService.Exec:input_type -> aspen.v1.ReadRequest
+	6, // 7: aspen.v1.RecoveryService.Exec:input_type -> aspen.v1.RecoveryRequest
+	8, // 8: aspen.v1.FeedbackService.Exec:output_type -> google.protobuf.Empty
+	2, // 9: aspen.v1.TxService.Exec:output_type -> aspen.v1.TxRequest
+	8, // 10: aspen.v1.LeaseService.Exec:output_type -> google.protobuf.Empty
+	5, // 11: aspen.v1.ReadService.Exec:output_type -> aspen.v1.ReadResponse
+	7, // 12: aspen.v1.RecoveryService.Exec:output_type -> aspen.v1.RecoveryResponse
+	8, // [8:13] is the sub-list for method output_type
+	3, // [3:8] is the sub-list for method input_type
 	3, // [3:3] is the sub-list for extension type_name
 	3, // [3:3] is the sub-list for extension extendee
 	0, // [0:3] is the sub-list for field type_name
@@ -451,9 +576,9 @@ func file_aspen_transport_grpc_v1_kv_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_aspen_transport_grpc_v1_kv_proto_rawDesc), len(file_aspen_transport_grpc_v1_kv_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
-			NumServices:   4,
+			NumServices:   5,
 		},
 		GoTypes:           file_aspen_transport_grpc_v1_kv_proto_goTypes,
 		DependencyIndexes: file_aspen_transport_grpc_v1_kv_proto_depIdxs,
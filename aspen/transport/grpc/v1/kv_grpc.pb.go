@@ -319,6 +319,106 @@ var LeaseService_ServiceDesc = grpc.ServiceDesc{
 	Metadata: "aspen/transport/grpc/v1/kv.proto",
 }
 
+const (
+	ReadService_Exec_FullMethodName = "/aspen.v1.ReadService/Exec"
+)
+
+// ReadServiceClient is the client API for ReadService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReadServiceClient interface {
+	Exec(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error)
+}
+
+type readServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReadServiceClient(cc grpc.ClientConnInterface) ReadServiceClient {
+	return &readServiceClient{cc}
+}
+
+func (c *readServiceClient) Exec(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadResponse)
+	err := c.cc.Invoke(ctx, ReadService_Exec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReadServiceServer is the server API for ReadService service.
+// All implementations should embed UnimplementedReadServiceServer
+// for forward compatibility.
+type ReadServiceServer interface {
+	Exec(context.Context, *ReadRequest) (*ReadResponse, error)
+}
+
+// UnimplementedReadServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReadServiceServer struct{}
+
+func (UnimplementedReadServiceServer) Exec(context.Context, *ReadRequest) (*ReadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedReadServiceServer) testEmbeddedByValue() {}
+
+// UnsafeReadServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReadServiceServer will
+// result in compilation errors.
+type UnsafeReadServiceServer interface {
+	mustEmbedUnimplementedReadServiceServer()
+}
+
+func RegisterReadServiceServer(s grpc.ServiceRegistrar, srv ReadServiceServer) {
+	// If the following call pancis, it indicates UnimplementedReadServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReadService_ServiceDesc, srv)
+}
+
+func _ReadService_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReadServiceServer).Exec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReadService_Exec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReadServiceServer).Exec(ctx, req.(*ReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReadService_ServiceDesc is the grpc.ServiceDesc for ReadService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReadService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "aspen.v1.ReadService",
+	HandlerType: (*ReadServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Exec",
+			Handler:    _ReadService_Exec_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "aspen/transport/grpc/v1/kv.proto",
+}
+
 const (
 	RecoveryService_Exec_FullMethodName = "/aspen.v1.RecoveryService/Exec"
 )
@@ -30,6 +30,7 @@ type Network struct {
 	lease      *fmock.Network[kv.TxRequest, types.Nil]
 	feedback   *fmock.Network[kv.FeedbackMessage, types.Nil]
 	recovery   *fmock.Network[kv.RecoveryRequest, kv.RecoveryResponse]
+	read       *fmock.Network[kv.ReadRequest, kv.ReadResponse]
 }
 
 func NewNetwork() *Network {
@@ -40,6 +41,7 @@ func NewNetwork() *Network {
 		lease:      fmock.NewNetwork[kv.TxRequest, types.Nil](),
 		feedback:   fmock.NewNetwork[kv.FeedbackMessage, types.Nil](),
 		recovery:   fmock.NewNetwork[kv.RecoveryRequest, kv.RecoveryResponse](),
+		read:       fmock.NewNetwork[kv.ReadRequest, kv.ReadResponse](),
 	}
 }
 
@@ -60,6 +62,8 @@ type transport struct {
 	feedbackClient *fmock.UnaryClient[kv.FeedbackMessage, types.Nil]
 	recoveryServer *fmock.StreamServer[kv.RecoveryRequest, kv.RecoveryResponse]
 	recoveryClient *fmock.StreamClient[kv.RecoveryRequest, kv.RecoveryResponse]
+	readServer     *fmock.UnaryServer[kv.ReadRequest, kv.ReadResponse]
+	readClient     *fmock.UnaryClient[kv.ReadRequest, kv.ReadResponse]
 }
 
 // Configure implements aspen.transport.
@@ -76,6 +80,8 @@ func (t *transport) Configure(ctx signal.Context, addr address.Address, external
 	t.feedbackClient = t.net.feedback.UnaryClient()
 	t.recoveryServer = t.net.recovery.StreamServer(addr)
 	t.recoveryClient = t.net.recovery.StreamClient()
+	t.readServer = t.net.read.UnaryServer(addr)
+	t.readClient = t.net.read.UnaryClient()
 	return nil
 }
 
@@ -103,6 +109,10 @@ func (t *transport) RecoveryClient() kv.RecoveryTransportClient { return t.recov
 
 func (t *transport) RecoveryServer() kv.RecoveryTransportServer { return t.recoveryServer }
 
+func (t *transport) ReadClient() kv.ReadTransportClient { return t.readClient }
+
+func (t *transport) ReadServer() kv.ReadTransportServer { return t.readServer }
+
 func (t *transport) Use(middleware ...freighter.Middleware) {
 	t.pledgeClient.Use(middleware...)
 	t.pledgeServer.Use(middleware...)
@@ -114,6 +124,8 @@ func (t *transport) Use(middleware ...freighter.Middleware) {
 	t.leaseServer.Use(middleware...)
 	t.feedbackClient.Use(middleware...)
 	t.feedbackServer.Use(middleware...)
+	t.readClient.Use(middleware...)
+	t.readServer.Use(middleware...)
 }
 
 func (t *transport) Report() alamos.Report {
@@ -31,6 +31,8 @@ type Transport interface {
 	LeaseClient() kv.LeaseTransportClient
 	FeedbackServer() kv.FeedbackTransportServer
 	FeedbackClient() kv.FeedbackTransportClient
+	ReadServer() kv.ReadTransportServer
+	ReadClient() kv.ReadTransportClient
 	RecoveryServer() kv.RecoveryTransportServer
 	RecoveryClient() kv.RecoveryTransportClient
 }
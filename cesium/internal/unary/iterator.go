@@ -206,6 +206,18 @@ func (i *Iterator) Next(ctx context.Context, span telem.TimeSpan) (ok bool) {
 
 func (i *Iterator) autoNext(ctx context.Context) bool {
 	i.view.Start = i.view.End
+	// Skip past any domains that end before the start of the view. If there are none
+	// left, we've reached the end of the iterator's data. Otherwise, make sure the view
+	// starts within a domain, as the index can't stamp from a timestamp in a gap.
+	for !i.internal.TimeRange().End.After(i.view.Start) {
+		if !i.internal.Next() {
+			i.reset(i.view.Start.SpanRange(0))
+			return false
+		}
+	}
+	if start := i.internal.TimeRange().Start; start.After(i.view.Start) {
+		i.view.Start = start
+	}
 	endApprox, err := i.idx.Stamp(
 		ctx,
 		i.view.Start,
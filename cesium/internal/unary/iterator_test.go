@@ -861,6 +861,64 @@ var _ = Describe("Iterator Behavior", Ordered, func() {
 						Expect(indexDB2.Close()).To(Succeed())
 					})
 
+					// These specs were added due to a bug in auto-spanning where the view
+					// of the iterator would start after the end of the domain it was
+					// positioned on, either in a gap before the next domain or after the
+					// last domain. The index cannot stamp from a timestamp that is not in
+					// a domain, so Next would fail with a discontinuous error instead of
+					// moving to the next domain or returning false.
+					Context("Auto Span Across Gaps", func() {
+						BeforeEach(func() {
+							Expect(unary.Write(ctx, indexDB, 10*telem.SecondTS, telem.NewSeriesSecondsTSV(10, 11, 12))).To(Succeed())
+							Expect(unary.Write(ctx, indexDB, 20*telem.SecondTS, telem.NewSeriesSecondsTSV(20, 21, 22))).To(Succeed())
+							Expect(unary.Write(ctx, db, 10*telem.SecondTS, telem.NewSeriesV[int64](1, 2, 3))).To(Succeed())
+							Expect(unary.Write(ctx, db, 20*telem.SecondTS, telem.NewSeriesV[int64](4, 5, 6))).To(Succeed())
+						})
+						It("Should move to the next domain when the view starts in a gap", func() {
+							i := MustSucceed(db.OpenIterator(unary.IteratorConfig{Bounds: telem.TimeRangeMax, AutoChunkSize: 3}))
+							Expect(i.SeekFirst(ctx)).To(BeTrue())
+							Expect(i.Next(ctx, unary.AutoSpan)).To(BeTrue())
+							Expect(i.Value().SeriesAt(0)).To(telem.MatchSeriesDataV[int64](1, 2, 3))
+							Expect(i.Next(ctx, unary.AutoSpan)).To(BeTrue())
+							Expect(i.Value().SeriesAt(0)).To(telem.MatchSeriesDataV[int64](4, 5, 6))
+							Expect(i.View().Start).To(Equal(20 * telem.SecondTS))
+							Expect(i.Next(ctx, unary.AutoSpan)).To(BeFalse())
+							Expect(i.Error()).ToNot(HaveOccurred())
+							Expect(i.Close()).To(Succeed())
+						})
+						It("Should span a chunk across a gap between domains", func() {
+							i := MustSucceed(db.OpenIterator(unary.IteratorConfig{Bounds: telem.TimeRangeMax, AutoChunkSize: 2}))
+							Expect(i.SeekFirst(ctx)).To(BeTrue())
+							Expect(i.Next(ctx, unary.AutoSpan)).To(BeTrue())
+							Expect(i.Value().SeriesAt(0)).To(telem.MatchSeriesDataV[int64](1, 2))
+							Expect(i.Next(ctx, unary.AutoSpan)).To(BeTrue())
+							Expect(i.Len()).To(Equal(int64(2)))
+							Expect(i.Value().SeriesAt(0)).To(telem.MatchSeriesDataV[int64](3))
+							Expect(i.Value().SeriesAt(1)).To(telem.MatchSeriesDataV[int64](4))
+							Expect(i.Next(ctx, unary.AutoSpan)).To(BeTrue())
+							Expect(i.Value().SeriesAt(0)).To(telem.MatchSeriesDataV[int64](5, 6))
+							Expect(i.Next(ctx, unary.AutoSpan)).To(BeFalse())
+							Expect(i.Error()).ToNot(HaveOccurred())
+							Expect(i.Close()).To(Succeed())
+						})
+						It("Should skip domains that end before the view starts", func() {
+							i := MustSucceed(db.OpenIterator(unary.IteratorConfig{Bounds: telem.TimeRangeMax, AutoChunkSize: 3}))
+							Expect(i.SeekGE(ctx, 15*telem.SecondTS)).To(BeTrue())
+							Expect(i.Next(ctx, unary.AutoSpan)).To(BeTrue())
+							Expect(i.Value().SeriesAt(0)).To(telem.MatchSeriesDataV[int64](4, 5, 6))
+							Expect(i.Next(ctx, unary.AutoSpan)).To(BeFalse())
+							Expect(i.Error()).ToNot(HaveOccurred())
+							Expect(i.Close()).To(Succeed())
+						})
+						It("Should return false without an error once the view starts after the last domain", func() {
+							i := MustSucceed(db.OpenIterator(unary.IteratorConfig{Bounds: telem.TimeRangeMax, AutoChunkSize: 3}))
+							Expect(i.SeekLast(ctx)).To(BeTrue())
+							Expect(i.Next(ctx, unary.AutoSpan)).To(BeFalse())
+							Expect(i.Error()).ToNot(HaveOccurred())
+							Expect(i.Close()).To(Succeed())
+						})
+					})
+
 					Context("Cut-off domain on index channel", func() {
 						var (
 							iKey     cesium.ChannelKey
@@ -256,6 +256,9 @@ var _ = Describe("Iterator Behavior", func() {
 					f = i.Value()
 					Expect(f.Count()).To(Equal(1))
 					Expect(f.Get(data1Key).Series[0]).To(telem.MatchSeriesDataV[uint16](25))
+
+					Expect(i.Next(cesium.AutoSpan)).To(BeFalse())
+					Expect(i.Error()).ToNot(HaveOccurred())
 					Expect(i.Close()).To(Succeed())
 				})
 			})
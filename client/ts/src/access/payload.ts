@@ -10,6 +10,7 @@
 import { z } from "zod";
 
 export const ALL_ACTION = "all";
+export const CONTROL_ACTION = "control";
 export const CREATE_ACTION = "create";
 export const DELETE_ACTION = "delete";
 export const RETRIEVE_ACTION = "retrieve";
@@ -17,6 +18,7 @@ export const UPDATE_ACTION = "update";
 
 export const actionZ = z.enum([
   ALL_ACTION,
+  CONTROL_ACTION,
   CREATE_ACTION,
   DELETE_ACTION,
   RETRIEVE_ACTION,
@@ -75,6 +75,8 @@ export class Client {
       objects: array.toArray(policy.objects),
       actions: array.toArray(policy.actions),
       subjects: array.toArray(policy.subjects),
+      effect: policy.effect,
+      inherit: policy.inherit,
     }));
     const res = await sendRequired<typeof createReqZ, typeof createResZ>(
       this.client,
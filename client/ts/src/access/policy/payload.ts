@@ -16,11 +16,16 @@ import { nullableArrayZ } from "@/util/zod";
 export const keyZ = z.uuid();
 export type Key = z.infer<typeof keyZ>;
 
+export const effectZ = z.enum(["allow", "deny"]);
+export type Effect = z.infer<typeof effectZ>;
+
 export const policyZ = z.object({
   key: keyZ,
   subjects: nullableArrayZ(ontology.idZ),
   objects: nullableArrayZ(ontology.idZ),
   actions: nullableArrayZ(actionZ),
+  effect: effectZ.or(z.literal("").transform(() => "allow" as const)).optional(),
+  inherit: z.boolean().optional(),
 });
 export interface Policy extends z.infer<typeof policyZ> {}
 
@@ -29,5 +34,7 @@ export const newZ = z.object({
   subjects: ontology.idZ.array().or(ontology.idZ),
   objects: ontology.idZ.array().or(ontology.idZ),
   actions: actionZ.array().or(actionZ),
+  effect: effectZ.optional(),
+  inherit: z.boolean().optional(),
 });
 export interface New extends z.input<typeof newZ> {}
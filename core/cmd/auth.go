@@ -11,6 +11,7 @@ package cmd
 
 import (
 	"context"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/samber/lo"
@@ -22,17 +23,22 @@ import (
 	"github.com/synnaxlabs/synnax/pkg/service/access/rbac"
 	"github.com/synnaxlabs/synnax/pkg/service/auth"
 	"github.com/synnaxlabs/synnax/pkg/service/auth/password"
+	"github.com/synnaxlabs/synnax/pkg/service/framer"
 	"github.com/synnaxlabs/synnax/pkg/service/user"
 	"github.com/synnaxlabs/x/gorp"
 )
 
-// sets the base permissions that need to exist in the server.
+// sets the base permissions that need to exist in the server, and migrates the
+// permissions of clusters created by older versions.
 func maybeSetBasePermissions(
 	ctx context.Context,
 	dist *distribution.Layer,
 	svc *service.Layer,
 ) error {
 	return dist.DB.WithTx(ctx, func(tx gorp.Tx) error {
+		if err := migrateControlPolicies(ctx, tx, svc); err != nil {
+			return err
+		}
 		// base policies that need to be created
 		basePolicies := map[ontology.Type]access.Action{
 			"label":            access.All,
@@ -53,11 +59,16 @@ func maybeSetBasePermissions(
 			"user":             access.Retrieve,
 			"schematic":        access.Retrieve,
 			"policy":           access.Retrieve,
+			"role":             access.Retrieve,
 			"builtin":          access.Retrieve,
-			"framer":           access.All,
+			// users can read the data of all channels by default, but writing to
+			// channels requires a policy that allows controlling them.
+			framer.OntologyType: access.Retrieve,
 		}
 		// for migration purposes, some old base policies that need to be deleted
-		oldBasePolicies := map[ontology.Type]access.Action{}
+		oldBasePolicies := map[ontology.Type]access.Action{
+			framer.OntologyType: access.All,
+		}
 
 		existingPolicies := make([]rbac.Policy, 0, len(basePolicies))
 		policiesToDelete := make([]uuid.UUID, 0, len(oldBasePolicies))
@@ -96,6 +107,31 @@ func maybeSetBasePermissions(
 	})
 }
 
+// migrateControlPolicies adds the control action to policies that allow creating
+// channel data, as writing to channels used to require the create action and now
+// requires the control action.
+func migrateControlPolicies(ctx context.Context, tx gorp.Tx, svc *service.Layer) error {
+	var policies []rbac.Policy
+	if err := svc.RBAC.NewRetrieve().Entries(&policies).Exec(ctx, tx); err != nil {
+		return err
+	}
+	w := svc.RBAC.NewWriter(tx)
+	for _, p := range policies {
+		if !lo.Contains(p.Actions, access.Create) ||
+			lo.Contains(p.Actions, access.Control) ||
+			!lo.ContainsBy(p.Objects, func(o ontology.ID) bool {
+				return o.Type == framer.OntologyType
+			}) {
+			continue
+		}
+		p.Actions = append(p.Actions, access.Control)
+		if err := w.Create(ctx, &p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func maybeProvisionRootUser(
 	ctx context.Context,
 	dist *distribution.Layer,
@@ -147,7 +183,7 @@ func maybeProvisionRootUser(
 	}
 
 	// Register the user first, then give them all permissions
-	if err = dist.DB.WithTx(ctx, func(tx gorp.Tx) error {
+	return dist.DB.WithTx(ctx, func(tx gorp.Tx) error {
 		if err = svc.Auth.NewWriter(tx).Register(ctx, creds); err != nil {
 			return err
 		}
@@ -163,8 +199,107 @@ func maybeProvisionRootUser(
 				Actions:  []access.Action{},
 			},
 		)
-	}); err != nil {
-		return err
+	})
+}
+
+// builtinRole is a role provisioned on every cluster, along with the actions the role
+// may take on each type of object. The base policies only apply to users that haven't
+// been assigned a role, so a user assigned a builtin role can only take the actions
+// the role allows.
+type builtinRole struct {
+	name        string
+	description string
+	actions     map[ontology.Type][]access.Action
+}
+
+// resourceTypes are the types of resources that users work with day to day.
+var resourceTypes = []ontology.Type{
+	"label", "group", "range", "range-alias", "workspace", "log", "lineplot",
+	"schematic", "schematic_symbol", "table", "rack", "device", "task", "channel",
+	"framer",
+}
+
+// adminTypes are the types of objects that describe the cluster and its users.
+var adminTypes = []ontology.Type{"cluster", "node", "builtin", "user", "policy", "role"}
+
+func grant(types []ontology.Type, actions ...access.Action) map[ontology.Type][]access.Action {
+	m := make(map[ontology.Type][]access.Action, len(types))
+	for _, t := range types {
+		m[t] = actions
 	}
-	return maybeSetBasePermissions(ctx, dist, svc)
+	return m
+}
+
+var builtinRoles = []builtinRole{
+	{
+		name:        "admin",
+		description: "Full access to the cluster.",
+	},
+	{
+		name:        "engineer",
+		description: "Creates and configures channels, devices, tasks, and visualizations.",
+		actions: lo.Assign(
+			grant(adminTypes, access.Retrieve),
+			grant(resourceTypes, access.All),
+		),
+	},
+	{
+		name:        "operator",
+		description: "Views data, records ranges, and controls hardware.",
+		actions: lo.Assign(
+			grant(slices.Concat(adminTypes, resourceTypes), access.Retrieve),
+			grant([]ontology.Type{"range", "range-alias", "label"}, access.All),
+			grant([]ontology.Type{"framer"}, access.Retrieve, access.Control),
+		),
+	},
+	{
+		name:        "viewer",
+		description: "Views data and visualizations.",
+		actions:     grant(slices.Concat(adminTypes, resourceTypes), access.Retrieve),
+	},
+}
+
+// maybeProvisionRoles creates any builtin roles that do not exist in the cluster.
+func maybeProvisionRoles(
+	ctx context.Context,
+	dist *distribution.Layer,
+	svc *service.Layer,
+) error {
+	return dist.DB.WithTx(ctx, func(tx gorp.Tx) error {
+		w := svc.RBAC.NewWriter(tx)
+		for _, b := range builtinRoles {
+			exists, err := svc.RBAC.NewRoleRetrieve().WhereNames(b.name).Exists(ctx, tx)
+			if err != nil {
+				return err
+			}
+			if exists {
+				continue
+			}
+			role := rbac.Role{Name: b.name, Description: b.description, Builtin: true}
+			if err = w.CreateRole(ctx, &role); err != nil {
+				return err
+			}
+			subjects := []ontology.ID{role.OntologyID()}
+			if b.actions == nil {
+				if err = w.Create(ctx, &rbac.Policy{
+					Subjects: subjects,
+					Objects:  []ontology.ID{rbac.AllowAllOntologyID},
+					Actions:  []access.Action{},
+				}); err != nil {
+					return err
+				}
+				continue
+			}
+			for t, actions := range b.actions {
+				if err = w.Create(ctx, &rbac.Policy{
+					Subjects: subjects,
+					Objects:  []ontology.ID{{Type: t}},
+					Actions:  actions,
+				}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
 }
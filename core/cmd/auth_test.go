@@ -0,0 +1,143 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cmd
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/mock"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/access/rbac"
+	"github.com/synnaxlabs/synnax/pkg/service/framer"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Auth", func() {
+	var (
+		builder *mock.Cluster
+		dist    mock.Node
+		svc     *service.Layer
+		subject = user.OntologyID(uuid.New())
+		objects = framer.OntologyIDs(channel.Keys{channel.NewKey(1, 1)})
+	)
+	BeforeEach(func() {
+		builder = mock.NewCluster()
+		dist = builder.Provision(ctx)
+		svc = &service.Layer{RBAC: MustSucceed(rbac.NewService(rbac.Config{
+			DB: dist.DB,
+			Proxies: map[ontology.Type]ontology.Type{
+				framer.OntologyType: channel.OntologyType,
+			},
+		}))}
+	})
+	AfterEach(func() {
+		Expect(builder.Close()).To(Succeed())
+	})
+	Describe("Base Permissions", func() {
+		It("Should allow users to read channel data", func() {
+			Expect(maybeSetBasePermissions(ctx, dist.Layer, svc)).To(Succeed())
+			Expect(svc.RBAC.Enforce(ctx, access.Request{
+				Subject: subject,
+				Action:  access.Retrieve,
+				Objects: objects,
+			})).To(Succeed())
+		})
+		It("Should deny users control of channels by default", func() {
+			Expect(maybeSetBasePermissions(ctx, dist.Layer, svc)).To(Succeed())
+			Expect(svc.RBAC.Enforce(ctx, access.Request{
+				Subject: subject,
+				Action:  access.Control,
+				Objects: objects,
+			})).To(MatchError(access.Denied))
+		})
+		It("Should replace the base policy that allowed all actions on channel data", func() {
+			Expect(svc.RBAC.NewWriter(nil).Create(ctx, &rbac.Policy{
+				Subjects: []ontology.ID{user.OntologyTypeID},
+				Objects:  []ontology.ID{{Type: framer.OntologyType}},
+				Actions:  []access.Action{access.All},
+			})).To(Succeed())
+			Expect(maybeSetBasePermissions(ctx, dist.Layer, svc)).To(Succeed())
+			Expect(svc.RBAC.Enforce(ctx, access.Request{
+				Subject: subject,
+				Action:  access.Control,
+				Objects: objects,
+			})).To(MatchError(access.Denied))
+		})
+		It("Should allow control to users that could create channel data", func() {
+			p := rbac.Policy{
+				Subjects: []ontology.ID{subject},
+				Objects:  objects,
+				Actions:  []access.Action{access.Create},
+			}
+			Expect(svc.RBAC.NewWriter(nil).Create(ctx, &p)).To(Succeed())
+			Expect(maybeSetBasePermissions(ctx, dist.Layer, svc)).To(Succeed())
+			Expect(svc.RBAC.Enforce(ctx, access.Request{
+				Subject: subject,
+				Action:  access.Control,
+				Objects: objects,
+			})).To(Succeed())
+		})
+		It("Should be idempotent", func() {
+			Expect(maybeSetBasePermissions(ctx, dist.Layer, svc)).To(Succeed())
+			var before []rbac.Policy
+			Expect(svc.RBAC.NewRetrieve().Entries(&before).Exec(ctx, nil)).To(Succeed())
+			Expect(maybeSetBasePermissions(ctx, dist.Layer, svc)).To(Succeed())
+			var after []rbac.Policy
+			Expect(svc.RBAC.NewRetrieve().Entries(&after).Exec(ctx, nil)).To(Succeed())
+			Expect(after).To(ConsistOf(before))
+		})
+	})
+	Describe("Builtin Roles", func() {
+		var channels []ontology.ID
+		BeforeEach(func() {
+			channels = []ontology.ID{channel.OntologyID(channel.NewKey(1, 1))}
+			Expect(maybeSetBasePermissions(ctx, dist.Layer, svc)).To(Succeed())
+			Expect(maybeProvisionRoles(ctx, dist.Layer, svc)).To(Succeed())
+		})
+		assign := func(name string) {
+			var r rbac.Role
+			Expect(svc.RBAC.NewRoleRetrieve().WhereNames(name).Entry(&r).Exec(ctx, nil)).To(Succeed())
+			Expect(svc.RBAC.NewWriter(nil).AssignRole(ctx, r.Key, subject)).To(Succeed())
+		}
+		enforce := func(action access.Action, objects []ontology.ID) error {
+			return svc.RBAC.Enforce(ctx, access.Request{
+				Subject: subject,
+				Action:  action,
+				Objects: objects,
+			})
+		}
+		It("Should allow users without a role to create channels", func() {
+			Expect(enforce(access.Create, channels)).To(Succeed())
+		})
+		It("Should only allow viewers to read", func() {
+			assign("viewer")
+			Expect(enforce(access.Retrieve, channels)).To(Succeed())
+			Expect(enforce(access.Retrieve, objects)).To(Succeed())
+			Expect(enforce(access.Create, channels)).To(MatchError(access.Denied))
+			Expect(enforce(access.Delete, channels)).To(MatchError(access.Denied))
+			Expect(enforce(access.Control, objects)).To(MatchError(access.Denied))
+		})
+		It("Should allow operators to control channels but not create them", func() {
+			assign("operator")
+			Expect(enforce(access.Control, objects)).To(Succeed())
+			Expect(enforce(access.Create, channels)).To(MatchError(access.Denied))
+		})
+		It("Should allow engineers to create and control channels", func() {
+			assign("engineer")
+			Expect(enforce(access.Create, channels)).To(Succeed())
+			Expect(enforce(access.Control, objects)).To(Succeed())
+		})
+	})
+})
@@ -59,11 +59,30 @@ var certNode = &cobra.Command{
 	},
 }
 
+var certClient = &cobra.Command{
+	Use:   "client [username]",
+	Short: "Generate a client certificate that authenticates API clients as a user.",
+	Long: `Generate a client certificate and key signed by the CA. Clients that present the
+certificate to a node started with --client-cert-auth are authenticated as the user
+with the given username. The files are written to client.<username>.crt and
+client.<username>.key in certs-dir.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ins := configureInstrumentation()
+		factory, err := cert.NewFactory(buildCertFactoryConfig(ins))
+		if err != nil {
+			return err
+		}
+		return factory.CreateClientPair(args[0])
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(certCmd)
 
 	certCmd.AddCommand(certCA)
 	certCmd.AddCommand(certNode)
+	certCmd.AddCommand(certClient)
 }
 
 func buildCertLoaderConfig(ins alamos.Instrumentation) cert.LoaderConfig {
@@ -82,6 +101,7 @@ func buildCertFactoryConfig(ins alamos.Instrumentation) cert.FactoryConfig {
 		LoaderConfig:  buildCertLoaderConfig(ins),
 		AllowKeyReuse: config.Bool(viper.GetBool(allowKeyReuseFlag)),
 		KeySize:       viper.GetInt(keySizeFlag),
+		ValidFor:      viper.GetDuration(certValidForFlag),
 	}
 }
 
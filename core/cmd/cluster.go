@@ -0,0 +1,268 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"go/types"
+	"io/fs"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/synnaxlabs/freighter"
+	"github.com/synnaxlabs/freighter/fhttp"
+	"github.com/synnaxlabs/synnax/pkg/api"
+	"github.com/synnaxlabs/synnax/pkg/distribution/cluster"
+	"github.com/synnaxlabs/synnax/pkg/security/cert"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/password"
+	"github.com/synnaxlabs/x/address"
+	"github.com/synnaxlabs/x/errors"
+)
+
+var clusterCmd = &cobra.Command{
+	Use:   "cluster",
+	Short: "Inspect and administer a running Synnax cluster.",
+	Args:  cobra.NoArgs,
+	PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+		// The connection flags share names with the flags of the start command, so
+		// we bind them when the command runs instead of during initialization.
+		bindFlags(cmd)
+		cmd.SilenceUsage = true
+	},
+}
+
+var clusterNodesCmd = &cobra.Command{
+	Use:   "nodes",
+	Short: "List the nodes in the cluster along with their state and heartbeat lag.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := newClusterClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		res, err := send[api.ClusterRetrieveNodesRequest, api.ClusterRetrieveNodesResponse](
+			cmd.Context(),
+			c,
+			"/api/v1/cluster/node/retrieve",
+			api.ClusterRetrieveNodesRequest{},
+		)
+		if err != nil {
+			return err
+		}
+		cmd.Printf("cluster %s\n\n", res.ClusterKey)
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "KEY\tADDRESS\tSTATE\tHEARTBEAT\tLAST SEEN\tLAG")
+		for _, n := range res.Nodes {
+			key := strconv.Itoa(int(n.Key))
+			if n.Host {
+				key += "*"
+			}
+			lastSeen, lag := "unknown", "unknown"
+			if !n.LastSeen.IsZero() {
+				lastSeen = n.LastSeen.Time().Format(time.RFC3339)
+				lag = time.Duration(n.Lag).Round(time.Millisecond).String()
+			}
+			_, _ = fmt.Fprintf(
+				w,
+				"%s\t%s\t%s\t%d.%d\t%s\t%s\n",
+				key,
+				n.Address,
+				nodeStateString(n.State),
+				n.HeartbeatGeneration,
+				n.HeartbeatVersion,
+				lastSeen,
+				lag,
+			)
+		}
+		return w.Flush()
+	},
+}
+
+var clusterLeasesCmd = &cobra.Command{
+	Use:   "leases",
+	Short: "Show the channels, racks, and key-value entries leased by each node.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := newClusterClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		res, err := send[api.ClusterRetrieveLeasesRequest, api.ClusterRetrieveLeasesResponse](
+			cmd.Context(),
+			c,
+			"/api/v1/cluster/lease/retrieve",
+			api.ClusterRetrieveLeasesRequest{},
+		)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "NODE\tCHANNELS\tRACKS\tKV KEYS")
+		for _, l := range res.Leases {
+			node := strconv.Itoa(int(l.Node))
+			if l.Node == cluster.Free {
+				node = "free"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", node, len(l.Channels), len(l.Racks), l.KVKeys)
+		}
+		return w.Flush()
+	},
+}
+
+var clusterGossipCmd = &cobra.Command{
+	Use:   "gossip",
+	Short: "Force the node to immediately exchange cluster state with its peers.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := newClusterClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if _, err = send[types.Nil, types.Nil](
+			cmd.Context(),
+			c,
+			"/api/v1/cluster/gossip",
+			types.Nil{},
+		); err != nil {
+			return err
+		}
+		cmd.Println("gossip complete")
+		return nil
+	},
+}
+
+var clusterEvictCmd = &cobra.Command{
+	Use:   "evict [node]",
+	Short: "Evict a permanently dead node from the cluster.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, err := strconv.ParseUint(args[0], 10, 16)
+		if err != nil {
+			return fmt.Errorf("invalid node key %q: %w", args[0], err)
+		}
+		c, err := newClusterClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if _, err = send[api.ClusterEvictNodeRequest, types.Nil](
+			cmd.Context(),
+			c,
+			"/api/v1/cluster/node/evict",
+			api.ClusterEvictNodeRequest{Key: cluster.NodeKey(key)},
+		); err != nil {
+			return err
+		}
+		cmd.Printf("evicted node %d\n", key)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(clusterCmd)
+	configureConnectionFlags(clusterCmd)
+
+	clusterCmd.AddCommand(clusterNodesCmd)
+	clusterCmd.AddCommand(clusterLeasesCmd)
+	clusterCmd.AddCommand(clusterGossipCmd)
+	clusterCmd.AddCommand(clusterEvictCmd)
+}
+
+// clusterClient is an authenticated connection to a node in the cluster.
+type clusterClient struct {
+	host    address.Address
+	factory *fhttp.ClientFactory
+	token   string
+}
+
+func newClusterClient(ctx context.Context) (clusterClient, error) {
+	c := clusterClient{host: address.Address(viper.GetString(hostFlag))}
+	cfg := fhttp.ClientFactoryConfig{}
+	if !viper.GetBool(insecureFlag) {
+		tlsCfg, err := buildClientTLS()
+		if err != nil {
+			return c, err
+		}
+		cfg.TLS = tlsCfg
+	}
+	c.factory = fhttp.NewClientFactory(cfg)
+	res, err := send[api.AuthLoginRequest, api.AuthLoginResponse](
+		ctx,
+		c,
+		"/api/v1/auth/login",
+		api.AuthLoginRequest{InsecureCredentials: auth.InsecureCredentials{
+			Username: viper.GetString(usernameFlag),
+			Password: password.Raw(viper.GetString(passwordFlag)),
+		}},
+	)
+	c.token = res.Token
+	return c, err
+}
+
+func send[RQ, RS freighter.Payload](
+	ctx context.Context,
+	c clusterClient,
+	path string,
+	req RQ,
+) (RS, error) {
+	client := fhttp.UnaryClient[RQ, RS](c.factory)
+	if c.token != "" {
+		client.Use(freighter.MiddlewareFunc(func(
+			ctx freighter.Context,
+			next freighter.Next,
+		) (freighter.Context, error) {
+			ctx.Params.Set("Authorization", "Bearer "+c.token)
+			return next(ctx)
+		}))
+	}
+	return client.Send(ctx, c.host+address.Address(path), req)
+}
+
+// buildClientTLS builds a TLS configuration that trusts the CA certificates in the
+// configured certificates directory, falling back to the system certificate pool.
+func buildClientTLS() (*tls.Config, error) {
+	loader, err := cert.NewLoader(buildCertLoaderConfig(configureInstrumentation()))
+	if err != nil {
+		return nil, err
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	cas, err := loader.LoadCAs()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for _, ca := range cas {
+		pool.AddCert(ca)
+	}
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+func nodeStateString(s cluster.NodeState) string {
+	switch s {
+	case cluster.Healthy:
+		return "healthy"
+	case cluster.Suspect:
+		return "suspect"
+	case cluster.Dead:
+		return "dead"
+	case cluster.Left:
+		return "left"
+	default:
+		return "unknown"
+	}
+}
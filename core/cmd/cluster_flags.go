@@ -0,0 +1,43 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cmd
+
+import "github.com/spf13/cobra"
+
+const hostFlag = "host"
+
+// configureConnectionFlags configures the flags used by commands that connect to a
+// running node.
+func configureConnectionFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(
+		hostFlag,
+		"localhost:9090",
+		"The address of the node to connect to.",
+	)
+
+	cmd.PersistentFlags().String(
+		usernameFlag,
+		"synnax",
+		"Username to authenticate with.",
+	)
+
+	cmd.PersistentFlags().String(
+		passwordFlag,
+		"seldon",
+		"Password to authenticate with.",
+	)
+
+	cmd.PersistentFlags().BoolP(
+		insecureFlag,
+		"i",
+		false,
+		"Connect to the node without TLS.",
+	)
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/synnaxlabs/freighter"
+	"github.com/synnaxlabs/freighter/fhttp"
+	"github.com/synnaxlabs/synnax/pkg/api"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/export"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export channel data from a running node to a CSV, Parquet, or Arrow file.",
+	Long: `Export channel data from a running node to a CSV, Parquet, or Arrow file.
+
+Channels are aligned on their index, and the index of every exported channel is
+included as a column. Channels with different indexes are merged in timestamp order,
+leaving cells empty where a channel has no sample at a row's timestamp.`,
+	Example: `synnax export --channels temperature,pressure --start 2025-01-01T00:00:00Z --end 2025-01-02T00:00:00Z -o data.parquet`,
+	Args:    cobra.NoArgs,
+	PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+		bindFlags(cmd)
+		cmd.SilenceUsage = true
+	},
+	RunE: func(cmd *cobra.Command, _ []string) (err error) {
+		req, err := buildExportRequest()
+		if err != nil {
+			return err
+		}
+		var out io.Writer = os.Stdout
+		if path := viper.GetString(exportOutputFlag); path != "" {
+			f, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			defer func() { err = errors.Combine(err, f.Close()) }()
+			out = f
+		}
+		c, err := newClusterClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		client := fhttp.StreamClient[api.FrameExportRequest, api.FrameExportResponse](c.factory)
+		client.Use(freighter.MiddlewareFunc(func(
+			ctx freighter.Context,
+			next freighter.Next,
+		) (freighter.Context, error) {
+			ctx.Params.Set("Authorization", "Bearer "+c.token)
+			return next(ctx)
+		}))
+		stream, err := client.Stream(cmd.Context(), c.host+"/api/v1/frame/export")
+		if err != nil {
+			return err
+		}
+		if err = stream.Send(req); err != nil {
+			return err
+		}
+		if err = stream.CloseSend(); err != nil {
+			return err
+		}
+		for {
+			res, err := stream.Receive()
+			if errors.Is(err, freighter.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if _, err = out.Write(res.Data); err != nil {
+				return err
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	configureExportFlags()
+}
+
+func buildExportRequest() (req api.FrameExportRequest, err error) {
+	for _, ch := range viper.GetStringSlice(exportChannelsFlag) {
+		if key, err := strconv.ParseUint(ch, 10, 32); err == nil {
+			req.Keys = append(req.Keys, channel.Key(key))
+		} else {
+			req.Names = append(req.Names, ch)
+		}
+	}
+	if req.TimeRange.Start, err = parseExportTimeStamp(viper.GetString(exportStartFlag), telem.TimeStampMin); err != nil {
+		return req, err
+	}
+	if req.TimeRange.End, err = parseExportTimeStamp(viper.GetString(exportEndFlag), telem.TimeStampMax); err != nil {
+		return req, err
+	}
+	if rng := viper.GetString(exportRangeFlag); rng != "" {
+		if req.Range, err = uuid.Parse(rng); err != nil {
+			return req, errors.Wrapf(err, "invalid range key %q", rng)
+		}
+		if viper.GetString(exportStartFlag) == "" && viper.GetString(exportEndFlag) == "" {
+			req.TimeRange = telem.TimeRangeZero
+		}
+	}
+	req.Format = export.Format(viper.GetString(exportFormatFlag))
+	if req.Format == "" {
+		req.Format = export.FormatCSV
+		switch strings.ToLower(filepath.Ext(viper.GetString(exportOutputFlag))) {
+		case ".parquet":
+			req.Format = export.FormatParquet
+		case ".arrow", ".arrows":
+			req.Format = export.FormatArrow
+		}
+	}
+	return req, req.Validate()
+}
+
+// parseExportTimeStamp parses a timestamp as either an RFC3339 string or nanoseconds
+// since the unix epoch, returning def if the string is empty.
+func parseExportTimeStamp(s string, def telem.TimeStamp) (telem.TimeStamp, error) {
+	if s == "" {
+		return def, nil
+	}
+	if ns, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return telem.TimeStamp(ns), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid timestamp %q", s)
+	}
+	return telem.NewTimeStamp(t), nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cmd
+
+const (
+	exportChannelsFlag = "channels"
+	exportStartFlag    = "start"
+	exportEndFlag      = "end"
+	exportRangeFlag    = "range"
+	exportFormatFlag   = "format"
+	exportOutputFlag   = "output"
+)
+
+func configureExportFlags() {
+	configureConnectionFlags(exportCmd)
+
+	exportCmd.Flags().StringSlice(
+		exportChannelsFlag,
+		nil,
+		"The keys or names of the channels to export.",
+	)
+
+	exportCmd.Flags().String(
+		exportStartFlag,
+		"",
+		"The start of the time range to export, as an RFC3339 timestamp or nanoseconds since the unix epoch.",
+	)
+
+	exportCmd.Flags().String(
+		exportEndFlag,
+		"",
+		"The end of the time range to export, as an RFC3339 timestamp or nanoseconds since the unix epoch.",
+	)
+
+	exportCmd.Flags().String(
+		exportRangeFlag,
+		"",
+		"The key of a range to export. Channel names are resolved as aliases on the range.",
+	)
+
+	exportCmd.Flags().StringP(
+		exportFormatFlag,
+		"f",
+		"",
+		"The file format to export (csv, parquet, or arrow). Defaults to the extension of the output file, or csv.",
+	)
+
+	exportCmd.Flags().StringP(
+		exportOutputFlag,
+		"o",
+		"",
+		"The file to write the export to. Defaults to stdout.",
+	)
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/synnaxlabs/freighter"
+	"github.com/synnaxlabs/freighter/fhttp"
+	"github.com/synnaxlabs/synnax/pkg/api"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/importer"
+	"github.com/synnaxlabs/x/errors"
+)
+
+// importChunkSize is the size of each chunk of the file sent to the node.
+const importChunkSize = 1 << 20
+
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import a CSV or Parquet file into channels on a running node.",
+	Long: `Import a CSV or Parquet file into channels on a running node.
+
+Each imported column is written to the channel with the same name, or to the channel
+given with --column column=channel. Timestamps in index columns must be strictly
+increasing, and are parsed as nanoseconds since the unix epoch or RFC3339 strings. The
+file is validated in full before any data is written.`,
+	Example: `synnax import data.csv --column time --column temp=temperature --index time --create-channels --range "Test 12"`,
+	Args:    cobra.ExactArgs(1),
+	PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+		bindFlags(cmd)
+		cmd.SilenceUsage = true
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		req, err := buildImportRequest(args[0])
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer func() { err = errors.Combine(err, f.Close()) }()
+		c, err := newClusterClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		client := fhttp.StreamClient[api.FrameImportRequest, api.FrameImportResponse](c.factory)
+		client.Use(freighter.MiddlewareFunc(func(
+			ctx freighter.Context,
+			next freighter.Next,
+		) (freighter.Context, error) {
+			ctx.Params.Set("Authorization", "Bearer "+c.token)
+			return next(ctx)
+		}))
+		stream, err := client.Stream(cmd.Context(), c.host+"/api/v1/frame/import")
+		if err != nil {
+			return err
+		}
+		msg := api.FrameImportRequest{Config: req}
+		buf := make([]byte, importChunkSize)
+		for {
+			n, err := f.Read(buf)
+			if n > 0 {
+				msg.Data = buf[:n]
+				if err := stream.Send(msg); err != nil {
+					return err
+				}
+				msg = api.FrameImportRequest{}
+			}
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if err = stream.CloseSend(); err != nil {
+			return err
+		}
+		res, err := stream.Receive()
+		if err != nil {
+			return err
+		}
+		cmd.Printf("imported %d rows spanning %s\n", res.Rows, res.TimeRange)
+		for _, ch := range res.Channels {
+			cmd.Printf("  %s (%d, %s)\n", ch.Name, ch.Key(), ch.DataType)
+		}
+		if req.Range != "" {
+			cmd.Printf("created range %s (%s)\n", req.Range, res.Range)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	configureImportFlags()
+}
+
+func buildImportRequest(path string) (importer.Request, error) {
+	req := importer.Request{
+		Format:         importer.Format(viper.GetString(importFormatFlag)),
+		CreateChannels: viper.GetBool(importCreateChannelsFlag),
+		Range:          viper.GetString(importRangeFlag),
+	}
+	if req.Format == "" {
+		req.Format = importer.Format(strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), "."))
+	}
+	indexes := viper.GetStringSlice(importIndexFlag)
+	for _, col := range viper.GetStringSlice(importColumnFlag) {
+		name, ch, _ := strings.Cut(col, "=")
+		req.Columns = append(req.Columns, importer.Column{
+			Name:    name,
+			Channel: ch,
+			IsIndex: slices.Contains(indexes, name),
+		})
+	}
+	for _, idx := range indexes {
+		if !slices.ContainsFunc(req.Columns, func(c importer.Column) bool { return c.Name == idx }) {
+			return req, errors.Newf("index column %s must also be provided with --%s", idx, importColumnFlag)
+		}
+	}
+	return req, req.Validate()
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cmd
+
+const (
+	importColumnFlag         = "column"
+	importIndexFlag          = "index"
+	importCreateChannelsFlag = "create-channels"
+	importRangeFlag          = "range"
+	importFormatFlag         = "format"
+)
+
+func configureImportFlags() {
+	configureConnectionFlags(importCmd)
+
+	importCmd.Flags().StringArray(
+		importColumnFlag,
+		nil,
+		"A column to import, as 'column' or 'column=channel'. Can be provided multiple times.",
+	)
+
+	importCmd.Flags().StringSlice(
+		importIndexFlag,
+		nil,
+		"The columns holding the timestamps of the other columns.",
+	)
+
+	importCmd.Flags().Bool(
+		importCreateChannelsFlag,
+		false,
+		"Create channels that do not exist, inferring their data types from the file.",
+	)
+
+	importCmd.Flags().String(
+		importRangeFlag,
+		"",
+		"The name of a range to create spanning the imported data.",
+	)
+
+	importCmd.Flags().StringP(
+		importFormatFlag,
+		"f",
+		"",
+		"The format of the file (csv, parquet or arrow). Defaults to the extension of the file.",
+	)
+}
@@ -21,6 +21,7 @@ const (
 	nodeCertFlag          = "node-cert"
 	allowKeyReuseFlag     = "allow-key-reuse"
 	keySizeFlag           = "key-size"
+	certValidForFlag      = "cert-valid-for"
 	verboseFlag           = "verbose"
 	debugFlag             = "debug"
 	logFilePathFlag       = "log-file-path"
@@ -86,6 +87,12 @@ func configureRootFlags() {
 		"The size to use for certificate key generation.",
 	)
 
+	rootCmd.PersistentFlags().Duration(
+		certValidForFlag,
+		cert.DefaultFactoryConfig.ValidFor,
+		"How long generated node and client certificates are valid for.",
+	)
+
 	rootCmd.PersistentFlags().BoolP(
 		verboseFlag,
 		"v",
@@ -32,6 +32,7 @@ import (
 	channeltransport "github.com/synnaxlabs/synnax/pkg/distribution/transport/grpc/channel"
 	framertransport "github.com/synnaxlabs/synnax/pkg/distribution/transport/grpc/framer"
 	"github.com/synnaxlabs/synnax/pkg/security"
+	"github.com/synnaxlabs/synnax/pkg/security/cert"
 	"github.com/synnaxlabs/synnax/pkg/server"
 	"github.com/synnaxlabs/synnax/pkg/service"
 	"github.com/synnaxlabs/synnax/pkg/service/hardware/embedded"
@@ -43,6 +44,7 @@ import (
 	xio "github.com/synnaxlabs/x/io"
 	xservice "github.com/synnaxlabs/x/service"
 	xsignal "github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/telem"
 	"go.uber.org/zap"
 )
 
@@ -86,6 +88,9 @@ func start(cmd *cobra.Command) {
 		verifier            = viper.GetString(string(verifierFlag))
 		memBacked           = viper.GetBool(memFlag)
 		listenAddress       = address.Address(viper.GetString(listenFlag))
+		flightListenAddress = address.Address(viper.GetString(flightListenFlag))
+		enableMQTT          = viper.GetBool(mqttFlag)
+		enableSQL           = viper.GetBool(sqlFlag)
 		dataPath            = viper.GetString(dataFlag)
 		slowConsumerTimeout = viper.GetDuration(slowConsumerTimeoutFlag)
 		rootUsername        = viper.GetString(usernameFlag)
@@ -128,11 +133,13 @@ func start(cmd *cobra.Command) {
 			closer            xio.MultiCloser
 			peers             = parsePeerAddressFlag()
 			securityProvider  security.Provider
+			certRotator       *security.Rotator
 			storageLayer      *storage.Layer
 			distributionLayer *distribution.Layer
 			serviceLayer      *service.Layer
 			apiLayer          *api.Layer
 			rootServer        *server.Server
+			flightServer      *server.Server
 			embeddedDriver    *embedded.Driver
 			certLoaderConfig  = buildCertLoaderConfig(ins)
 		)
@@ -149,6 +156,22 @@ func start(cmd *cobra.Command) {
 			return err
 		}
 
+		if !insecure {
+			var certFactory *cert.Factory
+			if certFactory, err = cert.NewFactory(buildCertFactoryConfig(ins)); !ok(err, nil) {
+				return err
+			}
+			if certRotator, err = security.OpenRotator(security.RotatorConfig{
+				Instrumentation: ins.Child("cert"),
+				Provider:        securityProvider,
+				Factory:         certFactory,
+				RenewBefore:     viper.GetDuration(certRenewBeforeFlag),
+				CheckInterval:   viper.GetDuration(certCheckIntervalFlag),
+			}); !ok(err, certRotator) {
+				return err
+			}
+		}
+
 		workDir, err := resolveWorkDir()
 		if err != nil {
 			return errors.Wrapf(err, "failed to resolve working directory")
@@ -188,10 +211,32 @@ func start(cmd *cobra.Command) {
 			return err
 		}
 
+		oidcConfig, err := parseOIDCFlags()
+		if err != nil {
+			return err
+		}
+		ldapConfig, err := parseLDAPFlags()
+		if err != nil {
+			return err
+		}
+		limitConfig, err := parseLimitFlags()
+		if err != nil {
+			return err
+		}
+		clientCAs, err := parseClientCertAuthFlags(securityProvider)
+		if err != nil {
+			return err
+		}
+
 		if serviceLayer, err = service.Open(ctx, service.Config{
 			Instrumentation: ins.Child("service"),
 			Distribution:    distributionLayer,
 			Security:        securityProvider,
+			OIDC:            oidcConfig,
+			LDAP:            ldapConfig,
+			MirrorAuditLog:  config.Bool(viper.GetBool(auditChannelFlag)),
+			Limit:           limitConfig,
+			MaxImportSize:   telem.Size(viper.GetInt(maxImportSizeFlag)) * telem.Megabyte,
 		}); !ok(err, serviceLayer) {
 			return err
 		}
@@ -212,6 +257,22 @@ func start(cmd *cobra.Command) {
 			return err
 		}
 
+		if err = maybeSetBasePermissions(
+			ctx,
+			distributionLayer,
+			serviceLayer,
+		); !ok(err, nil) {
+			return err
+		}
+
+		if err = maybeProvisionRoles(
+			ctx,
+			distributionLayer,
+			serviceLayer,
+		); !ok(err, nil) {
+			return err
+		}
+
 		// We run startup searching indexing after all services have been
 		// registered within the ontology. We used to fork a new goroutine for
 		// every service at registration time, but this caused a race condition
@@ -234,28 +295,63 @@ func start(cmd *cobra.Command) {
 		grpcAPI, grpcAPITrans := grpcapi.New(distributionLayer.Channel)
 		apiLayer.BindTo(grpcAPI)
 
+		branches := []server.Branch{
+			&server.SecureHTTPBranch{Transports: []fhttp.BindableTransport{r, serviceLayer.Console}},
+		}
+		// The gRPC branch matches all remaining connections, so the MQTT and Postgres
+		// branches need to come before it.
+		if enableMQTT {
+			branches = append(branches, &server.MQTTBranch{Broker: apiLayer.MQTT.Broker})
+		}
+		if enableSQL {
+			branches = append(branches, &server.PostgresBranch{Handler: apiLayer.SQL})
+		}
+		branches = append(
+			branches,
+			&server.GRPCBranch{Transports: slices.Concat(
+				grpcAPITrans,
+				distributionTransports,
+			)},
+			server.NewHTTPRedirectBranch(),
+		)
 		if rootServer, err = server.Serve(
 			server.Config{
-				Branches: []server.Branch{
-					&server.SecureHTTPBranch{Transports: []fhttp.BindableTransport{r, serviceLayer.Console}},
-					&server.GRPCBranch{Transports: slices.Concat(
-						grpcAPITrans,
-						distributionTransports,
-					)},
-					server.NewHTTPRedirectBranch(),
-				},
+				Branches:        branches,
 				Debug:           config.Bool(debug),
 				ListenAddress:   listenAddress,
 				Instrumentation: ins.Child("server"),
 				Security: server.SecurityConfig{
-					TLS:      securityProvider.TLS(),
-					Insecure: config.Bool(insecure),
+					TLS:       securityProvider.TLS(),
+					Insecure:  config.Bool(insecure),
+					ClientCAs: clientCAs,
 				},
 			},
 		); !ok(err, rootServer) {
 			return err
 		}
 
+		// Arrow Flight can't share a listener with the other gRPC services, so it's
+		// served on its own address.
+		if flightListenAddress != "" {
+			if flightServer, err = server.Serve(
+				server.Config{
+					Branches: []server.Branch{&server.FlightBranch{
+						Service:    apiLayer.Flight,
+						Middleware: apiLayer.Flight.Middleware(),
+					}},
+					Debug:           config.Bool(debug),
+					ListenAddress:   flightListenAddress,
+					Instrumentation: ins.Child("flight"),
+					Security: server.SecurityConfig{
+						TLS:      securityProvider.TLS(),
+						Insecure: config.Bool(insecure),
+					},
+				},
+			); !ok(err, flightServer) {
+				return err
+			}
+		}
+
 		if embeddedDriver, err = embedded.OpenDriver(
 			ctx,
 			embedded.Config{
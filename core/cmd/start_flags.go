@@ -10,17 +10,31 @@
 package cmd
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/samber/lo"
 	"github.com/spf13/viper"
+	"github.com/synnaxlabs/synnax/pkg/security"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/ldap"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/oidc"
 	"github.com/synnaxlabs/synnax/pkg/service/hardware/embedded"
+	"github.com/synnaxlabs/synnax/pkg/service/limit"
 	"github.com/synnaxlabs/x/address"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
 )
 
 const (
 	listenFlag              = "listen"
+	flightListenFlag        = "flight-listen"
+	mqttFlag                = "mqtt"
+	sqlFlag                 = "sql"
 	peersFlag               = "peers"
 	dataFlag                = "data"
 	memFlag                 = "mem"
@@ -32,6 +46,36 @@ const (
 	slowConsumerTimeoutFlag = "slow-consumer-timeout"
 	enableIntegrationsFlag  = "enable-integrations"
 	disableIntegrationsFlag = "disable-integrations"
+	oidcIssuerFlag          = "oidc-issuer"
+	oidcClientIDFlag        = "oidc-client-id"
+	oidcClientSecretFlag    = "oidc-client-secret"
+	oidcRedirectURLFlag     = "oidc-redirect-url"
+	oidcScopesFlag          = "oidc-scopes"
+	oidcUsernameClaimFlag   = "oidc-username-claim"
+	oidcGroupsClaimFlag     = "oidc-groups-claim"
+	oidcRoleMappingFlag     = "oidc-role-mapping"
+	ldapURLFlag             = "ldap-url"
+	ldapStartTLSFlag        = "ldap-start-tls"
+	ldapCACertFlag          = "ldap-ca-cert"
+	ldapBindDNFlag          = "ldap-bind-dn"
+	ldapBindPasswordFlag    = "ldap-bind-password"
+	ldapBaseDNFlag          = "ldap-base-dn"
+	ldapUserFilterFlag      = "ldap-user-filter"
+	ldapGroupAttributeFlag  = "ldap-group-attribute"
+	ldapRoleMappingFlag     = "ldap-role-mapping"
+	ldapCacheTTLFlag        = "ldap-cache-ttl"
+	auditChannelFlag        = "audit-channel"
+	clientCertAuthFlag      = "client-cert-auth"
+	clientCACertFlag        = "client-ca-cert"
+	certRenewBeforeFlag     = "cert-renew-before"
+	certCheckIntervalFlag   = "cert-check-interval"
+	rateLimitFlag           = "rate-limit"
+	rateLimitBurstFlag      = "rate-limit-burst"
+	rateLimitEndpointFlag   = "rate-limit-endpoint"
+	maxStreamersFlag        = "max-streamers"
+	maxWritersFlag          = "max-writers"
+	maxIteratorsFlag        = "max-iterators"
+	maxImportSizeFlag       = "max-import-size"
 )
 
 func configureStartFlags() {
@@ -42,6 +86,25 @@ func configureStartFlags() {
 		`The address to listen for client connections.`,
 	)
 
+	startCmd.Flags().String(
+		flightListenFlag,
+		"localhost:8815",
+		`The address to listen for Apache Arrow Flight connections. Set to an empty
+string to disable the Flight server.`,
+	)
+
+	startCmd.Flags().Bool(
+		mqttFlag,
+		false,
+		"Enable the embedded MQTT broker on the listen address.",
+	)
+
+	startCmd.Flags().Bool(
+		sqlFlag,
+		false,
+		"Enable the PostgreSQL wire protocol SQL endpoint on the listen address.",
+	)
+
 	startCmd.Flags().StringSliceP(
 		peersFlag,
 		"p",
@@ -112,6 +175,197 @@ func configureStartFlags() {
 		"Terminate slow consumers of the relay after this timeout.",
 	)
 
+	startCmd.Flags().String(
+		oidcIssuerFlag,
+		"",
+		"Issuer URL of an OpenID Connect identity provider to enable single sign-on with.",
+	)
+
+	startCmd.Flags().String(
+		oidcClientIDFlag,
+		"",
+		"Client ID registered for Synnax at the OpenID Connect identity provider.",
+	)
+
+	startCmd.Flags().String(
+		oidcClientSecretFlag,
+		"",
+		"Client secret registered for Synnax at the OpenID Connect identity provider.",
+	)
+
+	startCmd.Flags().String(
+		oidcRedirectURLFlag,
+		"",
+		"URL the OpenID Connect identity provider redirects users to after they sign in.",
+	)
+
+	startCmd.Flags().StringSlice(
+		oidcScopesFlag,
+		nil,
+		"Scopes to request from the OpenID Connect identity provider.",
+	)
+
+	startCmd.Flags().String(
+		oidcUsernameClaimFlag,
+		"",
+		"ID token claim used as the username of single sign-on users.",
+	)
+
+	startCmd.Flags().String(
+		oidcGroupsClaimFlag,
+		"",
+		"ID token claim that lists the groups of single sign-on users.",
+	)
+
+	startCmd.Flags().StringSlice(
+		oidcRoleMappingFlag,
+		nil,
+		`Assign a role to members of an identity provider group, in the form
+group=role.`,
+	)
+
+	startCmd.Flags().String(
+		ldapURLFlag,
+		"",
+		"URL of an LDAP directory to authenticate users against (ldap:// or ldaps://).",
+	)
+
+	startCmd.Flags().Bool(
+		ldapStartTLSFlag,
+		false,
+		"Upgrade ldap:// connections to the directory with StartTLS.",
+	)
+
+	startCmd.Flags().String(
+		ldapCACertFlag,
+		"",
+		"Path to a PEM CA certificate used to verify the LDAP directory.",
+	)
+
+	startCmd.Flags().String(
+		ldapBindDNFlag,
+		"",
+		"DN of the LDAP service account used to search for users.",
+	)
+
+	startCmd.Flags().String(
+		ldapBindPasswordFlag,
+		"",
+		"Password of the LDAP service account.",
+	)
+
+	startCmd.Flags().String(
+		ldapBaseDNFlag,
+		"",
+		"DN that searches for LDAP users start at.",
+	)
+
+	startCmd.Flags().String(
+		ldapUserFilterFlag,
+		"",
+		`Filter used to search for LDAP users, with %s standing for the username.
+Defaults to (uid=%s). Use (sAMAccountName=%s) for Active Directory.`,
+	)
+
+	startCmd.Flags().String(
+		ldapGroupAttributeFlag,
+		"",
+		"Attribute of LDAP user entries that lists their groups. Defaults to memberOf.",
+	)
+
+	startCmd.Flags().StringSlice(
+		ldapRoleMappingFlag,
+		nil,
+		`Assign a role to members of an LDAP group, in the form group=role. Groups can
+be given by DN or common name.`,
+	)
+
+	startCmd.Flags().Duration(
+		ldapCacheTTLFlag,
+		time.Hour,
+		`How long LDAP credentials remain usable while the directory is unreachable
+after a successful login. Set to a negative value to disable the cache.`,
+	)
+
+	startCmd.Flags().Bool(
+		auditChannelFlag,
+		false,
+		"Mirror entries in the audit log to the sy_audit channel as they are recorded.",
+	)
+
+	startCmd.Flags().Bool(
+		clientCertAuthFlag,
+		false,
+		`Authenticate API clients that present a certificate signed by the cluster CA
+as the user named by the certificate's common name.`,
+	)
+
+	startCmd.Flags().String(
+		clientCACertFlag,
+		"",
+		`Path to a PEM CA certificate whose client certificates are accepted in addition
+to those signed by the cluster CA. Requires --client-cert-auth.`,
+	)
+
+	startCmd.Flags().Duration(
+		certRenewBeforeFlag,
+		security.DefaultRotatorConfig.RenewBefore,
+		`How long before the node certificate expires that it is reissued from the CA.
+Must be shorter than --cert-valid-for.`,
+	)
+
+	startCmd.Flags().Duration(
+		certCheckIntervalFlag,
+		security.DefaultRotatorConfig.CheckInterval,
+		"The interval at which the expiry of the node certificate is checked.",
+	)
+
+	startCmd.Flags().Float64(
+		rateLimitFlag,
+		0,
+		`The number of requests per second each user may make to each API endpoint. Set
+to 0 to disable rate limiting.`,
+	)
+
+	startCmd.Flags().Int(
+		rateLimitBurstFlag,
+		0,
+		`The number of requests each user may make to each API endpoint at once before
+--rate-limit applies. Defaults to --rate-limit rounded up.`,
+	)
+
+	startCmd.Flags().StringSlice(
+		rateLimitEndpointFlag,
+		nil,
+		`Override --rate-limit for an API endpoint, in the form endpoint=rate or
+endpoint=rate:burst, e.g. /api/v1/frame/export=0.5:2. Set the rate to 0 to disable
+rate limiting for the endpoint.`,
+	)
+
+	startCmd.Flags().Int(
+		maxStreamersFlag,
+		0,
+		"The maximum number of streamers each user may have open. Set to 0 for no limit.",
+	)
+
+	startCmd.Flags().Int(
+		maxWritersFlag,
+		0,
+		"The maximum number of writers each user may have open. Set to 0 for no limit.",
+	)
+
+	startCmd.Flags().Int(
+		maxIteratorsFlag,
+		0,
+		"The maximum number of iterators each user may have open. Set to 0 for no limit.",
+	)
+
+	startCmd.Flags().Int(
+		maxImportSizeFlag,
+		1000,
+		"The maximum size, in megabytes, of a file imported through the API or Arrow Flight.",
+	)
+
 	decodedName, _ := base64.StdEncoding.DecodeString("bGljZW5zZS1rZXk=")
 	decodedUsage, _ := base64.StdEncoding.DecodeString("TGljZW5zZSBrZXkgaW4gZm9ybSAiIyMjIyMjLSMjIyMjIyMjLSMjIyMjIyMjIyMiLg==")
 
@@ -141,3 +395,131 @@ func parsePeerAddressFlag() []address.Address {
 	}
 	return peerAddresses
 }
+
+func parseOIDCFlags() (*oidc.Config, error) {
+	issuer := viper.GetString(oidcIssuerFlag)
+	if issuer == "" {
+		return nil, nil
+	}
+	cfg := &oidc.Config{
+		Issuer:        issuer,
+		ClientID:      viper.GetString(oidcClientIDFlag),
+		ClientSecret:  viper.GetString(oidcClientSecretFlag),
+		RedirectURL:   viper.GetString(oidcRedirectURLFlag),
+		Scopes:        viper.GetStringSlice(oidcScopesFlag),
+		UsernameClaim: viper.GetString(oidcUsernameClaimFlag),
+		GroupsClaim:   viper.GetString(oidcGroupsClaimFlag),
+	}
+	var err error
+	cfg.RoleMappings, err = parseRoleMappingFlag(oidcRoleMappingFlag)
+	return cfg, err
+}
+
+func parseLDAPFlags() (*ldap.Config, error) {
+	u := viper.GetString(ldapURLFlag)
+	if u == "" {
+		return nil, nil
+	}
+	cfg := &ldap.Config{
+		URL:            u,
+		StartTLS:       config.Bool(viper.GetBool(ldapStartTLSFlag)),
+		BindDN:         viper.GetString(ldapBindDNFlag),
+		BindPassword:   viper.GetString(ldapBindPasswordFlag),
+		BaseDN:         viper.GetString(ldapBaseDNFlag),
+		UserFilter:     viper.GetString(ldapUserFilterFlag),
+		GroupAttribute: viper.GetString(ldapGroupAttributeFlag),
+		CacheTTL:       viper.GetDuration(ldapCacheTTLFlag),
+	}
+	if caPath := viper.GetString(ldapCACertFlag); caPath != "" {
+		pem, err := os.ReadFile(caPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", ldapCACertFlag)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Newf("no certificates found in %s", caPath)
+		}
+		cfg.TLS = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	var err error
+	cfg.RoleMappings, err = parseRoleMappingFlag(ldapRoleMappingFlag)
+	return cfg, err
+}
+
+// parseLimitFlags returns the rate limits and concurrency quotas enforced on API calls.
+func parseLimitFlags() (limit.Config, error) {
+	endpoints, err := parseEndpointLimitFlag(rateLimitEndpointFlag)
+	return limit.Config{
+		RequestsPerSecond: viper.GetFloat64(rateLimitFlag),
+		Burst:             viper.GetInt(rateLimitBurstFlag),
+		Endpoints:         endpoints,
+		MaxStreamers:      viper.GetInt(maxStreamersFlag),
+		MaxWriters:        viper.GetInt(maxWritersFlag),
+		MaxIterators:      viper.GetInt(maxIteratorsFlag),
+	}, err
+}
+
+// parseEndpointLimitFlag parses the rate limits of endpoints given in the form
+// endpoint=rate or endpoint=rate:burst.
+func parseEndpointLimitFlag(flag string) (map[string]limit.EndpointLimit, error) {
+	limits := make(map[string]limit.EndpointLimit)
+	for _, l := range viper.GetStringSlice(flag) {
+		endpoint, value, ok := strings.Cut(l, "=")
+		if !ok || endpoint == "" || value == "" {
+			return nil, errors.Newf("invalid %s %q, expected endpoint=rate[:burst]", flag, l)
+		}
+		rate, burst, hasBurst := strings.Cut(value, ":")
+		var (
+			el  limit.EndpointLimit
+			err error
+		)
+		if el.RequestsPerSecond, err = strconv.ParseFloat(rate, 64); err != nil {
+			return nil, errors.Wrapf(err, "invalid rate in %s %q", flag, l)
+		}
+		if hasBurst {
+			if el.Burst, err = strconv.Atoi(burst); err != nil {
+				return nil, errors.Wrapf(err, "invalid burst in %s %q", flag, l)
+			}
+		}
+		limits[endpoint] = el
+	}
+	return limits, nil
+}
+
+// parseClientCertAuthFlags returns the pool of CAs that client certificates must be
+// signed by to authenticate API clients, or nil if client certificate authentication
+// is disabled.
+func parseClientCertAuthFlags(sec security.Provider) (*x509.CertPool, error) {
+	if !viper.GetBool(clientCertAuthFlag) {
+		if viper.GetString(clientCACertFlag) != "" {
+			return nil, errors.Newf("%s requires %s", clientCACertFlag, clientCertAuthFlag)
+		}
+		return nil, nil
+	}
+	if viper.GetBool(insecureFlag) {
+		return nil, errors.Newf("%s cannot be used with %s", clientCertAuthFlag, insecureFlag)
+	}
+	pool := sec.TLS().ClientCAs.Clone()
+	if caPath := viper.GetString(clientCACertFlag); caPath != "" {
+		pem, err := os.ReadFile(caPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", clientCACertFlag)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Newf("no certificates found in %s", caPath)
+		}
+	}
+	return pool, nil
+}
+
+func parseRoleMappingFlag(flag string) (map[string]string, error) {
+	mappings := make(map[string]string)
+	for _, m := range viper.GetStringSlice(flag) {
+		group, role, ok := strings.Cut(m, "=")
+		if !ok || group == "" || role == "" {
+			return nil, errors.Newf("invalid %s %q, expected group=role", flag, m)
+		}
+		mappings[group] = role
+	}
+	return mappings, nil
+}
@@ -21,13 +21,13 @@ import (
 )
 
 type AccessService struct {
-	internal *rbac.Service
+	internal enforcer
 	dbProvider
 }
 
 func NewAccessService(p Provider) *AccessService {
 	return &AccessService{
-		internal:   p.Service.RBAC,
+		internal:   p.access.access,
 		dbProvider: p.db,
 	}
 }
@@ -135,3 +135,174 @@ func (s *AccessService) DeletePolicy(ctx context.Context, req AccessDeletePolicy
 		return s.internal.NewWriter(tx).Delete(ctx, req.Keys...)
 	})
 }
+
+type (
+	AccessCreateRoleRequest struct {
+		Roles []rbac.Role `json:"roles" msgpack:"roles"`
+	}
+	AccessCreateRoleResponse = AccessCreateRoleRequest
+)
+
+// CreateRole creates the given roles. Roles created through the API are never builtin.
+func (s *AccessService) CreateRole(
+	ctx context.Context,
+	req AccessCreateRoleRequest,
+) (AccessCreateRoleResponse, error) {
+	if err := s.internal.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Objects: rbac.RoleOntologyIDsFromRoles(req.Roles),
+		Action:  access.Create,
+	}); err != nil {
+		return AccessCreateRoleResponse{}, err
+	}
+	return req, s.WithTx(ctx, func(tx gorp.Tx) error {
+		w := s.internal.NewWriter(tx)
+		for i := range req.Roles {
+			req.Roles[i].Builtin = false
+			if err := w.CreateRole(ctx, &req.Roles[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AccessRetrieveRoleRequest is a request for retrieving roles from the cluster.
+type AccessRetrieveRoleRequest struct {
+	// Keys is an optional list of keys of the roles to retrieve.
+	Keys []uuid.UUID `json:"keys" msgpack:"keys"`
+	// Names is an optional list of names of the roles to retrieve.
+	Names []string `json:"names" msgpack:"names"`
+	// Subjects is an optional list of users and groups to retrieve the assigned roles
+	// of.
+	Subjects []ontology.ID `json:"subjects" msgpack:"subjects"`
+}
+
+// AccessRetrieveRoleResponse is the response containing the retrieved roles.
+type AccessRetrieveRoleResponse struct {
+	Roles []rbac.Role `json:"roles" msgpack:"roles"`
+}
+
+// RetrieveRole retrieves the roles matching the request.
+func (s *AccessService) RetrieveRole(
+	ctx context.Context,
+	req AccessRetrieveRoleRequest,
+) (res AccessRetrieveRoleResponse, err error) {
+	q := s.internal.NewRoleRetrieve()
+	if len(req.Keys) > 0 {
+		q = q.WhereKeys(req.Keys...)
+	}
+	if len(req.Names) > 0 {
+		q = q.WhereNames(req.Names...)
+	}
+	if len(req.Subjects) > 0 {
+		q = q.WhereSubjects(req.Subjects...)
+	}
+	if err = q.Entries(&res.Roles).Exec(ctx, nil); err != nil {
+		return AccessRetrieveRoleResponse{}, err
+	}
+	if err = s.internal.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Action:  access.Retrieve,
+		Objects: rbac.RoleOntologyIDsFromRoles(res.Roles),
+	}); err != nil {
+		return AccessRetrieveRoleResponse{}, err
+	}
+	return res, nil
+}
+
+type AccessDeleteRoleRequest struct {
+	Keys []uuid.UUID `json:"keys" msgpack:"keys"`
+}
+
+// DeleteRole deletes the roles with the given keys, along with the policies granted
+// only to them.
+func (s *AccessService) DeleteRole(ctx context.Context, req AccessDeleteRoleRequest) (types.Nil, error) {
+	if err := s.internal.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Objects: rbac.RoleOntologyIDs(req.Keys),
+		Action:  access.Delete,
+	}); err != nil {
+		return types.Nil{}, err
+	}
+	return types.Nil{}, s.WithTx(ctx, func(tx gorp.Tx) error {
+		return s.internal.NewWriter(tx).DeleteRole(ctx, req.Keys...)
+	})
+}
+
+// AccessAssignRoleRequest is a request to assign a role to, or remove a role from,
+// users and groups of users.
+type AccessAssignRoleRequest struct {
+	// Key is the key of the role.
+	Key uuid.UUID `json:"key" msgpack:"key"`
+	// Subjects are the users and groups to assign the role to or remove it from.
+	Subjects []ontology.ID `json:"subjects" msgpack:"subjects"`
+}
+
+// AssignRole assigns a role to users and groups of users.
+func (s *AccessService) AssignRole(ctx context.Context, req AccessAssignRoleRequest) (types.Nil, error) {
+	if err := s.internal.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Objects: []ontology.ID{rbac.RoleOntologyID(req.Key)},
+		Action:  access.Update,
+	}); err != nil {
+		return types.Nil{}, err
+	}
+	return types.Nil{}, s.WithTx(ctx, func(tx gorp.Tx) error {
+		return s.internal.NewWriter(tx).AssignRole(ctx, req.Key, req.Subjects...)
+	})
+}
+
+// UnassignRole removes a role from users and groups of users.
+func (s *AccessService) UnassignRole(ctx context.Context, req AccessAssignRoleRequest) (types.Nil, error) {
+	if err := s.internal.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Objects: []ontology.ID{rbac.RoleOntologyID(req.Key)},
+		Action:  access.Update,
+	}); err != nil {
+		return types.Nil{}, err
+	}
+	return types.Nil{}, s.WithTx(ctx, func(tx gorp.Tx) error {
+		return s.internal.NewWriter(tx).UnassignRole(ctx, req.Key, req.Subjects...)
+	})
+}
+
+// AccessExplainRequest is a request to explain whether a subject may take an action on
+// a set of objects.
+type AccessExplainRequest struct {
+	// Subject is the subject to explain the request for. Defaults to the user making
+	// the request.
+	Subject ontology.ID `json:"subject" msgpack:"subject"`
+	// Objects are the objects the subject would take the action on.
+	Objects []ontology.ID `json:"objects" msgpack:"objects"`
+	// Action is the action the subject would take.
+	Action access.Action `json:"action" msgpack:"action"`
+}
+
+type AccessExplainResponse = rbac.Explanation
+
+// Explain reports whether the subject may take the action on the objects, along with
+// the policy that allowed or denied access to each object. Explaining a request for
+// another subject requires access to retrieve policies.
+func (s *AccessService) Explain(
+	ctx context.Context,
+	req AccessExplainRequest,
+) (AccessExplainResponse, error) {
+	caller := getSubject(ctx)
+	if req.Subject.IsZero() {
+		req.Subject = caller
+	} else if req.Subject != caller {
+		if err := s.internal.Enforce(ctx, access.Request{
+			Subject: caller,
+			Objects: []ontology.ID{{Type: rbac.PolicyOntologyType}},
+			Action:  access.Retrieve,
+		}); err != nil {
+			return AccessExplainResponse{}, err
+		}
+	}
+	return s.internal.Explain(ctx, access.Request{
+		Subject: req.Subject,
+		Objects: req.Objects,
+		Action:  req.Action,
+	})
+}
@@ -0,0 +1,272 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package api
+
+import (
+	"context"
+	"go/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/alarm"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/telem"
+)
+
+type AlarmService struct {
+	dbProvider
+	accessProvider
+	internal *alarm.Service
+}
+
+func NewAlarmService(p Provider) *AlarmService {
+	return &AlarmService{
+		internal:       p.Service.Alarm,
+		dbProvider:     p.db,
+		accessProvider: p.access,
+	}
+}
+
+type (
+	Alarm       = alarm.Alarm
+	AlarmStatus = alarm.Status
+	AlarmEvent  = alarm.Event
+)
+
+// AlarmCreateRequest is a request to create alarms in the cluster.
+type AlarmCreateRequest struct {
+	// Alarms are the alarms to create.
+	Alarms []Alarm `json:"alarms" msgpack:"alarms"`
+}
+
+// AlarmCreateResponse is a response to an AlarmCreateRequest.
+type AlarmCreateResponse struct {
+	// Alarms are the alarms that were created.
+	Alarms []Alarm `json:"alarms" msgpack:"alarms"`
+}
+
+func alarmKeys(alarms []Alarm) []uuid.UUID {
+	return lo.Map(alarms, func(a Alarm, _ int) uuid.UUID { return a.Key })
+}
+
+// Create creates the alarms in the cluster.
+func (s *AlarmService) Create(
+	ctx context.Context,
+	req AlarmCreateRequest,
+) (res AlarmCreateResponse, err error) {
+	if err = s.access.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Action:  access.Create,
+		Objects: alarm.OntologyIDs(alarmKeys(req.Alarms)),
+	}); err != nil {
+		return res, err
+	}
+	return res, s.WithTx(ctx, func(tx gorp.Tx) error {
+		w := s.internal.NewWriter(tx)
+		for i := range req.Alarms {
+			if err := w.Create(ctx, &req.Alarms[i]); err != nil {
+				return err
+			}
+		}
+		res.Alarms = req.Alarms
+		return nil
+	})
+}
+
+// AlarmRetrieveRequest is a request to retrieve alarms and their statuses.
+type AlarmRetrieveRequest struct {
+	// Keys are the keys of the alarms to retrieve.
+	Keys []uuid.UUID `json:"keys" msgpack:"keys"`
+	// Channels filters alarms by the channels they are evaluated against.
+	Channels []channel.Key `json:"channels" msgpack:"channels"`
+	// SearchTerm is used to fuzzy search for alarms by name.
+	SearchTerm string `json:"search_term" msgpack:"search_term"`
+	Limit      int    `json:"limit" msgpack:"limit"`
+	Offset     int    `json:"offset" msgpack:"offset"`
+	// IncludeStatus sets whether to include the current status of each alarm.
+	IncludeStatus bool `json:"include_status" msgpack:"include_status"`
+}
+
+// AlarmRetrieveResponse is a response to an AlarmRetrieveRequest.
+type AlarmRetrieveResponse struct {
+	// Alarms are the alarms that were retrieved.
+	Alarms []Alarm `json:"alarms" msgpack:"alarms"`
+	// Statuses are the current statuses of the retrieved alarms. Only populated if
+	// IncludeStatus is set.
+	Statuses []AlarmStatus `json:"statuses" msgpack:"statuses"`
+}
+
+// Retrieve retrieves alarms from the cluster.
+func (s *AlarmService) Retrieve(
+	ctx context.Context,
+	req AlarmRetrieveRequest,
+) (res AlarmRetrieveResponse, err error) {
+	q := s.internal.NewRetrieve()
+	if req.SearchTerm != "" {
+		q = q.Search(req.SearchTerm)
+	}
+	if len(req.Keys) != 0 {
+		q = q.WhereKeys(req.Keys...)
+	}
+	if len(req.Channels) != 0 {
+		q = q.WhereChannels(req.Channels...)
+	}
+	if req.Limit != 0 {
+		q = q.Limit(req.Limit)
+	}
+	if req.Offset != 0 {
+		q = q.Offset(req.Offset)
+	}
+	if err = q.Entries(&res.Alarms).Exec(ctx, nil); err != nil {
+		return AlarmRetrieveResponse{}, err
+	}
+	keys := alarmKeys(res.Alarms)
+	if err = s.access.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Action:  access.Retrieve,
+		Objects: alarm.OntologyIDs(keys),
+	}); err != nil {
+		return AlarmRetrieveResponse{}, err
+	}
+	if req.IncludeStatus && len(keys) > 0 {
+		if res.Statuses, err = s.internal.RetrieveStatuses(ctx, keys...); err != nil {
+			return AlarmRetrieveResponse{}, err
+		}
+	}
+	return res, nil
+}
+
+// AlarmDeleteRequest is a request to delete alarms from the cluster.
+type AlarmDeleteRequest struct {
+	// Keys are the keys of the alarms to delete.
+	Keys []uuid.UUID `json:"keys" msgpack:"keys"`
+}
+
+// Delete deletes alarms from the cluster. The alarm log is retained.
+func (s *AlarmService) Delete(
+	ctx context.Context,
+	req AlarmDeleteRequest,
+) (types.Nil, error) {
+	if err := s.access.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Action:  access.Delete,
+		Objects: alarm.OntologyIDs(req.Keys),
+	}); err != nil {
+		return types.Nil{}, err
+	}
+	return types.Nil{}, s.WithTx(ctx, func(tx gorp.Tx) error {
+		return s.internal.NewWriter(tx).Delete(ctx, req.Keys...)
+	})
+}
+
+// AlarmAcknowledgeRequest is a request to acknowledge alarms.
+type AlarmAcknowledgeRequest struct {
+	// Keys are the keys of the alarms to acknowledge.
+	Keys []uuid.UUID `json:"keys" msgpack:"keys"`
+}
+
+// Acknowledge acknowledges alarms on behalf of the requesting user.
+func (s *AlarmService) Acknowledge(
+	ctx context.Context,
+	req AlarmAcknowledgeRequest,
+) (types.Nil, error) {
+	subject := getSubject(ctx)
+	if err := s.access.Enforce(ctx, access.Request{
+		Subject: subject,
+		Action:  access.Update,
+		Objects: alarm.OntologyIDs(req.Keys),
+	}); err != nil {
+		return types.Nil{}, err
+	}
+	return types.Nil{}, s.WithTx(ctx, func(tx gorp.Tx) error {
+		w := s.internal.NewWriter(tx)
+		for _, k := range req.Keys {
+			if err := w.Acknowledge(ctx, k, subject); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AlarmShelveRequest is a request to shelve or un-shelve alarms.
+type AlarmShelveRequest struct {
+	// Keys are the keys of the alarms to shelve.
+	Keys []uuid.UUID `json:"keys" msgpack:"keys"`
+	// Duration is how long to shelve the alarms for. A zero duration un-shelves the
+	// alarms.
+	Duration telem.TimeSpan `json:"duration" msgpack:"duration"`
+}
+
+// Shelve shelves or un-shelves alarms on behalf of the requesting user.
+func (s *AlarmService) Shelve(
+	ctx context.Context,
+	req AlarmShelveRequest,
+) (types.Nil, error) {
+	subject := getSubject(ctx)
+	if err := s.access.Enforce(ctx, access.Request{
+		Subject: subject,
+		Action:  access.Update,
+		Objects: alarm.OntologyIDs(req.Keys),
+	}); err != nil {
+		return types.Nil{}, err
+	}
+	return types.Nil{}, s.WithTx(ctx, func(tx gorp.Tx) error {
+		w := s.internal.NewWriter(tx)
+		for _, k := range req.Keys {
+			var err error
+			if req.Duration == 0 {
+				err = w.Unshelve(ctx, k, subject)
+			} else {
+				err = w.Shelve(ctx, k, req.Duration, subject)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AlarmRetrieveEventsRequest is a request to retrieve entries from the alarm log.
+type AlarmRetrieveEventsRequest struct {
+	// Keys are the keys of the alarms to retrieve events for.
+	Keys []uuid.UUID `json:"keys" msgpack:"keys"`
+	// TimeRange filters events to those that occurred within the range. A zero
+	// time range retrieves all events.
+	TimeRange telem.TimeRange `json:"time_range" msgpack:"time_range"`
+}
+
+// AlarmRetrieveEventsResponse is a response to an AlarmRetrieveEventsRequest.
+type AlarmRetrieveEventsResponse struct {
+	// Events are the retrieved events in chronological order.
+	Events []AlarmEvent `json:"events" msgpack:"events"`
+}
+
+// RetrieveEvents retrieves entries from the alarm log.
+func (s *AlarmService) RetrieveEvents(
+	ctx context.Context,
+	req AlarmRetrieveEventsRequest,
+) (res AlarmRetrieveEventsResponse, err error) {
+	if err = s.access.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Action:  access.Retrieve,
+		Objects: alarm.OntologyIDs(req.Keys),
+	}); err != nil {
+		return res, err
+	}
+	q := s.internal.NewEventRetrieve().WhereAlarms(req.Keys...)
+	if !req.TimeRange.IsZero() {
+		q = q.WhereTimeRange(req.TimeRange)
+	}
+	return res, q.Entries(&res.Events).Exec(ctx, nil)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/synnaxlabs/freighter/falamos"
 	"github.com/synnaxlabs/synnax/pkg/distribution"
 	"github.com/synnaxlabs/synnax/pkg/service"
+	"github.com/synnaxlabs/synnax/pkg/service/limit"
 	"github.com/synnaxlabs/x/config"
 	"github.com/synnaxlabs/x/override"
 	"github.com/synnaxlabs/x/validate"
@@ -60,6 +61,17 @@ type Transport struct {
 	// AUTH
 	AuthLogin          freighter.UnaryServer[AuthLoginRequest, AuthLoginResponse]
 	AuthChangePassword freighter.UnaryServer[AuthChangePasswordRequest, types.Nil]
+	AuthLogout         freighter.UnaryServer[AuthLogoutRequest, types.Nil]
+	AuthOIDCAuthorize  freighter.UnaryServer[types.Nil, AuthOIDCAuthorizeResponse]
+	AuthOIDCCallback   freighter.UnaryServer[AuthOIDCCallbackRequest, AuthLoginResponse]
+	// SESSION
+	SessionRetrieve freighter.UnaryServer[SessionRetrieveRequest, SessionRetrieveResponse]
+	SessionRevoke   freighter.UnaryServer[SessionRevokeRequest, types.Nil]
+	// API KEY
+	APIKeyCreate   freighter.UnaryServer[APIKeyCreateRequest, APIKeyCreateResponse]
+	APIKeyRetrieve freighter.UnaryServer[APIKeyRetrieveRequest, APIKeyRetrieveResponse]
+	APIKeyRotate   freighter.UnaryServer[APIKeyRotateRequest, APIKeyRotateResponse]
+	APIKeyDelete   freighter.UnaryServer[APIKeyDeleteRequest, types.Nil]
 	// USER
 	UserRename         freighter.UnaryServer[UserRenameRequest, types.Nil]
 	UserChangeUsername freighter.UnaryServer[UserChangeUsernameRequest, types.Nil]
@@ -71,14 +83,18 @@ type Transport struct {
 	ChannelRetrieve      freighter.UnaryServer[ChannelRetrieveRequest, ChannelRetrieveResponse]
 	ChannelDelete        freighter.UnaryServer[ChannelDeleteRequest, types.Nil]
 	ChannelRename        freighter.UnaryServer[ChannelRenameRequest, types.Nil]
+	ChannelUpdate        freighter.UnaryServer[ChannelUpdateRequest, ChannelUpdateResponse]
 	ChannelRetrieveGroup freighter.UnaryServer[ChannelRetrieveGroupRequest, ChannelRetrieveGroupResponse]
 	// CONNECTIVITY
 	ConnectivityCheck freighter.UnaryServer[types.Nil, ConnectivityCheckResponse]
 	// FRAME
-	FrameWriter   freighter.StreamServer[FrameWriterRequest, FrameWriterResponse]
-	FrameIterator freighter.StreamServer[FrameIteratorRequest, FrameIteratorResponse]
-	FrameStreamer freighter.StreamServer[FrameStreamerRequest, FrameStreamerResponse]
-	FrameDelete   freighter.UnaryServer[FrameDeleteRequest, types.Nil]
+	FrameWriter            freighter.StreamServer[FrameWriterRequest, FrameWriterResponse]
+	FrameIterator          freighter.StreamServer[FrameIteratorRequest, FrameIteratorResponse]
+	FrameStreamer          freighter.StreamServer[FrameStreamerRequest, FrameStreamerResponse]
+	FrameDelete            freighter.UnaryServer[FrameDeleteRequest, types.Nil]
+	FrameRetrieveStreamers freighter.UnaryServer[FrameRetrieveStreamersRequest, FrameRetrieveStreamersResponse]
+	FrameExport            freighter.StreamServer[FrameExportRequest, FrameExportResponse]
+	FrameImport            freighter.StreamServer[FrameImportRequest, FrameImportResponse]
 	// RANGE
 	RangeCreate        freighter.UnaryServer[RangeCreateRequest, RangeCreateResponse]
 	RangeRetrieve      freighter.UnaryServer[RangeRetrieveRequest, RangeRetrieveResponse]
@@ -144,6 +160,15 @@ type Transport struct {
 	LabelDelete   freighter.UnaryServer[LabelDeleteRequest, types.Nil]
 	LabelAdd      freighter.UnaryServer[LabelAddRequest, types.Nil]
 	LabelRemove   freighter.UnaryServer[LabelRemoveRequest, types.Nil]
+	// ALARM
+	AlarmCreate         freighter.UnaryServer[AlarmCreateRequest, AlarmCreateResponse]
+	AlarmRetrieve       freighter.UnaryServer[AlarmRetrieveRequest, AlarmRetrieveResponse]
+	AlarmDelete         freighter.UnaryServer[AlarmDeleteRequest, types.Nil]
+	AlarmAcknowledge    freighter.UnaryServer[AlarmAcknowledgeRequest, types.Nil]
+	AlarmShelve         freighter.UnaryServer[AlarmShelveRequest, types.Nil]
+	AlarmRetrieveEvents freighter.UnaryServer[AlarmRetrieveEventsRequest, AlarmRetrieveEventsResponse]
+	// AUDIT
+	AuditRetrieve freighter.UnaryServer[AuditRetrieveRequest, AuditRetrieveResponse]
 	// DEVICE
 	HardwareCreateRack     freighter.UnaryServer[HardwareCreateRackRequest, HardwareCreateRackResponse]
 	HardwareRetrieveRack   freighter.UnaryServer[HardwareRetrieveRackRequest, HardwareRetrieveRackResponse]
@@ -159,6 +184,17 @@ type Transport struct {
 	AccessCreatePolicy   freighter.UnaryServer[AccessCreatePolicyRequest, AccessCreatePolicyResponse]
 	AccessDeletePolicy   freighter.UnaryServer[AccessDeletePolicyRequest, types.Nil]
 	AccessRetrievePolicy freighter.UnaryServer[AccessRetrievePolicyRequest, AccessRetrievePolicyResponse]
+	AccessCreateRole     freighter.UnaryServer[AccessCreateRoleRequest, AccessCreateRoleResponse]
+	AccessRetrieveRole   freighter.UnaryServer[AccessRetrieveRoleRequest, AccessRetrieveRoleResponse]
+	AccessDeleteRole     freighter.UnaryServer[AccessDeleteRoleRequest, types.Nil]
+	AccessAssignRole     freighter.UnaryServer[AccessAssignRoleRequest, types.Nil]
+	AccessUnassignRole   freighter.UnaryServer[AccessAssignRoleRequest, types.Nil]
+	AccessExplain        freighter.UnaryServer[AccessExplainRequest, AccessExplainResponse]
+	// CLUSTER
+	ClusterRetrieveNodes  freighter.UnaryServer[ClusterRetrieveNodesRequest, ClusterRetrieveNodesResponse]
+	ClusterRetrieveLeases freighter.UnaryServer[ClusterRetrieveLeasesRequest, ClusterRetrieveLeasesResponse]
+	ClusterGossip         freighter.UnaryServer[types.Nil, types.Nil]
+	ClusterEvictNode      freighter.UnaryServer[ClusterEvictNodeRequest, types.Nil]
 }
 
 // Layer wraps all implemented API services into a single container. Protocol-specific Layer
@@ -179,24 +215,38 @@ type Layer struct {
 	Log          *LogService
 	Table        *TableService
 	Label        *LabelService
+	Alarm        *AlarmService
+	Audit        *AuditService
 	Hardware     *HardwareService
 	Access       *AccessService
+	APIKey       *APIKeyService
+	Session      *SessionService
+	Cluster      *ClusterService
+	Flight       *FlightService
+	MQTT         *MQTTService
+	SQL          *SQLService
 }
 
 // BindTo binds the API layer to the provided Transport implementation.
 func (a *Layer) BindTo(t Transport) {
 	var (
-		tk                 = tokenMiddleware(a.provider.auth.token)
+		tk                 = tokenMiddleware(a.provider.auth, a.provider.user)
 		instrumentation    = lo.Must(falamos.Middleware(falamos.Config{Instrumentation: a.config.Instrumentation}))
 		insecureMiddleware = []freighter.Middleware{instrumentation}
 		secureMiddleware   = make([]freighter.Middleware, len(insecureMiddleware))
 	)
 	copy(secureMiddleware, insecureMiddleware)
-	secureMiddleware = append(secureMiddleware, tk)
+	secureMiddleware = append(
+		secureMiddleware,
+		tk,
+		rateLimitMiddleware(a.provider.Service.Limit),
+	)
 
 	freighter.UseOnAll(
 		insecureMiddleware,
 		t.AuthLogin,
+		t.AuthOIDCAuthorize,
+		t.AuthOIDCCallback,
 		t.ConnectivityCheck,
 	)
 
@@ -205,6 +255,17 @@ func (a *Layer) BindTo(t Transport) {
 
 		// AUTH
 		t.AuthChangePassword,
+		t.AuthLogout,
+
+		// SESSION
+		t.SessionRetrieve,
+		t.SessionRevoke,
+
+		// API KEY
+		t.APIKeyCreate,
+		t.APIKeyRetrieve,
+		t.APIKeyRotate,
+		t.APIKeyDelete,
 
 		// USER
 		t.UserRename,
@@ -218,6 +279,7 @@ func (a *Layer) BindTo(t Transport) {
 		t.ChannelRetrieve,
 		t.ChannelDelete,
 		t.ChannelRename,
+		t.ChannelUpdate,
 		t.ChannelRetrieveGroup,
 
 		// FRAME
@@ -225,6 +287,9 @@ func (a *Layer) BindTo(t Transport) {
 		t.FrameIterator,
 		t.FrameStreamer,
 		t.FrameDelete,
+		t.FrameRetrieveStreamers,
+		t.FrameExport,
+		t.FrameImport,
 
 		// ONTOLOGY
 		t.OntologyRetrieve,
@@ -301,6 +366,17 @@ func (a *Layer) BindTo(t Transport) {
 		t.LabelAdd,
 		t.LabelRemove,
 
+		// ALARM
+		t.AlarmCreate,
+		t.AlarmRetrieve,
+		t.AlarmDelete,
+		t.AlarmAcknowledge,
+		t.AlarmShelve,
+		t.AlarmRetrieveEvents,
+
+		// AUDIT
+		t.AuditRetrieve,
+
 		// HARDWARE
 		t.HardwareCreateRack,
 		t.HardwareDeleteRack,
@@ -318,11 +394,164 @@ func (a *Layer) BindTo(t Transport) {
 		t.AccessCreatePolicy,
 		t.AccessDeletePolicy,
 		t.AccessRetrievePolicy,
+		t.AccessCreateRole,
+		t.AccessRetrieveRole,
+		t.AccessDeleteRole,
+		t.AccessAssignRole,
+		t.AccessUnassignRole,
+		t.AccessExplain,
+		// CLUSTER
+		t.ClusterRetrieveNodes,
+		t.ClusterRetrieveLeases,
+		t.ClusterGossip,
+		t.ClusterEvictNode,
 	)
 
+	// Streams count against the concurrency quotas of the user that opened them.
+	lim := a.provider.Service.Limit
+	t.FrameStreamer.Use(quotaMiddleware(lim, limit.Streamer))
+	t.FrameWriter.Use(quotaMiddleware(lim, limit.Writer))
+	t.FrameIterator.Use(quotaMiddleware(lim, limit.Iterator))
+	t.FrameExport.Use(quotaMiddleware(lim, limit.Iterator))
+	t.FrameImport.Use(quotaMiddleware(lim, limit.Writer))
+
+	// Mutating calls and writer opens are recorded in the audit log. The audit
+	// middleware is added after the token middleware so that calls are attributed to
+	// the authenticated user.
+	au := auditMiddleware(a.config.Instrumentation, a.provider.Service.Audit)
+	t.FrameWriter.Use(au)
+	t.FrameImport.Use(au)
+
+	// AUTH
+	auditUnary(&t.AuthChangePassword, au)
+	auditUnary(&t.AuthLogout, au)
+
+	// SESSION
+	auditUnary(&t.SessionRevoke, au)
+
+	// API KEY
+	auditUnary(&t.APIKeyCreate, au)
+	auditUnary(&t.APIKeyRotate, au)
+	auditUnary(&t.APIKeyDelete, au)
+
+	// USER
+	auditUnary(&t.UserRename, au)
+	auditUnary(&t.UserChangeUsername, au)
+	auditUnary(&t.UserCreate, au)
+	auditUnary(&t.UserDelete, au)
+
+	// CHANNEL
+	auditUnary(&t.ChannelCreate, au)
+	auditUnary(&t.ChannelDelete, au)
+	auditUnary(&t.ChannelRename, au)
+	auditUnary(&t.ChannelUpdate, au)
+
+	// FRAME
+	auditUnary(&t.FrameDelete, au)
+
+	// ONTOLOGY
+	auditUnary(&t.OntologyAddChildren, au)
+	auditUnary(&t.OntologyRemoveChildren, au)
+	auditUnary(&t.OntologyMoveChildren, au)
+
+	// GROUP
+	auditUnary(&t.OntologyGroupCreate, au)
+	auditUnary(&t.OntologyGroupDelete, au)
+	auditUnary(&t.OntologyGroupRename, au)
+
+	// RANGE
+	auditUnary(&t.RangeCreate, au)
+	auditUnary(&t.RangeDelete, au)
+	auditUnary(&t.RangeKVSet, au)
+	auditUnary(&t.RangeKVDelete, au)
+	auditUnary(&t.RangeAliasSet, au)
+	auditUnary(&t.RangeRename, au)
+	auditUnary(&t.RangeAliasDelete, au)
+
+	// WORKSPACE
+	auditUnary(&t.WorkspaceCreate, au)
+	auditUnary(&t.WorkspaceDelete, au)
+	auditUnary(&t.WorkspaceRename, au)
+	auditUnary(&t.WorkspaceSetLayout, au)
+
+	// SCHEMATIC
+	auditUnary(&t.SchematicCreate, au)
+	auditUnary(&t.SchematicDelete, au)
+	auditUnary(&t.SchematicRename, au)
+	auditUnary(&t.SchematicSetData, au)
+	auditUnary(&t.SchematicCopy, au)
+
+	// SCHEMATIC SYMBOL
+	auditUnary(&t.SchematicSymbolCreate, au)
+	auditUnary(&t.SchematicSymbolDelete, au)
+	auditUnary(&t.SchematicSymbolRename, au)
+
+	// LINE PLOT
+	auditUnary(&t.LinePlotCreate, au)
+	auditUnary(&t.LinePlotRename, au)
+	auditUnary(&t.LinePlotSetData, au)
+	auditUnary(&t.LinePlotDelete, au)
+
+	// LOG
+	auditUnary(&t.LogCreate, au)
+	auditUnary(&t.LogDelete, au)
+	auditUnary(&t.LogRename, au)
+	auditUnary(&t.LogSetData, au)
+
+	// TABLE
+	auditUnary(&t.TableCreate, au)
+	auditUnary(&t.TableDelete, au)
+	auditUnary(&t.TableRename, au)
+	auditUnary(&t.TableSetData, au)
+
+	// LABEL
+	auditUnary(&t.LabelCreate, au)
+	auditUnary(&t.LabelDelete, au)
+	auditUnary(&t.LabelAdd, au)
+	auditUnary(&t.LabelRemove, au)
+
+	// ALARM
+	auditUnary(&t.AlarmCreate, au)
+	auditUnary(&t.AlarmDelete, au)
+	auditUnary(&t.AlarmAcknowledge, au)
+	auditUnary(&t.AlarmShelve, au)
+
+	// HARDWARE
+	auditUnary(&t.HardwareCreateRack, au)
+	auditUnary(&t.HardwareDeleteRack, au)
+	auditUnary(&t.HardwareCreateTask, au)
+	auditUnary(&t.HardwareCopyTask, au)
+	auditUnary(&t.HardwareDeleteTask, au)
+	auditUnary(&t.HardwareCreateDevice, au)
+	auditUnary(&t.HardwareDeleteDevice, au)
+
+	// ACCESS
+	auditUnary(&t.AccessCreatePolicy, au)
+	auditUnary(&t.AccessDeletePolicy, au)
+	auditUnary(&t.AccessCreateRole, au)
+	auditUnary(&t.AccessDeleteRole, au)
+	auditUnary(&t.AccessAssignRole, au)
+	auditUnary(&t.AccessUnassignRole, au)
+
+	// CLUSTER
+	auditUnary(&t.ClusterEvictNode, au)
+
 	// AUTH
 	t.AuthLogin.BindHandler(a.Auth.Login)
 	t.AuthChangePassword.BindHandler(a.Auth.ChangePassword)
+	t.AuthLogout.BindHandler(a.Auth.Logout)
+	t.AuthOIDCAuthorize.BindHandler(a.Auth.OIDCAuthorize)
+	t.AuthOIDCCallback.BindHandler(a.Auth.OIDCCallback)
+
+	// SESSION
+	t.SessionRetrieve.BindHandler(a.Session.Retrieve)
+	t.SessionRevoke.BindHandler(a.Session.Revoke)
+
+	// API KEY
+	t.APIKeyCreate.BindHandler(a.APIKey.Create)
+	t.APIKeyRetrieve.BindHandler(a.APIKey.Retrieve)
+	t.APIKeyRotate.BindHandler(a.APIKey.Rotate)
+	t.APIKeyDelete.BindHandler(a.APIKey.Delete)
 
 	// USER
 	t.UserRename.BindHandler(a.User.Rename)
@@ -337,6 +566,7 @@ func (a *Layer) BindTo(t Transport) {
 	t.ConnectivityCheck.BindHandler(a.Connectivity.Check)
 	t.ChannelDelete.BindHandler(a.Channel.Delete)
 	t.ChannelRename.BindHandler(a.Channel.Rename)
+	t.ChannelUpdate.BindHandler(a.Channel.Update)
 	t.ChannelRetrieveGroup.BindHandler(a.Channel.RetrieveGroup)
 
 	// FRAME
@@ -344,6 +574,9 @@ func (a *Layer) BindTo(t Transport) {
 	t.FrameIterator.BindHandler(a.Framer.Iterate)
 	t.FrameStreamer.BindHandler(a.Framer.Stream)
 	t.FrameDelete.BindHandler(a.Framer.FrameDelete)
+	t.FrameRetrieveStreamers.BindHandler(a.Framer.RetrieveStreamers)
+	t.FrameExport.BindHandler(a.Framer.Export)
+	t.FrameImport.BindHandler(a.Framer.Import)
 
 	// ONTOLOGY
 	t.OntologyRetrieve.BindHandler(a.Ontology.Retrieve)
@@ -420,6 +653,17 @@ func (a *Layer) BindTo(t Transport) {
 	t.LabelAdd.BindHandler(a.Label.Add)
 	t.LabelRemove.BindHandler(a.Label.Remove)
 
+	// ALARM
+	t.AlarmCreate.BindHandler(a.Alarm.Create)
+	t.AlarmRetrieve.BindHandler(a.Alarm.Retrieve)
+	t.AlarmDelete.BindHandler(a.Alarm.Delete)
+	t.AlarmAcknowledge.BindHandler(a.Alarm.Acknowledge)
+	t.AlarmShelve.BindHandler(a.Alarm.Shelve)
+	t.AlarmRetrieveEvents.BindHandler(a.Alarm.RetrieveEvents)
+
+	// AUDIT
+	t.AuditRetrieve.BindHandler(a.Audit.Retrieve)
+
 	// HARDWARE
 	t.HardwareCreateRack.BindHandler(a.Hardware.CreateRack)
 	t.HardwareRetrieveRack.BindHandler(a.Hardware.RetrieveRack)
@@ -436,6 +680,18 @@ func (a *Layer) BindTo(t Transport) {
 	t.AccessCreatePolicy.BindHandler(a.Access.CreatePolicy)
 	t.AccessDeletePolicy.BindHandler(a.Access.DeletePolicy)
 	t.AccessRetrievePolicy.BindHandler(a.Access.RetrievePolicy)
+	t.AccessCreateRole.BindHandler(a.Access.CreateRole)
+	t.AccessRetrieveRole.BindHandler(a.Access.RetrieveRole)
+	t.AccessDeleteRole.BindHandler(a.Access.DeleteRole)
+	t.AccessAssignRole.BindHandler(a.Access.AssignRole)
+	t.AccessUnassignRole.BindHandler(a.Access.UnassignRole)
+	t.AccessExplain.BindHandler(a.Access.Explain)
+
+	// CLUSTER
+	t.ClusterRetrieveNodes.BindHandler(a.Cluster.RetrieveNodes)
+	t.ClusterRetrieveLeases.BindHandler(a.Cluster.RetrieveLeases)
+	t.ClusterGossip.BindHandler(a.Cluster.Gossip)
+	t.ClusterEvictNode.BindHandler(a.Cluster.EvictNode)
 }
 
 // New instantiates the server API layer using the provided Config. This should only be called
@@ -449,6 +705,8 @@ func New(configs ...Config) (*Layer, error) {
 	api.Auth = NewAuthService(api.provider)
 	api.User = NewUserService(api.provider)
 	api.Access = NewAccessService(api.provider)
+	api.APIKey = NewAPIKeyService(api.provider)
+	api.Session = NewSessionService(api.provider)
 	api.Framer = NewFrameService(api.provider)
 	api.Channel = NewChannelService(api.provider)
 	api.Connectivity = NewConnectivityService(api.provider)
@@ -458,8 +716,16 @@ func New(configs ...Config) (*Layer, error) {
 	api.Schematic = NewSchematicService(api.provider)
 	api.LinePlot = NewLinePlotService(api.provider)
 	api.Label = NewLabelService(api.provider)
+	api.Alarm = NewAlarmService(api.provider)
+	api.Audit = NewAuditService(api.provider)
 	api.Hardware = NewHardwareService(api.provider)
 	api.Log = NewLogService(api.provider)
 	api.Table = NewTableService(api.provider)
+	api.Cluster = NewClusterService(api.provider)
+	api.Flight = NewFlightService(api.provider)
+	api.SQL = NewSQLService(api.provider)
+	if api.MQTT, err = NewMQTTService(api.provider); err != nil {
+		return nil, err
+	}
 	return api, nil
 }
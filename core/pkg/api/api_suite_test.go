@@ -15,9 +15,67 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/api"
+	"github.com/synnaxlabs/synnax/pkg/distribution"
+	"github.com/synnaxlabs/synnax/pkg/distribution/mock"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/security"
+	"github.com/synnaxlabs/synnax/pkg/service"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/access/rbac"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/password"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/config"
+	. "github.com/synnaxlabs/x/testutil"
 )
 
-var ctx = context.Background()
+var (
+	ctx         = context.Background()
+	mockCluster *mock.Cluster
+	dist        mock.Node
+	svc         *service.Layer
+	layer       *api.Layer
+)
+
+var _ = BeforeSuite(func() {
+	mockCluster = mock.NewCluster(distribution.Config{EnableSearch: config.False()})
+	dist = mockCluster.Provision(ctx)
+	svc = MustSucceed(service.Open(ctx, service.Config{
+		Distribution: dist.Layer,
+		Security:     MustSucceed(security.NewProvider()),
+	}))
+	layer = MustSucceed(api.New(api.Config{Service: svc, Distribution: dist.Layer}))
+})
+
+var _ = AfterSuite(func() {
+	Expect(svc.Close()).To(Succeed())
+	Expect(mockCluster.Close()).To(Succeed())
+})
+
+// createUser registers a user with the given username and password that is granted the
+// given actions on the given objects.
+func createUser(
+	username string,
+	pass string,
+	objects []ontology.ID,
+	actions ...access.Action,
+) user.User {
+	u := user.User{Username: username}
+	Expect(svc.User.NewWriter(nil).Create(ctx, &u)).To(Succeed())
+	Expect(svc.Auth.NewWriter(nil).Register(ctx, auth.InsecureCredentials{
+		Username: username,
+		Password: password.Raw(pass),
+	})).To(Succeed())
+	if len(objects) > 0 {
+		Expect(svc.RBAC.NewWriter(nil).Create(ctx, &rbac.Policy{
+			Subjects: []ontology.ID{user.OntologyID(u.Key)},
+			Objects:  objects,
+			Actions:  actions,
+		})).To(Succeed())
+	}
+	return u
+}
 
 func TestApi(t *testing.T) {
 	RegisterFailHandler(Fail)
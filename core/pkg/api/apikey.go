@@ -0,0 +1,232 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package api
+
+import (
+	"context"
+	"go/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/apikey"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// APIKeyService manages long-lived API keys that automation can use to authenticate
+// as a user, usually a service account. Managing the API keys of a user requires
+// access to update the user.
+type APIKeyService struct {
+	dbProvider
+	accessProvider
+	internal *apikey.Service
+}
+
+func NewAPIKeyService(p Provider) *APIKeyService {
+	return &APIKeyService{
+		dbProvider:     p.db,
+		accessProvider: p.access,
+		internal:       p.Service.APIKey,
+	}
+}
+
+// APIKey is an API key as returned to clients. The token is only set when the key is
+// created or rotated.
+type APIKey struct {
+	Key       uuid.UUID       `json:"key" msgpack:"key"`
+	Name      string          `json:"name" msgpack:"name"`
+	User      uuid.UUID       `json:"user" msgpack:"user"`
+	CreatedAt telem.TimeStamp `json:"created_at" msgpack:"created_at"`
+	ExpiresAt telem.TimeStamp `json:"expires_at" msgpack:"expires_at"`
+	Actions   []access.Action `json:"actions" msgpack:"actions"`
+	Token     string          `json:"token,omitempty" msgpack:"token,omitempty"`
+}
+
+func translateAPIKeyForward(k apikey.Key, token string) APIKey {
+	return APIKey{
+		Key:       k.Key,
+		Name:      k.Name,
+		User:      k.User,
+		CreatedAt: k.CreatedAt,
+		ExpiresAt: k.ExpiresAt,
+		Actions:   k.Actions,
+		Token:     token,
+	}
+}
+
+// NewAPIKey holds information for creating a new API key. If the user is not set, the
+// key is created for the user making the request.
+type NewAPIKey struct {
+	Name      string          `json:"name" msgpack:"name"`
+	User      uuid.UUID       `json:"user" msgpack:"user"`
+	ExpiresAt telem.TimeStamp `json:"expires_at" msgpack:"expires_at"`
+	Actions   []access.Action `json:"actions" msgpack:"actions"`
+}
+
+type (
+	APIKeyCreateRequest struct {
+		Keys []NewAPIKey `json:"keys" msgpack:"keys"`
+	}
+	APIKeyCreateResponse struct {
+		Keys []APIKey `json:"keys" msgpack:"keys"`
+	}
+)
+
+// Create creates the given API keys, returning them along with their tokens. The tokens
+// are not stored, and cannot be retrieved again.
+func (s *APIKeyService) Create(ctx context.Context, req APIKeyCreateRequest) (APIKeyCreateResponse, error) {
+	subject := getSubject(ctx)
+	subjectKey, err := user.KeyFromOntologyID(subject)
+	if err != nil {
+		return APIKeyCreateResponse{}, err
+	}
+	for i, k := range req.Keys {
+		if k.User == uuid.Nil {
+			req.Keys[i].User = subjectKey
+		}
+		// A request authenticated with a scoped API key can't create keys with a wider
+		// scope than its own.
+		if !lo.EveryBy(k.Actions, func(a access.Action) bool {
+			return allowedByAPIKey(ctx, a)
+		}) || (len(k.Actions) == 0 && !allowedByAPIKey(ctx, access.All)) {
+			return APIKeyCreateResponse{}, access.Denied
+		}
+	}
+	if err = s.access.Enforce(ctx, access.Request{
+		Subject: subject,
+		Action:  access.Update,
+		Objects: user.OntologyIDsFromKeys(lo.Map(req.Keys, func(k NewAPIKey, _ int) uuid.UUID {
+			return k.User
+		})),
+	}); err != nil {
+		return APIKeyCreateResponse{}, err
+	}
+	res := APIKeyCreateResponse{Keys: make([]APIKey, 0, len(req.Keys))}
+	return res, s.WithTx(ctx, func(tx gorp.Tx) error {
+		w := s.internal.NewWriter(tx)
+		for _, nk := range req.Keys {
+			k := apikey.Key{
+				Name:      nk.Name,
+				User:      nk.User,
+				ExpiresAt: nk.ExpiresAt,
+				Actions:   nk.Actions,
+			}
+			token, err := w.Create(ctx, &k)
+			if err != nil {
+				return err
+			}
+			res.Keys = append(res.Keys, translateAPIKeyForward(k, token))
+		}
+		return nil
+	})
+}
+
+type (
+	APIKeyRetrieveRequest struct {
+		Keys  []uuid.UUID `json:"keys" msgpack:"keys"`
+		Users []uuid.UUID `json:"users" msgpack:"users"`
+	}
+	APIKeyRetrieveResponse struct {
+		Keys []APIKey `json:"keys" msgpack:"keys"`
+	}
+)
+
+// Retrieve retrieves the API keys with the given keys or belonging to the given users.
+// The tokens of the keys are not returned.
+func (s *APIKeyService) Retrieve(ctx context.Context, req APIKeyRetrieveRequest) (APIKeyRetrieveResponse, error) {
+	q := s.internal.NewRetrieve()
+	if len(req.Keys) > 0 {
+		q = q.WhereKeys(req.Keys...)
+	}
+	if len(req.Users) > 0 {
+		q = q.WhereUsers(req.Users...)
+	}
+	var keys []apikey.Key
+	if err := q.Entries(&keys).Exec(ctx, nil); err != nil {
+		return APIKeyRetrieveResponse{}, err
+	}
+	if err := s.enforceOwners(ctx, keys); err != nil {
+		return APIKeyRetrieveResponse{}, err
+	}
+	return APIKeyRetrieveResponse{
+		Keys: lo.Map(keys, func(k apikey.Key, _ int) APIKey {
+			return translateAPIKeyForward(k, "")
+		}),
+	}, nil
+}
+
+type (
+	APIKeyRotateRequest struct {
+		Key uuid.UUID `json:"key" msgpack:"key"`
+	}
+	APIKeyRotateResponse struct {
+		Key APIKey `json:"key" msgpack:"key"`
+	}
+)
+
+// Rotate replaces the token of the API key with the given key, returning the new token.
+// The previous token is no longer valid after the key is rotated.
+func (s *APIKeyService) Rotate(ctx context.Context, req APIKeyRotateRequest) (APIKeyRotateResponse, error) {
+	var k apikey.Key
+	if err := s.internal.NewRetrieve().WhereKeys(req.Key).Entry(&k).Exec(ctx, nil); err != nil {
+		return APIKeyRotateResponse{}, err
+	}
+	if err := s.enforceOwners(ctx, []apikey.Key{k}); err != nil {
+		return APIKeyRotateResponse{}, err
+	}
+	var res APIKeyRotateResponse
+	return res, s.WithTx(ctx, func(tx gorp.Tx) error {
+		rotated, token, err := s.internal.NewWriter(tx).Rotate(ctx, req.Key)
+		res.Key = translateAPIKeyForward(rotated, token)
+		return err
+	})
+}
+
+type APIKeyDeleteRequest struct {
+	Keys []uuid.UUID `json:"keys" msgpack:"keys"`
+}
+
+// Delete revokes the API keys with the given keys.
+func (s *APIKeyService) Delete(ctx context.Context, req APIKeyDeleteRequest) (types.Nil, error) {
+	var keys []apikey.Key
+	if err := s.internal.NewRetrieve().
+		WhereKeys(req.Keys...).
+		Entries(&keys).
+		Exec(ctx, nil); err != nil && !errors.Is(err, query.NotFound) {
+		return types.Nil{}, err
+	}
+	if err := s.enforceOwners(ctx, keys); err != nil {
+		return types.Nil{}, err
+	}
+	return types.Nil{}, s.WithTx(ctx, func(tx gorp.Tx) error {
+		return s.internal.NewWriter(tx).Delete(ctx, req.Keys...)
+	})
+}
+
+// enforceOwners checks that the subject of the request may update the users that own
+// the given keys.
+func (s *APIKeyService) enforceOwners(ctx context.Context, keys []apikey.Key) error {
+	owners := lo.Uniq(lo.Map(keys, func(k apikey.Key, _ int) ontology.ID {
+		return user.OntologyID(k.User)
+	}))
+	if len(owners) == 0 {
+		return nil
+	}
+	return s.access.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Action:  access.Update,
+		Objects: owners,
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package api
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/audit"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// AuditService exposes the audit log of changes that users have made to the cluster.
+type AuditService struct {
+	accessProvider
+	internal *audit.Service
+}
+
+func NewAuditService(p Provider) *AuditService {
+	return &AuditService{
+		accessProvider: p.access,
+		internal:       p.Service.Audit,
+	}
+}
+
+type AuditEntry = audit.Entry
+
+// AuditRetrieveRequest is a request to retrieve entries from the audit log.
+type AuditRetrieveRequest struct {
+	// Users filters entries to calls made by the given users.
+	Users []uuid.UUID `json:"users" msgpack:"users"`
+	// Objects filters entries to calls that acted on the given resources. An ID with
+	// an empty key matches all resources of its type.
+	Objects []ontology.ID `json:"objects" msgpack:"objects"`
+	// TimeRange filters entries to calls made within the range. A zero time range
+	// retrieves all entries.
+	TimeRange telem.TimeRange `json:"time_range" msgpack:"time_range"`
+	// Limit is the maximum number of entries to retrieve.
+	Limit int `json:"limit" msgpack:"limit"`
+	// Offset is the number of entries to skip.
+	Offset int `json:"offset" msgpack:"offset"`
+}
+
+// AuditRetrieveResponse is a response to an AuditRetrieveRequest.
+type AuditRetrieveResponse struct {
+	// Entries are the retrieved entries in the order they were recorded.
+	Entries []AuditEntry `json:"entries" msgpack:"entries"`
+}
+
+// Retrieve retrieves entries from the audit log.
+func (s *AuditService) Retrieve(
+	ctx context.Context,
+	req AuditRetrieveRequest,
+) (res AuditRetrieveResponse, err error) {
+	if err = s.access.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Action:  access.Retrieve,
+		Objects: []ontology.ID{audit.OntologyID},
+	}); err != nil {
+		return res, err
+	}
+	q := s.internal.NewRetrieve()
+	if len(req.Users) > 0 {
+		q = q.WhereUsers(req.Users...)
+	}
+	if len(req.Objects) > 0 {
+		q = q.WhereObjects(req.Objects...)
+	}
+	if !req.TimeRange.IsZero() {
+		q = q.WhereTimeRange(req.TimeRange)
+	}
+	if req.Limit > 0 {
+		q = q.Limit(req.Limit)
+	}
+	if req.Offset > 0 {
+		q = q.Offset(req.Offset)
+	}
+	res.Entries = make([]AuditEntry, 0)
+	return res, q.Entries(&res.Entries).Exec(ctx, nil)
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/alamos"
+	"github.com/synnaxlabs/freighter"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/audit"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"go.uber.org/zap"
+)
+
+type auditContextKey struct{}
+
+// auditRecord collects the audit log entry for a call as it is handled. The actions
+// and objects of the entry are filled in as access control is enforced, and the
+// request summary is filled in once the request is decoded.
+type auditRecord struct {
+	alamos.Instrumentation
+	svc      *audit.Service
+	mu       sync.Mutex
+	entry    audit.Entry
+	recorded bool
+}
+
+func getAuditRecord(ctx context.Context) (*auditRecord, bool) {
+	r, ok := ctx.Value(auditContextKey{}).(*auditRecord)
+	return r, ok
+}
+
+// addAccess adds the actions and objects of an access control request to the entry.
+func (r *auditRecord) addAccess(req access.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !lo.Contains(r.entry.Actions, req.Action) {
+		r.entry.Actions = append(r.entry.Actions, req.Action)
+	}
+	r.entry.Objects = lo.Union(r.entry.Objects, req.Objects)
+}
+
+// setRequest sets the request summary of the entry.
+func (r *auditRecord) setRequest(req any) {
+	summary := summarizeAuditRequest(req)
+	r.mu.Lock()
+	r.entry.Request = summary
+	r.mu.Unlock()
+}
+
+// finish records the entry with the outcome of the call. Only the first call to finish
+// records the entry, so that streams can be recorded when they open rather than when
+// they close.
+func (r *auditRecord) finish(ctx context.Context, err error) {
+	r.mu.Lock()
+	if r.recorded {
+		r.mu.Unlock()
+		return
+	}
+	r.recorded = true
+	e := r.entry
+	r.mu.Unlock()
+	switch {
+	case err == nil:
+		e.Outcome = audit.Success
+	case errors.Is(err, access.Denied):
+		e.Outcome = audit.Denied
+		e.Error = err.Error()
+	default:
+		e.Outcome = audit.Failure
+		e.Error = err.Error()
+	}
+	// The entry must be recorded even if the call was cancelled.
+	if rErr := r.svc.Record(context.WithoutCancel(ctx), &e); rErr != nil {
+		r.L.Error("failed to record audit log entry",
+			zap.String("target", e.Target),
+			zap.Error(rErr),
+		)
+	}
+}
+
+// setAuditRequest sets the request summary of the audit log entry for the call, if it
+// is being audited.
+func setAuditRequest(ctx context.Context, req any) {
+	if r, ok := getAuditRecord(ctx); ok {
+		r.setRequest(req)
+	}
+}
+
+// finishAudit records the audit log entry for the call, if it is being audited.
+func finishAudit(ctx context.Context, err error) {
+	if r, ok := getAuditRecord(ctx); ok {
+		r.finish(ctx, err)
+	}
+}
+
+// startAudit starts the audit log entry for a call made by the subject, returning a
+// context that carries the entry so that access control checks made with it are added
+// to the entry. The entry is recorded when the returned record is finished.
+func startAudit(
+	ctx context.Context,
+	ins alamos.Instrumentation,
+	svc *audit.Service,
+	protocol string,
+	target string,
+	subject ontology.ID,
+) (context.Context, *auditRecord) {
+	r := &auditRecord{Instrumentation: ins, svc: svc}
+	r.entry.Time = telem.Now()
+	r.entry.Protocol = protocol
+	r.entry.Target = target
+	r.entry.User, _ = user.KeyFromOntologyID(subject)
+	return context.WithValue(ctx, auditContextKey{}, r), r
+}
+
+// auditMiddleware records the calls it is used on in the audit log, along with the
+// user that made them and their outcome. It must run after the token middleware, as
+// calls are attributed to the subject of the token.
+func auditMiddleware(ins alamos.Instrumentation, svc *audit.Service) freighter.Middleware {
+	return freighter.MiddlewareFunc(func(
+		ctx freighter.Context,
+		next freighter.Next,
+	) (freighter.Context, error) {
+		var r *auditRecord
+		ctx.Context, r = startAudit(
+			ctx.Context,
+			ins,
+			svc,
+			ctx.Protocol,
+			ctx.Target.String(),
+			getSubject(ctx),
+		)
+		oCtx, err := next(ctx)
+		r.finish(ctx, err)
+		return oCtx, err
+	})
+}
+
+// auditedUnaryServer sets the request summary of the audit log entry for each call to
+// the server it wraps.
+type auditedUnaryServer[RQ, RS freighter.Payload] struct {
+	freighter.UnaryServer[RQ, RS]
+}
+
+// BindHandler implements freighter.UnaryServer.
+func (s auditedUnaryServer[RQ, RS]) BindHandler(handle func(context.Context, RQ) (RS, error)) {
+	s.UnaryServer.BindHandler(func(ctx context.Context, req RQ) (RS, error) {
+		setAuditRequest(ctx, req)
+		return handle(ctx, req)
+	})
+}
+
+// auditUnary records the calls to the given server in the audit log.
+func auditUnary[RQ, RS freighter.Payload](
+	s *freighter.UnaryServer[RQ, RS],
+	mw freighter.Middleware,
+) {
+	(*s).Use(mw)
+	*s = auditedUnaryServer[RQ, RS]{UnaryServer: *s}
+}
+
+const (
+	// maxAuditRequestSize is the maximum size of the request summary in an audit log
+	// entry. Larger requests are truncated.
+	maxAuditRequestSize = 2048
+	redactedValue       = "[REDACTED]"
+)
+
+// redactedFields are the substrings of the names of request fields whose values are
+// never written to the audit log.
+var redactedFields = []string{"password", "secret", "token"}
+
+// summarizeAuditRequest encodes a request as JSON for the audit log, redacting any
+// secrets it contains.
+func summarizeAuditRequest(req any) string {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return ""
+	}
+	var v any
+	if err = json.Unmarshal(b, &v); err != nil {
+		return ""
+	}
+	if b, err = json.Marshal(redact(v)); err != nil {
+		return ""
+	}
+	if len(b) > maxAuditRequestSize {
+		return strings.ToValidUTF8(string(b[:maxAuditRequestSize]), "") + "..."
+	}
+	return string(b)
+}
+
+func redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, fv := range v {
+			lower := strings.ToLower(k)
+			if lo.SomeBy(redactedFields, func(f string) bool {
+				return strings.Contains(lower, f)
+			}) {
+				v[k] = redactedValue
+			} else {
+				v[k] = redact(fv)
+			}
+		}
+	case []any:
+		for i, ev := range v {
+			v[i] = redact(ev)
+		}
+	}
+	return v
+}
@@ -12,13 +12,21 @@ package api
 import (
 	"context"
 	"go/types"
+	"strings"
 
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/freighter"
 	"github.com/synnaxlabs/synnax/pkg/service/auth"
 	"github.com/synnaxlabs/synnax/pkg/service/auth/password"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/session"
 	"github.com/synnaxlabs/synnax/pkg/service/user"
 	"github.com/synnaxlabs/synnax/pkg/version"
+	"github.com/synnaxlabs/x/errors"
 	"github.com/synnaxlabs/x/gorp"
 	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
 )
 
 // AuthService is the core authentication service for the Synnax API.
@@ -62,7 +70,7 @@ func (s *AuthService) Login(ctx context.Context, req AuthLoginRequest) (AuthLogi
 	if err := s.user.NewRetrieve().WhereUsernames(req.Username).Entry(&u).Exec(ctx, nil); err != nil {
 		return AuthLoginResponse{}, err
 	}
-	tk, err := s.token.New(u.Key)
+	_, tk, err := s.session.Open(ctx, u.Key, clientFromContext(ctx))
 	endTime := telem.Now()
 	midPoint := startTime + (endTime-startTime)/2
 	return AuthLoginResponse{
@@ -82,10 +90,123 @@ type AuthChangePasswordRequest struct {
 	NewPassword password.Raw `json:"new_password" msgpack:"new_password" validate:"required"`
 }
 
-// ChangePassword changes the password for the user with the provided credentials.
+// ChangePassword changes the password for the user with the provided credentials. All
+// other sessions of the user are revoked, so the user must log in again with the new
+// password everywhere except the session that changed it.
 func (s *AuthService) ChangePassword(ctx context.Context, req AuthChangePasswordRequest) (types.Nil, error) {
 	return types.Nil{}, s.WithTx(ctx, func(tx gorp.Tx) error {
-		return s.authenticator.NewWriter(tx).
-			UpdatePassword(ctx, req.InsecureCredentials, req.NewPassword)
+		if err := s.authenticator.NewWriter(tx).
+			UpdatePassword(ctx, req.InsecureCredentials, req.NewPassword); err != nil {
+			return err
+		}
+		var u user.User
+		if err := s.user.NewRetrieve().
+			WhereUsernames(req.Username).
+			Entry(&u).
+			Exec(ctx, tx); err != nil {
+			return err
+		}
+		var sessions []session.Session
+		if err := s.session.NewRetrieve().
+			WhereUsers(u.Key).
+			Entries(&sessions).
+			Exec(ctx, tx); err != nil {
+			return err
+		}
+		current, _ := getSession(ctx)
+		revoke := lo.FilterMap(sessions, func(sess session.Session, _ int) (uuid.UUID, bool) {
+			return sess.Key, sess.Key != current.Key
+		})
+		if len(revoke) == 0 {
+			return nil
+		}
+		return s.session.NewWriter(tx).Revoke(ctx, revoke...)
 	})
 }
+
+// AuthLogoutRequest is a request to log out of the session the request was
+// authenticated with, or of every session of the user.
+type AuthLogoutRequest struct {
+	// All logs the user out of all of their sessions, rather than only the session the
+	// request was authenticated with.
+	All bool `json:"all" msgpack:"all"`
+}
+
+// Logout revokes the session that the request was authenticated with. If All is set,
+// every session of the user is revoked instead.
+func (s *AuthService) Logout(ctx context.Context, req AuthLogoutRequest) (types.Nil, error) {
+	userKey, err := user.KeyFromOntologyID(getSubject(ctx))
+	if err != nil {
+		return types.Nil{}, err
+	}
+	if req.All {
+		return types.Nil{}, s.WithTx(ctx, func(tx gorp.Tx) error {
+			return s.session.NewWriter(tx).RevokeByUsers(ctx, userKey)
+		})
+	}
+	sess, ok := getSession(ctx)
+	if !ok {
+		return types.Nil{}, errors.Wrap(
+			validate.Error,
+			"request was not authenticated with a session token",
+		)
+	}
+	return types.Nil{}, s.WithTx(ctx, func(tx gorp.Tx) error {
+		return s.session.NewWriter(tx).Revoke(ctx, sess.Key)
+	})
+}
+
+// AuthOIDCAuthorizeResponse is the start of a single sign-on login.
+type AuthOIDCAuthorizeResponse struct {
+	// URL is the URL of the identity provider that the user should be sent to in order
+	// to sign in.
+	URL string `json:"url" msgpack:"url"`
+	// State identifies the login, and is passed back to the redirect URL by the
+	// identity provider alongside the authorization code.
+	State string `json:"state" msgpack:"state"`
+}
+
+// OIDCAuthorize starts a single sign-on login through the configured OpenID Connect
+// identity provider.
+func (s *AuthService) OIDCAuthorize(ctx context.Context, _ types.Nil) (AuthOIDCAuthorizeResponse, error) {
+	if s.oidc == nil {
+		return AuthOIDCAuthorizeResponse{}, errSSONotConfigured
+	}
+	u, state, err := s.oidc.Authorize(ctx)
+	return AuthOIDCAuthorizeResponse{URL: u, State: state}, err
+}
+
+// AuthOIDCCallbackRequest completes a single sign-on login with the parameters that
+// the identity provider passed to the redirect URL.
+type AuthOIDCCallbackRequest struct {
+	Code  string `json:"code" msgpack:"code"`
+	State string `json:"state" msgpack:"state"`
+}
+
+// OIDCCallback completes a single sign-on login, provisioning the user on their first
+// login. If successful, returns the same response as Login.
+func (s *AuthService) OIDCCallback(ctx context.Context, req AuthOIDCCallbackRequest) (AuthLoginResponse, error) {
+	if s.oidc == nil {
+		return AuthLoginResponse{}, errSSONotConfigured
+	}
+	creds, err := s.oidc.Login(ctx, req.Code, req.State)
+	if err != nil {
+		return AuthLoginResponse{}, err
+	}
+	return s.Login(ctx, AuthLoginRequest{InsecureCredentials: creds})
+}
+
+var errSSONotConfigured = errors.Wrap(validate.Error, "single sign-on is not configured")
+
+// clientFromContext describes the client that sent the request with the given context.
+func clientFromContext(ctx context.Context) session.Client {
+	md := freighter.MDFromContext(ctx)
+	c := session.Client{Protocol: md.Protocol}
+	ua, ok := md.Params.Get(fiber.HeaderUserAgent)
+	if !ok {
+		// GRPC sends a lowercase header
+		ua, _ = md.Params.Get(strings.ToLower(fiber.HeaderUserAgent))
+	}
+	c.UserAgent, _ = ua.(string)
+	return c
+}
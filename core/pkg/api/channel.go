@@ -21,6 +21,7 @@ import (
 	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
 	"github.com/synnaxlabs/synnax/pkg/service/access"
 	"github.com/synnaxlabs/synnax/pkg/service/ranger"
+	"github.com/synnaxlabs/x/control"
 	"github.com/synnaxlabs/x/errors"
 	"github.com/synnaxlabs/x/gorp"
 	"github.com/synnaxlabs/x/query"
@@ -32,18 +33,23 @@ type ChannelKey = channel.Key
 // Channel is an API-friendly version of the channel.Channel type. It is simplified for
 // use purely as a data container.
 type Channel struct {
-	Key         channel.Key     `json:"key" msgpack:"key"`
-	Name        string          `json:"name" msgpack:"name"`
-	Leaseholder cluster.NodeKey `json:"leaseholder" msgpack:"leaseholder"`
-	DataType    telem.DataType  `json:"data_type" msgpack:"data_type"`
-	Density     telem.Density   `json:"density" msgpack:"density"`
-	IsIndex     bool            `json:"is_index" msgpack:"is_index"`
-	Index       channel.Key     `json:"index" msgpack:"index"`
-	Alias       string          `json:"alias" msgpack:"alias"`
-	Virtual     bool            `json:"virtual" msgpack:"virtual"`
-	Internal    bool            `json:"internal" msgpack:"internal"`
-	Requires    channel.Keys    `json:"requires" msgpack:"requires"`
-	Expression  string          `json:"expression" msgpack:"expression"`
+	Key         channel.Key         `json:"key" msgpack:"key"`
+	Name        string              `json:"name" msgpack:"name"`
+	Leaseholder cluster.NodeKey     `json:"leaseholder" msgpack:"leaseholder"`
+	DataType    telem.DataType      `json:"data_type" msgpack:"data_type"`
+	Density     telem.Density       `json:"density" msgpack:"density"`
+	IsIndex     bool                `json:"is_index" msgpack:"is_index"`
+	Index       channel.Key         `json:"index" msgpack:"index"`
+	Alias       string              `json:"alias" msgpack:"alias"`
+	Virtual     bool                `json:"virtual" msgpack:"virtual"`
+	Concurrency control.Concurrency `json:"concurrency" msgpack:"concurrency"`
+	Internal    bool                `json:"internal" msgpack:"internal"`
+	Requires    channel.Keys        `json:"requires" msgpack:"requires"`
+	Expression  string              `json:"expression" msgpack:"expression"`
+	Units       string              `json:"units" msgpack:"units"`
+	Description string              `json:"description" msgpack:"description"`
+	Scale       channel.Scale       `json:"scale" msgpack:"scale"`
+	Limits      channel.Limits      `json:"limits" msgpack:"limits"`
 }
 
 // ChannelService is the central service for all things Channel related.
@@ -248,9 +254,14 @@ func translateChannelsForward(channels []channel.Channel) []Channel {
 			Index:       ch.Index(),
 			Density:     ch.DataType.Density(),
 			Virtual:     ch.Virtual,
+			Concurrency: ch.Concurrency,
 			Internal:    ch.Internal,
 			Expression:  ch.Expression,
 			Requires:    ch.Requires,
+			Units:       ch.Units,
+			Description: ch.Description,
+			Scale:       ch.Scale,
+			Limits:      ch.Limits,
 		}
 	}
 	return translated
@@ -269,9 +280,14 @@ func translateChannelsBackward(channels []Channel) ([]channel.Channel, error) {
 			LocalIndex:  ch.Index.LocalKey(),
 			LocalKey:    ch.Key.LocalKey(),
 			Virtual:     ch.Virtual,
+			Concurrency: ch.Concurrency,
 			Internal:    ch.Internal,
 			Expression:  ch.Expression,
 			Requires:    ch.Requires,
+			Units:       ch.Units,
+			Description: ch.Description,
+			Scale:       ch.Scale,
+			Limits:      ch.Limits,
 		}
 		if ch.IsIndex {
 			tCH.LocalIndex = tCH.LocalKey
@@ -348,6 +364,83 @@ func (s *ChannelService) Rename(
 	})
 }
 
+type (
+	ChannelUpdateRequest struct {
+		// Channels are the channels to update. Each channel must have its key set, and
+		// only the name, concurrency, calculation, and metadata of a channel are
+		// applied. The remaining fields are taken from the existing channel and do not
+		// need to be sent. If the name is empty, the existing name is kept.
+		Channels []Channel `json:"channels" msgpack:"channels"`
+	}
+	ChannelUpdateResponse struct {
+		Channels []Channel `json:"channels" msgpack:"channels"`
+	}
+)
+
+// Update changes the mutable properties of existing channels in place, preserving
+// their data, ontology relationships, and range aliases.
+func (s *ChannelService) Update(
+	ctx context.Context,
+	req ChannelUpdateRequest,
+) (res ChannelUpdateResponse, _ error) {
+	keys := channel.Keys(lo.Map(req.Channels, func(ch Channel, _ int) channel.Key { return ch.Key }))
+	if err := s.access.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Action:  access.Update,
+		Objects: keys.OntologyIDs(),
+	}); err != nil {
+		return res, err
+	}
+	return res, s.WithTx(ctx, func(tx gorp.Tx) error {
+		existing := make([]channel.Channel, 0, len(keys))
+		if err := s.internal.NewRetrieve().
+			WhereKeys(keys...).
+			Entries(&existing).
+			Exec(ctx, tx); err != nil {
+			return err
+		}
+		byKey := lo.SliceToMap(existing, func(ch channel.Channel) (channel.Key, channel.Channel) {
+			return ch.Key(), ch
+		})
+		updated := make([]channel.Channel, len(req.Channels))
+		for i, ch := range req.Channels {
+			prev, ok := byKey[ch.Key]
+			if !ok {
+				return errors.Wrapf(query.NotFound, "channel %v not found", ch.Key)
+			}
+			updated[i] = mergeChannelUpdate(prev, ch)
+		}
+		if err := s.internal.NewWriter(tx).UpdateMany(ctx, &updated, false); err != nil {
+			return err
+		}
+		res.Channels = translateChannelsForward(updated)
+		return nil
+	})
+}
+
+// mergeChannelUpdate applies the mutable fields of an update to the existing channel,
+// so that the immutable fields of the update are ignored rather than compared. The
+// concurrency and calculation of an update are only applied to channels that can change
+// them, unless they are set, so that the update is rejected when it tries to change them
+// on other channels.
+func mergeChannelUpdate(prev channel.Channel, next Channel) channel.Channel {
+	if next.Name != "" {
+		prev.Name = next.Name
+	}
+	if prev.Virtual || next.Concurrency != control.Exclusive {
+		prev.Concurrency = next.Concurrency
+	}
+	if prev.IsCalculated() || next.Expression != "" || len(next.Requires) > 0 {
+		prev.Expression = next.Expression
+		prev.Requires = next.Requires
+	}
+	prev.Units = next.Units
+	prev.Description = next.Description
+	prev.Scale = next.Scale
+	prev.Limits = next.Limits
+	return prev
+}
+
 type ChannelRetrieveGroupRequest struct{}
 
 type ChannelRetrieveGroupResponse struct {
@@ -0,0 +1,88 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package api_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/api"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Channel", func() {
+	Describe("Update", Ordered, func() {
+		var (
+			editor user.User
+			ch     channel.Channel
+		)
+		BeforeAll(func() {
+			editor = createUser(
+				"editor",
+				"pass",
+				[]ontology.ID{{Type: channel.OntologyType}},
+				access.Update,
+			)
+		})
+		BeforeEach(func() {
+			idx := channel.Channel{Name: "update_time", DataType: telem.TimeStampT, IsIndex: true}
+			Expect(dist.Channel.Create(ctx, &idx)).To(Succeed())
+			ch = channel.Channel{
+				Name:       "update_data",
+				DataType:   telem.Float32T,
+				LocalIndex: idx.LocalKey,
+				Units:      "psi",
+			}
+			Expect(dist.Channel.Create(ctx, &ch)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(dist.Channel.DeleteMany(ctx, channel.Keys{ch.Key(), idx.Key()}, false)).To(Succeed())
+			})
+		})
+
+		It("Should only require the key and the mutable fields of a channel", func() {
+			res := MustSucceed(layer.Channel.Update(
+				subjectContext(editor),
+				api.ChannelUpdateRequest{Channels: []api.Channel{{
+					Key:         ch.Key(),
+					Units:       "bar",
+					Description: "tank pressure",
+				}}},
+			))
+			Expect(res.Channels).To(HaveLen(1))
+			Expect(res.Channels[0].Name).To(Equal("update_data"))
+			Expect(res.Channels[0].DataType).To(Equal(telem.Float32T))
+			Expect(res.Channels[0].Index).To(Equal(ch.Index()))
+			Expect(res.Channels[0].Units).To(Equal("bar"))
+			Expect(res.Channels[0].Description).To(Equal("tank pressure"))
+			var updated channel.Channel
+			Expect(dist.Channel.NewRetrieve().
+				WhereKeys(ch.Key()).
+				Entry(&updated).
+				Exec(ctx, nil)).To(Succeed())
+			Expect(updated.Units).To(Equal("bar"))
+			Expect(updated.DataType).To(Equal(telem.Float32T))
+		})
+
+		It("Should reject setting a calculation on a channel that is not calculated", func() {
+			_, err := layer.Channel.Update(
+				subjectContext(editor),
+				api.ChannelUpdateRequest{Channels: []api.Channel{{
+					Key:        ch.Key(),
+					Expression: "return 1",
+				}}},
+			)
+			Expect(err).To(MatchError(ContainSubstring("only calculated channels")))
+		})
+	})
+})
@@ -0,0 +1,218 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package api
+
+import (
+	"context"
+	"go/types"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/cluster"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/hardware/rack"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// ClusterService exposes administrative operations for inspecting and repairing the
+// state of the cluster as seen from the node serving the request.
+type ClusterService struct {
+	clusterProvider
+	accessProvider
+	admin   cluster.Admin
+	channel channel.Readable
+	rack    *rack.Service
+}
+
+func NewClusterService(p Provider) *ClusterService {
+	return &ClusterService{
+		clusterProvider: p.cluster,
+		accessProvider:  p.access,
+		admin:           p.Distribution.ClusterAdmin,
+		channel:         p.Distribution.Channel,
+		rack:            p.Service.Hardware.Rack,
+	}
+}
+
+// ClusterNode is information about a node in the cluster as seen from the host.
+type ClusterNode struct {
+	// Key is the key of the node.
+	Key cluster.NodeKey `json:"key" msgpack:"key"`
+	// Address is the reachable address of the node.
+	Address string `json:"address" msgpack:"address"`
+	// State is the state of the node (healthy, suspect, dead, or left).
+	State cluster.NodeState `json:"state" msgpack:"state"`
+	// Host is true if the node is the one that served the request.
+	Host bool `json:"host" msgpack:"host"`
+	// HeartbeatGeneration is the number of times the node has restarted.
+	HeartbeatGeneration uint32 `json:"heartbeat_generation" msgpack:"heartbeat_generation"`
+	// HeartbeatVersion is the number of heartbeats the node has emitted since it
+	// last restarted.
+	HeartbeatVersion uint32 `json:"heartbeat_version" msgpack:"heartbeat_version"`
+	// LastSeen is the last time the host observed the node's heartbeat advance. Zero
+	// if the host has not observed the heartbeat advance since it started.
+	LastSeen telem.TimeStamp `json:"last_seen" msgpack:"last_seen"`
+	// Lag is the time elapsed since LastSeen. Zero if LastSeen is zero.
+	Lag telem.TimeSpan `json:"lag" msgpack:"lag"`
+}
+
+type (
+	ClusterRetrieveNodesRequest struct {
+		Keys []cluster.NodeKey `json:"keys" msgpack:"keys"`
+	}
+	ClusterRetrieveNodesResponse struct {
+		ClusterKey string        `json:"cluster_key" msgpack:"cluster_key"`
+		Nodes      []ClusterNode `json:"nodes" msgpack:"nodes"`
+	}
+)
+
+// RetrieveNodes returns the nodes in the cluster with their heartbeats and lag as seen
+// from the host. If no keys are provided, all nodes are returned.
+func (s *ClusterService) RetrieveNodes(
+	ctx context.Context,
+	req ClusterRetrieveNodesRequest,
+) (res ClusterRetrieveNodesResponse, _ error) {
+	if err := s.enforce(ctx, access.Retrieve); err != nil {
+		return res, err
+	}
+	var (
+		hostKey = s.cluster.HostKey()
+		now     = time.Now()
+	)
+	res.ClusterKey = s.cluster.Key().String()
+	for _, n := range s.cluster.Nodes() {
+		if len(req.Keys) > 0 && !lo.Contains(req.Keys, n.Key) {
+			continue
+		}
+		cn := ClusterNode{
+			Key:                 n.Key,
+			Address:             n.Address.String(),
+			State:               n.State,
+			Host:                n.Key == hostKey,
+			HeartbeatGeneration: n.Heartbeat.Generation,
+			HeartbeatVersion:    n.Heartbeat.Version,
+		}
+		if t, ok := s.admin.LastSeen(n.Key); ok {
+			cn.LastSeen = telem.NewTimeStamp(t)
+			cn.Lag = telem.TimeSpan(now.Sub(t))
+		}
+		res.Nodes = append(res.Nodes, cn)
+	}
+	slices.SortFunc(res.Nodes, func(a, b ClusterNode) int { return int(a.Key) - int(b.Key) })
+	return res, nil
+}
+
+// ClusterNodeLeases is the set of resources leased by a particular node.
+type ClusterNodeLeases struct {
+	// Node is the key of the leaseholder.
+	Node cluster.NodeKey `json:"node" msgpack:"node"`
+	// Channels are the keys of the channels whose data is stored on the node.
+	Channels []channel.Key `json:"channels" msgpack:"channels"`
+	// Racks are the keys of the racks that are bound to the node.
+	Racks []rack.Key `json:"racks" msgpack:"racks"`
+	// KVKeys is the number of keys in the cluster-wide key-value store that are
+	// leased by the node.
+	KVKeys int `json:"kv_keys" msgpack:"kv_keys"`
+}
+
+type (
+	ClusterRetrieveLeasesRequest  struct{}
+	ClusterRetrieveLeasesResponse struct {
+		Leases []ClusterNodeLeases `json:"leases" msgpack:"leases"`
+	}
+)
+
+// RetrieveLeases returns the channels, racks, and key-value store entries leased by
+// each node in the cluster.
+func (s *ClusterService) RetrieveLeases(
+	ctx context.Context,
+	_ ClusterRetrieveLeasesRequest,
+) (res ClusterRetrieveLeasesResponse, _ error) {
+	if err := s.enforce(ctx, access.Retrieve); err != nil {
+		return res, err
+	}
+	leases := make(map[cluster.NodeKey]*ClusterNodeLeases)
+	get := func(key cluster.NodeKey) *ClusterNodeLeases {
+		l, ok := leases[key]
+		if !ok {
+			l = &ClusterNodeLeases{Node: key}
+			leases[key] = l
+		}
+		return l
+	}
+	for key := range s.cluster.Nodes() {
+		get(key)
+	}
+	var channels []channel.Channel
+	if err := s.channel.NewRetrieve().Entries(&channels).Exec(ctx, nil); err != nil {
+		return res, err
+	}
+	for _, ch := range channels {
+		l := get(ch.Key().Leaseholder())
+		l.Channels = append(l.Channels, ch.Key())
+	}
+	var racks []rack.Rack
+	if err := s.rack.NewRetrieve().Entries(&racks).Exec(ctx, nil); err != nil {
+		return res, err
+	}
+	for _, r := range racks {
+		l := get(r.Key.Node())
+		l.Racks = append(l.Racks, r.Key)
+	}
+	counts, err := s.admin.LeaseCounts(ctx)
+	if err != nil {
+		return res, err
+	}
+	for key, count := range counts {
+		get(key).KVKeys = count
+	}
+	res.Leases = lo.MapToSlice(leases, func(_ cluster.NodeKey, l *ClusterNodeLeases) ClusterNodeLeases {
+		return *l
+	})
+	slices.SortFunc(res.Leases, func(a, b ClusterNodeLeases) int { return int(a.Node) - int(b.Node) })
+	return res, nil
+}
+
+// Gossip forces the host to immediately exchange cluster state with every healthy
+// peer instead of waiting for the next gossip interval.
+func (s *ClusterService) Gossip(ctx context.Context, _ types.Nil) (types.Nil, error) {
+	if err := s.enforce(ctx, access.Update); err != nil {
+		return types.Nil{}, err
+	}
+	return types.Nil{}, s.admin.GossipNow(ctx)
+}
+
+type ClusterEvictNodeRequest struct {
+	Key cluster.NodeKey `json:"key" msgpack:"key"`
+}
+
+// EvictNode marks the node with the given key as having left the cluster. This
+// should only be used to remove nodes that are permanently dead.
+func (s *ClusterService) EvictNode(ctx context.Context, req ClusterEvictNodeRequest) (types.Nil, error) {
+	if err := s.access.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Action:  access.Delete,
+		Objects: []ontology.ID{cluster.NodeOntologyID(req.Key)},
+	}); err != nil {
+		return types.Nil{}, err
+	}
+	return types.Nil{}, s.admin.Evict(ctx, req.Key)
+}
+
+func (s *ClusterService) enforce(ctx context.Context, action access.Action) error {
+	return s.access.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Action:  action,
+		Objects: []ontology.ID{cluster.OntologyID(s.cluster.Key())},
+	})
+}
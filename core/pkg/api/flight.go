@@ -0,0 +1,264 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package api
+
+import (
+	"context"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/flight"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/synnaxlabs/freighter"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/password"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/session"
+	"github.com/synnaxlabs/synnax/pkg/service/framer"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/export"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/importer"
+	"github.com/synnaxlabs/synnax/pkg/service/limit"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/binary"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/validate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	// FlightTicket is the JSON encoded ticket of a Flight DoGet request. It describes
+	// the channels and time range to read, and is identical to a FrameExportRequest
+	// without a format.
+	FlightTicket = export.Request
+	// FlightPutCommand is the JSON encoded command of the descriptor of a Flight DoPut
+	// request. It describes the channels to write the record batches to, and is
+	// identical to a FrameImportRequest configuration without a format. If no columns
+	// are provided, every field in the schema of the record batches is written to the
+	// channel with the same name.
+	FlightPutCommand = importer.Request
+	// FlightPutResult is the JSON encoded application metadata of the result of a
+	// Flight DoPut request.
+	FlightPutResult = importer.Response
+)
+
+// flightCodec encodes and decodes Flight tickets, commands, and results.
+var flightCodec = &binary.JSONCodec{}
+
+// FlightService serves channel data over Apache Arrow Flight, allowing analytics
+// clients to read and write channels as Arrow record batches without implementing
+// Synnax's frame encoding.
+//
+// Clients authenticate by sending a bearer token in the authorization header of each
+// request. Clients that use Flight's basic authentication handshake receive a token in
+// exchange for their username and password.
+type FlightService struct {
+	flight.BaseFlightServer
+	authProvider
+	userProvider
+	auditProvider
+	limitProvider
+	framer *FrameService
+}
+
+var _ flight.FlightServer = (*FlightService)(nil)
+
+func NewFlightService(p Provider) *FlightService {
+	return &FlightService{
+		authProvider:  p.auth,
+		userProvider:  p.user,
+		auditProvider: p.audit,
+		limitProvider: p.limit,
+		framer:        NewFrameService(p),
+	}
+}
+
+// Middleware returns the middleware that authenticates Flight requests.
+func (s *FlightService) Middleware() []flight.ServerMiddleware {
+	return []flight.ServerMiddleware{
+		flight.CreateServerBasicAuthMiddleware(flightAuthValidator{s}),
+	}
+}
+
+// DoGet reads the data for the channels described by the ticket, streaming it back to
+// the client as Arrow record batches. Channels are aligned on their index in the same
+// way as an export. Gets count against the iterator quota of the user.
+func (s *FlightService) DoGet(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+	ctx := s.context(stream.Context())
+	target, _ := grpc.MethodFromServerStream(stream)
+	release, err := s.acquire(getSubject(ctx), target, limit.Iterator)
+	if err != nil {
+		return flightError(err)
+	}
+	defer release()
+	var req FlightTicket
+	if err := flightCodec.Decode(ctx, tkt.Ticket, &req); err != nil {
+		return flightError(errors.Wrapf(validate.Error, "invalid ticket: %s", err))
+	}
+	req.Format = export.FormatArrow
+	req, err = s.framer.Exporter.Resolve(ctx, req)
+	if err != nil {
+		return flightError(err)
+	}
+	if err = s.framer.access.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Action:  access.Retrieve,
+		Objects: framer.OntologyIDs(req.Keys),
+	}); err != nil {
+		return flightError(err)
+	}
+	return flightError(s.framer.Exporter.ExportRecords(
+		ctx,
+		req,
+		func(schema *arrow.Schema) (export.RecordWriter, error) {
+			return flight.NewRecordWriter(stream, ipc.WithSchema(schema)), nil
+		},
+	))
+}
+
+// DoPut writes the record batches sent by the client into channels. Access to the
+// channels is checked before any record batches are received, and writing to existing
+// channels requires the same access as opening a writer. The record batches are spooled
+// to a temporary file of bounded size and validated in full before any data is written,
+// in the same way as an import. Puts are recorded in the audit log, and count against
+// the writer quota of the user.
+func (s *FlightService) DoPut(stream flight.FlightService_DoPutServer) (err error) {
+	ctx := s.context(stream.Context())
+	defer func() { err = flightError(err) }()
+	target, _ := grpc.MethodFromServerStream(stream)
+	release, err := s.acquire(getSubject(ctx), target, limit.Writer)
+	if err != nil {
+		return err
+	}
+	defer release()
+	var audit *auditRecord
+	ctx.Context, audit = s.startAudit(ctx.Context, ctx.Protocol, target, getSubject(ctx))
+	defer func() { audit.finish(ctx, err) }()
+	r, err := flight.NewRecordReader(stream)
+	if err != nil {
+		return err
+	}
+	defer r.Release()
+	var req FlightPutCommand
+	if desc := r.LatestFlightDescriptor(); desc != nil && len(desc.Cmd) > 0 {
+		if err = flightCodec.Decode(ctx, desc.Cmd, &req); err != nil {
+			return errors.Wrapf(validate.Error, "invalid command: %s", err)
+		}
+	}
+	req.Format = importer.FormatArrow
+	audit.setRequest(req)
+	if len(req.Columns) == 0 {
+		for _, field := range r.Schema().Fields() {
+			req.Columns = append(req.Columns, importer.Column{Name: field.Name})
+		}
+	}
+	if err = s.framer.authorizeImport(ctx, req); err != nil {
+		return err
+	}
+	f, err := s.framer.Importer.NewSpool()
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Combine(err, f.Close()) }()
+	w := ipc.NewWriter(f, ipc.WithSchema(r.Schema()))
+	for r.Next() {
+		if err = w.Write(r.RecordBatch()); err != nil {
+			return errors.Combine(err, w.Close())
+		}
+	}
+	if err = errors.Combine(r.Err(), w.Close()); err != nil {
+		return err
+	}
+	res, err := s.framer.importFile(ctx, req, f)
+	if err != nil {
+		return err
+	}
+	b, err := flightCodec.Encode(ctx, res)
+	if err != nil {
+		return err
+	}
+	return stream.Send(&flight.PutResult{AppMetadata: b})
+}
+
+// context returns a freighter context carrying the subject of an authenticated Flight
+// request, so that access can be enforced in the same way as freighter requests.
+func (s *FlightService) context(ctx context.Context) freighter.Context {
+	fCtx := freighter.Context{
+		Context:  ctx,
+		Role:     freighter.Server,
+		Variant:  freighter.Stream,
+		Protocol: "flight",
+		Params:   make(freighter.Params),
+	}
+	if subject, ok := flight.AuthFromContext(ctx).(ontology.ID); ok {
+		setSubject(fCtx.Params, subject)
+	}
+	return fCtx
+}
+
+// flightAuthValidator validates the credentials and tokens of Flight requests.
+type flightAuthValidator struct{ svc *FlightService }
+
+var _ flight.BasicAuthValidator = flightAuthValidator{}
+
+// Validate implements flight.BasicAuthValidator, authenticating a user and returning a
+// new token for them.
+func (v flightAuthValidator) Validate(username, pass string) (string, error) {
+	ctx := context.Background()
+	creds := auth.InsecureCredentials{Username: username, Password: password.Raw(pass)}
+	if err := v.svc.authenticator.Authenticate(ctx, creds); err != nil {
+		return "", flightError(err)
+	}
+	var u user.User
+	if err := v.svc.user.NewRetrieve().WhereUsernames(username).Entry(&u).Exec(ctx, nil); err != nil {
+		return "", flightError(err)
+	}
+	_, tk, err := v.svc.session.Open(ctx, u.Key, session.Client{Protocol: "flight"})
+	return tk, err
+}
+
+// IsValid implements flight.BasicAuthValidator, validating a token and returning the
+// ontology ID of the user it was issued to.
+func (v flightAuthValidator) IsValid(token string) (any, error) {
+	sess, _, err := v.svc.session.Authenticate(context.Background(), token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return user.OntologyID(sess.User), nil
+}
+
+// flightError converts an error into a gRPC status error with a code that Flight
+// clients can act on.
+func flightError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var code codes.Code
+	switch {
+	case errors.Is(err, validate.Error):
+		code = codes.InvalidArgument
+	case errors.Is(err, query.NotFound):
+		code = codes.NotFound
+	case errors.Is(err, access.Denied):
+		code = codes.PermissionDenied
+	case errors.Is(err, auth.Error):
+		code = codes.Unauthenticated
+	case errors.Is(err, limit.Error):
+		code = codes.ResourceExhausted
+	default:
+		code = codes.Internal
+	}
+	return status.Error(code, err.Error())
+}
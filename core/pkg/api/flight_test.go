@@ -0,0 +1,87 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package api_test
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/flight"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/framer"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("Flight", func() {
+	var client flight.Client
+	BeforeEach(func() {
+		srv := flight.NewServerWithMiddleware(layer.Flight.Middleware())
+		Expect(srv.Init("localhost:0")).To(Succeed())
+		srv.RegisterFlightService(layer.Flight)
+		go func() { defer GinkgoRecover(); Expect(srv.Serve()).To(Succeed()) }()
+		client = MustSucceed(flight.NewClientWithMiddlewareCtx(
+			ctx,
+			srv.Addr().String(),
+			nil,
+			nil,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		))
+		DeferCleanup(func() {
+			Expect(client.Close()).To(Succeed())
+			srv.Shutdown()
+		})
+	})
+
+	Describe("DoPut", func() {
+		It("Should not allow a user that can only create channels to write to existing ones", func() {
+			idx := channel.Channel{Name: "time", DataType: telem.TimeStampT, IsIndex: true}
+			Expect(dist.Channel.Create(ctx, &idx)).To(Succeed())
+			u := createUser(
+				"creator",
+				"pass",
+				[]ontology.ID{{Type: channel.OntologyType}, {Type: framer.OntologyType}},
+				access.Create,
+				access.Retrieve,
+			)
+			authCtx := MustSucceed(client.AuthenticateBasicToken(ctx, u.Username, "pass"))
+			stream := MustSucceed(client.DoPut(authCtx))
+			schema := arrow.NewSchema(
+				[]arrow.Field{{Name: idx.Name, Type: arrow.FixedWidthTypes.Timestamp_ns}},
+				nil,
+			)
+			b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+			defer b.Release()
+			b.Field(0).(*array.TimestampBuilder).Append(arrow.Timestamp(telem.SecondTS))
+			rec := b.NewRecordBatch()
+			defer rec.Release()
+			w := flight.NewRecordWriter(stream, ipc.WithSchema(schema))
+			w.SetFlightDescriptor(&flight.FlightDescriptor{
+				Type: flight.DescriptorCMD,
+				Cmd:  []byte(`{}`),
+			})
+			// The server may reject the put before all the record batches are sent.
+			_ = w.Write(rec)
+			_ = w.Close()
+			_ = stream.CloseSend()
+			_, err := stream.Recv()
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		})
+	})
+})
@@ -10,13 +10,16 @@
 package api
 
 import (
+	"bufio"
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/binary"
 	"fmt"
 	"go/types"
 	"io"
 	"reflect"
+	"slices"
 
 	"github.com/synnaxlabs/alamos"
 	"github.com/synnaxlabs/freighter"
@@ -29,6 +32,9 @@ import (
 	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
 	"github.com/synnaxlabs/synnax/pkg/service/access"
 	"github.com/synnaxlabs/synnax/pkg/service/framer"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/export"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/importer"
+	"github.com/synnaxlabs/synnax/pkg/service/ranger"
 	"github.com/synnaxlabs/x/address"
 	xbinary "github.com/synnaxlabs/x/binary"
 	"github.com/synnaxlabs/x/config"
@@ -59,12 +65,16 @@ type FrameService struct {
 	accessProvider
 	Channel  channel.Readable
 	Internal *framer.Service
+	Exporter *export.Service
+	Importer *importer.Service
 }
 
 func NewFrameService(p Provider) *FrameService {
 	return &FrameService{
 		Instrumentation: p.Instrumentation,
 		Internal:        p.Service.Framer,
+		Exporter:        p.Service.Export,
+		Importer:        p.Service.Import,
 		Channel:         p.Distribution.Channel,
 		authProvider:    p.auth,
 		dbProvider:      p.db,
@@ -105,6 +115,40 @@ func (s *FrameService) FrameDelete(
 	})
 }
 
+type (
+	FrameRetrieveStreamersRequest  struct{}
+	FrameRetrieveStreamersResponse struct {
+		Streamers []framer.StreamerStats `json:"streamers" msgpack:"streamers"`
+	}
+)
+
+// RetrieveStreamers returns the number of frames delivered to and dropped for each
+// streamer open on the node, ordered by the time they were opened. Only streamers on
+// channels the subject is allowed to retrieve are returned.
+func (s *FrameService) RetrieveStreamers(
+	ctx context.Context,
+	_ FrameRetrieveStreamersRequest,
+) (res FrameRetrieveStreamersResponse, _ error) {
+	stats := s.Internal.Streamer.Stats()
+	slices.SortFunc(stats, func(a, b framer.StreamerStats) int {
+		return cmp.Compare(a.OpenedAt, b.OpenedAt)
+	})
+	res.Streamers = make([]framer.StreamerStats, 0, len(stats))
+	for _, st := range stats {
+		if err := s.access.Enforce(ctx, access.Request{
+			Subject: getSubject(ctx),
+			Action:  access.Retrieve,
+			Objects: framer.OntologyIDs(st.Keys),
+		}); errors.Is(err, access.Denied) {
+			continue
+		} else if err != nil {
+			return res, err
+		}
+		res.Streamers = append(res.Streamers, st)
+	}
+	return res, nil
+}
+
 type (
 	FrameIteratorRequest  = framer.IteratorRequest
 	FrameIteratorResponse = framer.IteratorResponse
@@ -186,13 +230,25 @@ const (
 func (s *FrameService) Stream(ctx context.Context, stream StreamerStream) error {
 	sCtx, cancel := signal.WithCancel(ctx, signal.WithInstrumentation(s.Instrumentation.Child("frame_streamer")))
 	defer cancel()
-	streamer, err := s.openStreamer(sCtx, getSubject(ctx), stream)
+	subject := getSubject(ctx)
+	streamer, err := s.openStreamer(sCtx, subject, stream)
 	if err != nil {
 		return err
 	}
 	var (
-		receiver = &freightfluence.Receiver[FrameStreamerRequest]{Receiver: stream}
-		sender   = &freightfluence.Sender[FrameStreamerResponse]{
+		receiver = &freightfluence.TransformReceiver[FrameStreamerRequest, FrameStreamerRequest]{
+			Receiver: stream,
+			Transform: func(ctx context.Context, req FrameStreamerRequest) (FrameStreamerRequest, bool, error) {
+				// Requests sent after the streamer is opened update the channels it
+				// streams, so the subject must be allowed to read the new channels.
+				return req, true, s.access.Enforce(ctx, access.Request{
+					Subject: subject,
+					Action:  access.Retrieve,
+					Objects: framer.OntologyIDs(req.Keys),
+				})
+			},
+		}
+		sender = &freightfluence.Sender[FrameStreamerResponse]{
 			Sender: freighter.SenderNopCloser[FrameStreamerResponse]{StreamSender: stream},
 		}
 		pipe = plumber.New()
@@ -230,6 +286,178 @@ func (s *FrameService) openStreamer(
 	return reader, stream.Send(framer.StreamerResponse{})
 }
 
+type (
+	FrameExportRequest = export.Request
+	// FrameExportResponse is a chunk of an exported file.
+	FrameExportResponse struct {
+		Data []byte `json:"data" msgpack:"data"`
+	}
+	FrameExportStream = freighter.ServerStream[FrameExportRequest, FrameExportResponse]
+)
+
+// frameExportChunkSize is the maximum size of each chunk sent to the client during an
+// export.
+const frameExportChunkSize = 1 << 20
+
+// Export receives a single export request and streams the exported file back to the
+// client in chunks.
+func (s *FrameService) Export(ctx context.Context, stream FrameExportStream) error {
+	req, err := stream.Receive()
+	if err != nil {
+		return err
+	}
+	if req, err = s.Exporter.Resolve(ctx, req); err != nil {
+		return err
+	}
+	if err = s.access.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Action:  access.Retrieve,
+		Objects: framer.OntologyIDs(req.Keys),
+	}); err != nil {
+		return err
+	}
+	w := bufio.NewWriterSize(exportSender{stream}, frameExportChunkSize)
+	if err = s.Exporter.Export(ctx, req, w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// exportSender is an io.Writer that sends each write to the client as a chunk of an
+// export.
+type exportSender struct{ stream FrameExportStream }
+
+func (e exportSender) Write(p []byte) (int, error) {
+	if err := e.stream.Send(FrameExportResponse{Data: bytes.Clone(p)}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+type (
+	// FrameImportRequest is a message sent by the client during an import. The first
+	// message carries the configuration of the import, and every message carries the
+	// next chunk of the imported file.
+	FrameImportRequest struct {
+		Config importer.Request `json:"config" msgpack:"config"`
+		Data   []byte           `json:"data" msgpack:"data"`
+	}
+	FrameImportResponse = importer.Response
+	FrameImportStream   = freighter.ServerStream[FrameImportRequest, FrameImportResponse]
+)
+
+// Import receives a file from the client in chunks, imports it once the client closes
+// its side of the stream, and responds with the result of the import. Access to the
+// channels of the import is checked before the file is received, and the file is
+// spooled to a temporary file of bounded size, as imports read the file more than once.
+func (s *FrameService) Import(ctx context.Context, stream FrameImportStream) (err error) {
+	req, err := stream.Receive()
+	if err != nil {
+		return err
+	}
+	setAuditRequest(ctx, req.Config)
+	if err = s.authorizeImport(ctx, req.Config); err != nil {
+		return err
+	}
+	f, err := s.Importer.NewSpool()
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Combine(err, f.Close()) }()
+	for msg := req; ; {
+		if _, err = f.Write(msg.Data); err != nil {
+			return err
+		}
+		if msg, err = stream.Receive(); errors.Is(err, freighter.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	res, err := s.importFile(ctx, req.Config, f)
+	if err != nil {
+		return err
+	}
+	return stream.Send(res)
+}
+
+// authorizeImport checks that the subject is allowed to create the channels and range
+// of an import of the request, and to write to its existing channels, before its file
+// is received.
+func (s *FrameService) authorizeImport(ctx context.Context, req importer.Request) error {
+	targets, err := s.Importer.Targets(ctx, req)
+	if err != nil {
+		return err
+	}
+	return s.enforceImport(ctx, req, targets)
+}
+
+// importFile opens an import of the provided file, checks that the subject is allowed
+// to create the channels and range of the import and to write to its existing
+// channels, and then executes it.
+func (s *FrameService) importFile(
+	ctx context.Context,
+	req importer.Request,
+	f importer.File,
+) (importer.Response, error) {
+	imp, err := s.Importer.Open(ctx, req, f)
+	if err != nil {
+		return importer.Response{}, err
+	}
+	if err = s.enforceImport(ctx, req, imp.Channels()); err != nil {
+		return importer.Response{}, err
+	}
+	return imp.Exec(ctx)
+}
+
+// enforceImport checks that the subject is allowed to create the given channels of an
+// import, control the existing ones so that it can write to them, and create the range
+// of the import. Writing imported data requires the same access as opening a writer.
+func (s *FrameService) enforceImport(
+	ctx context.Context,
+	req importer.Request,
+	channels []channel.Channel,
+) error {
+	var (
+		toCreate []channel.Channel
+		toWrite  channel.Keys
+		subject  = getSubject(ctx)
+	)
+	for _, ch := range channels {
+		if ch.Key() == 0 {
+			toCreate = append(toCreate, ch)
+		} else {
+			toWrite = append(toWrite, ch.Key())
+		}
+	}
+	if len(toCreate) > 0 {
+		if err := s.access.Enforce(ctx, access.Request{
+			Subject: subject,
+			Action:  access.Create,
+			Objects: channel.OntologyIDsFromChannels(toCreate),
+		}); err != nil {
+			return err
+		}
+	}
+	if len(toWrite) > 0 {
+		if err := s.access.Enforce(ctx, access.Request{
+			Subject: subject,
+			Action:  access.Control,
+			Objects: framer.OntologyIDs(toWrite),
+		}); err != nil {
+			return err
+		}
+	}
+	if req.Range != "" {
+		return s.access.Enforce(ctx, access.Request{
+			Subject: subject,
+			Action:  access.Create,
+			Objects: []ontology.ID{{Type: ranger.OntologyType}},
+		})
+	}
+	return nil
+}
+
 type FrameWriterConfig struct {
 	// Authorities is the authority to use when writing to the channels. We set this
 	// as an int and not control.Authorities because msgpack has a tough time decoding
@@ -326,14 +554,18 @@ func (s *FrameService) Write(_ctx context.Context, stream FrameWriterStream) err
 	// which case resources have already been freed and cancel does nothing).
 	defer cancel()
 
-	w, err := s.openWriter(ctx, getSubject(_ctx), stream)
+	subject := getSubject(_ctx)
+	w, err := s.openWriter(ctx, subject, stream)
+	// Writers are audited when they open rather than when they close, as they may
+	// stay open for the lifetime of a client.
+	finishAudit(_ctx, err)
 	if err != nil {
 		return err
 	}
 
 	receiver := &freightfluence.TransformReceiver[framer.WriterRequest, FrameWriterRequest]{
 		Receiver: stream,
-		Transform: func(_ context.Context, req FrameWriterRequest) (framer.WriterRequest, bool, error) {
+		Transform: func(ctx context.Context, req FrameWriterRequest) (framer.WriterRequest, bool, error) {
 			r := framer.WriterRequest{Command: req.Command, Frame: req.Frame}
 			if r.Command == writer.SetAuthority {
 				// We decode like this because msgpack has a tough time decoding slices of uint8.
@@ -342,6 +574,17 @@ func (s *FrameService) Write(_ctx context.Context, stream FrameWriterStream) err
 					r.Config.Authorities[i] = control.Authority(a)
 				}
 				r.Config.Keys = req.Config.Keys
+				// Changing authority without keys applies to the channels the writer
+				// was opened with, which were checked when it was opened.
+				if len(r.Config.Keys) > 0 {
+					if err := s.access.Enforce(ctx, access.Request{
+						Subject: subject,
+						Action:  access.Control,
+						Objects: framer.OntologyIDs(r.Config.Keys),
+					}); err != nil {
+						return r, true, err
+					}
+				}
 			}
 			return r, true, nil
 		},
@@ -379,10 +622,11 @@ func (s *FrameService) openWriter(
 	if err != nil {
 		return nil, err
 	}
+	setAuditRequest(ctx, req.Config)
 
 	if err = s.access.Enforce(ctx, access.Request{
 		Subject: subject,
-		Action:  access.Create,
+		Action:  access.Control,
 		Objects: framer.OntologyIDs(req.Config.Keys),
 	}); err != nil {
 		return nil, err
@@ -0,0 +1,73 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package api_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/freighter"
+	"github.com/synnaxlabs/synnax/pkg/api"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/framer"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+// subjectContext returns a context for a request made by the given user.
+func subjectContext(u user.User) freighter.Context {
+	return freighter.Context{
+		Context: ctx,
+		Params:  freighter.Params{"Subject": user.OntologyID(u.Key)},
+	}
+}
+
+var _ = Describe("Frame", func() {
+	Describe("RetrieveStreamers", func() {
+		It("Should return the streamers on channels the subject can retrieve", func() {
+			ch := channel.Channel{Name: "streamed", DataType: telem.Int64T, Virtual: true}
+			Expect(dist.Channel.Create(ctx, &ch)).To(Succeed())
+			s := MustSucceed(svc.Framer.NewStreamer(ctx, framer.StreamerConfig{
+				Keys: channel.Keys{ch.Key()},
+			}))
+			sCtx, cancel := signal.Isolated()
+			inlet, _ := confluence.Attach(s)
+			s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+			defer func() {
+				inlet.Close()
+				Expect(sCtx.Wait()).To(Succeed())
+				cancel()
+			}()
+			viewer := createUser(
+				"viewer",
+				"pass",
+				[]ontology.ID{{Type: framer.OntologyType}},
+				access.Retrieve,
+			)
+			Eventually(func(g Gomega) {
+				res := MustSucceed(layer.Framer.RetrieveStreamers(
+					subjectContext(viewer),
+					api.FrameRetrieveStreamersRequest{},
+				))
+				g.Expect(res.Streamers).To(ContainElement(HaveField("Keys", Equal(channel.Keys{ch.Key()}))))
+			}).Should(Succeed())
+			outsider := createUser("outsider", "pass", nil)
+			res := MustSucceed(layer.Framer.RetrieveStreamers(
+				subjectContext(outsider),
+				api.FrameRetrieveStreamersRequest{},
+			))
+			Expect(res.Streamers).To(BeEmpty())
+		})
+	})
+})
@@ -29,14 +29,29 @@ func New(channels channel.Readable) (a api.Transport, transports []fgrpc.Bindabl
 
 	// AUTH
 	a.AuthChangePassword = fnoop.UnaryServer[api.AuthChangePasswordRequest, types.Nil]{}
+	a.AuthLogout = fnoop.UnaryServer[api.AuthLogoutRequest, types.Nil]{}
+	a.AuthOIDCAuthorize = fnoop.UnaryServer[types.Nil, api.AuthOIDCAuthorizeResponse]{}
+	a.AuthOIDCCallback = fnoop.UnaryServer[api.AuthOIDCCallbackRequest, api.AuthLoginResponse]{}
+	a.SessionRetrieve = fnoop.UnaryServer[api.SessionRetrieveRequest, api.SessionRetrieveResponse]{}
+	a.SessionRevoke = fnoop.UnaryServer[api.SessionRevokeRequest, types.Nil]{}
+	a.APIKeyCreate = fnoop.UnaryServer[api.APIKeyCreateRequest, api.APIKeyCreateResponse]{}
+	a.APIKeyRetrieve = fnoop.UnaryServer[api.APIKeyRetrieveRequest, api.APIKeyRetrieveResponse]{}
+	a.APIKeyRotate = fnoop.UnaryServer[api.APIKeyRotateRequest, api.APIKeyRotateResponse]{}
+	a.APIKeyDelete = fnoop.UnaryServer[api.APIKeyDeleteRequest, types.Nil]{}
 
 	// HARDWARE
 	a.HardwareCopyTask = fnoop.UnaryServer[api.HardwareCopyTaskRequest, api.HardwareCopyTaskResponse]{}
 
 	// CHANNEL
 	a.ChannelRename = fnoop.UnaryServer[api.ChannelRenameRequest, types.Nil]{}
+	a.ChannelUpdate = fnoop.UnaryServer[api.ChannelUpdateRequest, api.ChannelUpdateResponse]{}
 	a.ChannelRetrieveGroup = fnoop.UnaryServer[api.ChannelRetrieveGroupRequest, api.ChannelRetrieveGroupResponse]{}
 
+	// FRAME
+	a.FrameRetrieveStreamers = fnoop.UnaryServer[api.FrameRetrieveStreamersRequest, api.FrameRetrieveStreamersResponse]{}
+	a.FrameExport = fnoop.StreamServer[api.FrameExportRequest, api.FrameExportResponse]{}
+	a.FrameImport = fnoop.StreamServer[api.FrameImportRequest, api.FrameImportResponse]{}
+
 	// USER
 	a.UserRename = fnoop.UnaryServer[api.UserRenameRequest, types.Nil]{}
 	a.UserChangeUsername = fnoop.UnaryServer[api.UserChangeUsernameRequest, types.Nil]{}
@@ -108,11 +123,32 @@ func New(channels channel.Readable) (a api.Transport, transports []fgrpc.Bindabl
 	a.LabelDelete = fnoop.UnaryServer[api.LabelDeleteRequest, types.Nil]{}
 	a.LabelAdd = fnoop.UnaryServer[api.LabelAddRequest, types.Nil]{}
 	a.LabelRemove = fnoop.UnaryServer[api.LabelRemoveRequest, types.Nil]{}
+	a.AlarmCreate = fnoop.UnaryServer[api.AlarmCreateRequest, api.AlarmCreateResponse]{}
+	a.AlarmRetrieve = fnoop.UnaryServer[api.AlarmRetrieveRequest, api.AlarmRetrieveResponse]{}
+	a.AlarmDelete = fnoop.UnaryServer[api.AlarmDeleteRequest, types.Nil]{}
+	a.AlarmAcknowledge = fnoop.UnaryServer[api.AlarmAcknowledgeRequest, types.Nil]{}
+	a.AlarmShelve = fnoop.UnaryServer[api.AlarmShelveRequest, types.Nil]{}
+	a.AlarmRetrieveEvents = fnoop.UnaryServer[api.AlarmRetrieveEventsRequest, api.AlarmRetrieveEventsResponse]{}
+
+	// AUDIT
+	a.AuditRetrieve = fnoop.UnaryServer[api.AuditRetrieveRequest, api.AuditRetrieveResponse]{}
 
 	// ACCESS
 	a.AccessCreatePolicy = fnoop.UnaryServer[api.AccessCreatePolicyRequest, api.AccessCreatePolicyResponse]{}
 	a.AccessDeletePolicy = fnoop.UnaryServer[api.AccessDeletePolicyRequest, types.Nil]{}
 	a.AccessRetrievePolicy = fnoop.UnaryServer[api.AccessRetrievePolicyRequest, api.AccessRetrievePolicyResponse]{}
+	a.AccessCreateRole = fnoop.UnaryServer[api.AccessCreateRoleRequest, api.AccessCreateRoleResponse]{}
+	a.AccessRetrieveRole = fnoop.UnaryServer[api.AccessRetrieveRoleRequest, api.AccessRetrieveRoleResponse]{}
+	a.AccessDeleteRole = fnoop.UnaryServer[api.AccessDeleteRoleRequest, types.Nil]{}
+	a.AccessAssignRole = fnoop.UnaryServer[api.AccessAssignRoleRequest, types.Nil]{}
+	a.AccessUnassignRole = fnoop.UnaryServer[api.AccessAssignRoleRequest, types.Nil]{}
+	a.AccessExplain = fnoop.UnaryServer[api.AccessExplainRequest, api.AccessExplainResponse]{}
+
+	// CLUSTER
+	a.ClusterRetrieveNodes = fnoop.UnaryServer[api.ClusterRetrieveNodesRequest, api.ClusterRetrieveNodesResponse]{}
+	a.ClusterRetrieveLeases = fnoop.UnaryServer[api.ClusterRetrieveLeasesRequest, api.ClusterRetrieveLeasesResponse]{}
+	a.ClusterGossip = fnoop.UnaryServer[types.Nil, types.Nil]{}
+	a.ClusterEvictNode = fnoop.UnaryServer[api.ClusterEvictNodeRequest, types.Nil]{}
 
 	return a, transports
 }
@@ -21,6 +21,19 @@ func New(router *fhttp.Router, codecResolver httputil.CodecResolver) (t api.Tran
 	// AUTH
 	t.AuthLogin = fhttp.UnaryServer[api.AuthLoginRequest, api.AuthLoginResponse](router, "/api/v1/auth/login")
 	t.AuthChangePassword = fhttp.UnaryServer[api.AuthChangePasswordRequest, types.Nil](router, "/api/v1/auth/change-password")
+	t.AuthLogout = fhttp.UnaryServer[api.AuthLogoutRequest, types.Nil](router, "/api/v1/auth/logout")
+	t.AuthOIDCAuthorize = fhttp.UnaryServer[types.Nil, api.AuthOIDCAuthorizeResponse](router, "/api/v1/auth/oidc/authorize")
+	t.AuthOIDCCallback = fhttp.UnaryServer[api.AuthOIDCCallbackRequest, api.AuthLoginResponse](router, "/api/v1/auth/oidc/callback")
+
+	// SESSION
+	t.SessionRetrieve = fhttp.UnaryServer[api.SessionRetrieveRequest, api.SessionRetrieveResponse](router, "/api/v1/auth/session/retrieve")
+	t.SessionRevoke = fhttp.UnaryServer[api.SessionRevokeRequest, types.Nil](router, "/api/v1/auth/session/revoke")
+
+	// API KEY
+	t.APIKeyCreate = fhttp.UnaryServer[api.APIKeyCreateRequest, api.APIKeyCreateResponse](router, "/api/v1/auth/key/create")
+	t.APIKeyRetrieve = fhttp.UnaryServer[api.APIKeyRetrieveRequest, api.APIKeyRetrieveResponse](router, "/api/v1/auth/key/retrieve")
+	t.APIKeyRotate = fhttp.UnaryServer[api.APIKeyRotateRequest, api.APIKeyRotateResponse](router, "/api/v1/auth/key/rotate")
+	t.APIKeyDelete = fhttp.UnaryServer[api.APIKeyDeleteRequest, types.Nil](router, "/api/v1/auth/key/delete")
 
 	// USER
 	t.UserRename = fhttp.UnaryServer[api.UserRenameRequest, types.Nil](router, "/api/v1/user/rename")
@@ -34,6 +47,7 @@ func New(router *fhttp.Router, codecResolver httputil.CodecResolver) (t api.Tran
 	t.ChannelRetrieve = fhttp.UnaryServer[api.ChannelRetrieveRequest, api.ChannelRetrieveResponse](router, "/api/v1/channel/retrieve")
 	t.ChannelDelete = fhttp.UnaryServer[api.ChannelDeleteRequest, types.Nil](router, "/api/v1/channel/delete")
 	t.ChannelRename = fhttp.UnaryServer[api.ChannelRenameRequest, types.Nil](router, "/api/v1/channel/rename")
+	t.ChannelUpdate = fhttp.UnaryServer[api.ChannelUpdateRequest, api.ChannelUpdateResponse](router, "/api/v1/channel/update")
 	t.ChannelRetrieveGroup = fhttp.UnaryServer[api.ChannelRetrieveGroupRequest, api.ChannelRetrieveGroupResponse](router, "/api/v1/channel/retrieve-group")
 
 	// CONNECTIVITY
@@ -44,6 +58,9 @@ func New(router *fhttp.Router, codecResolver httputil.CodecResolver) (t api.Tran
 	t.FrameIterator = fhttp.StreamServer[api.FrameIteratorRequest, api.FrameIteratorResponse](router, "/api/v1/frame/iterate")
 	t.FrameStreamer = fhttp.StreamServer[api.FrameStreamerRequest, api.FrameStreamerResponse](router, "/api/v1/frame/stream", fhttp.WithCodecResolver(codecResolver))
 	t.FrameDelete = fhttp.UnaryServer[api.FrameDeleteRequest, types.Nil](router, "/api/v1/frame/delete")
+	t.FrameRetrieveStreamers = fhttp.UnaryServer[api.FrameRetrieveStreamersRequest, api.FrameRetrieveStreamersResponse](router, "/api/v1/frame/streamer/retrieve")
+	t.FrameExport = fhttp.StreamServer[api.FrameExportRequest, api.FrameExportResponse](router, "/api/v1/frame/export")
+	t.FrameImport = fhttp.StreamServer[api.FrameImportRequest, api.FrameImportResponse](router, "/api/v1/frame/import")
 
 	// ONTOLOGY
 	t.OntologyRetrieve = fhttp.UnaryServer[api.OntologyRetrieveRequest, api.OntologyRetrieveResponse](router, "/api/v1/ontology/retrieve")
@@ -120,6 +137,17 @@ func New(router *fhttp.Router, codecResolver httputil.CodecResolver) (t api.Tran
 	t.LabelAdd = fhttp.UnaryServer[api.LabelAddRequest, types.Nil](router, "/api/v1/label/set")
 	t.LabelRemove = fhttp.UnaryServer[api.LabelRemoveRequest, types.Nil](router, "/api/v1/label/remove")
 
+	// ALARM
+	t.AlarmCreate = fhttp.UnaryServer[api.AlarmCreateRequest, api.AlarmCreateResponse](router, "/api/v1/alarm/create")
+	t.AlarmRetrieve = fhttp.UnaryServer[api.AlarmRetrieveRequest, api.AlarmRetrieveResponse](router, "/api/v1/alarm/retrieve")
+	t.AlarmDelete = fhttp.UnaryServer[api.AlarmDeleteRequest, types.Nil](router, "/api/v1/alarm/delete")
+	t.AlarmAcknowledge = fhttp.UnaryServer[api.AlarmAcknowledgeRequest, types.Nil](router, "/api/v1/alarm/acknowledge")
+	t.AlarmShelve = fhttp.UnaryServer[api.AlarmShelveRequest, types.Nil](router, "/api/v1/alarm/shelve")
+	t.AlarmRetrieveEvents = fhttp.UnaryServer[api.AlarmRetrieveEventsRequest, api.AlarmRetrieveEventsResponse](router, "/api/v1/alarm/retrieve-events")
+
+	// AUDIT
+	t.AuditRetrieve = fhttp.UnaryServer[api.AuditRetrieveRequest, api.AuditRetrieveResponse](router, "/api/v1/audit/retrieve")
+
 	// HARDWARE
 	t.HardwareCreateRack = fhttp.UnaryServer[api.HardwareCreateRackRequest, api.HardwareCreateRackResponse](router, "/api/v1/hardware/rack/create")
 	t.HardwareRetrieveRack = fhttp.UnaryServer[api.HardwareRetrieveRackRequest, api.HardwareRetrieveRackResponse](router, "/api/v1/hardware/rack/retrieve")
@@ -136,6 +164,18 @@ func New(router *fhttp.Router, codecResolver httputil.CodecResolver) (t api.Tran
 	t.AccessCreatePolicy = fhttp.UnaryServer[api.AccessCreatePolicyRequest, api.AccessCreatePolicyResponse](router, "/api/v1/access/policy/create")
 	t.AccessDeletePolicy = fhttp.UnaryServer[api.AccessDeletePolicyRequest, types.Nil](router, "/api/v1/access/policy/delete")
 	t.AccessRetrievePolicy = fhttp.UnaryServer[api.AccessRetrievePolicyRequest, api.AccessRetrievePolicyResponse](router, "/api/v1/access/policy/retrieve")
+	t.AccessCreateRole = fhttp.UnaryServer[api.AccessCreateRoleRequest, api.AccessCreateRoleResponse](router, "/api/v1/access/role/create")
+	t.AccessRetrieveRole = fhttp.UnaryServer[api.AccessRetrieveRoleRequest, api.AccessRetrieveRoleResponse](router, "/api/v1/access/role/retrieve")
+	t.AccessDeleteRole = fhttp.UnaryServer[api.AccessDeleteRoleRequest, types.Nil](router, "/api/v1/access/role/delete")
+	t.AccessAssignRole = fhttp.UnaryServer[api.AccessAssignRoleRequest, types.Nil](router, "/api/v1/access/role/assign")
+	t.AccessUnassignRole = fhttp.UnaryServer[api.AccessAssignRoleRequest, types.Nil](router, "/api/v1/access/role/unassign")
+	t.AccessExplain = fhttp.UnaryServer[api.AccessExplainRequest, api.AccessExplainResponse](router, "/api/v1/access/explain")
+
+	// CLUSTER
+	t.ClusterRetrieveNodes = fhttp.UnaryServer[api.ClusterRetrieveNodesRequest, api.ClusterRetrieveNodesResponse](router, "/api/v1/cluster/node/retrieve")
+	t.ClusterRetrieveLeases = fhttp.UnaryServer[api.ClusterRetrieveLeasesRequest, api.ClusterRetrieveLeasesResponse](router, "/api/v1/cluster/lease/retrieve")
+	t.ClusterGossip = fhttp.UnaryServer[types.Nil, types.Nil](router, "/api/v1/cluster/gossip")
+	t.ClusterEvictNode = fhttp.UnaryServer[api.ClusterEvictNodeRequest, types.Nil](router, "/api/v1/cluster/node/evict")
 
 	return t
 }
@@ -0,0 +1,47 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package api
+
+import (
+	"github.com/synnaxlabs/freighter"
+	"github.com/synnaxlabs/synnax/pkg/service/limit"
+)
+
+// rateLimitMiddleware rejects calls from users that exceed their rate limit for the
+// endpoint being called. It must run after the token middleware, as calls are limited
+// per authenticated user.
+func rateLimitMiddleware(l *limit.Service) freighter.Middleware {
+	return freighter.MiddlewareFunc(func(
+		ctx freighter.Context,
+		next freighter.Next,
+	) (freighter.Context, error) {
+		if err := l.Allow(getSubject(ctx), string(ctx.Target)); err != nil {
+			return ctx, err
+		}
+		return next(ctx)
+	})
+}
+
+// quotaMiddleware rejects streams from users that already have the maximum number of
+// streams of the given resource open. The stream counts against the quota of the user
+// until its handler returns. It must run after the token middleware.
+func quotaMiddleware(l *limit.Service, r limit.Resource) freighter.Middleware {
+	return freighter.MiddlewareFunc(func(
+		ctx freighter.Context,
+		next freighter.Next,
+	) (freighter.Context, error) {
+		release, err := l.Acquire(getSubject(ctx), r)
+		if err != nil {
+			return ctx, err
+		}
+		defer release()
+		return next(ctx)
+	})
+}
@@ -0,0 +1,825 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	mqtt "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/alamos"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/framer"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/export"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/importer"
+	"github.com/synnaxlabs/synnax/pkg/service/limit"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+	"go.uber.org/zap"
+)
+
+const (
+	// mqttTopicPrefix is the prefix of all topics reserved by Synnax.
+	mqttTopicPrefix = "synnax/"
+	// mqttChannelTopicPrefix is the prefix of the topics that are bridged to channels.
+	// The rest of the topic is the name of the channel.
+	mqttChannelTopicPrefix = mqttTopicPrefix + "ch/"
+	// mqttSharePrefix is the prefix of MQTT 5 shared subscription filters.
+	mqttSharePrefix = "$share/"
+)
+
+// MQTTService bridges an embedded MQTT broker to channels. Publishing to the topic
+// synnax/ch/<name> writes a sample to the channel with the given name, and subscribing
+// to the same topic streams the channel's samples from the relay. Topics outside of
+// synnax/ are relayed between clients as a plain broker.
+//
+// Clients authenticate with their username and password, or with a token passed as the
+// password and an empty username. Reading from and writing to channel topics is subject
+// to the same access control as the other APIs. A client ID is bound to the user that
+// opened its session until the session expires, and clients that connect with the ID
+// of a session belonging to another user are rejected.
+//
+// A published payload is either the value of the sample or a JSON object of the form
+// {"value": <value>, "time": <timestamp>}, where the timestamp is in nanoseconds since
+// the unix epoch or an RFC3339 string. Payloads published to JSON channels are always
+// written verbatim. Samples without a timestamp are stamped with the time they were
+// received. Samples are streamed to subscribers as plain values.
+//
+// Each channel is written to by a single writer that remains open until the broker
+// stops, so channels that share an index can't be written to through the broker.
+//
+// The channel subscriptions of a client count as a single streamer against the quota of
+// its user, and each writer counts against the quota of the user that opened it.
+type MQTTService struct {
+	mqtt.HookBase
+	alamos.Instrumentation
+	authProvider
+	userProvider
+	accessProvider
+	auditProvider
+	limitProvider
+	// Broker is the embedded broker. It should be served by a server.MQTTBranch.
+	Broker  *mqtt.Server
+	channel channel.Readable
+	framer  *framer.Service
+	mu      struct {
+		sync.Mutex
+		// sessions maps client IDs to their sessions.
+		sessions map[string]*mqttSession
+		writers  map[channel.Key]*mqttWriter
+	}
+	bridge *mqttBridge
+}
+
+// mqttSession is the session of a client that has authenticated with the broker.
+type mqttSession struct {
+	// client is the client that most recently connected with the ID of the session.
+	client *mqtt.Client
+	// subject is the user that the client authenticated as.
+	subject ontology.ID
+	// subscriptions maps the channels the client is subscribed to to their names.
+	subscriptions map[channel.Key]string
+	// releaseStreamer releases the streamer quota held by the session while it has
+	// channel subscriptions. It is nil if the session holds no quota.
+	releaseStreamer func()
+	// overQuota is set while processing a subscribe packet from a client that has
+	// reached its streamer quota, so that its channel subscriptions are rejected.
+	overQuota bool
+}
+
+// release releases the streamer quota held by the session, if any.
+func (sess *mqttSession) release() {
+	if sess.releaseStreamer != nil {
+		sess.releaseStreamer()
+		sess.releaseStreamer = nil
+	}
+}
+
+var _ mqtt.Hook = (*MQTTService)(nil)
+
+func NewMQTTService(p Provider) (*MQTTService, error) {
+	s := &MQTTService{
+		Instrumentation: p.Instrumentation,
+		authProvider:    p.auth,
+		userProvider:    p.user,
+		accessProvider:  p.access,
+		auditProvider:   p.audit,
+		limitProvider:   p.limit,
+		channel:         p.Distribution.Channel,
+		framer:          p.Service.Framer,
+	}
+	s.mu.sessions = make(map[string]*mqttSession)
+	s.mu.writers = make(map[channel.Key]*mqttWriter)
+	s.Broker = mqtt.New(&mqtt.Options{
+		InlineClient: true,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	return s, s.Broker.AddHook(s, nil)
+}
+
+// ID implements mqtt.Hook.
+func (s *MQTTService) ID() string { return "synnax" }
+
+// Provides implements mqtt.Hook.
+func (s *MQTTService) Provides(b byte) bool {
+	return bytes.Contains([]byte{
+		mqtt.OnStarted,
+		mqtt.OnStopped,
+		mqtt.OnConnectAuthenticate,
+		mqtt.OnSessionEstablished,
+		mqtt.OnACLCheck,
+		mqtt.OnPublish,
+		mqtt.OnSubscribe,
+		mqtt.OnSubscribed,
+		mqtt.OnUnsubscribed,
+		mqtt.OnDisconnect,
+		mqtt.OnClientExpired,
+	}, []byte{b})
+}
+
+// OnStarted implements mqtt.Hook, opening the streamer that relays channel samples to
+// subscribers.
+func (s *MQTTService) OnStarted() {
+	b, err := openMQTTBridge(s)
+	if err != nil {
+		s.L.Error("failed to open mqtt bridge", zap.Error(err))
+		return
+	}
+	s.bridge = b
+}
+
+// OnStopped implements mqtt.Hook, closing the bridge and all open writers.
+func (s *MQTTService) OnStopped() {
+	if s.bridge != nil {
+		if err := s.bridge.Close(); err != nil {
+			s.L.Error("failed to close mqtt bridge", zap.Error(err))
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, w := range s.mu.writers {
+		if err := w.close(); err != nil {
+			s.L.Error("failed to close mqtt writer", zap.Stringer("channel", key), zap.Error(err))
+		}
+	}
+	clear(s.mu.writers)
+}
+
+// OnConnectAuthenticate implements mqtt.Hook. Clients can't connect with the ID of a
+// session that belongs to another user, as they would take over its subscriptions.
+func (s *MQTTService) OnConnectAuthenticate(cl *mqtt.Client, pk packets.Packet) bool {
+	key, err := authenticatePassword(
+		context.Background(),
+		s.authProvider,
+		s.userProvider,
+		string(pk.Connect.Username),
+		string(pk.Connect.Password),
+	)
+	if err != nil {
+		s.L.Debug("mqtt client failed to authenticate", zap.String("client", cl.ID), zap.Error(err))
+		return false
+	}
+	subject := user.OntologyID(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, ok := s.mu.sessions[cl.ID]
+	if ok && prev.subject != subject {
+		s.L.Warn(
+			"rejected mqtt client connecting with the id of another user's session",
+			zap.String("client", cl.ID),
+			zap.Stringer("user", subject),
+		)
+		return false
+	}
+	sess := &mqttSession{
+		client:        cl,
+		subject:       subject,
+		subscriptions: make(map[channel.Key]string),
+	}
+	if ok {
+		// The subscriptions of the previous session may be inherited, so its quota
+		// is carried over until the session is established.
+		sess.releaseStreamer = prev.releaseStreamer
+	}
+	s.mu.sessions[cl.ID] = sess
+	return true
+}
+
+// OnSessionEstablished implements mqtt.Hook. Subscriptions inherited from an existing
+// session are checked again, as the access of the user may have changed since they
+// were granted, and the ones that are no longer allowed are dropped.
+func (s *MQTTService) OnSessionEstablished(cl *mqtt.Client, _ packets.Packet) {
+	ctx := context.Background()
+	subs := make(map[channel.Key]string)
+	for filter := range cl.State.Subscriptions.GetAll() {
+		if !s.OnACLCheck(cl, filter, false) {
+			cl.State.Subscriptions.Delete(filter)
+			if s.Broker.Topics.Unsubscribe(filter, cl.ID) {
+				atomic.AddInt64(&s.Broker.Info.Subscriptions, -1)
+			}
+			s.L.Debug(
+				"dropped inherited mqtt subscription",
+				zap.String("client", cl.ID),
+				zap.String("topic", filter),
+			)
+			continue
+		}
+		name, isChannel := mqttChannelName(mqttUnshare(filter))
+		if !isChannel {
+			continue
+		}
+		ch, err := s.retrieveChannel(ctx, name)
+		if err != nil {
+			continue
+		}
+		subs[ch.Key()] = name
+	}
+	s.mu.Lock()
+	if sess, ok := s.mu.sessions[cl.ID]; ok && sess.client == cl {
+		sess.subscriptions = subs
+		if len(subs) == 0 {
+			sess.release()
+		}
+	}
+	s.mu.Unlock()
+	s.updateKeys()
+}
+
+// OnACLCheck implements mqtt.Hook. Clients can only read from and write to channel
+// topics they have access to, and can't use wildcards to subscribe to channel topics.
+func (s *MQTTService) OnACLCheck(cl *mqtt.Client, topic string, write bool) bool {
+	s.mu.Lock()
+	sess, ok := s.mu.sessions[cl.ID]
+	s.mu.Unlock()
+	if !ok || sess.client != cl {
+		return false
+	}
+	topic = mqttUnshare(topic)
+	name, isChannel := mqttChannelName(topic)
+	if isChannel && !write {
+		s.mu.Lock()
+		overQuota := sess.overQuota
+		s.mu.Unlock()
+		if overQuota {
+			return false
+		}
+	}
+	if !isChannel {
+		// Wildcards at the first level of a filter would match channel topics.
+		return !strings.HasPrefix(topic, mqttTopicPrefix) &&
+			!strings.HasPrefix(topic, "+") &&
+			!strings.HasPrefix(topic, "#")
+	}
+	ctx := context.Background()
+	ch, err := s.retrieveChannel(ctx, name)
+	if err != nil {
+		return false
+	}
+	action := access.Retrieve
+	if write {
+		if ch.IsIndex || ch.IsCalculated() {
+			return false
+		}
+		action = access.Control
+	}
+	return s.access.Enforce(ctx, access.Request{
+		Subject: sess.subject,
+		Action:  action,
+		Objects: framer.OntologyIDs(channel.Keys{ch.Key()}),
+	}) == nil
+}
+
+// OnPublish implements mqtt.Hook, writing samples published to channel topics. Samples
+// that are written successfully are not relayed directly to subscribers, and instead
+// reach them through the bridge in the same way as samples written by any other
+// client.
+func (s *MQTTService) OnPublish(cl *mqtt.Client, pk packets.Packet) (packets.Packet, error) {
+	if cl.Net.Inline {
+		return pk, nil
+	}
+	name, isChannel := mqttChannelName(pk.TopicName)
+	if !isChannel {
+		return pk, nil
+	}
+	s.mu.Lock()
+	sess, ok := s.mu.sessions[cl.ID]
+	s.mu.Unlock()
+	if !ok {
+		return pk, packets.ErrNotAuthorized
+	}
+	err := s.write(context.Background(), sess.subject, name, pk.Payload)
+	if err == nil {
+		return pk, packets.CodeSuccessIgnore
+	}
+	s.L.Warn(
+		"failed to write mqtt publish",
+		zap.String("client", cl.ID),
+		zap.String("topic", pk.TopicName),
+		zap.Error(err),
+	)
+	if cl.Properties.ProtocolVersion != 5 || pk.FixedHeader.Qos == 0 {
+		return pk, packets.CodeSuccessIgnore
+	}
+	if errors.Is(err, validate.Error) {
+		return pk, packets.ErrPayloadFormatInvalid
+	}
+	if errors.Is(err, limit.Error) {
+		return pk, packets.ErrQuotaExceeded
+	}
+	return pk, packets.ErrImplementationSpecificError
+}
+
+// OnSubscribe implements mqtt.Hook. The first channel subscriptions of a client acquire
+// a streamer from the quota of its user, and are rejected if the user has reached its
+// quota.
+func (s *MQTTService) OnSubscribe(cl *mqtt.Client, pk packets.Packet) packets.Packet {
+	if !lo.SomeBy(pk.Filters, func(sub packets.Subscription) bool {
+		_, isChannel := mqttChannelName(mqttUnshare(sub.Filter))
+		return isChannel
+	}) {
+		return pk
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.mu.sessions[cl.ID]
+	if !ok || sess.client != cl || sess.releaseStreamer != nil {
+		return pk
+	}
+	release, err := s.limit.Acquire(sess.subject, limit.Streamer)
+	if err != nil {
+		s.L.Warn("rejected mqtt subscriptions", zap.String("client", cl.ID), zap.Error(err))
+		sess.overQuota = true
+		return pk
+	}
+	sess.releaseStreamer = release
+	return pk
+}
+
+// OnSubscribed implements mqtt.Hook, streaming the channels of granted subscriptions
+// to channel topics.
+func (s *MQTTService) OnSubscribed(cl *mqtt.Client, pk packets.Packet, reasonCodes []byte) {
+	ctx := context.Background()
+	changed := false
+	defer func() {
+		s.mu.Lock()
+		if sess, ok := s.mu.sessions[cl.ID]; ok && sess.client == cl {
+			sess.overQuota = false
+			if len(sess.subscriptions) == 0 {
+				sess.release()
+			}
+		}
+		s.mu.Unlock()
+	}()
+	for i, sub := range pk.Filters {
+		if reasonCodes[i] > packets.CodeGrantedQos2.Code {
+			continue
+		}
+		name, isChannel := mqttChannelName(mqttUnshare(sub.Filter))
+		if !isChannel {
+			continue
+		}
+		ch, err := s.retrieveChannel(ctx, name)
+		if err != nil {
+			s.L.Warn("failed to retrieve mqtt subscription channel", zap.String("topic", sub.Filter), zap.Error(err))
+			continue
+		}
+		s.mu.Lock()
+		if sess, ok := s.mu.sessions[cl.ID]; ok && sess.client == cl {
+			sess.subscriptions[ch.Key()] = name
+			changed = true
+		}
+		s.mu.Unlock()
+	}
+	if changed {
+		s.updateKeys()
+	}
+}
+
+// OnUnsubscribed implements mqtt.Hook.
+func (s *MQTTService) OnUnsubscribed(cl *mqtt.Client, pk packets.Packet) {
+	s.mu.Lock()
+	sess, ok := s.mu.sessions[cl.ID]
+	if !ok || sess.client != cl {
+		s.mu.Unlock()
+		return
+	}
+	for _, sub := range pk.Filters {
+		name, isChannel := mqttChannelName(mqttUnshare(sub.Filter))
+		if !isChannel {
+			continue
+		}
+		for key, n := range sess.subscriptions {
+			if n == name {
+				delete(sess.subscriptions, key)
+			}
+		}
+	}
+	if len(sess.subscriptions) == 0 {
+		sess.release()
+	}
+	s.mu.Unlock()
+	s.updateKeys()
+}
+
+// OnDisconnect implements mqtt.Hook.
+func (s *MQTTService) OnDisconnect(cl *mqtt.Client, _ error, expire bool) {
+	if expire {
+		s.removeClient(cl)
+	}
+}
+
+// OnClientExpired implements mqtt.Hook.
+func (s *MQTTService) OnClientExpired(cl *mqtt.Client) { s.removeClient(cl) }
+
+// removeClient removes the session of the client, unless it has been taken over by a
+// client that connected with the same ID.
+func (s *MQTTService) removeClient(cl *mqtt.Client) {
+	s.mu.Lock()
+	if sess, ok := s.mu.sessions[cl.ID]; ok && sess.client == cl {
+		sess.release()
+		delete(s.mu.sessions, cl.ID)
+	}
+	s.mu.Unlock()
+	s.updateKeys()
+}
+
+func (s *MQTTService) updateKeys() {
+	if s.bridge != nil {
+		s.bridge.update()
+	}
+}
+
+// subscribed returns the topic names of the channels that clients are subscribed to.
+func (s *MQTTService) subscribed() map[channel.Key]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make(map[channel.Key]string)
+	for _, sess := range s.mu.sessions {
+		for key, name := range sess.subscriptions {
+			names[key] = name
+		}
+	}
+	return names
+}
+
+func (s *MQTTService) retrieveChannel(ctx context.Context, name string) (channel.Channel, error) {
+	var ch channel.Channel
+	err := s.channel.NewRetrieve().WhereNames(name).Entry(&ch).Exec(ctx, nil)
+	return ch, err
+}
+
+// write parses a payload published by the subject and writes it to the channel with
+// the given name.
+func (s *MQTTService) write(
+	ctx context.Context,
+	subject ontology.ID,
+	name string,
+	payload []byte,
+) error {
+	ch, err := s.retrieveChannel(ctx, name)
+	if err != nil {
+		return err
+	}
+	value, ts, err := parseMQTTPayload(ch.DataType, payload)
+	if err != nil {
+		return err
+	}
+	var series telem.Series
+	if ch.DataType.IsVariable() {
+		series = telem.Series{
+			DataType: ch.DataType,
+			Data:     telem.MarshalStrings([]string{value}, ch.DataType),
+		}
+	} else {
+		sample, err := importer.ParseSample(ch.DataType, value)
+		if err != nil {
+			return err
+		}
+		series = telem.Series{DataType: ch.DataType, Data: sample}
+	}
+	s.mu.Lock()
+	w, ok := s.mu.writers[ch.Key()]
+	if !ok {
+		w = &mqttWriter{ch: ch}
+		s.mu.writers[ch.Key()] = w
+	}
+	s.mu.Unlock()
+	return w.write(ctx, s, subject, series, ts)
+}
+
+// mqttChannelName returns the name of the channel bridged to the given topic, and
+// whether the topic is bridged to a channel at all.
+func mqttChannelName(topic string) (string, bool) {
+	name, ok := strings.CutPrefix(topic, mqttChannelTopicPrefix)
+	if !ok || name == "" || strings.ContainsAny(name, "+#") {
+		return "", false
+	}
+	return name, true
+}
+
+// mqttUnshare returns the topic filter of a shared subscription filter, or the filter
+// itself if it isn't shared.
+func mqttUnshare(filter string) string {
+	rest, ok := strings.CutPrefix(filter, mqttSharePrefix)
+	if !ok {
+		return filter
+	}
+	if _, topic, ok := strings.Cut(rest, "/"); ok {
+		return topic
+	}
+	return rest
+}
+
+// parseMQTTPayload parses the string representation of a sample and its optional
+// timestamp from a published payload.
+func parseMQTTPayload(dt telem.DataType, payload []byte) (string, telem.TimeStamp, error) {
+	payload = bytes.TrimSpace(payload)
+	if dt == telem.JSONT || !bytes.HasPrefix(payload, []byte("{")) {
+		return string(payload), 0, nil
+	}
+	var msg struct {
+		Value json.RawMessage `json:"value"`
+		Time  json.RawMessage `json:"time"`
+	}
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		return "", 0, errors.Wrapf(validate.Error, "invalid payload: %s", err)
+	}
+	if len(msg.Value) == 0 {
+		return "", 0, errors.Wrap(validate.Error, "payload has no value")
+	}
+	value, err := unquoteMQTTField(msg.Value)
+	if err != nil {
+		return "", 0, err
+	}
+	if len(msg.Time) == 0 {
+		return value, 0, nil
+	}
+	t, err := unquoteMQTTField(msg.Time)
+	if err != nil {
+		return "", 0, err
+	}
+	if ns, err := strconv.ParseInt(t, 10, 64); err == nil {
+		return value, telem.TimeStamp(ns), nil
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, t)
+	if err != nil {
+		return "", 0, errors.Wrapf(validate.Error, "invalid timestamp %q", t)
+	}
+	return value, telem.NewTimeStamp(parsed), nil
+}
+
+func unquoteMQTTField(raw json.RawMessage) (string, error) {
+	if raw[0] != '"' {
+		return string(raw), nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", errors.Wrapf(validate.Error, "invalid payload: %s", err)
+	}
+	return s, nil
+}
+
+// mqttWriter writes samples published to a single channel.
+type mqttWriter struct {
+	mu   sync.Mutex
+	ch   channel.Channel
+	w    *framer.Writer
+	last telem.TimeStamp
+	// release releases the writer quota of the user that opened the writer.
+	release func()
+}
+
+// write writes a single sample published by the subject to the channel, opening the
+// writer if necessary. Writer opens are recorded in the audit log, and count against
+// the writer quota of the subject until the writer closes. If no timestamp is
+// provided, the sample is stamped with the current time. Timestamps are bumped so that
+// they are always strictly increasing.
+func (w *mqttWriter) write(
+	ctx context.Context,
+	svc *MQTTService,
+	subject ontology.ID,
+	series telem.Series,
+	ts telem.TimeStamp,
+) (err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if ts == 0 {
+		ts = telem.Now()
+	}
+	if ts <= w.last {
+		ts = w.last + 1
+	}
+	keys := channel.Keys{w.ch.Key()}
+	fr := core.UnaryFrame(w.ch.Key(), series)
+	if !w.ch.Virtual {
+		keys = append(keys, w.ch.Index())
+		fr = fr.Append(w.ch.Index(), telem.NewSeriesV[telem.TimeStamp](ts))
+	}
+	if w.w == nil {
+		cfg := framer.WriterConfig{
+			Keys:              keys,
+			Start:             ts,
+			ErrOnUnauthorized: config.True(),
+			EnableAutoCommit:  config.True(),
+			Sync:              config.True(),
+		}
+		if w.release, err = svc.limit.Acquire(subject, limit.Writer); err != nil {
+			return err
+		}
+		aCtx, audit := svc.startAudit(ctx, "mqtt", mqttChannelTopicPrefix+w.ch.Name, subject)
+		audit.setRequest(cfg)
+		// Access to the channel is enforced when the client publishes to its topic.
+		audit.addAccess(access.Request{
+			Subject: subject,
+			Action:  access.Control,
+			Objects: framer.OntologyIDs(channel.Keys{w.ch.Key()}),
+		})
+		w.w, err = svc.framer.OpenWriter(aCtx, cfg)
+		audit.finish(aCtx, err)
+		if err != nil {
+			w.release()
+			return err
+		}
+	}
+	if _, err = w.w.Write(fr); err != nil {
+		// Writers can't be used after an error, so the next sample opens a new one.
+		return errors.Combine(err, w.closeLocked())
+	}
+	w.last = ts
+	return nil
+}
+
+func (w *mqttWriter) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closeLocked()
+}
+
+func (w *mqttWriter) closeLocked() error {
+	if w.w == nil {
+		return nil
+	}
+	err := w.w.Close()
+	w.w = nil
+	w.release()
+	return err
+}
+
+// mqttBridge streams the channels that clients are subscribed to from the relay and
+// publishes their samples to the broker.
+type mqttBridge struct {
+	svc       *MQTTService
+	requests  confluence.Inlet[framer.StreamerRequest]
+	responses confluence.Outlet[framer.StreamerResponse]
+	updates   chan struct{}
+	stop      chan struct{}
+	names     map[channel.Key]string
+	shutdown  func() error
+}
+
+func openMQTTBridge(svc *MQTTService) (*mqttBridge, error) {
+	sCtx, cancel := signal.Isolated(signal.WithInstrumentation(svc.Instrumentation))
+	streamer, err := svc.framer.NewStreamer(sCtx, framer.StreamerConfig{})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	requests := confluence.NewStream[framer.StreamerRequest](1)
+	responses := confluence.NewStream[framer.StreamerResponse](10)
+	streamer.InFrom(requests)
+	streamer.OutTo(responses)
+	b := &mqttBridge{
+		svc:       svc,
+		requests:  requests,
+		responses: responses,
+		updates:   make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+	}
+	streamer.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+	sCtx.Go(b.run, signal.WithKey("mqtt_bridge"))
+	b.shutdown = func() error {
+		defer cancel()
+		close(b.stop)
+		return sCtx.Wait()
+	}
+	return b, nil
+}
+
+// update notifies the bridge that the channels clients are subscribed to have changed.
+func (b *mqttBridge) update() {
+	select {
+	case b.updates <- struct{}{}:
+	default:
+	}
+}
+
+func (b *mqttBridge) run(ctx context.Context) error {
+	defer func() {
+		// Closing the requests shuts down the streamer, and we need to drain its
+		// responses so that it doesn't block while exiting.
+		b.requests.Close()
+		for range b.responses.Outlet() {
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-b.stop:
+			return nil
+		case res, ok := <-b.responses.Outlet():
+			if !ok {
+				return nil
+			}
+			b.publish(res.Frame)
+		case <-b.updates:
+			b.updateKeys(ctx)
+		}
+	}
+}
+
+// updateKeys updates the channels streamed from the relay. Responses are drained while
+// waiting to send the request to avoid deadlocking with the streamer.
+func (b *mqttBridge) updateKeys(ctx context.Context) {
+	b.names = b.svc.subscribed()
+	keys := make(channel.Keys, 0, len(b.names))
+	for key := range b.names {
+		keys = append(keys, key)
+	}
+	req := framer.StreamerRequest{Keys: keys}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.stop:
+			return
+		case b.requests.Inlet() <- req:
+			return
+		case res, ok := <-b.responses.Outlet():
+			if !ok {
+				return
+			}
+			b.publish(res.Frame)
+		}
+	}
+}
+
+// publish publishes every sample in the frame to the topic of its channel.
+func (b *mqttBridge) publish(fr framer.Frame) {
+	for key, series := range fr.Entries() {
+		name, ok := b.names[key]
+		if !ok {
+			continue
+		}
+		topic := mqttChannelTopicPrefix + name
+		for _, payload := range mqttPayloads(series) {
+			if err := b.svc.Broker.Publish(topic, []byte(payload), false, 0); err != nil {
+				b.svc.L.Warn("failed to publish mqtt sample", zap.String("topic", topic), zap.Error(err))
+			}
+		}
+	}
+}
+
+// mqttPayloads returns the string representation of every sample in the series.
+func mqttPayloads(series telem.Series) []string {
+	if series.DataType.IsVariable() {
+		return telem.UnmarshalStrings(series.Data)
+	}
+	density := int(series.DataType.Density())
+	if density == 0 {
+		return nil
+	}
+	payloads := make([]string, 0, len(series.Data)/density)
+	for i := 0; i+density <= len(series.Data); i += density {
+		payloads = append(payloads, export.FormatSample(series.DataType, series.Data[i:i+density]))
+	}
+	return payloads
+}
+
+// Close stops the bridge.
+func (b *mqttBridge) Close() error { return b.shutdown() }
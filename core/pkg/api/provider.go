@@ -10,11 +10,22 @@
 package api
 
 import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/alamos"
 	"github.com/synnaxlabs/synnax/pkg/distribution/cluster"
 	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
 	"github.com/synnaxlabs/synnax/pkg/service/access/rbac"
+	"github.com/synnaxlabs/synnax/pkg/service/audit"
 	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/apikey"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/oidc"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/password"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/session"
 	"github.com/synnaxlabs/synnax/pkg/service/auth/token"
+	"github.com/synnaxlabs/synnax/pkg/service/limit"
 	"github.com/synnaxlabs/synnax/pkg/service/user"
 	"github.com/synnaxlabs/x/gorp"
 )
@@ -29,16 +40,26 @@ type Provider struct {
 	auth     authProvider
 	cluster  clusterProvider
 	ontology OntologyProvider
+	audit    auditProvider
+	limit    limitProvider
 }
 
 func NewProvider(cfg Config) Provider {
 	p := Provider{Config: cfg}
 	p.db = dbProvider{DB: cfg.Distribution.DB}
 	p.user = userProvider{user: cfg.Service.User}
-	p.access = accessProvider{access: cfg.Service.RBAC}
-	p.auth = authProvider{token: cfg.Service.Token, authenticator: cfg.Service.Auth}
+	p.access = accessProvider{access: enforcer{Service: cfg.Service.RBAC}}
+	p.auth = authProvider{
+		token:         cfg.Service.Token,
+		authenticator: cfg.Service.Auth,
+		apiKey:        cfg.Service.APIKey,
+		session:       cfg.Service.Session,
+		oidc:          cfg.Service.OIDC,
+	}
 	p.cluster = clusterProvider{cluster: cfg.Distribution.Cluster}
 	p.ontology = OntologyProvider{Ontology: cfg.Distribution.Ontology}
+	p.audit = auditProvider{ins: cfg.Instrumentation, audit: cfg.Service.Audit}
+	p.limit = limitProvider{limit: cfg.Service.Limit}
 	return p
 }
 
@@ -54,7 +75,25 @@ type userProvider struct {
 
 // AccessProvider provides access control information and utilities to services.
 type accessProvider struct {
-	access *rbac.Service
+	access enforcer
+}
+
+// enforcer enforces access control using RBAC. Requests authenticated with an API key
+// are additionally limited to the actions the key is scoped to, regardless of the
+// policies of the key's user.
+type enforcer struct{ *rbac.Service }
+
+var _ access.Enforcer = enforcer{}
+
+// Enforce implements access.Enforcer.
+func (e enforcer) Enforce(ctx context.Context, req access.Request) error {
+	if r, ok := getAuditRecord(ctx); ok {
+		r.addAccess(req)
+	}
+	if !allowedByAPIKey(ctx, req.Action) {
+		return access.Denied
+	}
+	return e.Service.Enforce(ctx, req)
 }
 
 // authProvider provides authentication and token utilities to services. In most cases
@@ -62,6 +101,33 @@ type accessProvider struct {
 type authProvider struct {
 	authenticator auth.Authenticator
 	token         *token.Service
+	apiKey        *apikey.Service
+	session       *session.Service
+	oidc          *oidc.Service
+}
+
+// authenticatePassword authenticates a client of a protocol that connects with a
+// username and password, returning the key of the client's user. Clients can
+// authenticate with a token instead by passing it as the password with an empty
+// username.
+func authenticatePassword(
+	ctx context.Context,
+	a authProvider,
+	u userProvider,
+	username string,
+	pass string,
+) (uuid.UUID, error) {
+	if username == "" {
+		sess, _, err := a.session.Authenticate(ctx, pass)
+		return sess.User, err
+	}
+	creds := auth.InsecureCredentials{Username: username, Password: password.Raw(pass)}
+	if err := a.authenticator.Authenticate(ctx, creds); err != nil {
+		return uuid.Nil, err
+	}
+	var usr user.User
+	err := u.user.NewRetrieve().WhereUsernames(username).Entry(&usr).Exec(ctx, nil)
+	return usr.Key, err
 }
 
 // OntologyProvider provides the cluster wide ontology to services.
@@ -69,6 +135,44 @@ type OntologyProvider struct {
 	Ontology *ontology.Ontology
 }
 
+// auditProvider provides the audit log to services that are not served through
+// freighter, and so are not audited by middleware.
+type auditProvider struct {
+	ins   alamos.Instrumentation
+	audit *audit.Service
+}
+
+// startAudit starts the audit log entry for a call made by the subject. See the
+// startAudit function for details.
+func (a auditProvider) startAudit(
+	ctx context.Context,
+	protocol string,
+	target string,
+	subject ontology.ID,
+) (context.Context, *auditRecord) {
+	return startAudit(ctx, a.ins, a.audit, protocol, target, subject)
+}
+
+// limitProvider provides rate limits and concurrency quotas to services that are not
+// served through freighter, and so are not limited by middleware.
+type limitProvider struct {
+	limit *limit.Service
+}
+
+// acquire consumes a call by the subject to the endpoint, and counts the stream that
+// the call opens against the subject's quota of the resource. If acquire succeeds, the
+// returned function must be called when the stream closes.
+func (l limitProvider) acquire(
+	subject ontology.ID,
+	endpoint string,
+	r limit.Resource,
+) (release func(), err error) {
+	if err = l.limit.Allow(subject, endpoint); err != nil {
+		return nil, err
+	}
+	return l.limit.Acquire(subject, r)
+}
+
 // clusterProvider provides cluster topology information to services.
 type clusterProvider struct {
 	cluster cluster.Cluster
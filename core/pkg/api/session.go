@@ -0,0 +1,124 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package api
+
+import (
+	"context"
+	"go/types"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/session"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/query"
+)
+
+// SessionService lists and revokes the login sessions of users. Users can always manage
+// their own sessions, while managing the sessions of another user requires access to
+// update that user.
+type SessionService struct {
+	dbProvider
+	accessProvider
+	internal *session.Service
+}
+
+func NewSessionService(p Provider) *SessionService {
+	return &SessionService{
+		dbProvider:     p.db,
+		accessProvider: p.access,
+		internal:       p.Service.Session,
+	}
+}
+
+type (
+	SessionRetrieveRequest struct {
+		// Users are the users to retrieve the sessions of. If empty, the sessions of the
+		// user making the request are retrieved.
+		Users []uuid.UUID `json:"users" msgpack:"users"`
+	}
+	SessionRetrieveResponse struct {
+		Sessions []session.Session `json:"sessions" msgpack:"sessions"`
+		// Current is the key of the session the request was authenticated with, or
+		// uuid.Nil if the request was authenticated with an API key.
+		Current uuid.UUID `json:"current" msgpack:"current"`
+	}
+)
+
+// Retrieve retrieves the sessions of the given users.
+func (s *SessionService) Retrieve(ctx context.Context, req SessionRetrieveRequest) (SessionRetrieveResponse, error) {
+	users := req.Users
+	if len(users) == 0 {
+		key, err := user.KeyFromOntologyID(getSubject(ctx))
+		if err != nil {
+			return SessionRetrieveResponse{}, err
+		}
+		users = []uuid.UUID{key}
+	}
+	if err := s.enforceUsers(ctx, users); err != nil {
+		return SessionRetrieveResponse{}, err
+	}
+	var res SessionRetrieveResponse
+	if err := s.internal.NewRetrieve().
+		WhereUsers(users...).
+		Entries(&res.Sessions).
+		Exec(ctx, nil); err != nil {
+		return SessionRetrieveResponse{}, err
+	}
+	if current, ok := getSession(ctx); ok {
+		res.Current = current.Key
+	}
+	return res, nil
+}
+
+type SessionRevokeRequest struct {
+	Keys []uuid.UUID `json:"keys" msgpack:"keys"`
+}
+
+// Revoke revokes the sessions with the given keys. Tokens for the sessions are rejected
+// from then on.
+func (s *SessionService) Revoke(ctx context.Context, req SessionRevokeRequest) (types.Nil, error) {
+	var sessions []session.Session
+	if err := s.internal.NewRetrieve().
+		WhereKeys(req.Keys...).
+		Entries(&sessions).
+		Exec(ctx, nil); err != nil && !errors.Is(err, query.NotFound) {
+		return types.Nil{}, err
+	}
+	if err := s.enforceUsers(ctx, lo.Map(sessions, func(sess session.Session, _ int) uuid.UUID {
+		return sess.User
+	})); err != nil {
+		return types.Nil{}, err
+	}
+	return types.Nil{}, s.WithTx(ctx, func(tx gorp.Tx) error {
+		return s.internal.NewWriter(tx).Revoke(ctx, req.Keys...)
+	})
+}
+
+// enforceUsers checks that the subject of the request may manage the sessions of the
+// given users.
+func (s *SessionService) enforceUsers(ctx context.Context, users []uuid.UUID) error {
+	subject := getSubject(ctx)
+	others := lo.Uniq(lo.FilterMap(users, func(u uuid.UUID, _ int) (ontology.ID, bool) {
+		id := user.OntologyID(u)
+		return id, id != subject
+	}))
+	if len(others) == 0 {
+		return nil
+	}
+	return s.access.Enforce(ctx, access.Request{
+		Subject: subject,
+		Action:  access.Update,
+		Objects: others,
+	})
+}
@@ -0,0 +1,635 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"math/big"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgproto3"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/synnaxlabs/alamos"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/limit"
+	"github.com/synnaxlabs/synnax/pkg/service/sql"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+	"go.uber.org/zap"
+)
+
+const (
+	// sqlEndpoint is the endpoint that SQL queries are rate limited under.
+	sqlEndpoint = "sql"
+	// sqlFlushInterval is the number of rows sent to a client between flushes of the
+	// connection's write buffer.
+	sqlFlushInterval = 1000
+	// sqlStartupTimeout is the maximum time a client can take to negotiate TLS and
+	// authenticate.
+	sqlStartupTimeout = 10 * time.Second
+)
+
+// SQLService serves read-only SQL queries over the PostgreSQL wire protocol, so that
+// tools such as psql, Grafana, and BI tools can query channels and ranges. See the sql
+// package for the supported dialect and tables.
+//
+// Clients authenticate with their username and password, or with a token passed as the
+// password and an empty username. Passwords are sent in cleartext, so when the server
+// is running in secure mode, connections that aren't upgraded to TLS before the client
+// authenticates are rejected. Queries are subject to the same access control as the
+// other APIs, and count against the iterator quota of the user while they run.
+type SQLService struct {
+	alamos.Instrumentation
+	authProvider
+	userProvider
+	accessProvider
+	limitProvider
+	sql *sql.Service
+	// processIDs is used to assign each connection a process ID.
+	processIDs atomic.Uint32
+}
+
+func NewSQLService(p Provider) *SQLService {
+	return &SQLService{
+		Instrumentation: p.Instrumentation,
+		authProvider:    p.auth,
+		userProvider:    p.user,
+		accessProvider:  p.access,
+		limitProvider:   p.limit,
+		sql:             p.Service.SQL,
+	}
+}
+
+// Handle serves a PostgreSQL wire protocol connection until the client disconnects or
+// the context is cancelled. If tlsConfig is not nil, clients must upgrade the
+// connection to TLS before authenticating. Handle closes the connection before
+// returning.
+func (s *SQLService) Handle(ctx context.Context, conn net.Conn, tlsConfig *tls.Config) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+	c := &sqlConn{
+		svc:        s,
+		conn:       conn,
+		backend:    pgproto3.NewBackend(conn, conn),
+		types:      pgtype.NewMap(),
+		statements: make(map[string]*sqlStatement),
+		portals:    make(map[string]*sqlPortal),
+	}
+	if err := c.serve(ctx, tlsConfig); err != nil && ctx.Err() == nil {
+		s.L.Debug("sql connection closed with error", zap.Error(err))
+	}
+}
+
+// sqlStatement is a statement prepared with the extended query protocol.
+type sqlStatement struct {
+	// query is nil for an empty statement.
+	query *sql.Query
+	// paramOIDs are the types of the statement's parameters.
+	paramOIDs []uint32
+}
+
+// sqlPortal is a prepared statement bound to parameters.
+type sqlPortal struct {
+	stmt          *sqlStatement
+	params        []any
+	resultFormats []int16
+	// executed is true once the portal's query has been executed. A portal's rows are
+	// materialized when it is first executed, and returned to the client in batches
+	// if the client limits the number of rows returned per execution.
+	executed bool
+	rows     [][][]byte
+	tag      string
+}
+
+type sqlConn struct {
+	svc        *SQLService
+	conn       net.Conn
+	backend    *pgproto3.Backend
+	types      *pgtype.Map
+	subject    ontology.ID
+	statements map[string]*sqlStatement
+	portals    map[string]*sqlPortal
+}
+
+func (c *sqlConn) serve(ctx context.Context, tlsConfig *tls.Config) error {
+	if err := c.conn.SetDeadline(time.Now().Add(sqlStartupTimeout)); err != nil {
+		return err
+	}
+	if err := c.startup(ctx, tlsConfig); err != nil {
+		return err
+	}
+	if err := c.conn.SetDeadline(time.Time{}); err != nil {
+		return err
+	}
+	// skip is true after an error in the extended query protocol, in which case all
+	// messages until the next sync are ignored.
+	skip := false
+	for {
+		msg, err := c.backend.Receive()
+		if err != nil {
+			return err
+		}
+		if _, ok := msg.(*pgproto3.Terminate); ok {
+			return nil
+		}
+		switch m := msg.(type) {
+		case *pgproto3.Query:
+			c.simpleQuery(ctx, m.String)
+			c.backend.Send(&pgproto3.ReadyForQuery{TxStatus: 'I'})
+		case *pgproto3.Sync:
+			skip = false
+			c.backend.Send(&pgproto3.ReadyForQuery{TxStatus: 'I'})
+		case *pgproto3.Flush:
+		default:
+			if skip {
+				continue
+			}
+			if err = c.extendedQuery(ctx, msg); err != nil {
+				c.sendError(err)
+				skip = true
+			}
+		}
+		if err = c.backend.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
+// startup negotiates TLS, authenticates the client, and sends the session parameters.
+func (c *sqlConn) startup(ctx context.Context, tlsConfig *tls.Config) error {
+	secure := false
+	for {
+		msg, err := c.backend.ReceiveStartupMessage()
+		if err != nil {
+			return err
+		}
+		switch m := msg.(type) {
+		case *pgproto3.SSLRequest:
+			if tlsConfig == nil {
+				if _, err = c.conn.Write([]byte{'N'}); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err = c.conn.Write([]byte{'S'}); err != nil {
+				return err
+			}
+			tlsConn := tls.Server(c.conn, tlsConfig)
+			if err = tlsConn.HandshakeContext(ctx); err != nil {
+				return err
+			}
+			c.conn = tlsConn
+			c.backend = pgproto3.NewBackend(tlsConn, tlsConn)
+			secure = true
+		case *pgproto3.GSSEncRequest:
+			if _, err = c.conn.Write([]byte{'N'}); err != nil {
+				return err
+			}
+		case *pgproto3.CancelRequest:
+			// Cancelling queries isn't supported, so cancel requests are dropped.
+			return nil
+		case *pgproto3.StartupMessage:
+			if tlsConfig != nil && !secure {
+				// Passwords are sent in cleartext, so they must not be sent over
+				// unencrypted connections to a secure server.
+				c.backend.Send(&pgproto3.ErrorResponse{
+					Severity: "FATAL",
+					Code:     "28000",
+					Message:  "the server requires connections to use SSL",
+				})
+				return errors.Combine(
+					c.backend.Flush(),
+					errors.New("rejected sql connection that did not use SSL"),
+				)
+			}
+			return c.authenticate(ctx, m.Parameters["user"])
+		default:
+			return errors.Newf("unexpected startup message %T", msg)
+		}
+	}
+}
+
+func (c *sqlConn) authenticate(ctx context.Context, username string) error {
+	c.backend.Send(&pgproto3.AuthenticationCleartextPassword{})
+	if err := c.backend.Flush(); err != nil {
+		return err
+	}
+	if err := c.backend.SetAuthType(pgproto3.AuthTypeCleartextPassword); err != nil {
+		return err
+	}
+	msg, err := c.backend.Receive()
+	if err != nil {
+		return err
+	}
+	pw, ok := msg.(*pgproto3.PasswordMessage)
+	if !ok {
+		return errors.Newf("expected password message, received %T", msg)
+	}
+	key, err := authenticatePassword(ctx, c.svc.authProvider, c.svc.userProvider, username, pw.Password)
+	if err != nil {
+		c.backend.Send(&pgproto3.ErrorResponse{
+			Severity: "FATAL",
+			Code:     "28P01",
+			Message:  "password authentication failed for user \"" + username + "\"",
+		})
+		return errors.Combine(c.backend.Flush(), err)
+	}
+	c.subject = user.OntologyID(key)
+	c.backend.Send(&pgproto3.AuthenticationOk{})
+	for _, p := range [][2]string{
+		{"server_version", sql.ServerVersion},
+		{"server_encoding", "UTF8"},
+		{"client_encoding", "UTF8"},
+		{"DateStyle", "ISO, MDY"},
+		{"TimeZone", "UTC"},
+		{"integer_datetimes", "on"},
+		{"standard_conforming_strings", "on"},
+		{"application_name", ""},
+	} {
+		c.backend.Send(&pgproto3.ParameterStatus{Name: p[0], Value: p[1]})
+	}
+	secret := make([]byte, 4)
+	_, _ = rand.Read(secret)
+	c.backend.Send(&pgproto3.BackendKeyData{ProcessID: c.svc.processIDs.Add(1), SecretKey: secret})
+	c.backend.Send(&pgproto3.ReadyForQuery{TxStatus: 'I'})
+	return c.backend.Flush()
+}
+
+// authorize is the sql.AuthorizeFunc for the connection's subject.
+func (c *sqlConn) authorize(ctx context.Context, objects []ontology.ID) error {
+	return c.svc.access.Enforce(ctx, access.Request{
+		Subject: c.subject,
+		Action:  access.Retrieve,
+		Objects: objects,
+	})
+}
+
+// simpleQuery executes each statement in a query with the simple query protocol,
+// stopping at the first error.
+func (c *sqlConn) simpleQuery(ctx context.Context, text string) {
+	queries, err := c.svc.sql.Prepare(ctx, text)
+	if err != nil {
+		c.sendError(err)
+		return
+	}
+	if len(queries) == 0 {
+		c.backend.Send(&pgproto3.EmptyQueryResponse{})
+		return
+	}
+	for _, q := range queries {
+		if len(q.Columns()) > 0 {
+			c.backend.Send(c.rowDescription(q, nil))
+		}
+		var (
+			values [][]byte
+			sent   int
+		)
+		tag, err := c.exec(ctx, q, nil, func(row sql.Row) (err error) {
+			// Rows are encoded into the write buffer as they are sent, so the slice
+			// of values can be reused.
+			if values, err = c.encodeRow(q, row, nil, values[:0]); err != nil {
+				return err
+			}
+			c.backend.Send(&pgproto3.DataRow{Values: values})
+			if sent++; sent%sqlFlushInterval == 0 {
+				return c.backend.Flush()
+			}
+			return nil
+		})
+		if err != nil {
+			c.sendError(err)
+			return
+		}
+		c.backend.Send(&pgproto3.CommandComplete{CommandTag: []byte(tag)})
+	}
+}
+
+// extendedQuery handles a message of the extended query protocol.
+func (c *sqlConn) extendedQuery(ctx context.Context, msg pgproto3.FrontendMessage) error {
+	switch m := msg.(type) {
+	case *pgproto3.Parse:
+		queries, err := c.svc.sql.Prepare(ctx, m.Query)
+		if err != nil {
+			return err
+		}
+		if len(queries) > 1 {
+			return errors.Wrap(validate.Error, "cannot insert multiple commands into a prepared statement")
+		}
+		stmt := &sqlStatement{}
+		if len(queries) == 1 {
+			stmt.query = queries[0]
+			types := stmt.query.ParamTypes()
+			stmt.paramOIDs = make([]uint32, len(types))
+			for i, t := range types {
+				stmt.paramOIDs[i] = sqlTypeOID(t)
+				if i < len(m.ParameterOIDs) && m.ParameterOIDs[i] != 0 {
+					stmt.paramOIDs[i] = m.ParameterOIDs[i]
+				}
+			}
+		}
+		c.statements[m.Name] = stmt
+		c.backend.Send(&pgproto3.ParseComplete{})
+	case *pgproto3.Bind:
+		stmt, ok := c.statements[m.PreparedStatement]
+		if !ok {
+			return errors.Wrapf(query.NotFound, "prepared statement %q does not exist", m.PreparedStatement)
+		}
+		if len(m.Parameters) != len(stmt.paramOIDs) {
+			return errors.Wrapf(
+				validate.Error,
+				"bind message supplies %d parameters, but prepared statement %q requires %d",
+				len(m.Parameters),
+				m.PreparedStatement,
+				len(stmt.paramOIDs),
+			)
+		}
+		params := make([]any, len(m.Parameters))
+		for i, raw := range m.Parameters {
+			v, err := c.decodeParam(stmt.paramOIDs[i], formatCode(m.ParameterFormatCodes, i), raw)
+			if err != nil {
+				return err
+			}
+			params[i] = v
+		}
+		c.portals[m.DestinationPortal] = &sqlPortal{
+			stmt:          stmt,
+			params:        params,
+			resultFormats: m.ResultFormatCodes,
+		}
+		c.backend.Send(&pgproto3.BindComplete{})
+	case *pgproto3.Describe:
+		if m.ObjectType == 'S' {
+			stmt, ok := c.statements[m.Name]
+			if !ok {
+				return errors.Wrapf(query.NotFound, "prepared statement %q does not exist", m.Name)
+			}
+			c.backend.Send(&pgproto3.ParameterDescription{ParameterOIDs: stmt.paramOIDs})
+			c.describe(stmt.query, nil)
+			return nil
+		}
+		portal, ok := c.portals[m.Name]
+		if !ok {
+			return errors.Wrapf(query.NotFound, "portal %q does not exist", m.Name)
+		}
+		c.describe(portal.stmt.query, portal.resultFormats)
+	case *pgproto3.Execute:
+		portal, ok := c.portals[m.Portal]
+		if !ok {
+			return errors.Wrapf(query.NotFound, "portal %q does not exist", m.Portal)
+		}
+		return c.execute(ctx, portal, int(m.MaxRows))
+	case *pgproto3.Close:
+		if m.ObjectType == 'S' {
+			delete(c.statements, m.Name)
+		} else {
+			delete(c.portals, m.Name)
+		}
+		c.backend.Send(&pgproto3.CloseComplete{})
+	default:
+		return errors.Wrapf(sql.UnsupportedError, "unsupported message %T", msg)
+	}
+	return nil
+}
+
+func (c *sqlConn) describe(q *sql.Query, formats []int16) {
+	if q == nil || len(q.Columns()) == 0 {
+		c.backend.Send(&pgproto3.NoData{})
+		return
+	}
+	c.backend.Send(c.rowDescription(q, formats))
+}
+
+// exec executes a query, counting it against the rate limit and iterator quota of the
+// user until it completes.
+func (c *sqlConn) exec(
+	ctx context.Context,
+	q *sql.Query,
+	params []any,
+	onRow func(sql.Row) error,
+) (string, error) {
+	release, err := c.svc.acquire(c.subject, sqlEndpoint, limit.Iterator)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	return q.Exec(ctx, params, c.authorize, onRow)
+}
+
+// execute executes a portal, sending at most maxRows rows if maxRows is positive.
+func (c *sqlConn) execute(ctx context.Context, p *sqlPortal, maxRows int) error {
+	if p.stmt.query == nil {
+		c.backend.Send(&pgproto3.EmptyQueryResponse{})
+		return nil
+	}
+	if !p.executed {
+		p.executed = true
+		var err error
+		p.tag, err = c.exec(ctx, p.stmt.query, p.params, func(row sql.Row) error {
+			values, err := c.encodeRow(p.stmt.query, row, p.resultFormats, nil)
+			p.rows = append(p.rows, values)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	n := len(p.rows)
+	if maxRows > 0 && maxRows < n {
+		n = maxRows
+	}
+	for _, values := range p.rows[:n] {
+		c.backend.Send(&pgproto3.DataRow{Values: values})
+	}
+	if p.rows = p.rows[n:]; len(p.rows) > 0 {
+		c.backend.Send(&pgproto3.PortalSuspended{})
+		return nil
+	}
+	c.backend.Send(&pgproto3.CommandComplete{CommandTag: []byte(p.tag)})
+	return nil
+}
+
+func (c *sqlConn) rowDescription(q *sql.Query, formats []int16) *pgproto3.RowDescription {
+	cols := q.Columns()
+	fields := make([]pgproto3.FieldDescription, len(cols))
+	for i, col := range cols {
+		fields[i] = pgproto3.FieldDescription{
+			Name:         []byte(col.Name),
+			DataTypeOID:  sqlTypeOID(col.Type),
+			DataTypeSize: sqlTypeSize(col.Type),
+			TypeModifier: -1,
+			Format:       formatCode(formats, i),
+		}
+	}
+	return &pgproto3.RowDescription{Fields: fields}
+}
+
+// encodeRow appends the encoded values of a row to values using the given result
+// format codes.
+func (c *sqlConn) encodeRow(q *sql.Query, row sql.Row, formats []int16, values [][]byte) ([][]byte, error) {
+	cols := q.Columns()
+	for i, v := range row {
+		if v == nil {
+			values = append(values, nil)
+			continue
+		}
+		buf, err := c.types.Encode(sqlTypeOID(cols[i].Type), formatCode(formats, i), sqlEncodable(v), nil)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, buf)
+	}
+	return values, nil
+}
+
+// decodeParam decodes a parameter of the given type. Parameters in the text format
+// are passed to the query as strings, which are parsed according to the types of the
+// parameters in the query.
+func (c *sqlConn) decodeParam(oid uint32, format int16, raw []byte) (any, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	if format == pgtype.TextFormatCode {
+		return string(raw), nil
+	}
+	t, ok := c.types.TypeForOID(oid)
+	if !ok {
+		return nil, errors.Wrapf(sql.UnsupportedError, "parameters of type %d are not supported in binary format", oid)
+	}
+	v, err := t.Codec.DecodeValue(c.types, oid, format, raw)
+	if err != nil {
+		return nil, errors.Wrapf(validate.Error, "invalid binary parameter: %s", err.Error())
+	}
+	switch x := v.(type) {
+	case pgtype.Interval:
+		return telem.TimeSpan(x.Microseconds)*telem.Microsecond +
+			telem.TimeSpan(x.Days)*telem.Day +
+			telem.TimeSpan(x.Months)*30*telem.Day, nil
+	case pgtype.Numeric:
+		f, err := x.Float64Value()
+		if err != nil {
+			return nil, err
+		}
+		return f.Float64, nil
+	}
+	return v, nil
+}
+
+// formatCode returns the format code of the value at position i given the format codes
+// of a message, which apply to every value if there is exactly one of them.
+func formatCode(codes []int16, i int) int16 {
+	switch {
+	case len(codes) == 1:
+		return codes[0]
+	case i < len(codes):
+		return codes[i]
+	}
+	return pgtype.TextFormatCode
+}
+
+// sqlTypeOID returns the PostgreSQL type OID of a type.
+func sqlTypeOID(t sql.Type) uint32 {
+	switch t {
+	case sql.TypeBool:
+		return pgtype.BoolOID
+	case sql.TypeInt:
+		return pgtype.Int8OID
+	case sql.TypeNumeric:
+		return pgtype.NumericOID
+	case sql.TypeFloat:
+		return pgtype.Float8OID
+	case sql.TypeJSON:
+		return pgtype.JSONBOID
+	case sql.TypeTimestamp:
+		return pgtype.TimestamptzOID
+	case sql.TypeInterval:
+		return pgtype.IntervalOID
+	case sql.TypeUUID:
+		return pgtype.UUIDOID
+	case sql.TypeTextArray:
+		return pgtype.TextArrayOID
+	}
+	return pgtype.TextOID
+}
+
+// sqlTypeSize returns the size of a type in bytes, or -1 for variable length types.
+func sqlTypeSize(t sql.Type) int16 {
+	switch t {
+	case sql.TypeBool:
+		return 1
+	case sql.TypeInt, sql.TypeFloat, sql.TypeTimestamp:
+		return 8
+	case sql.TypeInterval, sql.TypeUUID:
+		return 16
+	}
+	return -1
+}
+
+// sqlEncodable converts a value returned by a query to a value that can be encoded by
+// pgtype.
+func sqlEncodable(v any) any {
+	switch x := v.(type) {
+	case telem.TimeStamp:
+		return x.Time().UTC()
+	case telem.TimeSpan:
+		return pgtype.Interval{Microseconds: int64(x / telem.Microsecond), Valid: true}
+	case uint64:
+		return pgtype.Numeric{Int: new(big.Int).SetUint64(x), Valid: true}
+	}
+	return v
+}
+
+// sendError sends an error response with the SQLSTATE code that best matches err.
+func (c *sqlConn) sendError(err error) {
+	code := "XX000"
+	for _, e := range []struct {
+		err  error
+		code string
+	}{
+		{sql.SyntaxError, "42601"},
+		{sql.UnsupportedError, "0A000"},
+		{sql.UndefinedColumnError, "42703"},
+		{query.NotFound, "42P01"},
+		{access.Denied, "42501"},
+		{auth.Error, "28000"},
+		{limit.Error, "53400"},
+		{validate.Error, "42000"},
+		{context.Canceled, "57014"},
+	} {
+		if errors.Is(err, e.err) {
+			code = e.code
+			break
+		}
+	}
+	msg := err.Error()
+	for _, suffix := range []error{
+		sql.SyntaxError,
+		sql.UnsupportedError,
+		sql.UndefinedColumnError,
+		validate.Error,
+		query.NotFound,
+	} {
+		msg = strings.TrimSuffix(msg, ": "+suffix.Error())
+	}
+	c.backend.Send(&pgproto3.ErrorResponse{Severity: "ERROR", Code: code, Message: msg})
+}
@@ -11,11 +11,15 @@ package api
 
 import (
 	"context"
+	"crypto/x509"
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
 	"github.com/synnaxlabs/synnax/pkg/service/auth"
-	"github.com/synnaxlabs/synnax/pkg/service/auth/token"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/apikey"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/session"
 	"github.com/synnaxlabs/synnax/pkg/service/user"
 	"go.uber.org/zap"
 
@@ -23,24 +27,51 @@ import (
 	"github.com/synnaxlabs/freighter"
 	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
 	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/query"
 )
 
 const tokenRefreshHeader = "Refresh-Token"
 
-func tokenMiddleware(svc *token.Service) freighter.Middleware {
+// tokenMiddleware authenticates requests with the bearer token in their Authorization
+// parameter. The token may be either a session token issued on login or an API key.
+// Session tokens are rejected once their session is revoked.
+// Requests authenticated with an API key are limited to the actions the key is scoped
+// to. Requests without a token are authenticated with the client certificate of their
+// connection, if the server verified one.
+func tokenMiddleware(a authProvider, u userProvider) freighter.Middleware {
 	return freighter.MiddlewareFunc(func(
 		ctx freighter.Context,
 		next freighter.Next,
 	) (freighter.Context, error) {
 		tk, _err := tryParseToken(ctx.Params)
+		if errors.Is(_err, noAuthenticationParam) {
+			if c, ok := clientCertificate(ctx.Sec); ok {
+				key, err := authenticateClientCertificate(ctx, u, c)
+				if err != nil {
+					return ctx, err
+				}
+				setSubject(ctx.Params, user.OntologyID(key))
+				return next(ctx)
+			}
+		}
 		if _err != nil {
 			return ctx, _err
 		}
-		userKey, newTK, err := svc.ValidateMaybeRefresh(tk)
+		if apikey.IsToken(tk) {
+			k, err := a.apiKey.Authenticate(ctx, tk)
+			if err != nil {
+				return ctx, err
+			}
+			setSubject(ctx.Params, user.OntologyID(k.User))
+			ctx.Context = context.WithValue(ctx.Context, apiKeyContextKey{}, k)
+			return next(ctx)
+		}
+		sess, newTK, err := a.session.Authenticate(ctx, tk)
 		if err != nil {
 			return ctx, err
 		}
-		setSubject(ctx.Params, user.OntologyID(userKey))
+		setSubject(ctx.Params, user.OntologyID(sess.User))
+		ctx.Context = context.WithValue(ctx.Context, sessionContextKey{}, sess)
 		oCtx, err := next(ctx)
 		if newTK != "" {
 			oCtx.Params.Set(tokenRefreshHeader, newTK)
@@ -49,6 +80,48 @@ func tokenMiddleware(svc *token.Service) freighter.Middleware {
 	})
 }
 
+// clientCertificate returns the client certificate that the server verified for a
+// connection. Returns false if the client did not present a certificate.
+func clientCertificate(sec freighter.SecurityInfo) (*x509.Certificate, bool) {
+	if !sec.TLS.Used ||
+		len(sec.TLS.VerifiedChains) == 0 ||
+		len(sec.TLS.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+	return sec.TLS.VerifiedChains[0][0], true
+}
+
+// authenticateClientCertificate returns the key of the user that a verified client
+// certificate identifies by its subject common name. Certificates that are also valid
+// for server authentication, such as node certificates, cannot be used to authenticate
+// clients.
+func authenticateClientCertificate(
+	ctx context.Context,
+	u userProvider,
+	c *x509.Certificate,
+) (uuid.UUID, error) {
+	if lo.Contains(c.ExtKeyUsage, x509.ExtKeyUsageServerAuth) {
+		return uuid.Nil, errors.Wrap(
+			auth.InvalidCredentials,
+			"server certificates cannot be used to authenticate clients",
+		)
+	}
+	username := c.Subject.CommonName
+	if username == "" {
+		return uuid.Nil, errors.Wrap(auth.InvalidCredentials, "client certificate has no common name")
+	}
+	var usr user.User
+	err := u.user.NewRetrieve().WhereUsernames(username).Entry(&usr).Exec(ctx, nil)
+	if errors.Is(err, query.NotFound) {
+		return uuid.Nil, errors.Wrapf(
+			auth.InvalidCredentials,
+			"no user matches client certificate subject %q",
+			username,
+		)
+	}
+	return usr.Key, err
+}
+
 const tokenParamPrefix = "Bearer "
 
 var (
@@ -82,6 +155,39 @@ func tryParseToken(p freighter.Params) (string, error) {
 	return tkStr, nil
 }
 
+// apiKeyContextKey is the context key for the API key that a request was authenticated
+// with. It is a context value rather than a parameter so that clients cannot set it, and
+// so that it is carried by contexts derived from the request context.
+type apiKeyContextKey struct{}
+
+// getAPIKey returns the API key that the request with the given context was
+// authenticated with. Returns false if the request was not authenticated with an API
+// key.
+func getAPIKey(ctx context.Context) (apikey.Key, bool) {
+	k, ok := ctx.Value(apiKeyContextKey{}).(apikey.Key)
+	return k, ok
+}
+
+// allowedByAPIKey returns true if the API key that the request with the given context
+// was authenticated with is scoped to allow the given action, or if the request was not
+// authenticated with an API key.
+func allowedByAPIKey(ctx context.Context, action access.Action) bool {
+	k, ok := getAPIKey(ctx)
+	return !ok || k.Allows(action)
+}
+
+// sessionContextKey is the context key for the session that a request was
+// authenticated with.
+type sessionContextKey struct{}
+
+// getSession returns the session that the request with the given context was
+// authenticated with. Returns false if the request was not authenticated with a
+// session token.
+func getSession(ctx context.Context) (session.Session, bool) {
+	s, ok := ctx.Value(sessionContextKey{}).(session.Session)
+	return s, ok
+}
+
 const subjectKey = "Subject"
 
 func setSubject(p freighter.Params, subject ontology.ID) {
@@ -23,6 +23,7 @@ import (
 	"github.com/synnaxlabs/x/errors"
 	"github.com/synnaxlabs/x/gorp"
 	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/validate"
 )
 
 // UserService is the core authentication service for the Synnax API.
@@ -45,12 +46,14 @@ func NewUserService(p Provider) *UserService {
 }
 
 // NewUser holds information for creating a new user in a Synnax server. The username
-// and password are required, and the first and last name are optional.
+// and password are required, and the first and last name are optional. Service accounts
+// are created without a password, and authenticate with API keys instead.
 type NewUser struct {
 	auth.InsecureCredentials
-	FirstName string    `json:"first_name" msgpack:"first_name"`
-	LastName  string    `json:"last_name" msgpack:"last_name"`
-	Key       uuid.UUID `json:"key" msgpack:"key"`
+	FirstName      string    `json:"first_name" msgpack:"first_name"`
+	LastName       string    `json:"last_name" msgpack:"last_name"`
+	Key            uuid.UUID `json:"key" msgpack:"key"`
+	ServiceAccount bool      `json:"service_account" msgpack:"service_account"`
 }
 
 type (
@@ -77,22 +80,27 @@ func (svc *UserService) Create(ctx context.Context, req UserCreateRequest) (User
 		w := svc.internal.NewWriter(tx)
 		newUsers := make([]user.User, len(req.Users))
 		for i, u := range req.Users {
-			if err := svc.authenticator.NewWriter(tx).Register(ctx, u.InsecureCredentials); err != nil {
+			if !u.ServiceAccount {
+				if err := svc.authenticator.NewWriter(tx).Register(ctx, u.InsecureCredentials); err != nil {
+					return err
+				}
+			} else if err := validateServiceAccount(u); err != nil {
 				return err
 			}
 			newUsers[i].Username = u.Username
 			newUsers[i].FirstName = u.FirstName
 			newUsers[i].LastName = u.LastName
 			newUsers[i].Key = u.Key
+			newUsers[i].ServiceAccount = u.ServiceAccount
 			if err := w.Create(ctx, &newUsers[i]); err != nil {
 				return err
 			}
 
 			// Let the user update information about themselves
 			if err := svc.access.NewWriter(tx).Create(ctx, &rbac.Policy{
-				Subjects: []ontology.ID{user.OntologyID(u.Key)},
+				Subjects: []ontology.ID{user.OntologyID(newUsers[i].Key)},
 				Actions:  []access.Action{access.Update},
-				Objects:  []ontology.ID{user.OntologyID(u.Key)},
+				Objects:  []ontology.ID{user.OntologyID(newUsers[i].Key)},
 			}); err != nil {
 				return err
 			}
@@ -102,6 +110,15 @@ func (svc *UserService) Create(ctx context.Context, req UserCreateRequest) (User
 	})
 }
 
+// validateServiceAccount validates a new service account. Service accounts must have
+// a username, and can't have a password.
+func validateServiceAccount(u NewUser) error {
+	v := validate.New("user")
+	validate.NotEmptyString(v, "username", u.Username)
+	v.Ternary("password", u.Password != "", "service accounts can't have a password")
+	return v.Error()
+}
+
 type UserChangeUsernameRequest struct {
 	Key      uuid.UUID `json:"key" msgpack:"key"`
 	Username string    `json:"username" msgpack:"username"`
@@ -226,6 +243,12 @@ func (s *UserService) Delete(ctx context.Context, req UserDeleteRequest) (types.
 			})...); err != nil {
 			return err
 		}
+		if err := s.apiKey.NewWriter(tx).DeleteByUsers(ctx, req.Keys...); err != nil {
+			return err
+		}
+		if err := s.session.NewWriter(tx).RevokeByUsers(ctx, req.Keys...); err != nil {
+			return err
+		}
 		return s.internal.NewWriter(tx).Delete(ctx, req.Keys...)
 	})
 }
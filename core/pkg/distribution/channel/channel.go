@@ -200,22 +200,27 @@ type Channel struct {
 	// Expression is only used for calculated channels, and specifies the Lua expression
 	// to evaluate the calculated value.
 	Expression string `json:"expression" msgpack:"expression"`
+	// Units are the engineering units of the channel's values i.e. "psi" or "degC".
+	Units string `json:"units" msgpack:"units"`
+	// Description is a free-text description of the channel.
+	Description string `json:"description" msgpack:"description"`
+	// Scale converts the raw values written to the channel into engineering units.
+	Scale Scale `json:"scale" msgpack:"scale"`
+	// Limits are the warning and critical operating ranges for the channel's values.
+	Limits Limits `json:"limits" msgpack:"limits"`
 }
 
 func (c Channel) IsCalculated() bool {
 	return c.Virtual && c.Expression != ""
 }
 
-// Equals returns true if the two channels are meaningfully equal to each other. This
-// function should be used instead of a direct comparison, as it takes into account
-// the contents of the Requires field, ignoring the order of the keys.
-// If the exclude parameter is provided, the function will ignore the fields specified
-// in the exclude parameter.
-func (c Channel) Equals(other Channel, exclude ...string) bool {
-	comparisons := []struct {
-		field string
-		equal bool
-	}{
+type fieldComparison struct {
+	field string
+	equal bool
+}
+
+func (c Channel) compare(other Channel) []fieldComparison {
+	return []fieldComparison{
 		{"Name", c.Name == other.Name},
 		{"Leaseholder", c.Leaseholder == other.Leaseholder},
 		{"DataType", c.DataType == other.DataType},
@@ -226,25 +231,43 @@ func (c Channel) Equals(other Channel, exclude ...string) bool {
 		{"Concurrency", c.Concurrency == other.Concurrency},
 		{"Internal", c.Internal == other.Internal},
 		{"Expression", c.Expression == other.Expression},
+		{"Requires", requiresEqual(c.Requires, other.Requires)},
+		{"Units", c.Units == other.Units},
+		{"Description", c.Description == other.Description},
+		{"Scale", c.Scale.equals(other.Scale)},
+		{"Limits", c.Limits.equals(other.Limits)},
 	}
+}
 
-	for _, comp := range comparisons {
-		if !comp.equal && !lo.Contains(exclude, comp.field) {
-			return false
-		}
-	}
+func requiresEqual(a, b Keys) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
 
-	if !lo.Contains(exclude, "Requires") {
-		slices.Sort(c.Requires)
-		slices.Sort(other.Requires)
-		if !slices.Equal(c.Requires, other.Requires) {
+// Equals returns true if the two channels are meaningfully equal to each other. This
+// function should be used instead of a direct comparison, as it takes into account
+// the contents of the Requires field, ignoring the order of the keys.
+// If the exclude parameter is provided, the function will ignore the fields specified
+// in the exclude parameter.
+func (c Channel) Equals(other Channel, exclude ...string) bool {
+	for _, comp := range c.compare(other) {
+		if !comp.equal && !lo.Contains(exclude, comp.field) {
 			return false
 		}
 	}
-
 	return true
 }
 
+// ChangedFields returns the names of the fields that differ between the two channels,
+// using the same semantics as Equals.
+func (c Channel) ChangedFields(other Channel) []string {
+	return lo.FilterMap(c.compare(other), func(comp fieldComparison, _ int) (string, bool) {
+		return comp.field, !comp.equal
+	})
+}
+
 // String implements stringer, returning a nicely formatted string representation of the
 // Channel.
 func (c Channel) String() string {
@@ -122,6 +122,9 @@ func (lp *leaseProxy) renameHandler(ctx context.Context, msg RenameRequest) (typ
 func (lp *leaseProxy) create(ctx context.Context, tx gorp.Tx, _channels *[]Channel, opts CreateOptions) error {
 	channels := *_channels
 	for i, ch := range channels {
+		if err := ch.Metadata().Validate(); err != nil {
+			return err
+		}
 		if ch.Leaseholder == 0 {
 			channels[i].Leaseholder = lp.HostResolver.HostKey()
 		}
@@ -196,6 +199,7 @@ func (lp *leaseProxy) createAndUpdateFreeVirtual(
 				c.Name = ic.Name
 				c.Requires = ic.Requires
 				c.Expression = ic.Expression
+				c.SetMetadata(ic.Metadata())
 				return c, nil
 			}).
 		Exec(ctx, tx); err != nil && !errors.Is(err, query.NotFound) {
@@ -321,7 +325,18 @@ func (lp *leaseProxy) deleteOverwritten(
 			ch, i, found := lo.FindIndexOf(*channels, func(ch Channel) bool {
 				return ch.Name == c.Name && ch.Key() != c.Key()
 			})
-			equal := ch.Equals(*c, "LocalKey", "LocalIndex", "Leaseholder")
+			// Metadata does not affect how data is stored, so we don't delete
+			// channels whose metadata differs.
+			equal := ch.Equals(
+				*c,
+				"LocalKey",
+				"LocalIndex",
+				"Leaseholder",
+				"Units",
+				"Description",
+				"Scale",
+				"Limits",
+			)
 			shouldDelete := found && !equal
 			if shouldDelete {
 				storageToDelete = append(storageToDelete, c.Storage().Key)
@@ -605,3 +620,103 @@ func (lp *leaseProxy) renameGateway(ctx context.Context, tx gorp.Tx, keys Keys,
 	}
 	return lp.TSChannel.RenameChannels(ctx, keys.Storage(), names)
 }
+
+// update replaces the mutable fields of the given channels with the provided values.
+// The name, concurrency, calculation, and metadata of a channel can be changed, while
+// the fields that determine how a channel's data is stored cannot. On success,
+// channels is updated to reflect the persisted values.
+func (lp *leaseProxy) update(
+	ctx context.Context,
+	tx gorp.Tx,
+	channels *[]Channel,
+	allowInternal bool,
+) error {
+	keys := KeysFromChannels(*channels)
+	existing := make([]Channel, 0, len(keys))
+	if err := gorp.NewRetrieve[Key, Channel]().
+		WhereKeys(keys...).
+		Entries(&existing).
+		Exec(ctx, tx); err != nil {
+		return err
+	}
+	byKey := lo.SliceToMap(existing, func(c Channel) (Key, Channel) { return c.Key(), c })
+	var (
+		updated     = make([]Channel, 0, len(keys))
+		renameKeys  Keys
+		renameNames []string
+	)
+	for _, ch := range *channels {
+		prev := byKey[ch.Key()]
+		next, err := validateUpdate(prev, ch, allowInternal)
+		if err != nil {
+			return err
+		}
+		if next.Name != prev.Name {
+			renameKeys = append(renameKeys, next.Key())
+			renameNames = append(renameNames, next.Name)
+		}
+		updated = append(updated, next)
+	}
+	if err := lp.validateFreeVirtual(ctx, &updated, tx); err != nil {
+		return err
+	}
+	if len(renameKeys) > 0 {
+		if err := lp.rename(ctx, tx, renameKeys, renameNames, allowInternal); err != nil {
+			return err
+		}
+	}
+	if err := gorp.NewUpdate[Key, Channel]().
+		WhereKeys(keys...).
+		Change(func(c Channel) Channel { return updated[lo.IndexOf(keys, c.Key())] }).
+		Exec(ctx, tx); err != nil {
+		return err
+	}
+	*channels = updated
+	return nil
+}
+
+// validateUpdate checks that the changes from prev to next only touch mutable fields,
+// returning prev with the mutable fields of next applied.
+func validateUpdate(prev, next Channel, allowInternal bool) (Channel, error) {
+	if prev.Internal && !allowInternal {
+		return prev, errors.Wrapf(validate.Error, "cannot update internal channel %v", prev)
+	}
+	immutable := func(field string) error {
+		return validate.PathedError(
+			errors.Wrapf(validate.Error, "cannot be changed on channel %v", prev),
+			field,
+		)
+	}
+	for _, field := range prev.ChangedFields(next) {
+		switch field {
+		case "Leaseholder", "LocalKey", "DataType", "IsIndex", "LocalIndex", "Virtual", "Internal":
+			return prev, immutable(field)
+		case "Concurrency":
+			if !prev.Virtual {
+				return prev, validate.PathedError(
+					errors.Wrap(validate.Error, "only virtual channels can change their concurrency"),
+					field,
+				)
+			}
+		case "Expression", "Requires":
+			if !prev.IsCalculated() {
+				return prev, validate.PathedError(
+					errors.Wrap(validate.Error, "only calculated channels can change their calculation"),
+					field,
+				)
+			}
+		}
+	}
+	if prev.IsCalculated() && next.Expression == "" {
+		return prev, validate.PathedError(validate.RequiredError, "expression")
+	}
+	if err := next.Metadata().Validate(); err != nil {
+		return prev, err
+	}
+	prev.Name = next.Name
+	prev.Concurrency = next.Concurrency
+	prev.Expression = next.Expression
+	prev.Requires = next.Requires
+	prev.SetMetadata(next.Metadata())
+	return prev, nil
+}
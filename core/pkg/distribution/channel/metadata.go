@@ -0,0 +1,197 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package channel
+
+import (
+	"slices"
+
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// ScaleType is the type of scaling applied to convert raw values (such as ADC counts)
+// into engineering units.
+type ScaleType string
+
+const (
+	// ScaleTypeNone means that no scaling is applied.
+	ScaleTypeNone ScaleType = ""
+	// ScaleTypeLinear applies y = slope * x + offset.
+	ScaleTypeLinear ScaleType = "linear"
+	// ScaleTypePolynomial applies y = c[0] + c[1] * x + c[2] * x^2 + ...
+	ScaleTypePolynomial ScaleType = "polynomial"
+)
+
+// Scale describes how to convert raw values into engineering units.
+type Scale struct {
+	// Type is the type of scaling to apply.
+	Type ScaleType `json:"type" msgpack:"type"`
+	// Slope is the multiplier for linear scaling.
+	Slope float64 `json:"slope" msgpack:"slope"`
+	// Offset is the constant term for linear scaling.
+	Offset float64 `json:"offset" msgpack:"offset"`
+	// Coefficients are the polynomial coefficients in ascending order of degree.
+	Coefficients []float64 `json:"coefficients" msgpack:"coefficients"`
+}
+
+// IsZero returns true if no scaling is applied.
+func (s Scale) IsZero() bool { return s.Type == ScaleTypeNone }
+
+// Apply scales the raw value v.
+func (s Scale) Apply(v float64) float64 {
+	switch s.Type {
+	case ScaleTypeLinear:
+		return s.Slope*v + s.Offset
+	case ScaleTypePolynomial:
+		var out float64
+		for i := len(s.Coefficients) - 1; i >= 0; i-- {
+			out = out*v + s.Coefficients[i]
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// Validate checks that the scale is well-formed.
+func (s Scale) Validate() error {
+	switch s.Type {
+	case ScaleTypeNone:
+		return nil
+	case ScaleTypeLinear:
+		if s.Slope == 0 {
+			return validate.PathedError(
+				errors.Wrap(validate.Error, "linear scale slope must be non-zero"),
+				"slope",
+			)
+		}
+		return nil
+	case ScaleTypePolynomial:
+		if len(s.Coefficients) == 0 {
+			return validate.PathedError(
+				errors.Wrap(validate.RequiredError, "polynomial scale must have at least one coefficient"),
+				"coefficients",
+			)
+		}
+		return nil
+	default:
+		return validate.PathedError(
+			errors.Wrapf(validate.Error, "invalid scale type %q", s.Type),
+			"type",
+		)
+	}
+}
+
+func (s Scale) equals(other Scale) bool {
+	return s.Type == other.Type &&
+		s.Slope == other.Slope &&
+		s.Offset == other.Offset &&
+		slices.Equal(s.Coefficients, other.Coefficients)
+}
+
+// Limit is an inclusive range of acceptable values.
+type Limit struct {
+	// Min is the lower bound of the range.
+	Min float64 `json:"min" msgpack:"min"`
+	// Max is the upper bound of the range.
+	Max float64 `json:"max" msgpack:"max"`
+}
+
+// Contains returns true if v is within the limit.
+func (l Limit) Contains(v float64) bool { return v >= l.Min && v <= l.Max }
+
+// Limits are the acceptable operating ranges for a channel's values, expressed in
+// engineering units. A nil range is unset.
+type Limits struct {
+	// Warning is the range outside which values should be flagged for attention.
+	Warning *Limit `json:"warning" msgpack:"warning"`
+	// Critical is the range outside which values are considered dangerous.
+	Critical *Limit `json:"critical" msgpack:"critical"`
+}
+
+// IsZero returns true if no limits are set.
+func (l Limits) IsZero() bool { return l.Warning == nil && l.Critical == nil }
+
+// Validate checks that each limit is ordered and that the warning range lies within
+// the critical range.
+func (l Limits) Validate() error {
+	if l.Warning != nil && l.Warning.Min > l.Warning.Max {
+		return validate.PathedError(
+			errors.Wrap(validate.Error, "min must be less than or equal to max"),
+			"warning",
+		)
+	}
+	if l.Critical != nil && l.Critical.Min > l.Critical.Max {
+		return validate.PathedError(
+			errors.Wrap(validate.Error, "min must be less than or equal to max"),
+			"critical",
+		)
+	}
+	if l.Warning != nil && l.Critical != nil &&
+		(l.Warning.Min < l.Critical.Min || l.Warning.Max > l.Critical.Max) {
+		return validate.PathedError(
+			errors.Wrap(validate.Error, "warning range must be within the critical range"),
+			"warning",
+		)
+	}
+	return nil
+}
+
+func (l Limits) equals(other Limits) bool {
+	eq := func(a, b *Limit) bool { return a == b || (a != nil && b != nil && *a == *b) }
+	return eq(l.Warning, other.Warning) && eq(l.Critical, other.Critical)
+}
+
+// Metadata is the descriptive information attached to a channel that does not affect
+// how its data is stored.
+type Metadata struct {
+	// Units are the engineering units of the channel's values i.e. "psi" or "degC".
+	Units string `json:"units" msgpack:"units"`
+	// Description is a free-text description of the channel.
+	Description string `json:"description" msgpack:"description"`
+	// Scale converts raw values into engineering units.
+	Scale Scale `json:"scale" msgpack:"scale"`
+	// Limits are the acceptable operating ranges for the channel's values.
+	Limits Limits `json:"limits" msgpack:"limits"`
+}
+
+// IsZero returns true if no metadata is set.
+func (m Metadata) IsZero() bool {
+	return m.Units == "" && m.Description == "" && m.Scale.IsZero() && m.Limits.IsZero()
+}
+
+// Validate checks that the scale and limits are well-formed.
+func (m Metadata) Validate() error {
+	if err := m.Scale.Validate(); err != nil {
+		return validate.PathedError(err, "scale")
+	}
+	if err := m.Limits.Validate(); err != nil {
+		return validate.PathedError(err, "limits")
+	}
+	return nil
+}
+
+// Metadata returns the channel's metadata.
+func (c Channel) Metadata() Metadata {
+	return Metadata{
+		Units:       c.Units,
+		Description: c.Description,
+		Scale:       c.Scale,
+		Limits:      c.Limits,
+	}
+}
+
+// SetMetadata replaces the channel's metadata with md.
+func (c *Channel) SetMetadata(md Metadata) {
+	c.Units = md.Units
+	c.Description = md.Description
+	c.Scale = md.Scale
+	c.Limits = md.Limits
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package channel_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/mock"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Metadata", func() {
+	Describe("Scale", func() {
+		It("Should apply a linear scale", func() {
+			s := channel.Scale{Type: channel.ScaleTypeLinear, Slope: 2, Offset: 1}
+			Expect(s.Apply(3)).To(Equal(7.0))
+		})
+		It("Should apply a polynomial scale", func() {
+			s := channel.Scale{
+				Type:         channel.ScaleTypePolynomial,
+				Coefficients: []float64{1, 2, 3},
+			}
+			Expect(s.Apply(2)).To(Equal(17.0))
+		})
+		It("Should not modify values when no scale is set", func() {
+			Expect(channel.Scale{}.Apply(12)).To(Equal(12.0))
+		})
+		It("Should not allow a linear scale with a zero slope", func() {
+			s := channel.Scale{Type: channel.ScaleTypeLinear}
+			Expect(s.Validate()).To(MatchError(ContainSubstring("slope must be non-zero")))
+		})
+		It("Should not allow a polynomial scale without coefficients", func() {
+			s := channel.Scale{Type: channel.ScaleTypePolynomial}
+			Expect(s.Validate()).To(MatchError(ContainSubstring("at least one coefficient")))
+		})
+		It("Should not allow an unknown scale type", func() {
+			s := channel.Scale{Type: "cat"}
+			Expect(s.Validate()).To(MatchError(ContainSubstring("invalid scale type")))
+		})
+	})
+	Describe("Limits", func() {
+		It("Should allow a warning range within the critical range", func() {
+			l := channel.Limits{
+				Warning:  &channel.Limit{Min: 10, Max: 90},
+				Critical: &channel.Limit{Min: 0, Max: 100},
+			}
+			Expect(l.Validate()).To(Succeed())
+		})
+		It("Should not allow a limit whose min is greater than its max", func() {
+			l := channel.Limits{Critical: &channel.Limit{Min: 10, Max: 0}}
+			Expect(l.Validate()).To(MatchError(ContainSubstring("critical: min must be less than or equal to max")))
+		})
+		It("Should not allow a warning range outside the critical range", func() {
+			l := channel.Limits{
+				Warning:  &channel.Limit{Min: -10, Max: 90},
+				Critical: &channel.Limit{Min: 0, Max: 100},
+			}
+			Expect(l.Validate()).To(MatchError(ContainSubstring("within the critical range")))
+		})
+		It("Should check whether a value is within a limit", func() {
+			l := channel.Limit{Min: 0, Max: 100}
+			Expect(l.Contains(50)).To(BeTrue())
+			Expect(l.Contains(101)).To(BeFalse())
+		})
+		It("Should distinguish a zero range from an unset range", func() {
+			l := channel.Limits{Warning: &channel.Limit{}}
+			Expect(l.IsZero()).To(BeFalse())
+			Expect(l.Warning.Contains(0)).To(BeTrue())
+			Expect(l.Warning.Contains(1)).To(BeFalse())
+			Expect(channel.Limits{}.IsZero()).To(BeTrue())
+		})
+	})
+	Describe("Persistence", Ordered, func() {
+		var mockCluster *mock.Cluster
+		BeforeAll(func() { mockCluster = mock.ProvisionCluster(ctx, 2) })
+		AfterAll(func() { Expect(mockCluster.Close()).To(Succeed()) })
+		md := channel.Metadata{
+			Units:       "psi",
+			Description: "Pressure upstream of the main valve",
+			Scale:       channel.Scale{Type: channel.ScaleTypeLinear, Slope: 0.5, Offset: -2},
+			Limits: channel.Limits{
+				Warning:  &channel.Limit{Min: 0, Max: 500},
+				Critical: &channel.Limit{Min: -10, Max: 750},
+			},
+		}
+		It("Should persist metadata when creating a channel", func() {
+			ch := channel.Channel{Name: "pt_1", DataType: telem.Float32T, Virtual: true}
+			ch.SetMetadata(md)
+			Expect(mockCluster.Nodes[1].Channel.Create(ctx, &ch)).To(Succeed())
+			var res channel.Channel
+			Expect(mockCluster.Nodes[1].Channel.NewRetrieve().
+				WhereKeys(ch.Key()).
+				Entry(&res).
+				Exec(ctx, nil)).To(Succeed())
+			Expect(res.Metadata()).To(Equal(md))
+		})
+		It("Should persist metadata when creating a channel on a remote node", func() {
+			ch := channel.Channel{
+				Name:        "pt_2",
+				DataType:    telem.Float32T,
+				Virtual:     true,
+				Leaseholder: 1,
+			}
+			ch.SetMetadata(md)
+			Expect(mockCluster.Nodes[2].Channel.Create(ctx, &ch)).To(Succeed())
+			Expect(ch.Metadata()).To(Equal(md))
+			Eventually(func(g Gomega) {
+				var res channel.Channel
+				g.Expect(mockCluster.Nodes[1].Channel.NewRetrieve().
+					WhereKeys(ch.Key()).
+					Entry(&res).
+					Exec(ctx, nil)).To(Succeed())
+				g.Expect(res.Metadata()).To(Equal(md))
+			}).Should(Succeed())
+		})
+		It("Should not allow creating a channel with invalid metadata", func() {
+			ch := channel.Channel{
+				Name:     "pt_3",
+				DataType: telem.Float32T,
+				Virtual:  true,
+				Limits:   channel.Limits{Warning: &channel.Limit{Min: 5, Max: 1}},
+			}
+			Expect(mockCluster.Nodes[1].Channel.Create(ctx, &ch)).
+				To(MatchError(ContainSubstring("limits.warning")))
+		})
+		It("Should update the metadata of an existing channel", func() {
+			ch := channel.Channel{Name: "pt_4", DataType: telem.Float32T, Virtual: true}
+			Expect(mockCluster.Nodes[1].Channel.Create(ctx, &ch)).To(Succeed())
+			ch.SetMetadata(md)
+			Expect(mockCluster.Nodes[1].Channel.Update(ctx, &ch, false)).To(Succeed())
+			var res channel.Channel
+			Expect(mockCluster.Nodes[1].Channel.NewRetrieve().
+				WhereKeys(ch.Key()).
+				Entry(&res).
+				Exec(ctx, nil)).To(Succeed())
+			Expect(res.Name).To(Equal("pt_4"))
+			Expect(res.Metadata()).To(Equal(md))
+		})
+		It("Should not update the metadata when it is invalid", func() {
+			ch := channel.Channel{Name: "pt_5", DataType: telem.Float32T, Virtual: true}
+			Expect(mockCluster.Nodes[1].Channel.Create(ctx, &ch)).To(Succeed())
+			ch.Scale = channel.Scale{Type: channel.ScaleTypePolynomial}
+			Expect(mockCluster.Nodes[1].Channel.Update(ctx, &ch, false)).
+				To(MatchError(ContainSubstring("scale.coefficients")))
+		})
+		It("Should make units and descriptions searchable", func() {
+			ch := channel.Channel{Name: "tc_1", DataType: telem.Float32T, Virtual: true}
+			ch.SetMetadata(channel.Metadata{Units: "degC", Description: "Thermocouple on the nozzle"})
+			Expect(mockCluster.Nodes[1].Channel.Create(ctx, &ch)).To(Succeed())
+			r := MustSucceed(mockCluster.Nodes[1].Channel.RetrieveResource(ctx, ch.Key().String(), nil))
+			Expect(r.Data).To(HaveKeyWithValue("units", "degC"))
+			Expect(r.Data).To(HaveKeyWithValue("description", "Thermocouple on the nozzle"))
+		})
+	})
+})
@@ -51,6 +51,8 @@ var schema = zyn.Object(map[string]zyn.Schema{
 	"internal":    zyn.Bool(),
 	"virtual":     zyn.Bool(),
 	"expression":  zyn.String(),
+	"units":       zyn.String(),
+	"description": zyn.String(),
 })
 
 func newResource(c Channel) ontology.Resource {
@@ -64,6 +66,8 @@ func newResource(c Channel) ontology.Resource {
 		"internal":    c.Internal,
 		"virtual":     c.Virtual,
 		"expression":  c.Expression,
+		"units":       c.Units,
+		"description": c.Description,
 	})
 }
 
@@ -12,20 +12,136 @@ package signals
 import (
 	"context"
 	"io"
+	"sync"
 
 	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
 	"github.com/synnaxlabs/synnax/pkg/distribution/signals"
+	"github.com/synnaxlabs/x/binary"
+	"github.com/synnaxlabs/x/change"
+	"github.com/synnaxlabs/x/errors"
 	"github.com/synnaxlabs/x/gorp"
+	xio "github.com/synnaxlabs/x/io"
+	"github.com/synnaxlabs/x/observe"
 	"github.com/synnaxlabs/x/telem"
+	"go.uber.org/zap"
 )
 
+// UpdateChannelName is the name of the channel that Update events are published to.
+const UpdateChannelName = "sy_channel_update"
+
+// Update is published as a JSON sample on the UpdateChannelName channel whenever the
+// properties of an existing channel change.
+type Update struct {
+	// Key is the key of the updated channel.
+	Key channel.Key `json:"key"`
+	// Fields are the names of the fields that changed.
+	Fields []string `json:"fields"`
+	// Channel is the channel after the update.
+	Channel channel.Channel `json:"channel"`
+}
+
 func Publish(
 	ctx context.Context,
 	prov *signals.Provider,
 	db *gorp.DB,
 ) (io.Closer, error) {
-	return signals.PublishFromGorp(ctx, prov, signals.GorpPublisherConfigPureNumeric[channel.Key, channel.Channel](
+	setDeleteCloser, err := signals.PublishFromGorp(ctx, prov, signals.GorpPublisherConfigPureNumeric[channel.Key, channel.Channel](
 		db,
 		telem.Uint32T,
 	))
+	if err != nil {
+		return nil, err
+	}
+	updateCloser, err := publishUpdates(ctx, prov, db)
+	if err != nil {
+		return nil, errors.Combine(err, setDeleteCloser.Close())
+	}
+	return xio.MultiCloser{setDeleteCloser, updateCloser}, nil
+}
+
+// publishUpdates tracks the last known state of every channel in order to publish the
+// fields that changed whenever an existing channel is set. Observers only receive the
+// new value of a channel, so the previous values must be kept in memory to compute
+// the changed fields.
+func publishUpdates(
+	ctx context.Context,
+	prov *signals.Provider,
+	db *gorp.DB,
+) (io.Closer, error) {
+	var (
+		mu      sync.Mutex
+		codec   = &binary.JSONCodec{}
+		prev    = make(map[channel.Key]channel.Channel)
+		updates = observe.New[[]change.Change[[]byte, struct{}]]()
+	)
+	// Subscribe to changes before loading the existing channels, so that no changes
+	// made while loading them are missed.
+	disconnect := gorp.Observe[channel.Key, channel.Channel](db).OnChange(func(
+		ctx context.Context,
+		r gorp.TxReader[channel.Key, channel.Channel],
+	) {
+		mu.Lock()
+		var out []change.Change[[]byte, struct{}]
+		for c, ok := r.Next(ctx); ok; c, ok = r.Next(ctx) {
+			if c.Variant == change.Delete {
+				delete(prev, c.Key)
+				continue
+			}
+			p, found := prev[c.Key]
+			prev[c.Key] = c.Value
+			if !found {
+				continue
+			}
+			fields := p.ChangedFields(c.Value)
+			if len(fields) == 0 {
+				continue
+			}
+			b, err := codec.Encode(ctx, Update{Key: c.Key, Fields: fields, Channel: c.Value})
+			if err != nil {
+				prov.L.Error("failed to encode channel update", zap.Error(err))
+				continue
+			}
+			out = append(out, change.Change[[]byte, struct{}]{
+				Variant: change.Set,
+				Key:     append(b, '\n'),
+			})
+		}
+		mu.Unlock()
+		if len(out) > 0 {
+			updates.Notify(ctx, out)
+		}
+	})
+	var existing []channel.Channel
+	if err := gorp.NewRetrieve[channel.Key, channel.Channel]().
+		Entries(&existing).
+		Exec(ctx, db); err != nil {
+		disconnect()
+		return nil, err
+	}
+	mu.Lock()
+	for _, ch := range existing {
+		// Channels changed while loading are already tracked with a value at least
+		// as recent as the loaded one.
+		if _, found := prev[ch.Key()]; !found {
+			prev[ch.Key()] = ch
+		}
+	}
+	mu.Unlock()
+	closer, err := prov.PublishFromObservable(ctx, signals.ObservablePublisherConfig{
+		Name:       "channel_update",
+		Observable: updates,
+		SetChannel: channel.Channel{
+			Name:     UpdateChannelName,
+			DataType: telem.JSONT,
+			Internal: true,
+		},
+	})
+	if err != nil {
+		disconnect()
+		return nil, err
+	}
+	return xio.MultiCloser{
+		xio.CloserFunc(func() error { disconnect(); return nil }),
+		closer,
+	}, nil
 }
@@ -0,0 +1,162 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package channel_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/cluster"
+	"github.com/synnaxlabs/synnax/pkg/distribution/mock"
+	"github.com/synnaxlabs/x/control"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Update", Ordered, func() {
+	var (
+		mockCluster *mock.Cluster
+		svc         channel.Service
+		base        channel.Channel
+	)
+	BeforeAll(func() {
+		mockCluster = mock.ProvisionCluster(ctx, 2)
+		svc = mockCluster.Nodes[1].Channel
+		base = channel.Channel{Name: "base", DataType: telem.Float64T, Virtual: true}
+		Expect(svc.Create(ctx, &base)).To(Succeed())
+	})
+	AfterAll(func() { Expect(mockCluster.Close()).To(Succeed()) })
+	retrieve := func(key channel.Key) channel.Channel {
+		var res channel.Channel
+		Expect(svc.NewRetrieve().WhereKeys(key).Entry(&res).Exec(ctx, nil)).To(Succeed())
+		return res
+	}
+
+	Describe("Calculated Channels", func() {
+		It("Should update the expression and requirements of a calculated channel", func() {
+			other := channel.Channel{Name: "other", DataType: telem.Float64T, Virtual: true}
+			Expect(svc.Create(ctx, &other)).To(Succeed())
+			calc := channel.Channel{
+				Name:        "calc",
+				DataType:    telem.Float64T,
+				Virtual:     true,
+				Leaseholder: cluster.Free,
+				Requires:    channel.Keys{base.Key()},
+				Expression:  "return base * 2",
+			}
+			Expect(svc.Create(ctx, &calc)).To(Succeed())
+			calc.Requires = channel.Keys{base.Key(), other.Key()}
+			calc.Expression = "return base + other"
+			Expect(svc.Update(ctx, &calc, false)).To(Succeed())
+			res := retrieve(calc.Key())
+			Expect(res.Expression).To(Equal("return base + other"))
+			Expect(res.Requires).To(ConsistOf(base.Key(), other.Key()))
+		})
+		It("Should not allow clearing the expression of a calculated channel", func() {
+			calc := channel.Channel{
+				Name:        "calc_2",
+				DataType:    telem.Float64T,
+				Virtual:     true,
+				Leaseholder: cluster.Free,
+				Requires:    channel.Keys{base.Key()},
+				Expression:  "return base",
+			}
+			Expect(svc.Create(ctx, &calc)).To(Succeed())
+			calc.Expression = ""
+			Expect(svc.Update(ctx, &calc, false)).To(MatchError(ContainSubstring("expression")))
+		})
+		It("Should not allow adding an expression to a non-calculated channel", func() {
+			ch := base
+			ch.Expression = "return 1"
+			Expect(svc.Update(ctx, &ch, false)).
+				To(MatchError(ContainSubstring("only calculated channels")))
+		})
+	})
+
+	Describe("Concurrency", func() {
+		It("Should update the concurrency of a virtual channel", func() {
+			ch := channel.Channel{Name: "virt", DataType: telem.Float64T, Virtual: true}
+			Expect(svc.Create(ctx, &ch)).To(Succeed())
+			ch.Concurrency = control.Shared
+			Expect(svc.Update(ctx, &ch, false)).To(Succeed())
+			Expect(retrieve(ch.Key()).Concurrency).To(Equal(control.Shared))
+		})
+		It("Should not allow changing the concurrency of a persisted channel", func() {
+			ch := channel.Channel{Name: "time", DataType: telem.TimeStampT, IsIndex: true}
+			Expect(svc.Create(ctx, &ch)).To(Succeed())
+			ch.Concurrency = control.Shared
+			Expect(svc.Update(ctx, &ch, false)).
+				To(MatchError(ContainSubstring("only virtual channels")))
+		})
+	})
+
+	Describe("Immutable Fields", func() {
+		It("Should not allow changing the data type of a channel", func() {
+			ch := base
+			ch.DataType = telem.Int32T
+			Expect(svc.Update(ctx, &ch, false)).To(MatchError(ContainSubstring("data_type")))
+			Expect(retrieve(base.Key()).DataType).To(Equal(telem.Float64T))
+		})
+		It("Should not allow updating internal channels", func() {
+			ch := channel.Channel{Name: "internal", DataType: telem.Float64T, Virtual: true, Internal: true}
+			Expect(svc.Create(ctx, &ch)).To(Succeed())
+			ch.Name = "not_internal"
+			Expect(svc.Update(ctx, &ch, false)).To(MatchError(ContainSubstring("internal")))
+			Expect(svc.Update(ctx, &ch, true)).To(Succeed())
+		})
+		It("Should not allow marking a channel as internal", func() {
+			ch := base
+			ch.Internal = true
+			Expect(svc.Update(ctx, &ch, true)).To(MatchError(ContainSubstring("internal")))
+			Expect(retrieve(base.Key()).Internal).To(BeFalse())
+		})
+	})
+
+	Describe("Name and Metadata", func() {
+		It("Should rename a channel leased to a remote node", func() {
+			idx := channel.Channel{
+				Name:        "remote_time",
+				DataType:    telem.TimeStampT,
+				IsIndex:     true,
+				Leaseholder: 2,
+			}
+			Expect(svc.Create(ctx, &idx)).To(Succeed())
+			Eventually(func() error {
+				return svc.NewRetrieve().WhereKeys(idx.Key()).Exec(ctx, nil)
+			}).Should(Succeed())
+			idx.Name = "remote_time_renamed"
+			idx.Units = "ns"
+			Expect(svc.Update(ctx, &idx, false)).To(Succeed())
+			Eventually(func(g Gomega) {
+				var res channel.Channel
+				g.Expect(mockCluster.Nodes[2].Channel.NewRetrieve().
+					WhereKeys(idx.Key()).
+					Entry(&res).
+					Exec(ctx, nil)).To(Succeed())
+				g.Expect(res.Name).To(Equal("remote_time_renamed"))
+				g.Expect(res.Units).To(Equal("ns"))
+			}).Should(Succeed())
+		})
+		It("Should return an error when the channel does not exist", func() {
+			ch := channel.Channel{Name: "ghost", Leaseholder: 1, LocalKey: 5000}
+			Expect(svc.Update(ctx, &ch, false)).To(HaveOccurredAs(query.NotFound))
+		})
+	})
+
+	Describe("ChangedFields", func() {
+		It("Should return the fields that differ between two channels", func() {
+			a := channel.Channel{Name: "a", Requires: channel.Keys{1, 2}}
+			b := channel.Channel{Name: "b", Requires: channel.Keys{2, 1}, Units: "V"}
+			Expect(a.ChangedFields(b)).To(ConsistOf("Name", "Units"))
+			Expect(a.ChangedFields(a)).To(BeEmpty())
+		})
+	})
+})
@@ -27,6 +27,8 @@ type Writer interface {
 	Rename(ctx context.Context, key Key, newName string, allowInternal bool) error
 	RenameMany(ctx context.Context, keys []Key, newNames []string, allowInternal bool) error
 	MapRename(ctx context.Context, names map[string]string, allowInternal bool) error
+	Update(ctx context.Context, c *Channel, allowInternal bool) error
+	UpdateMany(ctx context.Context, channels *[]Channel, allowInternal bool) error
 }
 
 type writer struct {
@@ -118,6 +120,17 @@ func (w writer) RenameMany(
 	return w.svc.proxy.rename(ctx, w.tx, keys, newNames, allowInternal)
 }
 
+func (w writer) Update(ctx context.Context, c *Channel, allowInternal bool) error {
+	channels := []Channel{*c}
+	err := w.UpdateMany(ctx, &channels, allowInternal)
+	*c = channels[0]
+	return err
+}
+
+func (w writer) UpdateMany(ctx context.Context, channels *[]Channel, allowInternal bool) error {
+	return w.svc.proxy.update(ctx, w.tx, applyManyAdjustments(channels), allowInternal)
+}
+
 func applyAdjustments(c Channel) Channel {
 	c.Name = strings.TrimSpace(c.Name)
 	return c
@@ -22,9 +22,18 @@ type (
 	HostResolver = aspen.HostResolver
 	HostProvider = aspen.HostProvider
 	State        = aspen.ClusterState
+	Admin        = aspen.Admin
 )
 
+// ErrCannotEvictHost is returned when attempting to evict the host node from the
+// cluster.
+var ErrCannotEvictHost = aspen.ErrCannotEvictHost
+
 const (
 	Free         = aspen.Free
 	Bootstrapper = aspen.Bootstrapper
+	Healthy      = aspen.Healthy
+	Suspect      = aspen.Suspect
+	Dead         = aspen.Dead
+	Left         = aspen.Left
 )
@@ -22,6 +22,9 @@ type Request struct {
 
 type Response struct {
 	Frame core.Frame `json:"frame" msgpack:"frame"`
+	// ResumeToken is only set by resumable service streamers, and encodes the data
+	// delivered up to and including the response. It is never set by the relay.
+	ResumeToken string `json:"resume_token,omitempty" msgpack:"resume_token,omitempty"`
 }
 
 func reqToStorage(req Request) ts.StreamerRequest {
@@ -162,6 +162,9 @@ type Layer struct {
 	DB *gorp.DB
 	// Cluster provides information about the cluster topology. Nodes, keys, addresses, states, etc.
 	Cluster cluster.Cluster
+	// ClusterAdmin provides operations for diagnosing and repairing the cluster, such
+	// as forcing a round of gossip or evicting dead nodes.
+	ClusterAdmin cluster.Admin
 	// Channel is for creating, deleting, and retrieving channels across the cluster.
 	Channel channel.Service
 	// Framer is for reading, writing, and streaming frames of telemetry across the
@@ -219,6 +222,7 @@ func Open(ctx context.Context, cfgs ...Config) (*Layer, error) {
 		return nil, err
 	}
 	l.Cluster = aspenDB.Cluster
+	l.ClusterAdmin = aspenDB
 	l.DB = gorp.Wrap(
 		aspenDB,
 		gorp.WithCodec(&binary.TracingCodec{
@@ -44,7 +44,8 @@ type ObservablePublisherConfig struct {
 	SetChannel channel.Channel
 	// DeleteChannel is the channel used to propagate delete operations. Only Name and
 	// SetDataType need to be provided. The config will automatically set Leaseholder
-	// to Free and Virtual to true.
+	// to Free and Virtual to true. If Name is empty, delete operations are not
+	// propagated.
 	DeleteChannel channel.Channel
 	// Observable is the observable used to subscribe to changes. This observable should
 	// return byte slice keys that are properly encoded for the channel's data type.
@@ -67,11 +68,12 @@ const (
 func (c ObservablePublisherConfig) Validate() error {
 	v := validate.New("signals.ObservablePublisherConfig")
 	validate.NotEmptyString(v, "Label.Name", c.SetChannel.Name)
-	validate.NotEmptyString(v, "DeleteChannel.Name", c.DeleteChannel.Name)
 	v.Ternaryf("setChannel.leaseholder", !c.SetChannel.Free(), nonFree, c.SetChannel.Leaseholder)
-	v.Ternaryf("deleteChannel.leaseholder", !c.DeleteChannel.Free(), nonFree, c.DeleteChannel.Leaseholder)
 	v.Ternaryf("setChannel.virtual", !c.SetChannel.Virtual, nonVirtual, c.SetChannel.Name)
-	v.Ternaryf("deleteChannel.virtual", !c.DeleteChannel.Virtual, nonVirtual, c.DeleteChannel.Name)
+	if c.DeleteChannel.Name != "" {
+		v.Ternaryf("deleteChannel.leaseholder", !c.DeleteChannel.Free(), nonFree, c.DeleteChannel.Leaseholder)
+		v.Ternaryf("deleteChannel.virtual", !c.DeleteChannel.Virtual, nonVirtual, c.DeleteChannel.Name)
+	}
 	validate.NotNil(v, "ObservableSubscriber", c.Observable)
 	return v.Error()
 }
@@ -97,7 +99,10 @@ func (s *Provider) PublishFromObservable(ctx context.Context, cfgs ...Observable
 	if err != nil {
 		return nil, err
 	}
-	channels := []channel.Channel{cfg.SetChannel, cfg.DeleteChannel}
+	channels := []channel.Channel{cfg.SetChannel}
+	if cfg.DeleteChannel.Name != "" {
+		channels = append(channels, cfg.DeleteChannel)
+	}
 	if err = s.Channel.CreateMany(ctx, &channels, channel.RetrieveIfNameExists(true)); err != nil {
 		return nil, err
 	}
@@ -138,7 +143,7 @@ func (s *Provider) PublishFromObservable(ctx context.Context, cfgs ...Observable
 			if len(sets.Data) > 0 {
 				frame = frame.Append(cfg.SetChannel.Key(), sets)
 			}
-			if len(deletes.Data) > 0 {
+			if len(deletes.Data) > 0 && cfg.DeleteChannel.Name != "" {
 				frame = frame.Append(cfg.DeleteChannel.Key(), deletes)
 			}
 			return framer.WriterRequest{Command: writer.Write, Frame: frame}, true, nil
@@ -63,6 +63,15 @@ func (c createMessageTranslator) Forward(
 			Concurrency: uint32(ch.Concurrency),
 			Internal:    ch.Internal,
 			Virtual:     ch.Virtual,
+			Requires:    ch.Requires.Uint32(),
+			Expression:  ch.Expression,
+			Units:       ch.Units,
+			Description: ch.Description,
+			Scale:       translateScaleForward(ch.Scale),
+			Limits: &channelv1.Limits{
+				Warning:  translateLimitForward(ch.Limits.Warning),
+				Critical: translateLimitForward(ch.Limits.Critical),
+			},
 		})
 	}
 	return tr, nil
@@ -84,11 +93,52 @@ func (c createMessageTranslator) Backward(
 			Virtual:     ch.Virtual,
 			Concurrency: control.Concurrency(ch.Concurrency),
 			Internal:    ch.Internal,
+			Requires:    channel.KeysFromUint32(ch.Requires),
+			Expression:  ch.Expression,
+			Units:       ch.Units,
+			Description: ch.Description,
+			Scale:       translateScaleBackward(ch.Scale),
+			Limits: channel.Limits{
+				Warning:  translateLimitBackward(ch.Limits.GetWarning()),
+				Critical: translateLimitBackward(ch.Limits.GetCritical()),
+			},
 		})
 	}
 	return tr, nil
 }
 
+func translateScaleForward(s channel.Scale) *channelv1.Scale {
+	return &channelv1.Scale{
+		Type:         string(s.Type),
+		Slope:        s.Slope,
+		Offset:       s.Offset,
+		Coefficients: s.Coefficients,
+	}
+}
+
+func translateScaleBackward(s *channelv1.Scale) channel.Scale {
+	return channel.Scale{
+		Type:         channel.ScaleType(s.GetType()),
+		Slope:        s.GetSlope(),
+		Offset:       s.GetOffset(),
+		Coefficients: s.GetCoefficients(),
+	}
+}
+
+func translateLimitForward(l *channel.Limit) *channelv1.Limit {
+	if l == nil {
+		return nil
+	}
+	return &channelv1.Limit{Min: l.Min, Max: l.Max}
+}
+
+func translateLimitBackward(l *channelv1.Limit) *channel.Limit {
+	if l == nil {
+		return nil
+	}
+	return &channel.Limit{Min: l.Min, Max: l.Max}
+}
+
 func (d deleteRequestTranslator) Forward(
 	_ context.Context,
 	msg channel.DeleteRequest,
@@ -233,6 +233,12 @@ type Channel struct {
 	Virtual       bool                   `protobuf:"varint,7,opt,name=virtual,proto3" json:"virtual,omitempty"`
 	Concurrency   uint32                 `protobuf:"varint,8,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
 	Internal      bool                   `protobuf:"varint,9,opt,name=internal,proto3" json:"internal,omitempty"`
+	Requires      []uint32               `protobuf:"varint,10,rep,packed,name=requires,proto3" json:"requires,omitempty"`
+	Expression    string                 `protobuf:"bytes,11,opt,name=expression,proto3" json:"expression,omitempty"`
+	Units         string                 `protobuf:"bytes,12,opt,name=units,proto3" json:"units,omitempty"`
+	Description   string                 `protobuf:"bytes,13,opt,name=description,proto3" json:"description,omitempty"`
+	Scale         *Scale                 `protobuf:"bytes,14,opt,name=scale,proto3" json:"scale,omitempty"`
+	Limits        *Limits                `protobuf:"bytes,15,opt,name=limits,proto3" json:"limits,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -330,6 +336,220 @@ func (x *Channel) GetInternal() bool {
 	return false
 }
 
+func (x *Channel) GetRequires() []uint32 {
+	if x != nil {
+		return x.Requires
+	}
+	return nil
+}
+
+func (x *Channel) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *Channel) GetUnits() string {
+	if x != nil {
+		return x.Units
+	}
+	return ""
+}
+
+func (x *Channel) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Channel) GetScale() *Scale {
+	if x != nil {
+		return x.Scale
+	}
+	return nil
+}
+
+func (x *Channel) GetLimits() *Limits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+type Scale struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Slope         float64                `protobuf:"fixed64,2,opt,name=slope,proto3" json:"slope,omitempty"`
+	Offset        float64                `protobuf:"fixed64,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Coefficients  []float64              `protobuf:"fixed64,4,rep,packed,name=coefficients,proto3" json:"coefficients,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Scale) Reset() {
+	*x = Scale{}
+	mi := &file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Scale) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scale) ProtoMessage() {}
+
+func (x *Scale) ProtoReflect() protoreflect.Message {
+	mi := &file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scale.ProtoReflect.Descriptor instead.
+func (*Scale) Descriptor() ([]byte, []int) {
+	return file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Scale) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Scale) GetSlope() float64 {
+	if x != nil {
+		return x.Slope
+	}
+	return 0
+}
+
+func (x *Scale) GetOffset() float64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Scale) GetCoefficients() []float64 {
+	if x != nil {
+		return x.Coefficients
+	}
+	return nil
+}
+
+type Limit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Min           float64                `protobuf:"fixed64,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64                `protobuf:"fixed64,2,opt,name=max,proto3" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Limit) Reset() {
+	*x = Limit{}
+	mi := &file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Limit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Limit) ProtoMessage() {}
+
+func (x *Limit) ProtoReflect() protoreflect.Message {
+	mi := &file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Limit.ProtoReflect.Descriptor instead.
+func (*Limit) Descriptor() ([]byte, []int) {
+	return file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Limit) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *Limit) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+type Limits struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warning       *Limit                 `protobuf:"bytes,1,opt,name=warning,proto3" json:"warning,omitempty"`
+	Critical      *Limit                 `protobuf:"bytes,2,opt,name=critical,proto3" json:"critical,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Limits) Reset() {
+	*x = Limits{}
+	mi := &file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Limits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Limits) ProtoMessage() {}
+
+func (x *Limits) ProtoReflect() protoreflect.Message {
+	mi := &file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Limits.ProtoReflect.Descriptor instead.
+func (*Limits) Descriptor() ([]byte, []int) {
+	return file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Limits) GetWarning() *Limit {
+	if x != nil {
+		return x.Warning
+	}
+	return nil
+}
+
+func (x *Limits) GetCritical() *Limit {
+	if x != nil {
+		return x.Critical
+	}
+	return nil
+}
+
 var File_core_pkg_distribution_transport_grpc_channel_v1_channel_proto protoreflect.FileDescriptor
 
 const file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDesc = "" +
@@ -346,7 +566,7 @@ const file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDesc
 	"\x04keys\x18\x03 \x03(\rR\x04keys\"9\n" +
 	"\rRenameRequest\x12\x12\n" +
 	"\x04keys\x18\x01 \x03(\rR\x04keys\x12\x14\n" +
-	"\x05names\x18\x02 \x03(\tR\x05names\"\x8d\x02\n" +
+	"\x05names\x18\x02 \x03(\tR\x05names\"\xd6\x03\n" +
 	"\aChannel\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vleaseholder\x18\x02 \x01(\x05R\vleaseholder\x12\x1b\n" +
@@ -357,7 +577,27 @@ const file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDesc
 	"localIndex\x12\x18\n" +
 	"\avirtual\x18\a \x01(\bR\avirtual\x12 \n" +
 	"\vconcurrency\x18\b \x01(\rR\vconcurrency\x12\x1a\n" +
-	"\binternal\x18\t \x01(\bR\binternal2V\n" +
+	"\binternal\x18\t \x01(\bR\binternal\x12\x1a\n" +
+	"\brequires\x18\n" +
+	" \x03(\rR\brequires\x12\x1e\n" +
+	"\n" +
+	"expression\x18\v \x01(\tR\n" +
+	"expression\x12\x14\n" +
+	"\x05units\x18\f \x01(\tR\x05units\x12 \n" +
+	"\vdescription\x18\r \x01(\tR\vdescription\x12'\n" +
+	"\x05scale\x18\x0e \x01(\v2\x11.channel.v1.ScaleR\x05scale\x12*\n" +
+	"\x06limits\x18\x0f \x01(\v2\x12.channel.v1.LimitsR\x06limits\"m\n" +
+	"\x05Scale\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05slope\x18\x02 \x01(\x01R\x05slope\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x01R\x06offset\x12\"\n" +
+	"\fcoefficients\x18\x04 \x03(\x01R\fcoefficients\"+\n" +
+	"\x05Limit\x12\x10\n" +
+	"\x03min\x18\x01 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\x02 \x01(\x01R\x03max\"d\n" +
+	"\x06Limits\x12+\n" +
+	"\awarning\x18\x01 \x01(\v2\x11.channel.v1.LimitR\awarning\x12-\n" +
+	"\bcritical\x18\x02 \x01(\v2\x11.channel.v1.LimitR\bcritical2V\n" +
 	"\x14ChannelCreateService\x12>\n" +
 	"\x04Exec\x12\x19.channel.v1.CreateMessage\x1a\x19.channel.v1.CreateMessage\"\x002S\n" +
 	"\x14ChannelDeleteService\x12;\n" +
@@ -380,29 +620,36 @@ func file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDescG
 	return file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDescData
 }
 
-var file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_goTypes = []any{
 	(*CreateOptions)(nil), // 0: channel.v1.CreateOptions
 	(*CreateMessage)(nil), // 1: channel.v1.CreateMessage
 	(*DeleteRequest)(nil), // 2: channel.v1.DeleteRequest
 	(*RenameRequest)(nil), // 3: channel.v1.RenameRequest
 	(*Channel)(nil),       // 4: channel.v1.Channel
-	(*emptypb.Empty)(nil), // 5: google.protobuf.Empty
+	(*Scale)(nil),         // 5: channel.v1.Scale
+	(*Limit)(nil),         // 6: channel.v1.Limit
+	(*Limits)(nil),        // 7: channel.v1.Limits
+	(*emptypb.Empty)(nil), // 8: google.protobuf.Empty
 }
 var file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_depIdxs = []int32{
 	4, // 0: channel.v1.CreateMessage.channels:type_name -> channel.v1.Channel
 	0, // 1: channel.v1.CreateMessage.opts:type_name -> channel.v1.CreateOptions
-	1, // 2: channel.v1.ChannelCreateService.Exec:input_type -> channel.v1.CreateMessage
-	2, // 3: channel.v1.ChannelDeleteService.Exec:input_type -> channel.v1.DeleteRequest
-	3, // 4: channel.v1.ChannelRenameService.Exec:input_type -> channel.v1.RenameRequest
-	1, // 5: channel.v1.ChannelCreateService.Exec:output_type -> channel.v1.CreateMessage
-	5, // 6: channel.v1.ChannelDeleteService.Exec:output_type -> google.protobuf.Empty
-	5, // 7: channel.v1.ChannelRenameService.Exec:output_type -> google.protobuf.Empty
-	5, // [5:8] is the sub-list for method output_type
-	2, // [2:5] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	5, // 2: channel.v1.Channel.scale:type_name -> channel.v1.Scale
+	7, // 3: channel.v1.Channel.limits:type_name -> channel.v1.Limits
+	6, // 4: channel.v1.Limits.warning:type_name -> channel.v1.Limit
+	6, // 5: channel.v1.Limits.critical:type_name -> channel.v1.Limit
+	1, // 6: channel.v1.ChannelCreateService.Exec:input_type -> channel.v1.CreateMessage
+	2, // 7: channel.v1.ChannelDeleteService.Exec:input_type -> channel.v1.DeleteRequest
+	3, // 8: channel.v1.ChannelRenameService.Exec:input_type -> channel.v1.RenameRequest
+	1, // 9: channel.v1.ChannelCreateService.Exec:output_type -> channel.v1.CreateMessage
+	8, // 10: channel.v1.ChannelDeleteService.Exec:output_type -> google.protobuf.Empty
+	8, // 11: channel.v1.ChannelRenameService.Exec:output_type -> google.protobuf.Empty
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_init() }
@@ -416,7 +663,7 @@ func file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDesc), len(file_core_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   3,
 		},
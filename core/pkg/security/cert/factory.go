@@ -14,9 +14,11 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
 	"net"
 	"os"
+	"time"
 
 	"github.com/synnaxlabs/x/address"
 	"github.com/synnaxlabs/x/config"
@@ -36,6 +38,13 @@ type FactoryConfig struct {
 	Hosts []address.Address
 	// AllowKeyReuse allows the CA key to be reused if it already exists.
 	AllowKeyReuse *bool
+	// ValidFor is how long node and client certificates are valid for after they
+	// are issued.
+	ValidFor time.Duration
+	// CAValidFor is how long the CA certificate is valid for after it is issued.
+	// Node certificates are never issued past the expiry of the CA, so this should be
+	// much longer than ValidFor.
+	CAValidFor time.Duration
 }
 
 var (
@@ -45,6 +54,8 @@ var (
 		LoaderConfig:  DefaultLoaderConfig,
 		KeySize:       2048,
 		AllowKeyReuse: config.False(),
+		ValidFor:      time.Hour * 24 * 365,
+		CAValidFor:    time.Hour * 24 * 365 * 10,
 	}
 )
 
@@ -53,6 +64,8 @@ func (f FactoryConfig) Override(other FactoryConfig) FactoryConfig {
 	f.KeySize = override.Numeric(f.KeySize, other.KeySize)
 	f.Hosts = override.Slice(f.Hosts, other.Hosts)
 	f.AllowKeyReuse = override.Nil(f.AllowKeyReuse, other.AllowKeyReuse)
+	f.ValidFor = override.Numeric(f.ValidFor, other.ValidFor)
+	f.CAValidFor = override.Numeric(f.CAValidFor, other.CAValidFor)
 	f.LoaderConfig = f.LoaderConfig.Override(other.LoaderConfig)
 	return f
 }
@@ -62,6 +75,8 @@ func (f FactoryConfig) Validate() error {
 	v := validate.New("cert.Factory")
 	validate.Positive(v, "KeySize", f.KeySize)
 	validate.NotNil(v, "AllowKeyReuse", f.AllowKeyReuse)
+	validate.Positive(v, "ValidFor", f.ValidFor)
+	validate.Positive(v, "CAValidFor", f.CAValidFor)
 	v.Exec(f.LoaderConfig.Validate)
 	return v.Error()
 }
@@ -118,7 +133,7 @@ func (c *Factory) CreateCAPair() error {
 		}
 	}
 
-	base, err := newBasex509()
+	base, err := newBasex509(c.CAValidFor)
 	if err != nil {
 		return err
 	}
@@ -183,12 +198,95 @@ func (c *Factory) CreateNodePair() error {
 		return err
 	}
 
-	base, err := newBasex509()
+	base, err := c.newNodex509(ca)
 	if err != nil {
 		return err
 	}
+	c.setHosts(base)
+
+	b, err := x509.CreateCertificate(rand.Reader, base, ca, nodeKey.Public(), caPrivate)
+	if err != nil {
+		return err
+	}
+
+	return c.writePEM(c.NodeCertPath, xpem.FromCertBytes(b) /* multi */, false)
+}
+
+// RenewNodePair reissues the node certificate from the CA with a new validity
+// period. The node keeps its existing private key, and the certificate keeps its
+// existing hosts unless Hosts is set. The new certificate atomically replaces the
+// existing one, so readers never see a partially written file.
+func (c *Factory) RenewNodePair() error {
+	ca, caPrivate, err := c.Loader.LoadCAPair()
+	if err != nil {
+		return err
+	}
+	prev, nodeKey, err := c.Loader.LoadNodePair()
+	if err != nil {
+		return err
+	}
+	signer, ok := nodeKey.(crypto.Signer)
+	if !ok {
+		return errors.Newf("node key of type %T cannot be used to sign", nodeKey)
+	}
+
+	base, err := c.newNodex509(ca)
+	if err != nil {
+		return err
+	}
+	if len(c.Hosts) > 0 {
+		c.setHosts(base)
+	} else {
+		base.DNSNames = prev.DNSNames
+		base.IPAddresses = prev.IPAddresses
+	}
+
+	b, err := x509.CreateCertificate(rand.Reader, base, ca, signer.Public(), caPrivate)
+	if err != nil {
+		return err
+	}
+
+	tmp := c.NodeCertPath + ".tmp"
+	if err = c.FS.Remove(tmp); err != nil {
+		return err
+	}
+	if err = c.withFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_EXCL, func(f xfs.File) error {
+		return xpem.Write(f, xpem.FromCertBytes(b))
+	}); err != nil {
+		return err
+	}
+	return c.FS.Rename(tmp, c.NodeCertPath)
+}
+
+// RenewedNodeNotAfter returns the time at which a node certificate reissued by
+// RenewNodePair now would expire. It is capped by the expiry of the CA.
+func (c *Factory) RenewedNodeNotAfter() (time.Time, error) {
+	ca, _, err := c.Loader.LoadCAPair()
+	if err != nil {
+		return time.Time{}, err
+	}
+	notAfter := time.Now().Add(c.ValidFor)
+	if notAfter.After(ca.NotAfter) {
+		notAfter = ca.NotAfter
+	}
+	return notAfter, nil
+}
+
+// newNodex509 returns the template for a node certificate issued by the given CA. The
+// certificate never outlives the CA.
+func (c *Factory) newNodex509(ca *x509.Certificate) (*x509.Certificate, error) {
+	base, err := newBasex509(c.ValidFor)
+	if err != nil {
+		return nil, err
+	}
 	base.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	if base.NotAfter.After(ca.NotAfter) {
+		base.NotAfter = ca.NotAfter
+	}
+	return base, nil
+}
 
+func (c *Factory) setHosts(base *x509.Certificate) {
 	for _, h := range c.Hosts {
 		if ip := net.ParseIP(h.Host()); ip != nil {
 			base.IPAddresses = append(base.IPAddresses, ip)
@@ -196,13 +294,44 @@ func (c *Factory) CreateNodePair() error {
 			base.DNSNames = append(base.DNSNames, h.Host())
 		}
 	}
+}
 
-	b, err := x509.CreateCertificate(rand.Reader, base, ca, nodeKey.Public(), caPrivate)
+func (c *Factory) CreateClientPair(username string) error {
+	if err := validateClientUsername(username); err != nil {
+		return err
+	}
+	ca, caPrivate, err := c.Loader.LoadCAPair()
 	if err != nil {
 		return err
 	}
 
-	return c.writePEM(c.NodeCertPath, xpem.FromCertBytes(b) /* multi */, false)
+	clientKey, err := rsa.GenerateKey(rand.Reader, c.KeySize)
+	if err != nil {
+		return err
+	}
+
+	keyP, err := xpem.FromPrivateKey(clientKey)
+	if err != nil {
+		return err
+	}
+	if err = c.writePEM(ClientKeyPath(username), keyP, false); err != nil {
+		return err
+	}
+
+	base, err := newBasex509(c.ValidFor)
+	if err != nil {
+		return err
+	}
+	base.Subject = pkix.Name{CommonName: username}
+	base.KeyUsage = x509.KeyUsageDigitalSignature
+	base.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+
+	b, err := x509.CreateCertificate(rand.Reader, base, ca, clientKey.Public(), caPrivate)
+	if err != nil {
+		return err
+	}
+
+	return c.writePEM(ClientCertPath(username), xpem.FromCertBytes(b) /* multi */, false)
 }
 
 func (c *Factory) readPEM(p string) (b *pem.Block, err error) {
@@ -10,6 +10,9 @@
 package cert_test
 
 import (
+	"crypto/x509"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/synnaxlabs/synnax/pkg/security/cert"
@@ -93,4 +96,86 @@ var _ = Describe("Factory", func() {
 			Expect(err.Error()).To(ContainSubstring("no hosts provided"))
 		})
 	})
+	Describe("Node Renewal", func() {
+		It("Should reissue the node certificate with the same key and hosts", func() {
+			f := MustSucceed(cert.NewFactory(cert.FactoryConfig{
+				LoaderConfig: cert.LoaderConfig{FS: fs},
+				Hosts:        []address.Address{"synnaxlabs.com", "10.0.0.1:9090"},
+				KeySize:      mock.SmallKeySize,
+				ValidFor:     time.Hour,
+			}))
+			Expect(f.CreateCAPair()).To(Succeed())
+			Expect(f.CreateNodePair()).To(Succeed())
+			prev, prevKey := MustSucceed2(f.Loader.LoadNodePair())
+			renewer := MustSucceed(cert.NewFactory(cert.FactoryConfig{
+				LoaderConfig: cert.LoaderConfig{FS: fs},
+				KeySize:      mock.SmallKeySize,
+				ValidFor:     48 * time.Hour,
+			}))
+			Expect(renewer.RenewNodePair()).To(Succeed())
+			next, nextKey := MustSucceed2(f.Loader.LoadNodePair())
+			Expect(next.SerialNumber).ToNot(Equal(prev.SerialNumber))
+			Expect(next.NotAfter).To(BeTemporally(">", prev.NotAfter.Add(24*time.Hour)))
+			Expect(next.DNSNames).To(Equal([]string{"synnaxlabs.com"}))
+			Expect(next.IPAddresses).To(HaveLen(1))
+			Expect(next.IPAddresses[0].String()).To(Equal("10.0.0.1"))
+			Expect(nextKey).To(Equal(prevKey))
+			ca, _ := MustSucceed2(f.Loader.LoadCAPair())
+			Expect(next.CheckSignatureFrom(ca)).To(Succeed())
+			Expect(f.FS.Exists(f.NodeCertPath + ".tmp")).To(BeFalse())
+		})
+		It("Should not issue a node certificate that outlives the CA", func() {
+			f := MustSucceed(cert.NewFactory(cert.FactoryConfig{
+				LoaderConfig: cert.LoaderConfig{FS: fs},
+				Hosts:        []address.Address{"synnaxlabs.com"},
+				KeySize:      mock.SmallKeySize,
+				CAValidFor:   time.Hour,
+			}))
+			Expect(f.CreateCAPair()).To(Succeed())
+			Expect(f.CreateNodePair()).To(Succeed())
+			Expect(f.RenewNodePair()).To(Succeed())
+			ca, _ := MustSucceed2(f.Loader.LoadCAPair())
+			c, _ := MustSucceed2(f.Loader.LoadNodePair())
+			Expect(c.NotAfter).To(BeTemporally("<=", ca.NotAfter))
+		})
+		It("Should fail to renew a node certificate that does not exist", func() {
+			f := MustSucceed(cert.NewFactory(cert.FactoryConfig{
+				LoaderConfig: cert.LoaderConfig{FS: fs},
+				KeySize:      mock.SmallKeySize,
+			}))
+			Expect(f.CreateCAPair()).To(Succeed())
+			Expect(f.RenewNodePair()).To(MatchError(ContainSubstring("node certificate not found")))
+		})
+	})
+	Describe("Client Generation", func() {
+		It("Should generate a client certificate for a user", func() {
+			f := MustSucceed(cert.NewFactory(cert.FactoryConfig{
+				LoaderConfig: cert.LoaderConfig{FS: fs},
+				KeySize:      mock.SmallKeySize,
+			}))
+			Expect(f.CreateCAPair()).To(Succeed())
+			Expect(f.CreateClientPair("rack-1")).To(Succeed())
+			c, k := MustSucceed2(f.Loader.LoadClientPair("rack-1"))
+			Expect(k).ToNot(BeNil())
+			Expect(c.Subject.CommonName).To(Equal("rack-1"))
+			Expect(c.ExtKeyUsage).To(Equal([]x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}))
+			ca, _ := MustSucceed2(f.Loader.LoadCAPair())
+			Expect(c.CheckSignatureFrom(ca)).To(Succeed())
+		})
+		It("Should not allow a username that is not a valid file name", func() {
+			f := MustSucceed(cert.NewFactory(cert.FactoryConfig{
+				LoaderConfig: cert.LoaderConfig{FS: fs},
+				KeySize:      mock.SmallKeySize,
+			}))
+			Expect(f.CreateCAPair()).To(Succeed())
+			Expect(f.CreateClientPair("../node")).To(MatchError(ContainSubstring("invalid client username")))
+		})
+		It("Should fail to generate a client cert if no CA is present", func() {
+			f := MustSucceed(cert.NewFactory(cert.FactoryConfig{
+				LoaderConfig: cert.LoaderConfig{FS: fs},
+				KeySize:      mock.SmallKeySize,
+			}))
+			Expect(f.CreateClientPair("rack-1")).To(MatchError(ContainSubstring("CA certificate not found")))
+		})
+	})
 })
@@ -24,6 +24,7 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"strings"
 )
 
 // LoaderConfig is the configuration for creating a new Loader.
@@ -160,6 +161,36 @@ func (l *Loader) LoadNodeTLS() (c *tls.Certificate, err error) {
 	return
 }
 
+// ClientCertPath returns the path of the client certificate for the user with the
+// given username, relative to CertsDir.
+func ClientCertPath(username string) string { return "client." + username + ".crt" }
+
+// ClientKeyPath returns the path of the client private key for the user with the
+// given username, relative to CertsDir.
+func ClientKeyPath(username string) string { return "client." + username + ".key" }
+
+// validateClientUsername checks that the given username can be used to name the files
+// of a client certificate.
+func validateClientUsername(username string) error {
+	if username == "" || strings.ContainsAny(username, `/\`) || strings.HasPrefix(username, ".") {
+		return errors.Wrapf(validate.Error, "[cert] - invalid client username %q", username)
+	}
+	return nil
+}
+
+// LoadClientPair loads the client certificate and its private key for the user with
+// the given username.
+func (l *Loader) LoadClientPair(username string) (c *x509.Certificate, k crypto.PrivateKey, err error) {
+	if err = validateClientUsername(username); err != nil {
+		return nil, nil, err
+	}
+	c, k, err = l.loadX509(ClientCertPath(username), ClientKeyPath(username))
+	if errors.Is(err, fs.ErrNotExist) {
+		err = errors.Wrapf(err, "client certificate not found")
+	}
+	return
+}
+
 func (l *Loader) loadX509(certPath, keyPath string) (*x509.Certificate, crypto.PrivateKey, error) {
 	c, err := l.loadTLS(certPath, keyPath)
 	if err != nil {
@@ -19,11 +19,10 @@ import (
 
 const (
 	validFrom    = -time.Hour * 24
-	validFor     = time.Hour * 24 * 365
 	caCommonName = "Synnax CA"
 )
 
-func newBasex509() (*x509.Certificate, error) {
+func newBasex509(validFor time.Duration) (*x509.Certificate, error) {
 	sn, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
 	cert := &x509.Certificate{
 		SerialNumber: sn,
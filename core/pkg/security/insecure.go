@@ -14,6 +14,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/tls"
+	"crypto/x509"
 )
 
 // insecureProvider is an implementation of Provider for use in insecure clusters.
@@ -31,3 +32,7 @@ func (p *insecureProvider) TLS() *tls.Config { return nil }
 
 // NodePrivate implements KeyProvider.
 func (p *insecureProvider) NodePrivate() crypto.PrivateKey { return p.nodeSecret }
+
+func (p *insecureProvider) NodeCert() *x509.Certificate { return nil }
+
+func (p *insecureProvider) ReloadNodeCert() error { return nil }
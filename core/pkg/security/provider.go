@@ -12,6 +12,7 @@ package security
 import (
 	"crypto"
 	"crypto/tls"
+	"crypto/x509"
 
 	"github.com/samber/lo"
 	"github.com/synnaxlabs/synnax/pkg/security/cert"
@@ -38,9 +39,21 @@ type KeyProvider interface {
 // Provider provides security information and services for the node. It's important to note
 // that Provider itself does not implement any security mechanisms, but rather provides
 // configuration and information for other components to implement them.
+type CertProvider interface {
+	// NodeCert returns the node's TLS certificate, or nil if the node is running in
+	// insecure mode.
+	NodeCert() *x509.Certificate
+	// ReloadNodeCert reloads the node's certificate and key from disk. TLS handshakes
+	// that start after ReloadNodeCert returns use the reloaded certificate, both when
+	// the node serves connections and when it dials its peers. If the certificate
+	// fails to load, the node keeps using its current one.
+	ReloadNodeCert() error
+}
+
 type Provider interface {
 	TLSProvider
 	KeyProvider
+	CertProvider
 }
 
 // ProviderConfig is the configuration for creating a new Provider.
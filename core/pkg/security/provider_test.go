@@ -70,6 +70,45 @@ var _ = Describe("OtelProvider", func() {
 			})
 
 		})
+		Describe("Reload", func() {
+			It("Should serve the reloaded node certificate to existing TLS configurations", func() {
+				fs := xfs.NewMem()
+				mock.GenerateCerts(fs)
+				prov := MustSucceed(security.NewProvider(security.ProviderConfig{
+					LoaderConfig: cert.LoaderConfig{FS: fs},
+					KeySize:      mock.SmallKeySize,
+					Insecure:     config.False(),
+				}))
+				cfg := prov.TLS()
+				prev := prov.NodeCert()
+				Expect(prev).ToNot(BeNil())
+				f := MustSucceed(cert.NewFactory(cert.FactoryConfig{
+					LoaderConfig: cert.LoaderConfig{FS: fs},
+					KeySize:      mock.SmallKeySize,
+				}))
+				Expect(f.RenewNodePair()).To(Succeed())
+				Expect(prov.ReloadNodeCert()).To(Succeed())
+				next := prov.NodeCert()
+				Expect(next.SerialNumber).ToNot(Equal(prev.SerialNumber))
+				c := MustSucceed(cfg.GetCertificate(&tls.ClientHelloInfo{}))
+				Expect(c.Leaf.SerialNumber).To(Equal(next.SerialNumber))
+				c = MustSucceed(cfg.GetClientCertificate(&tls.CertificateRequestInfo{}))
+				Expect(c.Leaf.SerialNumber).To(Equal(next.SerialNumber))
+			})
+			It("Should keep the current certificate if the reload fails", func() {
+				fs := xfs.NewMem()
+				mock.GenerateCerts(fs)
+				prov := MustSucceed(security.NewProvider(security.ProviderConfig{
+					LoaderConfig: cert.LoaderConfig{FS: fs},
+					KeySize:      mock.SmallKeySize,
+					Insecure:     config.False(),
+				}))
+				prev := prov.NodeCert()
+				Expect(fs.Remove(cert.DefaultLoaderConfig.AbsoluteNodeCertPath())).To(Succeed())
+				Expect(prov.ReloadNodeCert()).To(HaveOccurredAs(os.ErrNotExist))
+				Expect(prov.NodeCert()).To(Equal(prev))
+			})
+		})
 	})
 	Describe("Insecure", func() {
 		Describe("TLS Properties", func() {
@@ -79,6 +118,8 @@ var _ = Describe("OtelProvider", func() {
 					KeySize:  mock.SmallKeySize,
 				}))
 				Expect(prov.TLS()).To(BeNil())
+				Expect(prov.NodeCert()).To(BeNil())
+				Expect(prov.ReloadNodeCert()).To(Succeed())
 			})
 		})
 		Describe("Node Private", func() {
@@ -0,0 +1,197 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package security
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/synnaxlabs/alamos"
+	"github.com/synnaxlabs/synnax/pkg/security/cert"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/validate"
+	"go.uber.org/zap"
+)
+
+// RotatorConfig is the configuration for a Rotator.
+type RotatorConfig struct {
+	// Instrumentation is used for logging, tracing, and metrics.
+	alamos.Instrumentation
+	// Provider is the provider whose node certificate is rotated.
+	//
+	// [REQUIRED]
+	Provider Provider
+	// Factory is used to reissue the node certificate from the CA.
+	//
+	// [REQUIRED]
+	Factory *cert.Factory
+	// RenewBefore is how long before the node certificate expires that it is
+	// reissued. It must be shorter than the validity of the certificates issued by
+	// Factory, or the certificate would be reissued on every check.
+	//
+	// [OPTIONAL] - Defaults to 30 days
+	RenewBefore time.Duration
+	// CheckInterval is the interval at which the expiry of the node certificate is
+	// checked.
+	//
+	// [OPTIONAL] - Defaults to 1h
+	CheckInterval time.Duration
+}
+
+var (
+	_ config.Config[RotatorConfig] = RotatorConfig{}
+	// DefaultRotatorConfig is the default configuration for a Rotator.
+	DefaultRotatorConfig = RotatorConfig{
+		RenewBefore:   time.Hour * 24 * 30,
+		CheckInterval: time.Hour,
+	}
+)
+
+// Override implements config.Config.
+func (c RotatorConfig) Override(other RotatorConfig) RotatorConfig {
+	c.Instrumentation = override.Zero(c.Instrumentation, other.Instrumentation)
+	c.Provider = override.Nil(c.Provider, other.Provider)
+	c.Factory = override.Nil(c.Factory, other.Factory)
+	c.RenewBefore = override.Numeric(c.RenewBefore, other.RenewBefore)
+	c.CheckInterval = override.Numeric(c.CheckInterval, other.CheckInterval)
+	return c
+}
+
+// Validate implements config.Config.
+func (c RotatorConfig) Validate() error {
+	v := validate.New("security.Rotator")
+	validate.NotNil(v, "Provider", c.Provider)
+	validate.NotNil(v, "Factory", c.Factory)
+	validate.Positive(v, "RenewBefore", c.RenewBefore)
+	validate.Positive(v, "CheckInterval", c.CheckInterval)
+	if c.Factory != nil {
+		validate.LessThan(v, "RenewBefore", c.RenewBefore, c.Factory.ValidFor)
+	}
+	return v.Error()
+}
+
+// Rotator periodically checks the expiry of the node certificate, reissuing it from
+// the CA before it expires and hot-reloading it into the Provider so that the node
+// never has to restart. Certificates replaced on disk by an operator are picked up on
+// the next check.
+type Rotator struct {
+	cfg      RotatorConfig
+	stop     chan struct{}
+	shutdown io.Closer
+}
+
+// OpenRotator opens a new Rotator using the provided configuration, checking the node
+// certificate once before returning. If OpenRotator succeeds, the Rotator must be shut
+// down by calling Close after use.
+func OpenRotator(cfgs ...RotatorConfig) (*Rotator, error) {
+	cfg, err := config.New(DefaultRotatorConfig, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	r := &Rotator{cfg: cfg, stop: make(chan struct{})}
+	if err = r.Rotate(); err != nil {
+		cfg.L.Error("failed to rotate node certificate", zap.Error(err))
+	}
+	sCtx, cancel := signal.Isolated(signal.WithInstrumentation(cfg.Instrumentation))
+	sCtx.Go(r.run, signal.WithKey("rotate"))
+	r.shutdown = signal.NewGracefulShutdown(sCtx, cancel)
+	return r, nil
+}
+
+func (r *Rotator) run(ctx context.Context) error {
+	t := time.NewTicker(r.cfg.CheckInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-r.stop:
+			return nil
+		case <-t.C:
+			if err := r.Rotate(); err != nil {
+				r.cfg.L.Error("failed to rotate node certificate", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Rotate reloads the node certificate from disk and reissues it if it expires within
+// RenewBefore. The certificate is not reissued if the new certificate would not expire
+// later than the current one, which happens when the CA is about to expire. Rotate is safe to call concurrently with TLS handshakes.
+func (r *Rotator) Rotate() error {
+	if err := r.cfg.Provider.ReloadNodeCert(); err != nil {
+		r.cfg.L.Warn("failed to reload node certificate", zap.Error(err))
+	}
+	r.checkCA()
+	c := r.cfg.Provider.NodeCert()
+	if c == nil {
+		return nil
+	}
+	if time.Until(c.NotAfter) > r.cfg.RenewBefore {
+		return nil
+	}
+	notAfter, err := r.cfg.Factory.RenewedNodeNotAfter()
+	if err != nil {
+		return err
+	}
+	// Node certificates never outlive the CA, so once the CA is about to expire, a
+	// reissued certificate would expire no later than the current one.
+	if !notAfter.After(c.NotAfter) {
+		r.cfg.L.Warn(
+			"node certificate is about to expire and cannot be renewed until the CA certificate is replaced",
+			zap.Time("expires", c.NotAfter),
+		)
+		return nil
+	}
+	r.cfg.L.Info("renewing node certificate", zap.Time("expires", c.NotAfter))
+	if err := r.cfg.Factory.RenewNodePair(); err != nil {
+		return errors.Wrapf(err, "failed to renew node certificate expiring at %s", c.NotAfter)
+	}
+	if err := r.cfg.Provider.ReloadNodeCert(); err != nil {
+		return err
+	}
+	r.cfg.L.Info(
+		"renewed node certificate",
+		zap.Time("expires", r.cfg.Provider.NodeCert().NotAfter),
+	)
+	return nil
+}
+
+// checkCA warns when a CA certificate expires within RenewBefore, as CA certificates
+// are not reissued automatically.
+func (r *Rotator) checkCA() {
+	cas, err := r.cfg.Factory.Loader.LoadCAs()
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			r.cfg.L.Warn("failed to load CA certificates", zap.Error(err))
+		}
+		return
+	}
+	for _, ca := range cas {
+		if time.Until(ca.NotAfter) <= r.cfg.RenewBefore {
+			r.cfg.L.Warn(
+				"CA certificate is about to expire and must be replaced manually",
+				zap.String("subject", ca.Subject.String()),
+				zap.Time("expires", ca.NotAfter),
+			)
+		}
+	}
+}
+
+// Close stops the Rotator, waiting for its internal goroutines to exit.
+func (r *Rotator) Close() error {
+	close(r.stop)
+	return r.shutdown.Close()
+}
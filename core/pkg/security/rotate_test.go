@@ -0,0 +1,115 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package security_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/security"
+	"github.com/synnaxlabs/synnax/pkg/security/cert"
+	"github.com/synnaxlabs/synnax/pkg/security/mock"
+	"github.com/synnaxlabs/x/address"
+	"github.com/synnaxlabs/x/config"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Rotator", func() {
+	var (
+		prov    security.Provider
+		factory *cert.Factory
+	)
+	BeforeEach(func() {
+		fs := xfs.NewMem()
+		mock.GenerateCerts(fs)
+		prov = MustSucceed(security.NewProvider(security.ProviderConfig{
+			LoaderConfig: cert.LoaderConfig{FS: fs},
+			KeySize:      mock.SmallKeySize,
+			Insecure:     config.False(),
+		}))
+		factory = MustSucceed(cert.NewFactory(cert.FactoryConfig{
+			LoaderConfig: cert.LoaderConfig{FS: fs},
+			KeySize:      mock.SmallKeySize,
+			ValidFor:     time.Hour * 24 * 800,
+		}))
+	})
+	It("Should renew a node certificate that is about to expire", func() {
+		prev := prov.NodeCert()
+		r := MustSucceed(security.OpenRotator(security.RotatorConfig{
+			Provider:    prov,
+			Factory:     factory,
+			RenewBefore: time.Hour * 24 * 400,
+		}))
+		next := prov.NodeCert()
+		Expect(next.SerialNumber).ToNot(Equal(prev.SerialNumber))
+		Expect(next.NotAfter).To(BeTemporally(">", prev.NotAfter))
+		Expect(r.Rotate()).To(Succeed())
+		Expect(prov.NodeCert().SerialNumber).To(Equal(next.SerialNumber))
+		Expect(r.Close()).To(Succeed())
+	})
+	It("Should not renew a node certificate that is not about to expire", func() {
+		prev := prov.NodeCert()
+		r := MustSucceed(security.OpenRotator(security.RotatorConfig{
+			Provider: prov,
+			Factory:  factory,
+		}))
+		Expect(r.Rotate()).To(Succeed())
+		Expect(prov.NodeCert().SerialNumber).To(Equal(prev.SerialNumber))
+		Expect(r.Close()).To(Succeed())
+	})
+	It("Should not renew a node certificate that cannot outlive the CA", func() {
+		fs := xfs.NewMem()
+		factory = MustSucceed(cert.NewFactory(cert.FactoryConfig{
+			LoaderConfig: cert.LoaderConfig{FS: fs},
+			KeySize:      mock.SmallKeySize,
+			ValidFor:     time.Hour * 24 * 365,
+			Hosts:        []address.Address{"localhost:26260"},
+			CAValidFor:   time.Hour * 24 * 100,
+		}))
+		Expect(factory.CreateCAPair()).To(Succeed())
+		Expect(factory.CreateNodePair()).To(Succeed())
+		prov = MustSucceed(security.NewProvider(security.ProviderConfig{
+			LoaderConfig: cert.LoaderConfig{FS: fs},
+			KeySize:      mock.SmallKeySize,
+			Insecure:     config.False(),
+		}))
+		prev := prov.NodeCert()
+		r := MustSucceed(security.OpenRotator(security.RotatorConfig{
+			Provider:    prov,
+			Factory:     factory,
+			RenewBefore: time.Hour * 24 * 200,
+		}))
+		Expect(r.Rotate()).To(Succeed())
+		Expect(prov.NodeCert().SerialNumber).To(Equal(prev.SerialNumber))
+		Expect(r.Close()).To(Succeed())
+	})
+	It("Should renew the node certificate periodically", func() {
+		prev := prov.NodeCert()
+		r := MustSucceed(security.OpenRotator(security.RotatorConfig{
+			Provider:      prov,
+			Factory:       factory,
+			CheckInterval: 10 * time.Millisecond,
+		}))
+		Expect(factory.RenewNodePair()).To(Succeed())
+		Eventually(func() any {
+			return prov.NodeCert().SerialNumber
+		}).ShouldNot(Equal(prev.SerialNumber))
+		Expect(r.Close()).To(Succeed())
+	})
+	It("Should not allow renewing certificates before they are issued", func() {
+		Expect(security.OpenRotator(security.RotatorConfig{
+			Provider:    prov,
+			Factory:     factory,
+			RenewBefore: factory.ValidFor,
+		})).Error().To(MatchError(ContainSubstring("renew_before")))
+	})
+})
@@ -14,6 +14,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"os"
+	"sync"
 
 	"github.com/synnaxlabs/synnax/pkg/security/cert"
 	"github.com/synnaxlabs/x/errors"
@@ -23,8 +24,11 @@ import (
 type secureProvider struct {
 	ProviderConfig
 	loader   *cert.Loader
-	tls      *tls.Certificate
 	certPool *x509.CertPool
+	mu       struct {
+		sync.RWMutex
+		tls *tls.Certificate
+	}
 }
 
 func newSecureProvider(cfg ProviderConfig) (Provider, error) {
@@ -40,8 +44,7 @@ func newSecureProvider(cfg ProviderConfig) (Provider, error) {
 	for _, ca := range cas {
 		p.certPool.AddCert(ca)
 	}
-	p.tls, err = l.LoadNodeTLS()
-	if err != nil {
+	if err = p.ReloadNodeCert(); err != nil {
 		return nil, err
 	}
 	return p, nil
@@ -83,12 +86,38 @@ func (p *secureProvider) TLS() *tls.Config {
 }
 
 // NodePrivate implements KeyProvider.
-func (p *secureProvider) NodePrivate() crypto.PrivateKey { return p.tls.PrivateKey }
+func (p *secureProvider) NodePrivate() crypto.PrivateKey { return p.current().PrivateKey }
+
+// NodeCert implements CertProvider.
+func (p *secureProvider) NodeCert() *x509.Certificate { return p.current().Leaf }
+
+// ReloadNodeCert implements CertProvider.
+func (p *secureProvider) ReloadNodeCert() error {
+	c, err := p.loader.LoadNodeTLS()
+	if err != nil {
+		return err
+	}
+	if c.Leaf == nil {
+		if c.Leaf, err = x509.ParseCertificate(c.Certificate[0]); err != nil {
+			return err
+		}
+	}
+	p.mu.Lock()
+	p.mu.tls = c
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *secureProvider) current() *tls.Certificate {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.mu.tls
+}
 
 func (p *secureProvider) getClientCert(info *tls.CertificateRequestInfo) (*tls.Certificate, error) {
-	return p.tls, nil
+	return p.current(), nil
 }
 
 func (p *secureProvider) getCert(info *tls.ClientHelloInfo) (*tls.Certificate, error) {
-	return p.tls, nil
+	return p.current(), nil
 }
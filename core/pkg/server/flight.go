@@ -0,0 +1,65 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package server
+
+import (
+	"github.com/apache/arrow-go/v18/arrow/flight"
+	"github.com/cockroachdb/cmux"
+	"google.golang.org/grpc"
+)
+
+// FlightBranch is a Branch that serves Apache Arrow Flight traffic. Flight runs over
+// gRPC, and gRPC clients wait for the server's settings before sending their first
+// request, so Flight connections can't be told apart from other gRPC connections on a
+// shared listener. The FlightBranch matches all connections, and should be served by
+// its own Server.
+type FlightBranch struct {
+	// Service is the Flight service the Branch will serve.
+	Service flight.FlightServer
+	// Middleware is a list of middleware applied to every Flight request.
+	Middleware []flight.ServerMiddleware
+	server     *grpc.Server
+}
+
+var _ Branch = (*FlightBranch)(nil)
+
+// Routing implements Branch.
+func (f *FlightBranch) Routing() BranchRouting {
+	return BranchRouting{
+		Policy:   ServeAlwaysPreferSecure,
+		Matchers: []cmux.Matcher{cmux.Any()},
+	}
+}
+
+// Key implements Branch.
+func (f *FlightBranch) Key() string { return "flight" }
+
+// Serve implements Branch.
+func (f *FlightBranch) Serve(ctx BranchContext) error {
+	opts := []grpc.ServerOption{grpcCredentials(ctx)}
+	for _, m := range f.Middleware {
+		if m.Unary != nil {
+			opts = append(opts, grpc.ChainUnaryInterceptor(m.Unary))
+		}
+		if m.Stream != nil {
+			opts = append(opts, grpc.ChainStreamInterceptor(m.Stream))
+		}
+	}
+	f.server = grpc.NewServer(opts...)
+	flight.RegisterFlightServiceServer(f.server, f.Service)
+	return f.server.Serve(ctx.Lis)
+}
+
+// Stop implements Branch. Stop is safe to call even if Serve has not been called.
+func (f *FlightBranch) Stop() {
+	if f.server != nil {
+		f.server.Stop()
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package server_test
+
+import (
+	"context"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/flight"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/server"
+	"github.com/synnaxlabs/x/config"
+	. "github.com/synnaxlabs/x/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// echoFlightServer responds to DoGet requests with a single record batch holding the
+// length of the ticket.
+type echoFlightServer struct{ flight.BaseFlightServer }
+
+func (*echoFlightServer) DoGet(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+	schema := arrow.NewSchema([]arrow.Field{{Name: "len", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).Append(int64(len(tkt.Ticket)))
+	rec := b.NewRecordBatch()
+	defer rec.Release()
+	w := flight.NewRecordWriter(stream, ipc.WithSchema(schema))
+	if err := w.Write(rec); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+var _ = Describe("Flight", func() {
+	It("Should serve Flight requests", func() {
+		b := MustSucceed(server.Serve(server.Config{
+			ListenAddress: "localhost:26261",
+			Security:      server.SecurityConfig{Insecure: config.True()},
+			Debug:         config.False(),
+			Branches: []server.Branch{
+				&server.FlightBranch{Service: &echoFlightServer{}},
+			},
+		}))
+		defer func() { Expect(b.Close()).To(Succeed()) }()
+		ctx := context.Background()
+
+		client := MustSucceed(flight.NewClientWithMiddlewareCtx(
+			ctx,
+			"localhost:26261",
+			nil,
+			nil,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		))
+		defer func() { Expect(client.Close()).To(Succeed()) }()
+		stream := MustSucceed(client.DoGet(ctx, &flight.Ticket{Ticket: []byte("abc")}))
+		r := MustSucceed(flight.NewRecordReader(stream))
+		defer r.Release()
+		Expect(r.Next()).To(BeTrue())
+		Expect(r.RecordBatch().Column(0).(*array.Int64).Value(0)).To(Equal(int64(3)))
+	})
+})
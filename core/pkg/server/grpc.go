@@ -38,7 +38,7 @@ func (g *GRPCBranch) Key() string { return "grpc" }
 
 // Serve implements Branch.
 func (g *GRPCBranch) Serve(ctx BranchContext) error {
-	opts := []grpc.ServerOption{g.credentials(ctx)}
+	opts := []grpc.ServerOption{grpcCredentials(ctx)}
 	g.server = grpc.NewServer(opts...)
 	for _, t := range g.Transports {
 		t.BindTo(g.server)
@@ -49,7 +49,7 @@ func (g *GRPCBranch) Serve(ctx BranchContext) error {
 // Stop implements Branch. Stop is safe to call even if Serve has not been called.
 func (g *GRPCBranch) Stop() { g.server.Stop() }
 
-func (g *GRPCBranch) credentials(ctx BranchContext) grpc.ServerOption {
+func grpcCredentials(ctx BranchContext) grpc.ServerOption {
 	if *ctx.Security.Insecure {
 		return grpc.Creds(insecure.NewCredentials())
 	}
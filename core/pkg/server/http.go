@@ -10,6 +10,8 @@
 package server
 
 import (
+	"crypto/tls"
+	"net"
 	"time"
 
 	"github.com/cockroachdb/cmux"
@@ -52,9 +54,41 @@ func (b *SecureHTTPBranch) Serve(ctx BranchContext) error {
 	for _, t := range b.Transports {
 		t.BindTo(b.internal)
 	}
-	return b.internal.Listener(ctx.Lis)
+	return b.internal.Listener(tlsStateListener{ctx.Lis})
 }
 
+// tlsStateListener exposes the TLS connection state of connections accepted from a
+// TLS multiplexer, which would otherwise be hidden behind the multiplexer's connection
+// wrapper. This allows HTTP handlers to inspect the client certificates of requests.
+type tlsStateListener struct{ net.Listener }
+
+// Accept implements net.Listener.
+func (l tlsStateListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return c, err
+	}
+	if mc, ok := c.(*cmux.MuxConn); ok {
+		if tc, ok := mc.Conn.(*tls.Conn); ok {
+			return tlsMuxConn{MuxConn: mc, tls: tc}, nil
+		}
+	}
+	return c, nil
+}
+
+// tlsMuxConn is a multiplexed connection whose underlying connection is a TLS
+// connection.
+type tlsMuxConn struct {
+	*cmux.MuxConn
+	tls *tls.Conn
+}
+
+// Handshake runs the TLS handshake of the underlying connection.
+func (c tlsMuxConn) Handshake() error { return c.tls.Handshake() }
+
+// ConnectionState returns the TLS connection state of the underlying connection.
+func (c tlsMuxConn) ConnectionState() tls.ConnectionState { return c.tls.ConnectionState() }
+
 // Stop	implements Branch. Stop is safe to call even if Serve has not been called.
 func (b *SecureHTTPBranch) Stop() {
 	if b.internal != nil {
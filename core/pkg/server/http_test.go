@@ -11,13 +11,22 @@ package server_test
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"net/http"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/freighter"
 	"github.com/synnaxlabs/freighter/fhttp"
+	"github.com/synnaxlabs/synnax/pkg/security"
+	"github.com/synnaxlabs/synnax/pkg/security/cert"
+	"github.com/synnaxlabs/synnax/pkg/security/mock"
 	"github.com/synnaxlabs/synnax/pkg/server"
 	"github.com/synnaxlabs/x/config"
+	xfs "github.com/synnaxlabs/x/io/fs"
 	. "github.com/synnaxlabs/x/testutil"
 )
 
@@ -53,3 +62,74 @@ var _ = Describe("HTTP", func() {
 		Expect(b.Close()).To(Succeed())
 	})
 })
+
+var _ = Describe("Client Certificates", func() {
+	var (
+		fs     xfs.FS
+		prov   security.Provider
+		b      *server.Server
+		client *http.Client
+	)
+	BeforeEach(func() {
+		fs = xfs.NewMem()
+		mock.GenerateCerts(fs)
+		f := MustSucceed(cert.NewFactory(cert.FactoryConfig{
+			LoaderConfig: cert.LoaderConfig{FS: fs},
+			KeySize:      mock.SmallKeySize,
+		}))
+		Expect(f.CreateClientPair("rack-1")).To(Succeed())
+		prov = MustSucceed(security.NewProvider(security.ProviderConfig{
+			LoaderConfig: cert.LoaderConfig{FS: fs},
+			KeySize:      mock.SmallKeySize,
+			Insecure:     config.False(),
+		}))
+		c, k := MustSucceed2(f.Loader.LoadClientPair("rack-1"))
+		client = &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true,
+			Certificates:       []tls.Certificate{{Certificate: [][]byte{c.Raw}, PrivateKey: k}},
+		}}}
+	})
+	AfterEach(func() { Expect(b.Close()).To(Succeed()) })
+	serve := func(clientCAs *x509.CertPool) {
+		r := fhttp.NewRouter()
+		fhttp.UnaryServer[int, string](r, "/cn").BindHandler(func(ctx context.Context, _ int) (string, error) {
+			sec := freighter.MDFromContext(ctx).Sec
+			if len(sec.TLS.VerifiedChains) == 0 {
+				return "", nil
+			}
+			return sec.TLS.VerifiedChains[0][0].Subject.CommonName, nil
+		})
+		b = MustSucceed(server.Serve(server.Config{
+			ListenAddress: "localhost:26260",
+			Security: server.SecurityConfig{
+				Insecure:  config.False(),
+				TLS:       prov.TLS(),
+				ClientCAs: clientCAs,
+			},
+			Branches: []server.Branch{
+				&server.SecureHTTPBranch{Transports: []fhttp.BindableTransport{r}},
+			},
+		}))
+	}
+	commonName := func() string {
+		var cn string
+		Eventually(func(g Gomega) {
+			res, err := client.Post("https://localhost:26260/cn", "application/json", strings.NewReader("1"))
+			g.Expect(err).ToNot(HaveOccurred())
+			defer func() { g.Expect(res.Body.Close()).To(Succeed()) }()
+			g.Expect(res.StatusCode).To(Equal(http.StatusOK))
+			g.Expect(json.NewDecoder(res.Body).Decode(&cn)).To(Succeed())
+		}).Should(Succeed())
+		return cn
+	}
+
+	It("Should expose verified client certificates to handlers", func() {
+		serve(prov.TLS().ClientCAs)
+		Expect(commonName()).To(Equal("rack-1"))
+	})
+
+	It("Should not request client certificates when no client CAs are configured", func() {
+		serve(nil)
+		Expect(commonName()).To(BeEmpty())
+	})
+})
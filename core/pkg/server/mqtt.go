@@ -0,0 +1,106 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package server
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/cockroachdb/cmux"
+	mqtt "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/listeners"
+)
+
+// MQTTBranch is a Branch that serves MQTT traffic using an embedded broker. MQTT
+// clients send a CONNECT packet as soon as they open a connection, so the branch can
+// share a listener with the other branches.
+type MQTTBranch struct {
+	// Broker is the broker the Branch will serve connections on. The broker is closed
+	// when the Branch is stopped.
+	Broker   *mqtt.Server
+	initOnce sync.Once
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+var _ Branch = (*MQTTBranch)(nil)
+
+// Routing implements Branch.
+func (m *MQTTBranch) Routing() BranchRouting {
+	return BranchRouting{
+		Policy:   ServeAlwaysPreferSecure,
+		Matchers: []cmux.Matcher{mqttConnectMatcher},
+	}
+}
+
+// Key implements Branch.
+func (m *MQTTBranch) Key() string { return "mqtt" }
+
+// Serve implements Branch.
+func (m *MQTTBranch) Serve(ctx BranchContext) error {
+	m.init()
+	if err := m.Broker.AddListener(listeners.NewNet(m.Key(), ctx.Lis)); err != nil {
+		return err
+	}
+	if err := m.Broker.Serve(); err != nil {
+		return err
+	}
+	<-m.done
+	return nil
+}
+
+// Stop implements Branch. Stop is safe to call even if Serve has not been called.
+func (m *MQTTBranch) Stop() {
+	m.init()
+	m.stopOnce.Do(func() {
+		close(m.done)
+		_ = m.Broker.Close()
+	})
+}
+
+func (m *MQTTBranch) init() {
+	m.initOnce.Do(func() { m.done = make(chan struct{}) })
+}
+
+// mqttConnectMatcher matches connections that open with an MQTT 3.1, 3.1.1, or 5
+// CONNECT packet.
+func mqttConnectMatcher(r io.Reader) bool {
+	var b [1]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil || b[0] != 0x10 {
+		return false
+	}
+	// The fixed header is followed by a variable length integer of up to four bytes
+	// holding the remaining length of the packet.
+	for i := 0; ; i++ {
+		if i == 4 {
+			return false
+		}
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return false
+		}
+		if b[0]&0x80 == 0 {
+			break
+		}
+	}
+	var length [2]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		return false
+	}
+	n := int(length[0])<<8 | int(length[1])
+	if n != 4 && n != 6 {
+		return false
+	}
+	name := make([]byte, n)
+	if _, err := io.ReadFull(r, name); err != nil {
+		return false
+	}
+	return bytes.Equal(name, []byte("MQTT")) || bytes.Equal(name, []byte("MQIsdp"))
+}
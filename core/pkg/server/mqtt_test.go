@@ -0,0 +1,65 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package server_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	mqtt "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/server"
+	"github.com/synnaxlabs/x/config"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("MQTT", func() {
+	It("Should serve MQTT alongside HTTP on the same listener", func() {
+		broker := mqtt.New(&mqtt.Options{
+			Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		})
+		Expect(broker.AddHook(new(auth.AllowHook), nil)).To(Succeed())
+		b := MustSucceed(server.Serve(server.Config{
+			ListenAddress: "localhost:26262",
+			Security:      server.SecurityConfig{Insecure: config.True()},
+			Debug:         config.False(),
+			Branches: []server.Branch{
+				&server.MQTTBranch{Broker: broker},
+				server.NewSimpleHTTPBranch(
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusTeapot)
+					}),
+					server.ServeAlwaysPreferInsecure,
+				),
+			},
+		}))
+		defer func() { Expect(b.Close()).To(Succeed()) }()
+
+		received := make(chan string, 1)
+		client := paho.NewClient(paho.NewClientOptions().
+			AddBroker("tcp://localhost:26262").
+			SetClientID("test"))
+		Expect(client.Connect().WaitTimeout(5 * time.Second)).To(BeTrue())
+		defer client.Disconnect(0)
+		Expect(client.Subscribe("echo", 0, func(_ paho.Client, msg paho.Message) {
+			received <- string(msg.Payload())
+		}).WaitTimeout(5 * time.Second)).To(BeTrue())
+		Expect(client.Publish("echo", 0, false, "hello").WaitTimeout(5 * time.Second)).To(BeTrue())
+		Eventually(received).Should(Receive(Equal("hello")))
+
+		res := MustSucceed(http.Get("http://localhost:26262/"))
+		Expect(res.StatusCode).To(Equal(http.StatusTeapot))
+	})
+})
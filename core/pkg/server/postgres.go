@@ -0,0 +1,153 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/cockroachdb/cmux"
+	"github.com/samber/lo"
+)
+
+// PostgresHandler handles connections that speak the PostgreSQL wire protocol.
+type PostgresHandler interface {
+	// Handle serves the connection until the client disconnects or the context is
+	// cancelled. If tlsConfig is not nil, the handler should upgrade the connection to
+	// TLS when the client requests it. Handle is responsible for closing the
+	// connection.
+	Handle(ctx context.Context, conn net.Conn, tlsConfig *tls.Config)
+}
+
+// PostgresBranch is a Branch that serves PostgreSQL wire protocol traffic. Postgres
+// clients open a connection with a plaintext startup packet and then ask to upgrade
+// the connection to TLS, so the Branch is always served without TLS and leaves the
+// upgrade to its handler.
+type PostgresBranch struct {
+	// Handler is the handler the Branch will pass accepted connections to.
+	Handler  PostgresHandler
+	initOnce sync.Once
+	stopOnce sync.Once
+	mu       sync.Mutex
+	lis      net.Listener
+	conns    map[net.Conn]struct{}
+	cancel   context.CancelFunc
+	ctx      context.Context
+	wg       sync.WaitGroup
+}
+
+var _ Branch = (*PostgresBranch)(nil)
+
+// Routing implements Branch.
+func (p *PostgresBranch) Routing() BranchRouting {
+	return BranchRouting{
+		Policy:   ServeAlwaysPreferInsecure,
+		Matchers: []cmux.Matcher{postgresStartupMatcher},
+	}
+}
+
+// Key implements Branch.
+func (p *PostgresBranch) Key() string { return "postgres" }
+
+// Serve implements Branch.
+func (p *PostgresBranch) Serve(ctx BranchContext) error {
+	p.init()
+	p.mu.Lock()
+	p.lis = ctx.Lis
+	p.mu.Unlock()
+	var tlsConfig *tls.Config
+	if ctx.Security.Insecure != nil && !*ctx.Security.Insecure {
+		tlsConfig = ctx.Security.TLS
+	}
+	for {
+		conn, err := ctx.Lis.Accept()
+		if err != nil {
+			p.wg.Wait()
+			if p.ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if !p.track(conn) {
+			_ = conn.Close()
+			continue
+		}
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			defer p.untrack(conn)
+			p.Handler.Handle(p.ctx, conn, tlsConfig)
+		}()
+	}
+}
+
+// Stop implements Branch. Stop is safe to call even if Serve has not been called.
+func (p *PostgresBranch) Stop() {
+	p.init()
+	p.stopOnce.Do(func() {
+		p.cancel()
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.lis != nil {
+			_ = p.lis.Close()
+		}
+		for conn := range p.conns {
+			_ = conn.Close()
+		}
+	})
+}
+
+func (p *PostgresBranch) init() {
+	p.initOnce.Do(func() {
+		p.conns = make(map[net.Conn]struct{})
+		p.ctx, p.cancel = context.WithCancel(context.Background())
+	})
+}
+
+// track adds the connection to the set of open connections, returning false if the
+// Branch has been stopped.
+func (p *PostgresBranch) track(conn net.Conn) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ctx.Err() != nil {
+		return false
+	}
+	p.conns[conn] = struct{}{}
+	return true
+}
+
+func (p *PostgresBranch) untrack(conn net.Conn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.conns, conn)
+}
+
+// postgresRequestCodes are the codes that may follow the length of the first packet
+// a Postgres client sends: a version 3.0 startup message, or an SSL, GSSAPI
+// encryption, or cancel request.
+var postgresRequestCodes = []uint32{196608, 80877103, 80877104, 80877102}
+
+// postgresStartupMatcher matches connections that open with a Postgres startup
+// packet.
+func postgresStartupMatcher(r io.Reader) bool {
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return false
+	}
+	length := binary.BigEndian.Uint32(b[:4])
+	if length < 8 || length > 10000 {
+		return false
+	}
+	return lo.Contains(postgresRequestCodes, binary.BigEndian.Uint32(b[4:]))
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package server_test
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/server"
+	"github.com/synnaxlabs/x/config"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+// sslRefuser refuses SSL requests and echoes every byte it receives afterwards.
+type sslRefuser struct{}
+
+func (sslRefuser) Handle(_ context.Context, conn net.Conn, _ *tls.Config) {
+	defer func() { _ = conn.Close() }()
+	var req [8]byte
+	if _, err := io.ReadFull(conn, req[:]); err != nil {
+		return
+	}
+	if _, err := conn.Write([]byte{'N'}); err != nil {
+		return
+	}
+	_, _ = io.Copy(conn, conn)
+}
+
+var _ = Describe("Postgres", func() {
+	It("Should serve Postgres connections alongside HTTP on the same listener", func() {
+		b := MustSucceed(server.Serve(server.Config{
+			ListenAddress: "localhost:26263",
+			Security:      server.SecurityConfig{Insecure: config.True()},
+			Debug:         config.False(),
+			Branches: []server.Branch{
+				&server.PostgresBranch{Handler: sslRefuser{}},
+				server.NewSimpleHTTPBranch(
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusTeapot)
+					}),
+					server.ServeAlwaysPreferInsecure,
+				),
+			},
+		}))
+		defer func() { Expect(b.Close()).To(Succeed()) }()
+
+		conn := MustSucceed(net.Dial("tcp", "localhost:26263"))
+		defer func() { Expect(conn.Close()).To(Succeed()) }()
+		var req [8]byte
+		binary.BigEndian.PutUint32(req[:4], 8)
+		binary.BigEndian.PutUint32(req[4:], 80877103)
+		MustSucceed(conn.Write(req[:]))
+		var res [1]byte
+		MustSucceed(io.ReadFull(conn, res[:]))
+		Expect(res[0]).To(Equal(byte('N')))
+		MustSucceed(conn.Write([]byte("ping")))
+		echo := make([]byte, 4)
+		MustSucceed(io.ReadFull(conn, echo))
+		Expect(string(echo)).To(Equal("ping"))
+
+		httpRes := MustSucceed(http.Get("http://localhost:26263/"))
+		Expect(httpRes.StatusCode).To(Equal(http.StatusTeapot))
+	})
+})
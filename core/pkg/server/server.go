@@ -12,6 +12,7 @@ package server
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"io"
 	"net"
 
@@ -60,11 +61,28 @@ type SecurityConfig struct {
 	Insecure *bool
 	// TLS is the TLS configuration for the server.
 	TLS *tls.Config
+	// ClientCAs is the pool of certificate authorities that API clients can
+	// authenticate with certificates signed by. If set, the server requests a
+	// certificate from clients connecting over TLS, and verifies it if one is given.
+	// Clients that do not present a certificate are still accepted. If nil, client
+	// certificates are not requested.
+	ClientCAs *x509.CertPool
 }
 
 // Report implements the alamos.ReportProvider interface.
 func (s SecurityConfig) Report() alamos.Report {
-	return alamos.Report{"insecure": *s.Insecure}
+	return alamos.Report{"insecure": *s.Insecure, "client_cert_auth": s.ClientCAs != nil}
+}
+
+// serverTLS returns the TLS configuration used to terminate TLS connections.
+func (s SecurityConfig) serverTLS() *tls.Config {
+	if s.ClientCAs == nil {
+		return s.TLS
+	}
+	cfg := s.TLS.Clone()
+	cfg.ClientAuth = tls.VerifyClientCertIfGiven
+	cfg.ClientCAs = s.ClientCAs
+	return cfg
 }
 
 var (
@@ -85,6 +103,7 @@ func (c Config) Override(other Config) Config {
 	c.ListenAddress = override.String(c.ListenAddress, other.ListenAddress)
 	c.Security.Insecure = override.Nil(c.Security.Insecure, other.Security.Insecure)
 	c.Security.TLS = override.Nil(c.Security.TLS, other.Security.TLS)
+	c.Security.ClientCAs = override.Nil(c.Security.ClientCAs, other.Security.ClientCAs)
 	c.Branches = override.Slice(c.Branches, other.Branches)
 	c.Debug = override.Nil(c.Debug, other.Debug)
 	c.Instrumentation = override.Zero(c.Instrumentation, other.Instrumentation)
@@ -147,9 +166,18 @@ func (s *Server) Close() error {
 }
 
 func (s *Server) serveSecure(sCtx signal.Context, root cmux.CMux) error {
+	// Route plaintext HTTP/1 traffic along with the traffic of any branches that are
+	// served without TLS to the insecure mux. Branches served without TLS, such as the
+	// Postgres branch, may negotiate TLS themselves.
+	insecureMatchers := []cmux.Matcher{cmux.HTTP1Fast()}
+	for _, b := range s.Branches {
+		if b.Routing().Policy.ShouldServe(false, true) {
+			insecureMatchers = append(insecureMatchers, b.Routing().Matchers...)
+		}
+	}
 	var (
-		insecure = cmux.New(root.Match(cmux.HTTP1Fast()))
-		secure   = cmux.New(tls.NewListener(root.Match(cmux.Any()), s.Security.TLS))
+		insecure = cmux.New(root.Match(insecureMatchers...))
+		secure   = cmux.New(tls.NewListener(root.Match(cmux.Any()), s.Security.serverTLS()))
 	)
 
 	s.startBranches(sCtx, secure /*insecureMux*/, false)
@@ -12,7 +12,11 @@ package access
 type Action string
 
 const (
-	All      Action = "all"
+	All Action = "all"
+	// Control is the action of writing live data to channels and taking control of
+	// them. It is distinct from Create so that access to actuate hardware can be
+	// granted separately from access to create data.
+	Control  Action = "control"
 	Create   Action = "create"
 	Delete   Action = "delete"
 	Retrieve Action = "retrieve"
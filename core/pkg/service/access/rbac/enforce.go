@@ -12,19 +12,243 @@ package rbac
 import (
 	"context"
 
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
 	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/x/set"
 )
 
 var _ access.Enforcer = (*Service)(nil)
 
 // Enforce implements the access.Enforcer interface.
 func (s *Service) Enforce(ctx context.Context, req access.Request) error {
-	var policies []Policy
-	if err := s.NewRetrieve().Entries(&policies).WhereSubjects(req.Subject).Exec(ctx, s.DB); err != nil {
+	e, err := s.evaluate(ctx, req, false)
+	if err != nil {
 		return err
 	}
-	if allowRequest(req, policies) {
+	if e.Allowed {
 		return access.Granted
 	}
 	return access.Denied
 }
+
+// Decision is the outcome of evaluating an access.Request for one of its objects.
+type Decision struct {
+	// Object is the object the decision was made for.
+	Object ontology.ID `json:"object" msgpack:"object"`
+	// Allowed is true if the subject may take the requested action on the object.
+	Allowed bool `json:"allowed" msgpack:"allowed"`
+	// Policy is the key of the policy that allowed or denied access to the object. It
+	// is uuid.Nil if no policy applies to the object, in which case access is denied
+	// by default.
+	Policy uuid.UUID `json:"policy" msgpack:"policy"`
+	// Via is the ancestor of the object that an inherited policy applies to. It is
+	// zero if the policy applies to the object directly.
+	Via ontology.ID `json:"via" msgpack:"via"`
+}
+
+// Explanation reports why an access.Request was allowed or denied.
+type Explanation struct {
+	// Allowed is true if the request is allowed.
+	Allowed bool `json:"allowed" msgpack:"allowed"`
+	// Subjects are the subjects the request was evaluated for: the subject of the
+	// request along with the groups it belongs to and the roles assigned to it.
+	Subjects []ontology.ID `json:"subjects" msgpack:"subjects"`
+	// Decisions holds a decision for each object in the request.
+	Decisions []Decision `json:"decisions" msgpack:"decisions"`
+}
+
+// Explain evaluates the request in the same way as Enforce, and reports the policy
+// that allowed or denied access to each of its objects.
+func (s *Service) Explain(ctx context.Context, req access.Request) (Explanation, error) {
+	return s.evaluate(ctx, req, true)
+}
+
+// policyIndex indexes the policies that apply to a request by the objects they apply
+// to, so that each requested object can be decided in constant time.
+type policyIndex struct {
+	all       []Policy
+	exact     map[ontology.ID][]Policy
+	types     map[ontology.Type][]Policy
+	inherited map[ontology.ID][]Policy
+}
+
+func newPolicyIndex(req access.Request, policies []Policy) policyIndex {
+	idx := policyIndex{
+		exact:     make(map[ontology.ID][]Policy),
+		types:     make(map[ontology.Type][]Policy),
+		inherited: make(map[ontology.ID][]Policy),
+	}
+	for _, p := range policies {
+		if !p.appliesTo(req.Action) {
+			continue
+		}
+		for _, o := range p.Objects {
+			switch {
+			case o.Type == AllowAllOntologyType:
+				idx.all = append(idx.all, p)
+			case o.IsType():
+				idx.types[o.Type] = append(idx.types[o.Type], p)
+			default:
+				idx.exact[o] = append(idx.exact[o], p)
+				if p.Inherit {
+					idx.inherited[o] = append(idx.inherited[o], p)
+				}
+			}
+		}
+	}
+	return idx
+}
+
+// decide decides access to an object, preferring policies that deny access over those
+// that allow it.
+func (idx policyIndex) decide(o ontology.ID, ancestors []ontology.ID) Decision {
+	d := Decision{Object: o}
+	consider := func(policies []Policy, via ontology.ID) bool {
+		for _, p := range policies {
+			if p.denies() {
+				d = Decision{Object: o, Policy: p.Key, Via: via}
+				return true
+			}
+			if !d.Allowed {
+				d = Decision{Object: o, Allowed: true, Policy: p.Key, Via: via}
+			}
+		}
+		return false
+	}
+	if consider(idx.all, ontology.ID{}) ||
+		consider(idx.types[o.Type], ontology.ID{}) ||
+		consider(idx.exact[o], ontology.ID{}) {
+		return d
+	}
+	for _, a := range ancestors {
+		if consider(idx.inherited[a], a) {
+			return d
+		}
+	}
+	return d
+}
+
+func (s *Service) evaluate(
+	ctx context.Context,
+	req access.Request,
+	explain bool,
+) (Explanation, error) {
+	mem, err := s.members(ctx, req.Subject)
+	if err != nil {
+		return Explanation{}, err
+	}
+	subjects := mem.subjects
+	var policies []Policy
+	if err = s.NewRetrieve().
+		WhereSubjects(subjects...).
+		Entries(&policies).
+		Exec(ctx, s.DB); err != nil {
+		return Explanation{}, err
+	}
+	if mem.hasRoles {
+		// Policies that allow entire types of subjects, such as the base policies
+		// that apply to all users, are defaults for subjects that haven't been
+		// assigned a role. Subjects with roles are only granted the policies of their
+		// roles and the policies that name them or their groups directly. Deny
+		// policies on types of subjects still apply to subjects with roles.
+		policies = lo.Filter(policies, func(p Policy, _ int) bool {
+			return p.denies() || lo.Some(p.Subjects, subjects)
+		})
+	}
+	var (
+		idx       = newPolicyIndex(req, policies)
+		ancestors map[ontology.ID][]ontology.ID
+	)
+	// Looking up the ancestors of objects reads the ontology, so only do it if an
+	// inherited policy could apply.
+	if len(idx.inherited) > 0 {
+		if ancestors, err = s.objectAncestors(ctx, req.Objects); err != nil {
+			return Explanation{}, err
+		}
+	}
+	e := Explanation{Allowed: true, Subjects: subjects}
+	decided := make(set.Set[ontology.ID], len(req.Objects))
+	for _, o := range req.Objects {
+		if decided.Contains(o) {
+			continue
+		}
+		decided.Add(o)
+		d := idx.decide(o, ancestors[o])
+		if !d.Allowed {
+			e.Allowed = false
+			if !explain {
+				return e, nil
+			}
+		}
+		if explain {
+			e.Decisions = append(e.Decisions, d)
+		}
+	}
+	return e, nil
+}
+
+// members returns the subject along with the groups it belongs to and the roles
+// assigned to it or its groups.
+func (s *Service) members(ctx context.Context, subject ontology.ID) (members, error) {
+	mem := members{subjects: []ontology.ID{subject}}
+	if subject.IsZero() {
+		return mem, nil
+	}
+	cached, ok, version := s.membership.cached(subject)
+	if ok {
+		return cached, nil
+	}
+	ancestors, err := s.membership.ancestors(ctx, s.DB, mem.subjects)
+	if err != nil {
+		return members{}, err
+	}
+	mem.subjects = append(mem.subjects, ancestors[subject]...)
+	var roles []Role
+	if err = s.NewRoleRetrieve().
+		WhereSubjects(mem.subjects...).
+		Entries(&roles).
+		Exec(ctx, s.DB); err != nil {
+		return members{}, err
+	}
+	mem.subjects = append(mem.subjects, RoleOntologyIDsFromRoles(roles)...)
+	mem.hasRoles = len(roles) > 0
+	s.membership.cache(subject, mem, version)
+	return mem, nil
+}
+
+// objectAncestors returns the ancestors of each object in the ontology. Objects whose
+// types have a proxy are treated as children of the resource they stand in for.
+func (s *Service) objectAncestors(
+	ctx context.Context,
+	objects []ontology.ID,
+) (map[ontology.ID][]ontology.ID, error) {
+	resources := make([]ontology.ID, 0, len(objects))
+	for _, o := range objects {
+		resources = append(resources, s.resource(o))
+	}
+	ancestors, err := s.membership.ancestors(ctx, s.DB, resources)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[ontology.ID][]ontology.ID, len(objects))
+	for _, o := range objects {
+		r := s.resource(o)
+		if r == o {
+			out[o] = ancestors[o]
+		} else {
+			out[o] = append([]ontology.ID{r}, ancestors[r]...)
+		}
+	}
+	return out, nil
+}
+
+// resource returns the ontology resource that the object stands in for, or the object
+// itself if its type has no proxy.
+func (s *Service) resource(o ontology.ID) ontology.ID {
+	if t, ok := s.Proxies[o.Type]; ok {
+		return ontology.ID{Type: t, Key: o.Key}
+	}
+	return o
+}
@@ -13,9 +13,11 @@ import (
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
 	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
 	"github.com/synnaxlabs/synnax/pkg/service/access"
 	"github.com/synnaxlabs/synnax/pkg/service/access/rbac"
+	"github.com/synnaxlabs/synnax/pkg/service/framer"
 	"github.com/synnaxlabs/synnax/pkg/service/user"
 	"github.com/synnaxlabs/x/gorp"
 	"github.com/synnaxlabs/x/kv/memkv"
@@ -224,4 +226,136 @@ var _ = Describe("enforcer", func() {
 			})).To(Succeed())
 		})
 	})
+
+	Describe("Enforce - channel data", func() {
+		var (
+			operator = user.OntologyID(uuid.New())
+			keys     = make(channel.Keys, 500)
+		)
+		BeforeEach(func() {
+			for i := range keys {
+				keys[i] = channel.NewKey(1, channel.LocalKey(i+1))
+			}
+			Expect(writer.Create(ctx, &rbac.Policy{
+				Subjects: []ontology.ID{operator},
+				Objects:  []ontology.ID{{Type: framer.OntologyType}},
+				Actions:  []access.Action{access.Retrieve},
+			})).To(Succeed())
+			Expect(writer.Create(ctx, &rbac.Policy{
+				Subjects: []ontology.ID{operator},
+				Objects:  framer.OntologyIDs(keys[:10]),
+				Actions:  []access.Action{access.Control},
+			})).To(Succeed())
+		})
+		It("Should allow retrieving the data of many channels through a type policy", func() {
+			Expect(svc.Enforce(ctx, access.Request{
+				Subject: operator,
+				Objects: framer.OntologyIDs(keys),
+				Action:  access.Retrieve,
+			})).To(Succeed())
+		})
+		It("Should allow controlling channels with a control policy", func() {
+			Expect(svc.Enforce(ctx, access.Request{
+				Subject: operator,
+				Objects: framer.OntologyIDs(append(keys[:10:10], keys[0])),
+				Action:  access.Control,
+			})).To(Succeed())
+		})
+		It("Should deny controlling channels with only a retrieve policy", func() {
+			Expect(svc.Enforce(ctx, access.Request{
+				Subject: operator,
+				Objects: framer.OntologyIDs(keys[5:15]),
+				Action:  access.Control,
+			})).To(Equal(access.Denied))
+		})
+	})
+
+	Describe("Deny and inheritance", func() {
+		var (
+			operator    = user.OntologyID(uuid.New())
+			plant       = ontology.ID{Type: "group", Key: "plant"}
+			restricted  = ontology.ID{Type: "group", Key: "restricted"}
+			pump, valve = channel.OntologyID(1), channel.OntologyID(2)
+			allow, deny rbac.Policy
+		)
+		BeforeEach(func() {
+			for _, rel := range []ontology.Relationship{
+				{From: plant, To: pump, Type: ontology.ParentOf},
+				{From: plant, To: restricted, Type: ontology.ParentOf},
+				{From: restricted, To: valve, Type: ontology.ParentOf},
+			} {
+				Expect(gorp.NewCreate[[]byte, ontology.Relationship]().Entry(&rel).Exec(ctx, db)).To(Succeed())
+			}
+			svc = MustSucceed(rbac.NewService(rbac.Config{
+				DB:      db,
+				Proxies: map[ontology.Type]ontology.Type{framer.OntologyType: channel.OntologyType},
+			}))
+			allow = rbac.Policy{
+				Subjects: []ontology.ID{operator},
+				Objects:  []ontology.ID{plant},
+				Actions:  []access.Action{access.Control},
+				Inherit:  true,
+			}
+			deny = rbac.Policy{
+				Subjects: []ontology.ID{operator},
+				Objects:  []ontology.ID{restricted},
+				Actions:  []access.Action{access.Control},
+				Effect:   rbac.Deny,
+				Inherit:  true,
+			}
+			Expect(writer.Create(ctx, &allow)).To(Succeed())
+			Expect(writer.Create(ctx, &deny)).To(Succeed())
+		})
+		It("Should apply inherited policies to descendants and the data of channels", func() {
+			Expect(svc.Enforce(ctx, access.Request{
+				Subject: operator,
+				Objects: framer.OntologyIDs(channel.Keys{1}),
+				Action:  access.Control,
+			})).To(Succeed())
+		})
+		It("Should prefer policies that deny access", func() {
+			Expect(svc.Enforce(ctx, access.Request{
+				Subject: operator,
+				Objects: framer.OntologyIDs(channel.Keys{1, 2}),
+				Action:  access.Control,
+			})).To(MatchError(access.Denied))
+		})
+		It("Should explain which policy decided access to each object", func() {
+			e := MustSucceed(svc.Explain(ctx, access.Request{
+				Subject: operator,
+				Objects: []ontology.ID{pump, valve, userID},
+				Action:  access.Control,
+			}))
+			Expect(e.Allowed).To(BeFalse())
+			Expect(e.Decisions).To(Equal([]rbac.Decision{
+				{Object: pump, Allowed: true, Policy: allow.Key, Via: plant},
+				{Object: valve, Policy: deny.Key, Via: restricted},
+				{Object: userID},
+			}))
+		})
+		It("Should apply deny policies on the type of a subject to subjects with roles", func() {
+			r := rbac.Role{Name: "operator"}
+			Expect(writer.CreateRole(ctx, &r)).To(Succeed())
+			Expect(writer.AssignRole(ctx, r.Key, operator)).To(Succeed())
+			Expect(writer.Create(ctx, &rbac.Policy{
+				Subjects: []ontology.ID{r.OntologyID()},
+				Objects:  []ontology.ID{plant},
+				Actions:  []access.Action{access.Control},
+				Inherit:  true,
+			})).To(Succeed())
+			req := access.Request{
+				Subject: operator,
+				Objects: framer.OntologyIDs(channel.Keys{1}),
+				Action:  access.Control,
+			}
+			Expect(svc.Enforce(ctx, req)).To(Succeed())
+			Expect(writer.Create(ctx, &rbac.Policy{
+				Subjects: []ontology.ID{user.OntologyTypeID},
+				Objects:  []ontology.ID{{Type: framer.OntologyType}},
+				Actions:  []access.Action{access.Control},
+				Effect:   rbac.Deny,
+			})).To(Succeed())
+			Expect(svc.Enforce(ctx, req)).To(MatchError(access.Denied))
+		})
+	})
 })
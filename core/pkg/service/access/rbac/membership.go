@@ -0,0 +1,193 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package rbac
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/x/change"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/set"
+)
+
+// maxAncestorDepth is the maximum depth the ontology is searched to find the ancestors
+// of subjects and objects. It guards against cycles in malformed ontologies.
+const maxAncestorDepth = 32
+
+// members are the subjects a request is evaluated for.
+type members struct {
+	// subjects are the subject of the request along with the groups it belongs to and
+	// the roles assigned to it or its groups.
+	subjects []ontology.ID
+	// hasRoles is true if any roles are assigned to the subject or its groups.
+	hasRoles bool
+}
+
+// membership caches the parents of resources in the ontology and the groups and roles
+// of subjects, so that enforcing a request doesn't scan the relationships and roles in
+// the DB. The caches are kept up to date by observing changes to relationships and
+// roles.
+type membership struct {
+	mu sync.Mutex
+	// parents maps resources to their parents in the ontology. It is nil until it is
+	// first loaded from the DB.
+	parents map[ontology.ID][]ontology.ID
+	// members caches the members of subjects. It is cleared whenever a relationship
+	// or role changes.
+	members map[ontology.ID]members
+	// version is incremented every time members is cleared, so that members computed
+	// concurrently with a change are not cached.
+	version int
+}
+
+// onRelationshipChange updates the parents of resources when relationships change.
+func (m *membership) onRelationshipChange(
+	ctx context.Context,
+	reader gorp.TxReader[[]byte, ontology.Relationship],
+) {
+	var changes []change.Change[[]byte, ontology.Relationship]
+	for ch, ok := reader.Next(ctx); ok; ch, ok = reader.Next(ctx) {
+		if ch.Variant == change.Delete {
+			var err error
+			if ch.Value, err = ontology.ParseRelationship(ch.Key); err != nil {
+				continue
+			}
+		}
+		if ch.Value.Type == ontology.ParentOf {
+			changes = append(changes, ch)
+		}
+	}
+	if len(changes) == 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.invalidateLocked()
+	if m.parents == nil {
+		return
+	}
+	for _, ch := range changes {
+		rel := ch.Value
+		parents := m.parents[rel.To]
+		if ch.Variant == change.Delete {
+			parents = lo.Without(parents, rel.From)
+		} else if !lo.Contains(parents, rel.From) {
+			parents = append(parents, rel.From)
+		}
+		if len(parents) == 0 {
+			delete(m.parents, rel.To)
+		} else {
+			m.parents[rel.To] = parents
+		}
+	}
+}
+
+// onRoleChange clears the cached members of subjects when roles change.
+func (m *membership) onRoleChange(ctx context.Context, reader gorp.TxReader[uuid.UUID, Role]) {
+	if _, ok := reader.Next(ctx); !ok {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.invalidateLocked()
+}
+
+func (m *membership) invalidateLocked() {
+	clear(m.members)
+	m.version++
+}
+
+// cached returns the cached members of the subject, along with the version of the
+// cache they were read from.
+func (m *membership) cached(subject ontology.ID) (members, bool, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mem, ok := m.members[subject]
+	return mem, ok, m.version
+}
+
+// cache caches the members of the subject, unless a relationship or role has changed
+// since version.
+func (m *membership) cache(subject ontology.ID, mem members, version int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.version != version {
+		return
+	}
+	if m.members == nil {
+		m.members = make(map[ontology.ID]members)
+	}
+	m.members[subject] = mem
+}
+
+// ancestors returns the ancestors of each of the given resources in the ontology,
+// ordered from the nearest to the furthest.
+func (m *membership) ancestors(
+	ctx context.Context,
+	db *gorp.DB,
+	resources []ontology.ID,
+) (map[ontology.ID][]ontology.ID, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.loadParentsLocked(ctx, db); err != nil {
+		return nil, err
+	}
+	out := make(map[ontology.ID][]ontology.ID, len(resources))
+	for _, r := range resources {
+		if _, ok := out[r]; ok {
+			continue
+		}
+		var (
+			ancestors []ontology.ID
+			visited   = make(set.Set[ontology.ID])
+			frontier  = []ontology.ID{r}
+		)
+		for depth := 0; len(frontier) > 0 && depth < maxAncestorDepth; depth++ {
+			var next []ontology.ID
+			for _, f := range frontier {
+				for _, p := range m.parents[f] {
+					if !visited.Contains(p) {
+						visited.Add(p)
+						ancestors = append(ancestors, p)
+						next = append(next, p)
+					}
+				}
+			}
+			frontier = next
+		}
+		out[r] = ancestors
+	}
+	return out, nil
+}
+
+// loadParentsLocked loads the parents of all resources with a single pass over the
+// relationships in the DB, if they haven't been loaded already.
+func (m *membership) loadParentsLocked(ctx context.Context, db *gorp.DB) error {
+	if m.parents != nil {
+		return nil
+	}
+	parents := make(map[ontology.ID][]ontology.ID)
+	if err := gorp.NewRetrieve[[]byte, ontology.Relationship]().
+		Where(func(rel *ontology.Relationship) bool {
+			if rel.Type == ontology.ParentOf && !lo.Contains(parents[rel.To], rel.From) {
+				parents[rel.To] = append(parents[rel.To], rel.From)
+			}
+			return false
+		}).
+		Exec(ctx, db); err != nil {
+		return err
+	}
+	m.parents = parents
+	return nil
+}
@@ -15,23 +15,41 @@ import (
 	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
 	"github.com/synnaxlabs/synnax/pkg/service/access"
 	"github.com/synnaxlabs/x/gorp"
-	"github.com/synnaxlabs/x/set"
+)
+
+// Effect is the effect a policy has on the requests it applies to.
+type Effect string
+
+const (
+	// Allow allows the requests a policy applies to.
+	Allow Effect = "allow"
+	// Deny denies the requests a policy applies to. Deny policies take precedence over
+	// allow policies.
+	Deny Effect = "deny"
 )
 
 // Policy is a simple access control policy in the RBAC model. A policy sets an action
-// that is allowed. All other accesses except for those specified by a policy are denied
-// by default.
+// that is allowed or denied. All other accesses except for those allowed by a policy
+// are denied by default, and a policy that denies an access overrides any policy that
+// allows it.
 //
 // In a policy, **Subjects do Actions on Objects**.
 type Policy struct {
 	// Key is a unique uuid to identify the policy.
 	Key uuid.UUID `json:"key" msgpack:"key"`
-	// Subjects it the list of subjects of the policy
+	// Subjects it the list of subjects of the policy. Subjects may be users, groups of
+	// users, or roles.
 	Subjects []ontology.ID `json:"subjects" msgpack:"subjects"`
 	// Objects is the list of objects that the policy applies to
 	Objects []ontology.ID `json:"objects" msgpack:"objects"`
 	// Actions is the list of actions that the policy applies to
 	Actions []access.Action `json:"actions" msgpack:"actions"`
+	// Effect is whether the policy allows or denies the requests it applies to. The
+	// zero value allows requests.
+	Effect Effect `json:"effect" msgpack:"effect"`
+	// Inherit sets whether the policy also applies to the descendants of its objects in
+	// the ontology, such as everything under a group.
+	Inherit bool `json:"inherit" msgpack:"inherit"`
 }
 
 var _ gorp.Entry[uuid.UUID] = Policy{}
@@ -42,59 +60,14 @@ func (p Policy) GorpKey() uuid.UUID { return p.Key }
 // SetOptions implements the gorp.Entry interface.
 func (p Policy) SetOptions() []any { return nil }
 
-// allowRequest returns true if the policies allow the given access.Request.
-//
-// For a request to be allowed:
-//   - The request's subject must have object-action pairs for each object specified in
-//     the request for the action specified in the request.
-//   - An object-action pair is a pair with the specified action in the request and an
-//     object that is either a type object with the correct type, or an object that
-//     exactly matches one of the requested objects.
-func allowRequest(req access.Request, policies []Policy) bool {
-	requestedObjects := set.FromSlice(req.Objects)
-
-	for _, policy := range policies {
-		if !lo.Contains(policy.Subjects, req.Subject) {
-			// Policy not directly pertaining to the requested subject.
-			shouldContinue := true
-			for _, s := range policy.Subjects {
-				if s.IsType() && s.Type == req.Subject.Type {
-					shouldContinue = false
-					break
-				}
-			}
-			if shouldContinue {
-				continue
-			}
-		}
-		if policy.Actions != nil && !lo.Contains(policy.Actions, req.Action) && !lo.Contains(policy.Actions, access.All) {
-			// If the current policy does not describe the requested action, skip the
-			// policy. Unless the policy is an AllowAll, in which case do not skip.
-			if !lo.Contains(policy.Objects, AllowAllOntologyID) {
-				continue
-			}
-		}
-
-		for _, o := range policy.Objects {
-			if o.Type == AllowAllOntologyType {
-				// If the subject has an AllowAll policy, allow all requests.
-				return true
-			}
-			if o.IsType() {
-				// If an object applies to an entire type, then all requested objects of
-				// that type may be satisfied.
-				for requestedO := range requestedObjects {
-					if requestedO.Type == o.Type {
-						delete(requestedObjects, requestedO)
-					}
-				}
-			} else {
-				// If o is one of the requested objects, it will be removed from the
-				// set. If it is not, then this is a no-op.
-				delete(requestedObjects, o)
-			}
-		}
-	}
+// denies returns true if the policy denies the requests it applies to.
+func (p Policy) denies() bool { return p.Effect == Deny }
 
-	return len(requestedObjects) == 0
+// appliesTo returns true if the policy describes the given action. A policy with no
+// actions applies to all actions, as does a policy on the AllowAll object.
+func (p Policy) appliesTo(action access.Action) bool {
+	return p.Actions == nil ||
+		lo.Contains(p.Actions, action) ||
+		lo.Contains(p.Actions, access.All) ||
+		lo.Contains(p.Objects, AllowAllOntologyID)
 }
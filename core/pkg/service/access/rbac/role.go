@@ -0,0 +1,203 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package rbac
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Role is a named set of policies that can be assigned to users and groups of users.
+// A role is granted policies by using its ontology ID as a subject of the policies, and
+// every user assigned the role, either directly or through a group, is treated as a
+// subject of those policies.
+type Role struct {
+	// Key is a unique uuid to identify the role.
+	Key uuid.UUID `json:"key" msgpack:"key"`
+	// Name is a unique, human-readable name for the role.
+	Name string `json:"name" msgpack:"name"`
+	// Description describes the purpose of the role.
+	Description string `json:"description" msgpack:"description"`
+	// Subjects are the users and groups assigned the role.
+	Subjects []ontology.ID `json:"subjects" msgpack:"subjects"`
+	// Builtin is true if the role is provisioned by the cluster. Builtin roles cannot
+	// be deleted.
+	Builtin bool `json:"builtin" msgpack:"builtin"`
+}
+
+var _ gorp.Entry[uuid.UUID] = Role{}
+
+// GorpKey implements the gorp.Entry interface.
+func (r Role) GorpKey() uuid.UUID { return r.Key }
+
+// SetOptions implements the gorp.Entry interface.
+func (r Role) SetOptions() []any { return nil }
+
+// OntologyID returns the ontology ID of the role.
+func (r Role) OntologyID() ontology.ID { return RoleOntologyID(r.Key) }
+
+const RoleOntologyType ontology.Type = "role"
+
+// RoleOntologyID returns the ontology ID of the role with the given key.
+func RoleOntologyID(k uuid.UUID) ontology.ID {
+	return ontology.ID{Type: RoleOntologyType, Key: k.String()}
+}
+
+// RoleOntologyIDs returns the ontology IDs of the roles with the given keys.
+func RoleOntologyIDs(keys []uuid.UUID) []ontology.ID {
+	return lo.Map(keys, func(k uuid.UUID, _ int) ontology.ID { return RoleOntologyID(k) })
+}
+
+// RoleOntologyIDsFromRoles returns the ontology IDs of the given roles.
+func RoleOntologyIDsFromRoles(roles []Role) []ontology.ID {
+	return lo.Map(roles, func(r Role, _ int) ontology.ID { return r.OntologyID() })
+}
+
+// RoleRetriever is used to retrieve roles.
+type RoleRetriever struct {
+	baseTx gorp.Tx
+	gorp   gorp.Retrieve[uuid.UUID, Role]
+}
+
+// NewRoleRetrieve opens a new RoleRetriever.
+func (s *Service) NewRoleRetrieve() RoleRetriever {
+	return RoleRetriever{baseTx: s.DB, gorp: gorp.NewRetrieve[uuid.UUID, Role]()}
+}
+
+// WhereKeys filters roles by their keys.
+func (r RoleRetriever) WhereKeys(keys ...uuid.UUID) RoleRetriever {
+	r.gorp = r.gorp.WhereKeys(keys...)
+	return r
+}
+
+// WhereNames filters roles by their names.
+func (r RoleRetriever) WhereNames(names ...string) RoleRetriever {
+	r.gorp = r.gorp.Where(func(role *Role) bool { return lo.Contains(names, role.Name) })
+	return r
+}
+
+// WhereSubjects filters roles to those assigned to any of the given subjects.
+func (r RoleRetriever) WhereSubjects(subjects ...ontology.ID) RoleRetriever {
+	r.gorp = r.gorp.Where(func(role *Role) bool {
+		for _, s := range role.Subjects {
+			if lo.Contains(subjects, s) {
+				return true
+			}
+		}
+		return false
+	})
+	return r
+}
+
+// Entry binds the role that the query will fill results into.
+func (r RoleRetriever) Entry(role *Role) RoleRetriever {
+	r.gorp = r.gorp.Entry(role)
+	return r
+}
+
+// Entries binds the slice that the query will fill results into.
+func (r RoleRetriever) Entries(roles *[]Role) RoleRetriever {
+	r.gorp = r.gorp.Entries(roles)
+	return r
+}
+
+// Exists returns true if any roles match the query.
+func (r RoleRetriever) Exists(ctx context.Context, tx gorp.Tx) (bool, error) {
+	return r.gorp.Exists(ctx, gorp.OverrideTx(r.baseTx, tx))
+}
+
+// Exec executes the query.
+func (r RoleRetriever) Exec(ctx context.Context, tx gorp.Tx) error {
+	return r.gorp.Exec(ctx, gorp.OverrideTx(r.baseTx, tx))
+}
+
+// CreateRole creates the given role, assigning it a key if it does not have one. Role
+// names must be unique.
+func (w Writer) CreateRole(ctx context.Context, r *Role) error {
+	v := validate.New("role")
+	validate.NotEmptyString(v, "name", r.Name)
+	if err := v.Error(); err != nil {
+		return err
+	}
+	exists, err := gorp.NewRetrieve[uuid.UUID, Role]().
+		Where(func(e *Role) bool { return e.Name == r.Name && e.Key != r.Key }).
+		Exists(ctx, w.tx)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Wrapf(validate.Error, "role with name %q already exists", r.Name)
+	}
+	if r.Key == uuid.Nil {
+		r.Key = uuid.New()
+	}
+	return gorp.NewCreate[uuid.UUID, Role]().Entry(r).Exec(ctx, w.tx)
+}
+
+// DeleteRole deletes the roles with the given keys along with the policies granted only
+// to them. Builtin roles cannot be deleted.
+func (w Writer) DeleteRole(ctx context.Context, keys ...uuid.UUID) error {
+	var roles []Role
+	if err := gorp.NewRetrieve[uuid.UUID, Role]().
+		WhereKeys(keys...).
+		Entries(&roles).
+		Exec(ctx, w.tx); err != nil && !errors.Is(err, query.NotFound) {
+		return err
+	}
+	for _, r := range roles {
+		if r.Builtin {
+			return errors.Wrapf(validate.Error, "cannot delete builtin role %q", r.Name)
+		}
+	}
+	ids := RoleOntologyIDs(keys)
+	if err := gorp.NewDelete[uuid.UUID, Policy]().
+		Where(func(p *Policy) bool {
+			return len(p.Subjects) > 0 && lo.Every(ids, p.Subjects)
+		}).
+		Exec(ctx, w.tx); err != nil {
+		return err
+	}
+	return gorp.NewDelete[uuid.UUID, Role]().WhereKeys(keys...).Exec(ctx, w.tx)
+}
+
+// AssignRole assigns the role with the given key to the given users and groups.
+func (w Writer) AssignRole(ctx context.Context, key uuid.UUID, subjects ...ontology.ID) error {
+	return w.updateRoleSubjects(ctx, key, func(existing []ontology.ID) []ontology.ID {
+		return lo.Union(existing, subjects)
+	})
+}
+
+// UnassignRole removes the role with the given key from the given users and groups.
+func (w Writer) UnassignRole(ctx context.Context, key uuid.UUID, subjects ...ontology.ID) error {
+	return w.updateRoleSubjects(ctx, key, func(existing []ontology.ID) []ontology.ID {
+		return lo.Without(existing, subjects...)
+	})
+}
+
+func (w Writer) updateRoleSubjects(
+	ctx context.Context,
+	key uuid.UUID,
+	f func([]ontology.ID) []ontology.ID,
+) error {
+	return gorp.NewUpdate[uuid.UUID, Role]().
+		WhereKeys(key).
+		Change(func(r Role) Role {
+			r.Subjects = f(r.Subjects)
+			return r
+		}).
+		Exec(ctx, w.tx)
+}
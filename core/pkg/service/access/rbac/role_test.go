@@ -0,0 +1,136 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package rbac_test
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution/group"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/access/rbac"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/kv/memkv"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Role", func() {
+	var (
+		db     *gorp.DB
+		writer rbac.Writer
+		svc    *rbac.Service
+		alice  = user.OntologyID(uuid.New())
+		team   = group.OntologyID(uuid.New())
+		valve  = ontology.ID{Type: "channel", Key: "1"}
+	)
+	BeforeEach(func() {
+		db = gorp.Wrap(memkv.New())
+		svc = MustSucceed(rbac.NewService(rbac.Config{DB: db}))
+		writer = svc.NewWriter(nil)
+	})
+	AfterEach(func() {
+		Expect(db.Close()).To(Succeed())
+	})
+	Describe("Create", func() {
+		It("Should create a role with a unique name", func() {
+			r := rbac.Role{Name: "operator"}
+			Expect(writer.CreateRole(ctx, &r)).To(Succeed())
+			Expect(r.Key).ToNot(Equal(uuid.Nil))
+			Expect(writer.CreateRole(ctx, &rbac.Role{Name: "operator"})).
+				To(MatchError(validate.Error))
+		})
+	})
+	Describe("Assign", func() {
+		It("Should assign and unassign a role", func() {
+			r := rbac.Role{Name: "operator"}
+			Expect(writer.CreateRole(ctx, &r)).To(Succeed())
+			Expect(writer.AssignRole(ctx, r.Key, alice, team)).To(Succeed())
+			var roles []rbac.Role
+			Expect(svc.NewRoleRetrieve().WhereSubjects(alice).Entries(&roles).Exec(ctx, nil)).To(Succeed())
+			Expect(roles).To(HaveLen(1))
+			Expect(roles[0].Subjects).To(ConsistOf(alice, team))
+			Expect(writer.UnassignRole(ctx, r.Key, alice)).To(Succeed())
+			roles = nil
+			Expect(svc.NewRoleRetrieve().WhereSubjects(alice).Entries(&roles).Exec(ctx, nil)).To(Succeed())
+			Expect(roles).To(BeEmpty())
+		})
+	})
+	Describe("Delete", func() {
+		It("Should delete a role along with the policies granted only to it", func() {
+			r := rbac.Role{Name: "operator"}
+			Expect(writer.CreateRole(ctx, &r)).To(Succeed())
+			onlyRole := rbac.Policy{Subjects: []ontology.ID{r.OntologyID()}, Objects: []ontology.ID{valve}}
+			shared := rbac.Policy{Subjects: []ontology.ID{r.OntologyID(), alice}, Objects: []ontology.ID{valve}}
+			Expect(writer.Create(ctx, &onlyRole)).To(Succeed())
+			Expect(writer.Create(ctx, &shared)).To(Succeed())
+			Expect(writer.DeleteRole(ctx, r.Key)).To(Succeed())
+			var policies []rbac.Policy
+			Expect(svc.NewRetrieve().Entries(&policies).Exec(ctx, nil)).To(Succeed())
+			Expect(policies).To(HaveLen(1))
+			Expect(policies[0].Key).To(Equal(shared.Key))
+		})
+		It("Should not delete a builtin role", func() {
+			r := rbac.Role{Name: "admin", Builtin: true}
+			Expect(writer.CreateRole(ctx, &r)).To(Succeed())
+			Expect(writer.DeleteRole(ctx, r.Key)).To(MatchError(validate.Error))
+		})
+	})
+	Describe("Enforce", func() {
+		It("Should grant the policies of a role to users assigned it through a group", func() {
+			r := rbac.Role{Name: "operator"}
+			Expect(writer.CreateRole(ctx, &r)).To(Succeed())
+			Expect(writer.Create(ctx, &rbac.Policy{
+				Subjects: []ontology.ID{r.OntologyID()},
+				Objects:  []ontology.ID{valve},
+				Actions:  []access.Action{access.Control},
+			})).To(Succeed())
+			req := access.Request{Subject: alice, Objects: []ontology.ID{valve}, Action: access.Control}
+			Expect(svc.Enforce(ctx, req)).To(MatchError(access.Denied))
+			Expect(writer.AssignRole(ctx, r.Key, team)).To(Succeed())
+			Expect(svc.Enforce(ctx, req)).To(MatchError(access.Denied))
+			Expect(gorp.NewCreate[[]byte, ontology.Relationship]().Entry(&ontology.Relationship{
+				From: team,
+				To:   alice,
+				Type: ontology.ParentOf,
+			}).Exec(ctx, db)).To(Succeed())
+			Expect(svc.Enforce(ctx, req)).To(Succeed())
+			Expect(gorp.NewDelete[[]byte, ontology.Relationship]().WhereKeys(ontology.Relationship{
+				From: team,
+				To:   alice,
+				Type: ontology.ParentOf,
+			}.GorpKey()).Exec(ctx, db)).To(Succeed())
+			Expect(svc.Enforce(ctx, req)).To(MatchError(access.Denied))
+		})
+		It("Should only apply policies on the type of a subject to subjects without roles", func() {
+			base := rbac.Policy{
+				Subjects: []ontology.ID{user.OntologyTypeID},
+				Objects:  []ontology.ID{{Type: "channel"}},
+				Actions:  []access.Action{access.All},
+			}
+			Expect(writer.Create(ctx, &base)).To(Succeed())
+			req := access.Request{Subject: alice, Objects: []ontology.ID{valve}, Action: access.Create}
+			Expect(svc.Enforce(ctx, req)).To(Succeed())
+			r := rbac.Role{Name: "viewer"}
+			Expect(writer.CreateRole(ctx, &r)).To(Succeed())
+			Expect(writer.Create(ctx, &rbac.Policy{
+				Subjects: []ontology.ID{r.OntologyID()},
+				Objects:  []ontology.ID{{Type: "channel"}},
+				Actions:  []access.Action{access.Retrieve},
+			})).To(Succeed())
+			Expect(writer.AssignRole(ctx, r.Key, alice)).To(Succeed())
+			Expect(svc.Enforce(ctx, req)).To(MatchError(access.Denied))
+			req.Action = access.Retrieve
+			Expect(svc.Enforce(ctx, req)).To(Succeed())
+		})
+	})
+})
@@ -11,14 +11,22 @@ package rbac
 
 import (
 	"github.com/google/uuid"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
 	"github.com/synnaxlabs/x/config"
 	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/observe"
 	"github.com/synnaxlabs/x/override"
 	"github.com/synnaxlabs/x/validate"
 )
 
 type Config struct {
 	DB *gorp.DB
+	// Proxies maps the types of objects that are not in the ontology to the types of
+	// the ontology resources they stand in for, so that inherited policies on the
+	// ancestors of a resource also apply to the objects. For example, framer objects
+	// stand in for the data of channels.
+	// [OPTIONAL]
+	Proxies map[ontology.Type]ontology.Type
 }
 
 var (
@@ -29,6 +37,7 @@ var (
 // Override implements [config.Config].
 func (c Config) Override(other Config) Config {
 	c.DB = override.Nil(c.DB, other.DB)
+	c.Proxies = override.Nil(c.Proxies, other.Proxies)
 	return c
 }
 
@@ -41,6 +50,8 @@ func (c Config) Validate() error {
 
 type Service struct {
 	Config
+	membership membership
+	disconnect []observe.Disconnect
 }
 
 func NewService(configs ...Config) (*Service, error) {
@@ -48,7 +59,21 @@ func NewService(configs ...Config) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Service{Config: cfg}, nil
+	s := &Service{Config: cfg}
+	s.disconnect = []observe.Disconnect{
+		gorp.Observe[[]byte, ontology.Relationship](cfg.DB).OnChange(s.membership.onRelationshipChange),
+		gorp.Observe[uuid.UUID, Role](cfg.DB).OnChange(s.membership.onRoleChange),
+	}
+	return s, nil
+}
+
+// Close stops the service from observing changes to the ontology and roles. It should
+// be called when the service is no longer needed.
+func (s *Service) Close() error {
+	for _, d := range s.disconnect {
+		d()
+	}
+	return nil
 }
 
 func (s *Service) NewWriter(tx gorp.Tx) Writer {
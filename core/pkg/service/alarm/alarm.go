@@ -0,0 +1,234 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package alarm
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/status"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/types"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Type is the type of rule an alarm evaluates.
+type Type string
+
+const (
+	// TypeLimit activates when a channel's value rises above High or falls below Low.
+	TypeLimit Type = "limit"
+	// TypeRateOfChange activates when the absolute rate of change of a channel's value
+	// exceeds MaxRate units per second.
+	TypeRateOfChange Type = "rate_of_change"
+	// TypeStale activates when a channel has not received a new value within
+	// StaleAfter.
+	TypeStale Type = "stale"
+	// TypeBoolean activates when a channel's value is truthy (non-zero) and ActiveWhen
+	// is true, or falsy and ActiveWhen is false.
+	TypeBoolean Type = "boolean"
+)
+
+// Alarm is a rule evaluated against the live values of a channel.
+type Alarm struct {
+	// Key is the unique identifier for the alarm.
+	Key uuid.UUID `json:"key" msgpack:"key"`
+	// Name is a human-readable name for the alarm.
+	Name string `json:"name" msgpack:"name"`
+	// Channel is the channel the alarm is evaluated against.
+	Channel channel.Key `json:"channel" msgpack:"channel"`
+	// Type is the type of rule the alarm evaluates.
+	Type Type `json:"type" msgpack:"type"`
+	// Severity is the status variant published when the alarm is active. Defaults to
+	// status.WarningVariant.
+	Severity status.Variant `json:"severity" msgpack:"severity"`
+	// High is the upper limit for TypeLimit alarms. A nil value means no upper limit.
+	High *float64 `json:"high" msgpack:"high"`
+	// Low is the lower limit for TypeLimit alarms. A nil value means no lower limit.
+	Low *float64 `json:"low" msgpack:"low"`
+	// MaxRate is the maximum absolute rate of change, in units per second, for
+	// TypeRateOfChange alarms.
+	MaxRate float64 `json:"max_rate" msgpack:"max_rate"`
+	// StaleAfter is how long a channel can go without new data before a TypeStale
+	// alarm activates.
+	StaleAfter telem.TimeSpan `json:"stale_after" msgpack:"stale_after"`
+	// ActiveWhen is the state of the channel that activates a TypeBoolean alarm.
+	ActiveWhen bool `json:"active_when" msgpack:"active_when"`
+	// Deadband is the distance a value must move back inside a limit (or below
+	// MaxRate) before an active alarm clears. This prevents alarms from chattering
+	// when a value hovers around a limit.
+	Deadband float64 `json:"deadband" msgpack:"deadband"`
+	// Latching alarms stay active after their condition clears until they are
+	// acknowledged.
+	Latching bool `json:"latching" msgpack:"latching"`
+}
+
+var _ gorp.Entry[uuid.UUID] = Alarm{}
+
+// GorpKey implements gorp.Entry.
+func (a Alarm) GorpKey() uuid.UUID { return a.Key }
+
+// SetOptions implements gorp.Entry.
+func (a Alarm) SetOptions() []any { return nil }
+
+// Validate checks that the alarm's rule is well-formed.
+func (a Alarm) Validate() error {
+	v := validate.New("alarm")
+	validate.NotEmptyString(v, "name", a.Name)
+	validate.NonZero(v, "channel", a.Channel)
+	validate.GreaterThanEq(v, "deadband", a.Deadband, 0)
+	switch a.Type {
+	case TypeLimit:
+		v.Ternary("high", a.High == nil && a.Low == nil, "limit alarms must set a high or low limit")
+		v.Ternary(
+			"low",
+			a.High != nil && a.Low != nil && *a.Low > *a.High,
+			"low limit must be less than or equal to the high limit",
+		)
+	case TypeRateOfChange:
+		validate.Positive(v, "max_rate", a.MaxRate)
+	case TypeStale:
+		validate.Positive(v, "stale_after", a.StaleAfter)
+	case TypeBoolean:
+	default:
+		v.Ternaryf("type", true, "invalid alarm type %q", a.Type)
+	}
+	return v.Error()
+}
+
+// Violated returns true if the value and rate of change (in units per second) violate
+// the alarm's rule. active is whether the alarm is currently active, and is used to
+// apply the deadband: an active alarm only clears once the value has moved Deadband
+// back inside the limit. Violated always returns false for TypeStale alarms, which are
+// evaluated against the time since the last sample.
+func (a Alarm) Violated(active bool, value, rate float64) bool {
+	db := 0.0
+	if active {
+		db = a.Deadband
+	}
+	switch a.Type {
+	case TypeLimit:
+		return (a.High != nil && value > *a.High-db) || (a.Low != nil && value < *a.Low+db)
+	case TypeRateOfChange:
+		return math.Abs(rate) > a.MaxRate-db
+	case TypeBoolean:
+		return (value != 0) == a.ActiveWhen
+	default:
+		return false
+	}
+}
+
+// StatusDetails are the alarm-specific details of a Status.
+type StatusDetails struct {
+	// Alarm is the key of the alarm the status is for.
+	Alarm uuid.UUID `json:"alarm" msgpack:"alarm"`
+	// Channel is the channel the alarm is evaluated against.
+	Channel channel.Key `json:"channel" msgpack:"channel"`
+	// Active is true if the alarm's condition is violated, or if the alarm is latched
+	// and has not been acknowledged.
+	Active bool `json:"active" msgpack:"active"`
+	// Acknowledged is true if an operator has acknowledged the alarm since it last
+	// activated.
+	Acknowledged bool `json:"acknowledged" msgpack:"acknowledged"`
+	// ShelvedUntil is the time until which the alarm is shelved. A zero value means
+	// the alarm is not shelved.
+	ShelvedUntil telem.TimeStamp `json:"shelved_until" msgpack:"shelved_until"`
+	// Value is the value of the alarm's channel at the most recent status change.
+	Value float64 `json:"value" msgpack:"value"`
+}
+
+// Shelved returns true if the alarm is shelved at the given time.
+func (d StatusDetails) Shelved(now telem.TimeStamp) bool {
+	return d.ShelvedUntil.After(now)
+}
+
+// Status is the current state of an alarm.
+type Status status.Status[StatusDetails]
+
+var (
+	_ gorp.Entry[uuid.UUID] = Status{}
+	_ types.CustomTypeName  = (*Status)(nil)
+)
+
+// GorpKey implements gorp.Entry.
+func (s Status) GorpKey() uuid.UUID { return s.Details.Alarm }
+
+// SetOptions implements gorp.Entry.
+func (s Status) SetOptions() []any { return nil }
+
+// CustomTypeName implements types.CustomTypeName to ensure that the Status struct does
+// not conflict with any other types in gorp.
+func (s Status) CustomTypeName() string { return "AlarmStatus" }
+
+// EventKind is the kind of change recorded in the alarm log.
+type EventKind string
+
+const (
+	// EventActivated is recorded when an alarm becomes active.
+	EventActivated EventKind = "activated"
+	// EventCleared is recorded when an alarm stops being active.
+	EventCleared EventKind = "cleared"
+	// EventAcknowledged is recorded when an operator acknowledges an alarm.
+	EventAcknowledged EventKind = "acknowledged"
+	// EventShelved is recorded when an operator shelves an alarm.
+	EventShelved EventKind = "shelved"
+	// EventUnshelved is recorded when a shelved alarm is un-shelved, either by an
+	// operator or because the shelve period expired.
+	EventUnshelved EventKind = "unshelved"
+)
+
+// Event is an entry in the alarm log.
+type Event struct {
+	// Key is the unique identifier for the event.
+	Key uuid.UUID `json:"key" msgpack:"key"`
+	// Alarm is the key of the alarm the event is for.
+	Alarm uuid.UUID `json:"alarm" msgpack:"alarm"`
+	// Kind is the kind of event.
+	Kind EventKind `json:"kind" msgpack:"kind"`
+	// Time is the time at which the event occurred.
+	Time telem.TimeStamp `json:"time" msgpack:"time"`
+	// Value is the value of the alarm's channel at the time of the event.
+	Value float64 `json:"value" msgpack:"value"`
+	// Message is a human-readable description of the event.
+	Message string `json:"message" msgpack:"message"`
+	// Subject is the entity that caused the event. This is empty for events recorded
+	// by the alarm service itself.
+	Subject ontology.ID `json:"subject" msgpack:"subject"`
+	// ShelvedUntil is the end of the shelve period for EventShelved events.
+	ShelvedUntil telem.TimeStamp `json:"shelved_until" msgpack:"shelved_until"`
+}
+
+var (
+	_ gorp.Entry[uuid.UUID] = Event{}
+	_ types.CustomTypeName  = (*Event)(nil)
+)
+
+// GorpKey implements gorp.Entry.
+func (e Event) GorpKey() uuid.UUID { return e.Key }
+
+// SetOptions implements gorp.Entry.
+func (e Event) SetOptions() []any { return nil }
+
+// CustomTypeName implements types.CustomTypeName to ensure that the Event struct does
+// not conflict with any other types in gorp.
+func (e Event) CustomTypeName() string { return "AlarmEvent" }
+
+// operator returns true if the event was caused by an operator rather than by the
+// alarm service evaluating a channel.
+func (e Event) operator() bool {
+	return e.Kind == EventAcknowledged || e.Kind == EventShelved ||
+		(e.Kind == EventUnshelved && !e.Subject.IsZero())
+}
+
+var errNotNumeric = errors.Wrap(validate.Error, "alarms can only be evaluated against numeric channels")
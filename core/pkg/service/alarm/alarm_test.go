@@ -0,0 +1,298 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package alarm_test
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/alarm"
+	"github.com/synnaxlabs/x/binary"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/status"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Alarm", func() {
+	Describe("Validate", func() {
+		It("Should require a limit alarm to have a high or low limit", func() {
+			a := alarm.Alarm{Name: "a", Channel: 1, Type: alarm.TypeLimit}
+			Expect(a.Validate()).To(MatchError(ContainSubstring("high or low limit")))
+		})
+		It("Should require the low limit to be below the high limit", func() {
+			a := alarm.Alarm{Name: "a", Channel: 1, Type: alarm.TypeLimit, High: new(float64), Low: lo.ToPtr(1.0)}
+			Expect(a.Validate()).To(MatchError(ContainSubstring("less than or equal to the high limit")))
+		})
+		It("Should require a positive stale duration", func() {
+			a := alarm.Alarm{Name: "a", Channel: 1, Type: alarm.TypeStale}
+			Expect(a.Validate()).To(MatchError(ContainSubstring("stale_after")))
+		})
+		It("Should reject an unknown alarm type", func() {
+			a := alarm.Alarm{Name: "a", Channel: 1, Type: "dog"}
+			Expect(a.Validate()).To(MatchError(ContainSubstring("invalid alarm type")))
+		})
+	})
+
+	Describe("Violated", func() {
+		It("Should apply the deadband when clearing a high limit", func() {
+			a := alarm.Alarm{Type: alarm.TypeLimit, High: lo.ToPtr(10.0), Deadband: 2}
+			Expect(a.Violated(false, 9, 0)).To(BeFalse())
+			Expect(a.Violated(false, 11, 0)).To(BeTrue())
+			Expect(a.Violated(true, 9, 0)).To(BeTrue())
+			Expect(a.Violated(true, 7, 0)).To(BeFalse())
+		})
+		It("Should apply the deadband when clearing a low limit", func() {
+			a := alarm.Alarm{Type: alarm.TypeLimit, Low: lo.ToPtr(0.0), Deadband: 1}
+			Expect(a.Violated(false, -1, 0)).To(BeTrue())
+			Expect(a.Violated(true, 0.5, 0)).To(BeTrue())
+			Expect(a.Violated(true, 2, 0)).To(BeFalse())
+		})
+		It("Should evaluate the absolute rate of change", func() {
+			a := alarm.Alarm{Type: alarm.TypeRateOfChange, MaxRate: 5}
+			Expect(a.Violated(false, 0, -6)).To(BeTrue())
+			Expect(a.Violated(false, 0, 4)).To(BeFalse())
+		})
+		It("Should evaluate boolean alarms", func() {
+			a := alarm.Alarm{Type: alarm.TypeBoolean, ActiveWhen: true}
+			Expect(a.Violated(false, 1, 0)).To(BeTrue())
+			Expect(a.Violated(false, 0, 0)).To(BeFalse())
+			a.ActiveWhen = false
+			Expect(a.Violated(false, 0, 0)).To(BeTrue())
+		})
+	})
+
+	Describe("Service", Ordered, func() {
+		var (
+			svc *alarm.Service
+			w   *framer.Writer
+			ch  channel.Channel
+		)
+		subject := ontology.ID{Type: "user", Key: uuid.New().String()}
+		BeforeAll(func() {
+			svc = MustSucceed(alarm.OpenService(ctx, alarm.Config{
+				DB:                 dist.DB,
+				Ontology:           dist.Ontology,
+				Group:              dist.Group,
+				Signals:            dist.Signals,
+				Channel:            dist.Channel,
+				Framer:             dist.Framer,
+				HostProvider:       dist.Cluster,
+				EvaluationInterval: 20 * telem.Millisecond,
+			}))
+		})
+		AfterAll(func() { Expect(svc.Close()).To(Succeed()) })
+		BeforeEach(func() {
+			ch = channel.Channel{Name: "pressure", DataType: telem.Float64T, Virtual: true}
+			Expect(dist.Channel.Create(ctx, &ch)).To(Succeed())
+			w = MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{
+				Start: telem.Now(),
+				Keys:  channel.Keys{ch.Key()},
+			}))
+		})
+		AfterEach(func() { Expect(w.Close()).To(Succeed()) })
+		write := func(v float64) {
+			MustSucceed(w.Write(core.UnaryFrame(ch.Key(), telem.NewSeriesV(v))))
+		}
+		// writeUntil repeatedly writes v until the alarm's status satisfies matcher. We
+		// write more than once because the alarm may not be streaming its channel
+		// immediately after it is created.
+		writeUntil := func(key uuid.UUID, v float64, matcher OmegaMatcher) {
+			Eventually(func(g Gomega) {
+				write(v)
+				s, ok := svc.Status(key)
+				g.Expect(ok).To(BeTrue())
+				g.Expect(s).To(matcher)
+			}).Should(Succeed())
+		}
+		active := func(a bool) OmegaMatcher {
+			return HaveField("Details.Active", a)
+		}
+		eventKinds := func(key uuid.UUID) []alarm.EventKind {
+			var events []alarm.Event
+			Expect(svc.NewEventRetrieve().WhereAlarms(key).Entries(&events).Exec(ctx, nil)).To(Succeed())
+			kinds := make([]alarm.EventKind, len(events))
+			for i, e := range events {
+				kinds[i] = e.Kind
+			}
+			return kinds
+		}
+
+		It("Should not allow creating an alarm on a channel that does not exist", func() {
+			a := alarm.Alarm{Name: "ghost", Channel: channel.NewKey(1, 5000), Type: alarm.TypeStale, StaleAfter: telem.Second}
+			Expect(svc.NewWriter(nil).Create(ctx, &a)).To(HaveOccurredAs(query.NotFound))
+		})
+
+		It("Should not allow creating a limit alarm on a non-numeric channel", func() {
+			strCh := channel.Channel{Name: "log", DataType: telem.StringT, Virtual: true}
+			Expect(dist.Channel.Create(ctx, &strCh)).To(Succeed())
+			a := alarm.Alarm{Name: "log", Channel: strCh.Key(), Type: alarm.TypeLimit, High: lo.ToPtr(1.0)}
+			Expect(svc.NewWriter(nil).Create(ctx, &a)).To(MatchError(ContainSubstring("numeric")))
+		})
+
+		It("Should activate and clear a limit alarm with a deadband", func() {
+			a := alarm.Alarm{
+				Name:     "high pressure",
+				Channel:  ch.Key(),
+				Type:     alarm.TypeLimit,
+				High:     lo.ToPtr(10.0),
+				Deadband: 2,
+				Severity: status.ErrorVariant,
+			}
+			Expect(svc.NewWriter(nil).Create(ctx, &a)).To(Succeed())
+			writeUntil(a.Key, 5, HaveField("Details.Value", 5.0))
+			writeUntil(a.Key, 11, And(active(true), HaveField("Variant", status.ErrorVariant)))
+			write(9)
+			Consistently(func() bool {
+				s, _ := svc.Status(a.Key)
+				return s.Details.Active
+			}, 100*time.Millisecond).Should(BeTrue())
+			writeUntil(a.Key, 7, And(active(false), HaveField("Variant", status.SuccessVariant)))
+			Eventually(func() []alarm.EventKind {
+				return eventKinds(a.Key)
+			}).Should(Equal([]alarm.EventKind{alarm.EventActivated, alarm.EventCleared}))
+			statuses := MustSucceed(svc.RetrieveStatuses(ctx, a.Key))
+			Expect(statuses).To(HaveLen(1))
+			Expect(statuses[0].Details.Active).To(BeFalse())
+		})
+
+		It("Should hold a latching alarm active until it is acknowledged", func() {
+			a := alarm.Alarm{
+				Name:       "valve open",
+				Channel:    ch.Key(),
+				Type:       alarm.TypeBoolean,
+				ActiveWhen: true,
+				Latching:   true,
+			}
+			Expect(svc.NewWriter(nil).Create(ctx, &a)).To(Succeed())
+			writeUntil(a.Key, 1, active(true))
+			writeUntil(a.Key, 0, HaveField("Details.Value", 0.0))
+			s, _ := svc.Status(a.Key)
+			Expect(s.Details.Active).To(BeTrue())
+			Expect(svc.NewWriter(nil).Acknowledge(ctx, a.Key, subject)).To(Succeed())
+			Eventually(func(g Gomega) {
+				s, _ := svc.Status(a.Key)
+				g.Expect(s.Details.Active).To(BeFalse())
+				g.Expect(s.Details.Acknowledged).To(BeTrue())
+			}).Should(Succeed())
+			Eventually(func() []alarm.EventKind {
+				return eventKinds(a.Key)
+			}).Should(Equal([]alarm.EventKind{
+				alarm.EventActivated,
+				alarm.EventAcknowledged,
+				alarm.EventCleared,
+			}))
+		})
+
+		It("Should publish a disabled status while an alarm is shelved", func() {
+			a := alarm.Alarm{Name: "low pressure", Channel: ch.Key(), Type: alarm.TypeLimit, Low: lo.ToPtr(0.0)}
+			Expect(svc.NewWriter(nil).Create(ctx, &a)).To(Succeed())
+			writeUntil(a.Key, -1, active(true))
+			Expect(svc.NewWriter(nil).Shelve(ctx, a.Key, 200*telem.Millisecond, subject)).To(Succeed())
+			Eventually(func(g Gomega) {
+				s, _ := svc.Status(a.Key)
+				g.Expect(s.Variant).To(Equal(status.DisabledVariant))
+				g.Expect(s.Details.Active).To(BeTrue())
+			}).Should(Succeed())
+			Eventually(func(g Gomega) {
+				s, _ := svc.Status(a.Key)
+				g.Expect(s.Variant).To(Equal(status.WarningVariant))
+			}).Should(Succeed())
+			Eventually(func() []alarm.EventKind {
+				return eventKinds(a.Key)
+			}).Should(ContainElements(alarm.EventShelved, alarm.EventUnshelved))
+		})
+
+		It("Should not allow shelving an alarm for a non-positive duration", func() {
+			a := alarm.Alarm{Name: "shelf", Channel: ch.Key(), Type: alarm.TypeLimit, Low: lo.ToPtr(0.0)}
+			Expect(svc.NewWriter(nil).Create(ctx, &a)).To(Succeed())
+			Expect(svc.NewWriter(nil).Shelve(ctx, a.Key, 0, subject)).
+				To(MatchError(ContainSubstring("duration")))
+		})
+
+		It("Should return an error when acknowledging an alarm that does not exist", func() {
+			Expect(svc.NewWriter(nil).Acknowledge(ctx, uuid.New(), subject)).
+				To(HaveOccurredAs(query.NotFound))
+		})
+
+		It("Should activate a stale alarm when a channel stops receiving data", func() {
+			a := alarm.Alarm{
+				Name:       "stale pressure",
+				Channel:    ch.Key(),
+				Type:       alarm.TypeStale,
+				StaleAfter: 100 * telem.Millisecond,
+			}
+			Expect(svc.NewWriter(nil).Create(ctx, &a)).To(Succeed())
+			Eventually(func(g Gomega) {
+				s, _ := svc.Status(a.Key)
+				g.Expect(s.Details.Active).To(BeTrue())
+			}).Should(Succeed())
+			writeUntil(a.Key, 1, active(false))
+		})
+
+		It("Should activate a rate of change alarm", func() {
+			a := alarm.Alarm{Name: "ramp", Channel: ch.Key(), Type: alarm.TypeRateOfChange, MaxRate: 1000}
+			Expect(svc.NewWriter(nil).Create(ctx, &a)).To(Succeed())
+			writeUntil(a.Key, 0, HaveField("Details.Value", 0.0))
+			v := 0.0
+			Eventually(func(g Gomega) {
+				v += 1e6
+				write(v)
+				s, _ := svc.Status(a.Key)
+				g.Expect(s.Details.Active).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		It("Should publish statuses on the alarm status channel", func() {
+			sCtx, cancel := signal.WithCancel(ctx)
+			defer cancel()
+			streamer := MustSucceed(dist.Framer.NewStreamer(ctx, framer.StreamerConfig{
+				Keys: channel.Keys{svc.StatusChannelKey()},
+			}))
+			_, outlet := confluence.Attach(streamer, 1, 10)
+			streamer.Flow(sCtx)
+			a := alarm.Alarm{Name: "streamed", Channel: ch.Key(), Type: alarm.TypeLimit, High: lo.ToPtr(0.0)}
+			Expect(svc.NewWriter(nil).Create(ctx, &a)).To(Succeed())
+			writeUntil(a.Key, 1, active(true))
+			Eventually(func(g Gomega) {
+				var res framer.StreamerResponse
+				g.Eventually(outlet.Outlet()).Should(Receive(&res))
+				series := res.Frame.Get(svc.StatusChannelKey()).Series
+				g.Expect(series).ToNot(BeEmpty())
+				var s alarm.Status
+				codec := &binary.JSONCodec{}
+				g.Expect(codec.Decode(ctx, series[0].At(-1), &s)).To(Succeed())
+				g.Expect(s.Details.Alarm).To(Equal(a.Key))
+				g.Expect(s.Details.Active).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		It("Should stop evaluating an alarm once it is deleted", func() {
+			a := alarm.Alarm{Name: "deleted", Channel: ch.Key(), Type: alarm.TypeLimit, High: lo.ToPtr(0.0)}
+			Expect(svc.NewWriter(nil).Create(ctx, &a)).To(Succeed())
+			writeUntil(a.Key, 1, active(true))
+			Expect(svc.NewWriter(nil).Delete(ctx, a.Key)).To(Succeed())
+			Eventually(func() bool {
+				_, ok := svc.Status(a.Key)
+				return ok
+			}).Should(BeFalse())
+			Expect(svc.NewRetrieve().WhereKeys(a.Key).Exec(ctx, nil)).To(HaveOccurredAs(query.NotFound))
+		})
+	})
+})
@@ -0,0 +1,534 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package alarm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/cluster"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/writer"
+	"github.com/synnaxlabs/x/change"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/gorp"
+	xio "github.com/synnaxlabs/x/io"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/status"
+	"github.com/synnaxlabs/x/telem"
+	"go.uber.org/zap"
+)
+
+// StatusChannelName is the name of the channel that alarm statuses are published on.
+const StatusChannelName = "sy_alarm_status"
+
+// entry is the runtime evaluation state of a single alarm.
+type entry struct {
+	alarm Alarm
+	// index is the index channel of the alarm's channel, used to timestamp samples
+	// when calculating rates of change. index is zero for virtual channels, whose
+	// samples are timestamped on arrival.
+	index channel.Key
+	// condition is true if the alarm's rule is currently violated.
+	condition bool
+	// latched is true if the alarm has activated and has not yet been acknowledged.
+	latched bool
+	// hasValue is true if a sample has been received since the alarm was loaded.
+	hasValue  bool
+	lastValue float64
+	lastTime  telem.TimeStamp
+	status    Status
+}
+
+// update is a batch of statuses and events produced by evaluating alarms that need to
+// be published and persisted.
+type update struct {
+	statuses []Status
+	events   []Event
+}
+
+func (u *update) add(e *entry, kind EventKind, now telem.TimeStamp, msg string) {
+	e.status.Time = now
+	u.statuses = append(u.statuses, e.status)
+	if kind == "" {
+		return
+	}
+	u.events = append(u.events, Event{
+		Key:          uuid.New(),
+		Alarm:        e.alarm.Key,
+		Kind:         kind,
+		Time:         now,
+		Value:        e.status.Details.Value,
+		Message:      msg,
+		ShelvedUntil: e.status.Details.ShelvedUntil,
+	})
+}
+
+func (u *update) empty() bool { return len(u.statuses) == 0 && len(u.events) == 0 }
+
+// engine evaluates the alarms leased to this node. All evaluation happens on a single
+// goroutine, which receives frames from the relay, changes to alarm definitions,
+// operator actions, and evaluation ticks.
+type engine struct {
+	cfg Config
+	// mu protects entries. entries is only modified by the evaluation goroutine, so
+	// the lock only needs to be held for reads from outside of it.
+	mu        sync.RWMutex
+	entries   map[uuid.UUID]*entry
+	statusKey channel.Key
+	// ops are operations to run on the evaluation goroutine.
+	ops       chan func(ctx context.Context, u *update)
+	requests  confluence.Inlet[framer.StreamerRequest]
+	responses confluence.Outlet[framer.StreamerResponse]
+	writer    confluence.Inlet[framer.WriterRequest]
+	// stop is closed to shut down the evaluation goroutine.
+	stop   chan struct{}
+	closer io.Closer
+}
+
+func openEngine(ctx context.Context, cfg Config) (e *engine, err error) {
+	e = &engine{
+		cfg:     cfg,
+		entries: make(map[uuid.UUID]*entry),
+		ops:     make(chan func(context.Context, *update), 50),
+		stop:    make(chan struct{}),
+	}
+	statusCh := channel.Channel{
+		Name:        StatusChannelName,
+		DataType:    telem.JSONT,
+		Leaseholder: cfg.HostProvider.HostKey(),
+		Virtual:     true,
+		Internal:    true,
+	}
+	if err = cfg.Channel.Create(
+		ctx,
+		&statusCh,
+		channel.OverwriteIfNameExistsAndDifferentProperties(),
+		channel.RetrieveIfNameExists(true),
+	); err != nil {
+		return nil, err
+	}
+	e.statusKey = statusCh.Key()
+
+	var alarms []Alarm
+	if err = gorp.NewRetrieve[uuid.UUID, Alarm]().Entries(&alarms).Exec(ctx, cfg.DB); err != nil {
+		return nil, err
+	}
+	for _, a := range alarms {
+		if err = e.load(ctx, a); err != nil {
+			return nil, err
+		}
+	}
+
+	sCtx, cancel := signal.Isolated(signal.WithInstrumentation(cfg.Instrumentation))
+	defer func() {
+		if err != nil {
+			cancel()
+		}
+	}()
+	streamer, err := cfg.Framer.NewStreamer(ctx, framer.StreamerConfig{Keys: e.keys()})
+	if err != nil {
+		return nil, err
+	}
+	requests := confluence.NewStream[framer.StreamerRequest](1)
+	responses := confluence.NewStream[framer.StreamerResponse](10)
+	streamer.InFrom(requests)
+	streamer.OutTo(responses)
+	e.requests, e.responses = requests, responses
+
+	stateWriter, err := cfg.Framer.NewStreamWriter(ctx, framer.WriterConfig{
+		Start: telem.Now(),
+		Keys:  channel.Keys{e.statusKey},
+	})
+	if err != nil {
+		return nil, err
+	}
+	writerRequests := confluence.NewStream[framer.WriterRequest](1)
+	stateWriter.InFrom(writerRequests)
+	e.writer = writerRequests
+	obs := confluence.NewObservableSubscriber[framer.WriterResponse]()
+	obs.OnChange(func(ctx context.Context, r framer.WriterResponse) {
+		cfg.L.Error("unexpected alarm status writer error", zap.Int("seq_num", r.SeqNum), zap.Error(r.Err))
+	})
+	writerResponses := confluence.NewStream[framer.WriterResponse](1)
+	obs.InFrom(writerResponses)
+	stateWriter.OutTo(writerResponses)
+
+	streamer.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+	stateWriter.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+	sCtx.Go(e.run, signal.WithKey("evaluate"))
+
+	dcAlarms := gorp.Observe[uuid.UUID, Alarm](cfg.DB).OnChange(e.handleAlarmChanges)
+	dcEvents := gorp.Observe[uuid.UUID, Event](cfg.DB).OnChange(e.handleEventChanges)
+	e.closer = xio.MultiCloser{
+		xio.NopCloserFunc(dcAlarms),
+		xio.NopCloserFunc(dcEvents),
+		xio.CloserFunc(func() error {
+			defer cancel()
+			close(e.stop)
+			return sCtx.Wait()
+		}),
+	}
+	return e, nil
+}
+
+// owns returns true if the given channel's alarms should be evaluated on this node.
+func (e *engine) owns(ch channel.Channel) bool {
+	host := e.cfg.HostProvider.HostKey()
+	return ch.Leaseholder == host || (ch.Free() && host == cluster.Bootstrapper)
+}
+
+// load adds the given alarm to the engine if it is evaluated on this node, restoring
+// its persisted status if one exists.
+func (e *engine) load(ctx context.Context, a Alarm) error {
+	var ch channel.Channel
+	if err := e.cfg.Channel.NewRetrieve().WhereKeys(a.Channel).Entry(&ch).Exec(ctx, nil); err != nil {
+		if errors.Is(err, query.NotFound) {
+			e.cfg.L.Warn("channel for alarm not found", zap.Stringer("alarm", a.Key), zap.Error(err))
+			return nil
+		}
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.owns(ch) {
+		delete(e.entries, a.Key)
+		return nil
+	}
+	if existing, ok := e.entries[a.Key]; ok {
+		existing.alarm = a
+		existing.index = ch.Index()
+		return nil
+	}
+	en := &entry{alarm: a, index: ch.Index(), lastTime: telem.Now()}
+	en.status = Status{
+		Key:     a.Key.String(),
+		Variant: status.SuccessVariant,
+		Message: fmt.Sprintf("%s is normal", a.Name),
+		Time:    telem.Now(),
+		Details: StatusDetails{Alarm: a.Key, Channel: a.Channel},
+	}
+	if err := gorp.NewRetrieve[uuid.UUID, Status]().
+		WhereKeys(a.Key).
+		Entry(&en.status).
+		Exec(ctx, e.cfg.DB); err != nil && !errors.Is(err, query.NotFound) {
+		return err
+	}
+	en.condition = en.status.Details.Active
+	en.latched = a.Latching && en.status.Details.Active && !en.status.Details.Acknowledged
+	e.entries[a.Key] = en
+	return nil
+}
+
+// keys returns the channels that need to be streamed to evaluate the engine's alarms.
+func (e *engine) keys() channel.Keys {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	keys := make(channel.Keys, 0, len(e.entries)*2)
+	for _, en := range e.entries {
+		keys = append(keys, en.alarm.Channel)
+		if en.index != 0 && en.alarm.Type == TypeRateOfChange {
+			keys = append(keys, en.index)
+		}
+	}
+	return keys
+}
+
+func (e *engine) status(key uuid.UUID) (Status, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	en, ok := e.entries[key]
+	if !ok {
+		return Status{}, false
+	}
+	return en.status, true
+}
+
+// enqueue schedules the given operation to run on the evaluation goroutine.
+func (e *engine) enqueue(ctx context.Context, op func(ctx context.Context, u *update)) {
+	if err := signal.SendUnderContext(ctx, e.ops, op); err != nil {
+		e.cfg.L.Warn("failed to enqueue alarm operation", zap.Error(err))
+	}
+}
+
+func (e *engine) run(ctx context.Context) error {
+	defer func() {
+		e.writer.Close()
+		// Closing the requests shuts down the streamer, and we need to drain its
+		// responses so that it doesn't block while exiting.
+		e.requests.Close()
+		for range e.responses.Outlet() {
+		}
+	}()
+	ticker := time.NewTicker(e.cfg.EvaluationInterval.Duration())
+	defer ticker.Stop()
+	for {
+		var u update
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-e.stop:
+			return nil
+		case res, ok := <-e.responses.Outlet():
+			if !ok {
+				return nil
+			}
+			e.evaluate(res.Frame, &u)
+		case op := <-e.ops:
+			op(ctx, &u)
+		case <-ticker.C:
+			e.tick(&u)
+		}
+		e.publish(ctx, u)
+	}
+}
+
+// updateKeys updates the channels streamed from the relay. Responses are drained while
+// waiting to send the request to avoid deadlocking with the streamer.
+func (e *engine) updateKeys(ctx context.Context, u *update) {
+	req := framer.StreamerRequest{Keys: e.keys()}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.stop:
+			return
+		case e.requests.Inlet() <- req:
+			return
+		case res, ok := <-e.responses.Outlet():
+			if !ok {
+				return
+			}
+			e.evaluate(res.Frame, u)
+		}
+	}
+}
+
+// publish writes the statuses in u to the status channel and persists the statuses and
+// events to gorp.
+func (e *engine) publish(ctx context.Context, u update) {
+	if u.empty() {
+		return
+	}
+	if len(u.statuses) > 0 {
+		if err := signal.SendUnderContext(ctx, e.writer.Inlet(), framer.WriterRequest{
+			Command: writer.Write,
+			Frame:   core.UnaryFrame(e.statusKey, telem.NewSeriesStaticJSONV(u.statuses...)),
+		}); err != nil {
+			return
+		}
+	}
+	if err := e.cfg.DB.WithTx(ctx, func(tx gorp.Tx) error {
+		if err := gorp.NewCreate[uuid.UUID, Status]().
+			Entries(&u.statuses).
+			Exec(ctx, tx); err != nil {
+			return err
+		}
+		return gorp.NewCreate[uuid.UUID, Event]().Entries(&u.events).Exec(ctx, tx)
+	}); err != nil {
+		e.cfg.L.Error("failed to persist alarm state", zap.Error(err))
+	}
+}
+
+// evaluate evaluates the alarms against the samples in the given frame.
+func (e *engine) evaluate(fr framer.Frame, u *update) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := telem.Now()
+	for _, en := range e.entries {
+		data := fr.Get(en.alarm.Channel)
+		if data.Len() == 0 {
+			continue
+		}
+		if en.alarm.Type == TypeStale {
+			en.lastTime = now
+			en.set(false, now, u)
+			continue
+		}
+		if data.DataType().IsVariable() {
+			continue
+		}
+		var index telem.MultiSeries
+		if en.index != 0 {
+			index = fr.Get(en.index)
+		}
+		for i, s := range data.Series {
+			var ts telem.Series
+			if i < len(index.Series) && index.Series[i].Len() == s.Len() {
+				ts = index.Series[i]
+			}
+			unmarshal := telem.UnmarshalF[float64](s.DataType)
+			for j := range s.Len() {
+				t := now
+				if ts.Len() > 0 {
+					t = telem.ValueAt[telem.TimeStamp](ts, int(j))
+				}
+				en.sample(unmarshal(s.At(int(j))), t, now, u)
+			}
+		}
+	}
+}
+
+// tick checks for stale alarms and expired shelves.
+func (e *engine) tick(u *update) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := telem.Now()
+	for _, en := range e.entries {
+		d := &en.status.Details
+		if !d.ShelvedUntil.IsZero() && !d.Shelved(now) {
+			d.ShelvedUntil = 0
+			en.refresh()
+			u.add(en, EventUnshelved, now, fmt.Sprintf("%s shelve expired", en.alarm.Name))
+		}
+		if en.alarm.Type == TypeStale {
+			en.set(en.lastTime.Span(now) > en.alarm.StaleAfter, now, u)
+		}
+	}
+}
+
+func (e *engine) handleAlarmChanges(ctx context.Context, r gorp.TxReader[uuid.UUID, Alarm]) {
+	var (
+		sets    []Alarm
+		deletes []uuid.UUID
+	)
+	for c, ok := r.Next(ctx); ok; c, ok = r.Next(ctx) {
+		if c.Variant == change.Delete {
+			deletes = append(deletes, c.Key)
+		} else {
+			sets = append(sets, c.Value)
+		}
+	}
+	e.enqueue(ctx, func(ctx context.Context, u *update) {
+		for _, a := range sets {
+			if err := e.load(ctx, a); err != nil {
+				e.cfg.L.Error("failed to load alarm", zap.Stringer("alarm", a.Key), zap.Error(err))
+			}
+		}
+		e.mu.Lock()
+		for _, k := range deletes {
+			delete(e.entries, k)
+		}
+		e.mu.Unlock()
+		e.updateKeys(ctx, u)
+	})
+}
+
+func (e *engine) handleEventChanges(ctx context.Context, r gorp.TxReader[uuid.UUID, Event]) {
+	var events []Event
+	for c, ok := r.Next(ctx); ok; c, ok = r.Next(ctx) {
+		if c.Variant == change.Set && c.Value.operator() {
+			events = append(events, c.Value)
+		}
+	}
+	if len(events) == 0 {
+		return
+	}
+	e.enqueue(ctx, func(_ context.Context, u *update) {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		now := telem.Now()
+		for _, evt := range events {
+			en, ok := e.entries[evt.Alarm]
+			if !ok {
+				continue
+			}
+			d := &en.status.Details
+			wasActive := d.Active
+			switch evt.Kind {
+			case EventAcknowledged:
+				d.Acknowledged = true
+				en.latched = false
+			case EventShelved:
+				d.ShelvedUntil = evt.ShelvedUntil
+			case EventUnshelved:
+				d.ShelvedUntil = 0
+			}
+			en.refresh()
+			// The operator event is already in the log, so we only need to record
+			// an event if acknowledging the alarm released its latch.
+			if wasActive && !d.Active {
+				u.add(en, EventCleared, now, fmt.Sprintf("%s cleared", en.alarm.Name))
+			} else {
+				u.add(en, "", now, "")
+			}
+		}
+	})
+}
+
+// sample evaluates the alarm against a single sample taken at time t.
+func (en *entry) sample(v float64, t, now telem.TimeStamp, u *update) {
+	var rate float64
+	if en.hasValue && t.After(en.lastTime) {
+		rate = (v - en.lastValue) / en.lastTime.Span(t).Seconds()
+	}
+	first := !en.hasValue
+	en.hasValue, en.lastValue, en.lastTime = true, v, t
+	en.status.Details.Value = v
+	if first && en.alarm.Type == TypeRateOfChange {
+		return
+	}
+	en.set(en.alarm.Violated(en.condition, v, rate), now, u)
+}
+
+// set transitions the alarm's condition, recording an event in u if the alarm becomes
+// active or clears.
+func (en *entry) set(condition bool, now telem.TimeStamp, u *update) {
+	if condition == en.condition {
+		return
+	}
+	en.condition = condition
+	if condition {
+		en.status.Details.Acknowledged = false
+		en.latched = en.alarm.Latching
+	}
+	wasActive := en.status.Details.Active
+	en.refresh()
+	if en.status.Details.Active == wasActive {
+		return
+	}
+	if en.status.Details.Active {
+		u.add(en, EventActivated, now, fmt.Sprintf("%s activated", en.alarm.Name))
+	} else {
+		u.add(en, EventCleared, now, fmt.Sprintf("%s cleared", en.alarm.Name))
+	}
+}
+
+// refresh recomputes the alarm's status from its condition, latch, and shelve state.
+func (en *entry) refresh() {
+	d := &en.status.Details
+	d.Active = en.condition || en.latched
+	s := &en.status
+	switch {
+	case d.Shelved(telem.Now()):
+		s.Variant = status.DisabledVariant
+		s.Message = fmt.Sprintf("%s is shelved", en.alarm.Name)
+	case d.Active:
+		s.Variant = en.alarm.Severity
+		s.Message = fmt.Sprintf("%s is active", en.alarm.Name)
+		if d.Acknowledged {
+			s.Message = fmt.Sprintf("%s is active and acknowledged", en.alarm.Name)
+		}
+	default:
+		s.Variant = status.SuccessVariant
+		s.Message = fmt.Sprintf("%s is normal", en.alarm.Name)
+	}
+}
+
+// Close implements io.Closer.
+func (e *engine) Close() error { return e.closer.Close() }
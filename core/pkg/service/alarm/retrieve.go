@@ -0,0 +1,145 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package alarm
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology/search"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// Retrieve is a query builder for retrieving alarms.
+type Retrieve struct {
+	baseTX     gorp.Tx
+	otg        *ontology.Ontology
+	gorp       gorp.Retrieve[uuid.UUID, Alarm]
+	searchTerm string
+}
+
+// NewRetrieve opens a new query builder for retrieving alarms.
+func (s *Service) NewRetrieve() Retrieve {
+	return Retrieve{
+		baseTX: s.cfg.DB,
+		otg:    s.cfg.Ontology,
+		gorp:   gorp.NewRetrieve[uuid.UUID, Alarm](),
+	}
+}
+
+// Search searches for alarms whose names fuzzy match the given term.
+func (r Retrieve) Search(term string) Retrieve { r.searchTerm = term; return r }
+
+// WhereKeys filters alarms by their keys.
+func (r Retrieve) WhereKeys(keys ...uuid.UUID) Retrieve {
+	r.gorp = r.gorp.WhereKeys(keys...)
+	return r
+}
+
+// WhereChannels filters alarms by the channels they are evaluated against.
+func (r Retrieve) WhereChannels(keys ...channel.Key) Retrieve {
+	r.gorp = r.gorp.Where(func(a *Alarm) bool {
+		return slices.Contains(keys, a.Channel)
+	}, gorp.Required())
+	return r
+}
+
+// Entry binds the alarm that the query will fill.
+func (r Retrieve) Entry(a *Alarm) Retrieve { r.gorp = r.gorp.Entry(a); return r }
+
+// Entries binds a slice of alarms that the query will fill.
+func (r Retrieve) Entries(a *[]Alarm) Retrieve { r.gorp = r.gorp.Entries(a); return r }
+
+// Limit limits the number of results returned.
+func (r Retrieve) Limit(limit int) Retrieve { r.gorp = r.gorp.Limit(limit); return r }
+
+// Offset offsets the results returned.
+func (r Retrieve) Offset(offset int) Retrieve { r.gorp = r.gorp.Offset(offset); return r }
+
+// Exec executes the query against the provided transaction.
+func (r Retrieve) Exec(ctx context.Context, tx gorp.Tx) error {
+	if r.searchTerm != "" {
+		ids, err := r.otg.SearchIDs(ctx, search.Request{Type: OntologyType, Term: r.searchTerm})
+		if err != nil {
+			return err
+		}
+		keys, err := KeysFromOntologyIDs(ids)
+		if err != nil {
+			return err
+		}
+		r = r.WhereKeys(keys...)
+	}
+	return r.gorp.Exec(ctx, gorp.OverrideTx(r.baseTX, tx))
+}
+
+// EventRetrieve is a query builder for retrieving entries from the alarm log.
+type EventRetrieve struct {
+	baseTX  gorp.Tx
+	gorp    gorp.Retrieve[uuid.UUID, Event]
+	entries *[]Event
+}
+
+// NewEventRetrieve opens a new query builder for retrieving entries from the alarm
+// log. Events are returned in chronological order.
+func (s *Service) NewEventRetrieve() EventRetrieve {
+	return EventRetrieve{baseTX: s.cfg.DB, gorp: gorp.NewRetrieve[uuid.UUID, Event]()}
+}
+
+// WhereAlarms filters events by the alarms they are for.
+func (r EventRetrieve) WhereAlarms(keys ...uuid.UUID) EventRetrieve {
+	r.gorp = r.gorp.Where(func(e *Event) bool {
+		return slices.Contains(keys, e.Alarm)
+	}, gorp.Required())
+	return r
+}
+
+// WhereTimeRange filters events to those that occurred within the given time range.
+func (r EventRetrieve) WhereTimeRange(tr telem.TimeRange) EventRetrieve {
+	r.gorp = r.gorp.Where(func(e *Event) bool {
+		return tr.ContainsStamp(e.Time)
+	}, gorp.Required())
+	return r
+}
+
+// Entries binds a slice of events that the query will fill.
+func (r EventRetrieve) Entries(e *[]Event) EventRetrieve {
+	r.gorp = r.gorp.Entries(e)
+	r.entries = e
+	return r
+}
+
+// Exec executes the query against the provided transaction.
+func (r EventRetrieve) Exec(ctx context.Context, tx gorp.Tx) error {
+	if err := r.gorp.Exec(ctx, gorp.OverrideTx(r.baseTX, tx)); err != nil {
+		return err
+	}
+	if r.entries != nil {
+		slices.SortStableFunc(*r.entries, func(a, b Event) int {
+			return cmp.Compare(a.Time, b.Time)
+		})
+	}
+	return nil
+}
+
+// RetrieveStatuses retrieves the current statuses of the alarms with the given keys.
+// If no keys are provided, the statuses of all alarms are returned.
+func (s *Service) RetrieveStatuses(ctx context.Context, keys ...uuid.UUID) ([]Status, error) {
+	var res []Status
+	q := gorp.NewRetrieve[uuid.UUID, Status]().Entries(&res)
+	if len(keys) > 0 {
+		q = q.WhereKeys(keys...)
+	}
+	return res, q.Exec(ctx, s.cfg.DB)
+}
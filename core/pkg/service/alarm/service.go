@@ -0,0 +1,168 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package alarm implements server-side alarming. Alarms are rules evaluated against the
+// live values of channels as they flow through the framer relay. Changes in alarm
+// state are published on the sy_alarm_status channel and recorded in a persisted
+// alarm log.
+package alarm
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/alamos"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/cluster"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/synnax/pkg/distribution/group"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/distribution/signals"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/gorp"
+	xio "github.com/synnaxlabs/x/io"
+	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Config is the configuration for opening the alarm service.
+type Config struct {
+	// Instrumentation is used for logging, tracing, etc.
+	//
+	// [OPTIONAL]
+	alamos.Instrumentation
+	// DB is the gorp database that alarms, statuses, and the alarm log are stored in.
+	//
+	// [REQUIRED]
+	DB *gorp.DB
+	// Ontology is used to define alarms as resources in the cluster ontology.
+	//
+	// [REQUIRED]
+	Ontology *ontology.Ontology
+	// Group is used to create the top-level group that alarms are placed in.
+	//
+	// [REQUIRED]
+	Group *group.Service
+	// Signals is used to publish changes to alarm definitions.
+	//
+	// [OPTIONAL]
+	Signals *signals.Provider
+	// Channel is used to retrieve the channels that alarms are evaluated against and to
+	// create the alarm status channel.
+	//
+	// [REQUIRED]
+	Channel channel.ReadWriteable
+	// Framer is used to stream channel values from the relay and to write alarm
+	// statuses.
+	//
+	// [REQUIRED]
+	Framer *framer.Service
+	// HostProvider is used to determine which alarms are evaluated on this node.
+	// Alarms are evaluated on the leaseholder of their channel, and alarms on free
+	// channels are evaluated on the bootstrapper.
+	//
+	// [REQUIRED]
+	HostProvider cluster.HostProvider
+	// EvaluationInterval is how often stale alarms and shelve expirations are checked.
+	//
+	// [OPTIONAL] - Defaults to 1 second.
+	EvaluationInterval telem.TimeSpan
+}
+
+var (
+	_ config.Config[Config] = Config{}
+	// DefaultConfig is the default configuration for opening the alarm service. This
+	// configuration is not valid on its own, and must be overridden with the required
+	// fields detailed in the Config struct.
+	DefaultConfig = Config{EvaluationInterval: telem.Second}
+)
+
+// Override implements config.Config.
+func (c Config) Override(other Config) Config {
+	c.Instrumentation = override.Zero(c.Instrumentation, other.Instrumentation)
+	c.DB = override.Nil(c.DB, other.DB)
+	c.Ontology = override.Nil(c.Ontology, other.Ontology)
+	c.Group = override.Nil(c.Group, other.Group)
+	c.Signals = override.Nil(c.Signals, other.Signals)
+	c.Channel = override.Nil(c.Channel, other.Channel)
+	c.Framer = override.Nil(c.Framer, other.Framer)
+	c.HostProvider = override.Nil(c.HostProvider, other.HostProvider)
+	c.EvaluationInterval = override.Numeric(c.EvaluationInterval, other.EvaluationInterval)
+	return c
+}
+
+// Validate implements config.Config.
+func (c Config) Validate() error {
+	v := validate.New("alarm")
+	validate.NotNil(v, "db", c.DB)
+	validate.NotNil(v, "ontology", c.Ontology)
+	validate.NotNil(v, "group", c.Group)
+	validate.NotNil(v, "channel", c.Channel)
+	validate.NotNil(v, "framer", c.Framer)
+	validate.NotNil(v, "host_provider", c.HostProvider)
+	validate.Positive(v, "evaluation_interval", c.EvaluationInterval)
+	return v.Error()
+}
+
+// Service is the main entrypoint for managing and evaluating alarms.
+type Service struct {
+	cfg    Config
+	group  group.Group
+	engine *engine
+	closer io.Closer
+}
+
+const groupName = "Alarms"
+
+// OpenService opens a new alarm service using the provided configuration. The service
+// begins evaluating the alarms leased to this node immediately, and must be closed
+// after use.
+func OpenService(ctx context.Context, configs ...Config) (*Service, error) {
+	cfg, err := config.New(DefaultConfig, configs...)
+	if err != nil {
+		return nil, err
+	}
+	g, err := cfg.Group.CreateOrRetrieve(ctx, groupName, ontology.RootID)
+	if err != nil {
+		return nil, err
+	}
+	s := &Service{cfg: cfg, group: g}
+	cfg.Ontology.RegisterService(s)
+	var closer xio.MultiCloser
+	if cfg.Signals != nil {
+		sigCloser, err := signals.PublishFromGorp(
+			ctx,
+			cfg.Signals,
+			signals.GorpPublisherConfigUUID[Alarm](cfg.DB),
+		)
+		if err != nil {
+			return nil, err
+		}
+		closer = append(closer, sigCloser)
+	}
+	if s.engine, err = openEngine(ctx, cfg); err != nil {
+		return nil, errors.Combine(err, closer.Close())
+	}
+	s.closer = append(xio.MultiCloser{s.engine}, closer...)
+	return s, nil
+}
+
+// Status returns the current status of the alarm with the given key. Status only
+// returns statuses for alarms evaluated on this node. Use RetrieveStatuses to retrieve
+// the persisted statuses of alarms across the cluster.
+func (s *Service) Status(key uuid.UUID) (Status, bool) { return s.engine.status(key) }
+
+// StatusChannelKey returns the key of the channel that alarm statuses are published on.
+func (s *Service) StatusChannelKey() channel.Key { return s.engine.statusKey }
+
+// Close stops evaluating alarms and releases all resources held by the service.
+func (s *Service) Close() error { return s.closer.Close() }
@@ -0,0 +1,154 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package alarm
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/group"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/status"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Writer is used to create, delete, acknowledge, and shelve alarms within a gorp
+// transaction.
+type Writer struct {
+	tx      gorp.Tx
+	otg     ontology.Writer
+	channel channel.Readable
+	group   group.Group
+}
+
+// NewWriter opens a new writer for creating, deleting, acknowledging, and shelving
+// alarms. If tx is nil, the writer will execute operations directly against the
+// underlying database.
+func (s *Service) NewWriter(tx gorp.Tx) Writer {
+	return Writer{
+		tx:      gorp.OverrideTx(s.cfg.DB, tx),
+		otg:     s.cfg.Ontology.NewWriter(tx),
+		channel: s.cfg.Channel,
+		group:   s.group,
+	}
+}
+
+// Create creates the given alarm. If the alarm does not have a key, a new key will be
+// generated. If an alarm with the same key already exists, it will be replaced.
+func (w Writer) Create(ctx context.Context, a *Alarm) error {
+	if a.Key == uuid.Nil {
+		a.Key = uuid.New()
+	}
+	if a.Severity == "" {
+		a.Severity = status.WarningVariant
+	}
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	var ch channel.Channel
+	if err := w.channel.NewRetrieve().
+		WhereKeys(a.Channel).
+		Entry(&ch).
+		Exec(ctx, w.tx); err != nil {
+		return err
+	}
+	if a.Type != TypeStale && ch.DataType.IsVariable() {
+		return validate.PathedError(errNotNumeric, "channel")
+	}
+	if err := gorp.NewCreate[uuid.UUID, Alarm]().Entry(a).Exec(ctx, w.tx); err != nil {
+		return err
+	}
+	otgID := OntologyID(a.Key)
+	if err := w.otg.DefineResource(ctx, otgID); err != nil {
+		return err
+	}
+	return w.otg.DefineRelationship(ctx, w.group.OntologyID(), ontology.ParentOf, otgID)
+}
+
+// Delete deletes the alarms with the given keys along with their statuses. Entries in
+// the alarm log are retained.
+func (w Writer) Delete(ctx context.Context, keys ...uuid.UUID) error {
+	if err := gorp.NewDelete[uuid.UUID, Alarm]().WhereKeys(keys...).Exec(ctx, w.tx); err != nil {
+		return err
+	}
+	if err := gorp.NewDelete[uuid.UUID, Status]().WhereKeys(keys...).Exec(ctx, w.tx); err != nil {
+		return err
+	}
+	return w.otg.DeleteManyResources(ctx, OntologyIDs(keys))
+}
+
+// Acknowledge acknowledges the alarm with the given key on behalf of subject. A
+// latched alarm whose condition has cleared will clear once acknowledged.
+func (w Writer) Acknowledge(ctx context.Context, key uuid.UUID, subject ontology.ID) error {
+	return w.record(ctx, Event{
+		Alarm:   key,
+		Kind:    EventAcknowledged,
+		Subject: subject,
+		Message: "Alarm acknowledged",
+	})
+}
+
+// Shelve suppresses the alarm with the given key for the provided duration on behalf
+// of subject. The alarm continues to be evaluated and logged while shelved, but its
+// status is published with the disabled variant.
+func (w Writer) Shelve(
+	ctx context.Context,
+	key uuid.UUID,
+	duration telem.TimeSpan,
+	subject ontology.ID,
+) error {
+	if duration <= 0 {
+		return validate.PathedError(
+			errors.Wrap(validate.Error, "shelve duration must be positive"),
+			"duration",
+		)
+	}
+	now := telem.Now()
+	return w.record(ctx, Event{
+		Alarm:        key,
+		Kind:         EventShelved,
+		Subject:      subject,
+		Message:      "Alarm shelved for " + duration.String(),
+		Time:         now,
+		ShelvedUntil: now.Add(duration),
+	})
+}
+
+// Unshelve un-shelves the alarm with the given key on behalf of subject.
+func (w Writer) Unshelve(ctx context.Context, key uuid.UUID, subject ontology.ID) error {
+	return w.record(ctx, Event{
+		Alarm:   key,
+		Kind:    EventUnshelved,
+		Subject: subject,
+		Message: "Alarm unshelved",
+	})
+}
+
+func (w Writer) record(ctx context.Context, e Event) error {
+	exists, err := gorp.NewRetrieve[uuid.UUID, Alarm]().
+		WhereKeys(e.Alarm).
+		Exists(ctx, w.tx)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Wrapf(query.NotFound, "alarm %s not found", e.Alarm)
+	}
+	e.Key = uuid.New()
+	if e.Time.IsZero() {
+		e.Time = telem.Now()
+	}
+	return gorp.NewCreate[uuid.UUID, Event]().Entry(&e).Exec(ctx, w.tx)
+}
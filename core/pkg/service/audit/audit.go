@@ -0,0 +1,80 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package audit implements an append-only log of the changes that users make to the
+// cluster. Entries are stored in gorp, and can optionally be mirrored to the persisted
+// sy_audit channel so that they can be read alongside telemetry.
+package audit
+
+import (
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/types"
+)
+
+// Outcome is the outcome of an audited call.
+type Outcome string
+
+const (
+	// Success is the outcome of a call that completed without error.
+	Success Outcome = "success"
+	// Denied is the outcome of a call that was rejected by access control.
+	Denied Outcome = "denied"
+	// Failure is the outcome of a call that failed for any other reason.
+	Failure Outcome = "failure"
+)
+
+// Entry is an entry in the audit log.
+type Entry struct {
+	// Key is the unique identifier for the entry. Keys are time-ordered.
+	Key uuid.UUID `json:"key" msgpack:"key"`
+	// Time is the time at which the call was made.
+	Time telem.TimeStamp `json:"time" msgpack:"time"`
+	// User is the key of the user that made the call.
+	User uuid.UUID `json:"user" msgpack:"user"`
+	// Protocol is the protocol that the call was made over.
+	Protocol string `json:"protocol" msgpack:"protocol"`
+	// Target is the endpoint that was called.
+	Target string `json:"target" msgpack:"target"`
+	// Actions are the actions the call performed, as checked by access control.
+	Actions []access.Action `json:"actions" msgpack:"actions"`
+	// Objects are the resources the call acted on, as checked by access control.
+	Objects []ontology.ID `json:"objects" msgpack:"objects"`
+	// Request is a summary of the request, with secrets redacted.
+	Request string `json:"request" msgpack:"request"`
+	// Outcome is the outcome of the call.
+	Outcome Outcome `json:"outcome" msgpack:"outcome"`
+	// Error is the error that the call failed with, if any.
+	Error string `json:"error,omitempty" msgpack:"error"`
+}
+
+var (
+	_ gorp.Entry[uuid.UUID] = Entry{}
+	_ types.CustomTypeName  = (*Entry)(nil)
+)
+
+// GorpKey implements gorp.Entry.
+func (e Entry) GorpKey() uuid.UUID { return e.Key }
+
+// SetOptions implements gorp.Entry.
+func (e Entry) SetOptions() []any { return nil }
+
+// CustomTypeName implements types.CustomTypeName to ensure that the Entry struct does
+// not conflict with any other types in gorp.
+func (e Entry) CustomTypeName() string { return "AuditEntry" }
+
+// OntologyType is the type of the audit log in the ontology. Access to the log is
+// controlled by policies on this type.
+const OntologyType ontology.Type = "audit"
+
+// OntologyID is the ontology ID of the audit log.
+var OntologyID = ontology.ID{Type: OntologyType}
@@ -0,0 +1,175 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package audit_test
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/audit"
+	"github.com/synnaxlabs/x/binary"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/kv/memkv"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Audit", func() {
+	Describe("Retrieve", func() {
+		var (
+			db    *gorp.DB
+			svc   *audit.Service
+			alice = uuid.New()
+			bob   = uuid.New()
+			chA   = ontology.ID{Type: "channel", Key: "1"}
+			chB   = ontology.ID{Type: "channel", Key: "2"}
+			rng   = ontology.ID{Type: "range", Key: uuid.NewString()}
+		)
+		BeforeEach(func() {
+			db = gorp.Wrap(memkv.New())
+			svc = MustSucceed(audit.OpenService(ctx, audit.Config{DB: db}))
+			for _, e := range []audit.Entry{
+				{Time: 1 * telem.SecondTS, User: alice, Actions: []access.Action{access.Create}, Objects: []ontology.ID{chA}},
+				{Time: 2 * telem.SecondTS, User: bob, Actions: []access.Action{access.Delete}, Objects: []ontology.ID{chB}},
+				{Time: 3 * telem.SecondTS, User: alice, Actions: []access.Action{access.Update}, Objects: []ontology.ID{rng}},
+			} {
+				Expect(svc.Record(ctx, &e)).To(Succeed())
+				Expect(e.Key).ToNot(Equal(uuid.Nil))
+			}
+		})
+		AfterEach(func() {
+			Expect(svc.Close()).To(Succeed())
+			Expect(db.Close()).To(Succeed())
+		})
+		times := func(entries []audit.Entry) []telem.TimeStamp {
+			ts := make([]telem.TimeStamp, len(entries))
+			for i, e := range entries {
+				ts[i] = e.Time
+			}
+			return ts
+		}
+
+		It("Should retrieve all entries in the order they were recorded", func() {
+			var entries []audit.Entry
+			Expect(svc.NewRetrieve().Entries(&entries).Exec(ctx, nil)).To(Succeed())
+			Expect(times(entries)).To(Equal([]telem.TimeStamp{
+				1 * telem.SecondTS, 2 * telem.SecondTS, 3 * telem.SecondTS,
+			}))
+		})
+
+		It("Should retrieve entries by user", func() {
+			var entries []audit.Entry
+			Expect(svc.NewRetrieve().WhereUsers(bob).Entries(&entries).Exec(ctx, nil)).To(Succeed())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].User).To(Equal(bob))
+		})
+
+		It("Should retrieve entries by resource", func() {
+			var entries []audit.Entry
+			Expect(svc.NewRetrieve().WhereObjects(chB).Entries(&entries).Exec(ctx, nil)).To(Succeed())
+			Expect(times(entries)).To(Equal([]telem.TimeStamp{2 * telem.SecondTS}))
+		})
+
+		It("Should retrieve entries for all resources of a type", func() {
+			var entries []audit.Entry
+			Expect(svc.NewRetrieve().
+				WhereObjects(ontology.ID{Type: "channel"}).
+				Entries(&entries).
+				Exec(ctx, nil)).To(Succeed())
+			Expect(times(entries)).To(Equal([]telem.TimeStamp{1 * telem.SecondTS, 2 * telem.SecondTS}))
+		})
+
+		It("Should retrieve entries by action", func() {
+			var entries []audit.Entry
+			Expect(svc.NewRetrieve().WhereActions(access.Delete).Entries(&entries).Exec(ctx, nil)).To(Succeed())
+			Expect(times(entries)).To(Equal([]telem.TimeStamp{2 * telem.SecondTS}))
+		})
+
+		It("Should retrieve entries by time range", func() {
+			var entries []audit.Entry
+			Expect(svc.NewRetrieve().
+				WhereTimeRange(telem.TimeRange{Start: 2 * telem.SecondTS, End: 4 * telem.SecondTS}).
+				WhereUsers(alice).
+				Entries(&entries).
+				Exec(ctx, nil)).To(Succeed())
+			Expect(times(entries)).To(Equal([]telem.TimeStamp{3 * telem.SecondTS}))
+		})
+
+		It("Should paginate entries", func() {
+			var entries []audit.Entry
+			Expect(svc.NewRetrieve().Offset(1).Limit(1).Entries(&entries).Exec(ctx, nil)).To(Succeed())
+			Expect(times(entries)).To(Equal([]telem.TimeStamp{2 * telem.SecondTS}))
+		})
+
+		It("Should set the time of entries that have none", func() {
+			e := audit.Entry{User: alice}
+			before := telem.Now()
+			Expect(svc.Record(ctx, &e)).To(Succeed())
+			Expect(e.Time).To(BeNumerically(">=", before))
+		})
+	})
+
+	Describe("Mirror", func() {
+		It("Should require a channel service when mirroring is enabled", func() {
+			Expect(audit.OpenService(ctx, audit.Config{
+				DB:     dist.DB,
+				Mirror: config.True(),
+			})).Error().To(MatchError(ContainSubstring("channel")))
+		})
+
+		It("Should write entries to the audit channel", func() {
+			svc := MustSucceed(audit.OpenService(ctx, audit.Config{
+				DB:           dist.DB,
+				Mirror:       config.True(),
+				Channel:      dist.Channel,
+				Framer:       dist.Framer,
+				HostProvider: dist.Cluster,
+			}))
+			defer func() { Expect(svc.Close()).To(Succeed()) }()
+			var ch channel.Channel
+			Expect(dist.Channel.NewRetrieve().
+				WhereNames(audit.ChannelName).
+				Entry(&ch).
+				Exec(ctx, nil)).To(Succeed())
+			Expect(ch.DataType).To(Equal(telem.JSONT))
+
+			sCtx, cancel := signal.WithCancel(ctx)
+			defer cancel()
+			streamer := MustSucceed(dist.Framer.NewStreamer(ctx, framer.StreamerConfig{
+				Keys: channel.Keys{ch.Key()},
+			}))
+			_, outlet := confluence.Attach(streamer, 1, 10)
+			streamer.Flow(sCtx)
+			user := uuid.New()
+			// Entries are recorded until one is received, as the streamer may not be
+			// subscribed to the channel immediately after it is opened.
+			Eventually(func(g Gomega) {
+				g.Expect(svc.Record(ctx, &audit.Entry{User: user, Target: "/channel/create"})).To(Succeed())
+				var res framer.StreamerResponse
+				g.Eventually(outlet.Outlet()).WithTimeout(50 * time.Millisecond).Should(Receive(&res))
+				series := res.Frame.Get(ch.Key()).Series
+				g.Expect(series).ToNot(BeEmpty())
+				var e audit.Entry
+				g.Expect((&binary.JSONCodec{}).Decode(ctx, series[0].At(-1), &e)).To(Succeed())
+				g.Expect(e.User).To(Equal(user))
+				g.Expect(e.Target).To(Equal("/channel/create"))
+			}).Should(Succeed())
+		})
+	})
+})
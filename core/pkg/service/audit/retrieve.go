@@ -0,0 +1,89 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package audit
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// Retrieve is a query builder for retrieving audit log entries. Entries are returned
+// in the order they were recorded.
+type Retrieve struct {
+	baseTX gorp.Tx
+	gorp   gorp.Retrieve[uuid.UUID, Entry]
+}
+
+// NewRetrieve opens a new query builder for retrieving audit log entries.
+func (s *Service) NewRetrieve() Retrieve {
+	return Retrieve{baseTX: s.cfg.DB, gorp: gorp.NewRetrieve[uuid.UUID, Entry]()}
+}
+
+// WhereUsers filters entries by the users that made the calls.
+func (r Retrieve) WhereUsers(keys ...uuid.UUID) Retrieve {
+	r.gorp = r.gorp.Where(func(e *Entry) bool {
+		return slices.Contains(keys, e.User)
+	}, gorp.Required())
+	return r
+}
+
+// WhereObjects filters entries by the resources the calls acted on. An ID with an
+// empty key matches all resources of its type.
+func (r Retrieve) WhereObjects(ids ...ontology.ID) Retrieve {
+	r.gorp = r.gorp.Where(func(e *Entry) bool {
+		return slices.ContainsFunc(e.Objects, func(o ontology.ID) bool {
+			return slices.ContainsFunc(ids, func(id ontology.ID) bool {
+				return id.Type == o.Type && (id.Key == "" || id.Key == o.Key)
+			})
+		})
+	}, gorp.Required())
+	return r
+}
+
+// WhereActions filters entries by the actions the calls performed.
+func (r Retrieve) WhereActions(actions ...access.Action) Retrieve {
+	r.gorp = r.gorp.Where(func(e *Entry) bool {
+		return slices.ContainsFunc(e.Actions, func(a access.Action) bool {
+			return slices.Contains(actions, a)
+		})
+	}, gorp.Required())
+	return r
+}
+
+// WhereTimeRange filters entries to calls made within the given time range.
+func (r Retrieve) WhereTimeRange(tr telem.TimeRange) Retrieve {
+	r.gorp = r.gorp.Where(func(e *Entry) bool {
+		return tr.ContainsStamp(e.Time)
+	}, gorp.Required())
+	return r
+}
+
+// Entry binds the entry that the query will fill.
+func (r Retrieve) Entry(e *Entry) Retrieve { r.gorp = r.gorp.Entry(e); return r }
+
+// Entries binds a slice of entries that the query will fill.
+func (r Retrieve) Entries(e *[]Entry) Retrieve { r.gorp = r.gorp.Entries(e); return r }
+
+// Limit limits the number of results returned.
+func (r Retrieve) Limit(limit int) Retrieve { r.gorp = r.gorp.Limit(limit); return r }
+
+// Offset offsets the results returned.
+func (r Retrieve) Offset(offset int) Retrieve { r.gorp = r.gorp.Offset(offset); return r }
+
+// Exec executes the query against the provided transaction.
+func (r Retrieve) Exec(ctx context.Context, tx gorp.Tx) error {
+	return r.gorp.Exec(ctx, gorp.OverrideTx(r.baseTX, tx))
+}
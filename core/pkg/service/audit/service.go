@@ -0,0 +1,211 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package audit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/alamos"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/cluster"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/writer"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/gorp"
+	xio "github.com/synnaxlabs/x/io"
+	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+	"go.uber.org/zap"
+)
+
+// ChannelName is the name of the channel that entries are mirrored to. The storage
+// engine cannot persist variable density data types, so the channel is virtual, and
+// entries are durably stored in the audit log itself.
+const ChannelName = "sy_audit"
+
+// Config is the configuration for opening the audit service.
+type Config struct {
+	// Instrumentation is used for logging, tracing, etc.
+	//
+	// [OPTIONAL]
+	alamos.Instrumentation
+	// DB is the gorp database that the audit log is stored in.
+	//
+	// [REQUIRED]
+	DB *gorp.DB
+	// Mirror sets whether entries are mirrored to the sy_audit channel as they are
+	// recorded.
+	//
+	// [OPTIONAL] - Defaults to false.
+	Mirror *bool
+	// Channel is used to create the mirror channel.
+	//
+	// [REQUIRED] - If Mirror is true.
+	Channel channel.Writeable
+	// Framer is used to write entries to the mirror channel.
+	//
+	// [REQUIRED] - If Mirror is true.
+	Framer *framer.Service
+	// HostProvider is used to place the mirror channel on this node.
+	//
+	// [REQUIRED] - If Mirror is true.
+	HostProvider cluster.HostProvider
+}
+
+var (
+	_ config.Config[Config] = Config{}
+	// DefaultConfig is the default configuration for opening the audit service. This
+	// configuration is not valid on its own, and must be overridden with the required
+	// fields detailed in the Config struct.
+	DefaultConfig = Config{Mirror: config.False()}
+)
+
+// Override implements config.Config.
+func (c Config) Override(other Config) Config {
+	c.Instrumentation = override.Zero(c.Instrumentation, other.Instrumentation)
+	c.DB = override.Nil(c.DB, other.DB)
+	c.Mirror = override.Nil(c.Mirror, other.Mirror)
+	c.Channel = override.Nil(c.Channel, other.Channel)
+	c.Framer = override.Nil(c.Framer, other.Framer)
+	c.HostProvider = override.Nil(c.HostProvider, other.HostProvider)
+	return c
+}
+
+// Validate implements config.Config.
+func (c Config) Validate() error {
+	v := validate.New("audit")
+	validate.NotNil(v, "db", c.DB)
+	validate.NotNil(v, "mirror", c.Mirror)
+	if c.Mirror != nil && *c.Mirror {
+		validate.NotNil(v, "channel", c.Channel)
+		validate.NotNil(v, "framer", c.Framer)
+		validate.NotNil(v, "host_provider", c.HostProvider)
+	}
+	return v.Error()
+}
+
+// mirrorBufferSize is the number of entries that can be waiting to be written to the
+// mirror channel before new entries are dropped from the mirror.
+const mirrorBufferSize = 100
+
+// Service records and retrieves audit log entries.
+type Service struct {
+	cfg    Config
+	mirror *mirror
+	closer xio.MultiCloser
+}
+
+// mirror writes entries to the mirror channel.
+type mirror struct {
+	key      channel.Key
+	requests confluence.Inlet[framer.WriterRequest]
+}
+
+// OpenService opens a new audit service. If the returned error is nil, the service must
+// be closed by calling Close after use.
+func OpenService(ctx context.Context, cfgs ...Config) (*Service, error) {
+	cfg, err := config.New(DefaultConfig, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	s := &Service{cfg: cfg}
+	if *cfg.Mirror {
+		if err = s.openMirror(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *Service) openMirror(ctx context.Context) error {
+	ch := channel.Channel{
+		Name:        ChannelName,
+		DataType:    telem.JSONT,
+		Leaseholder: s.cfg.HostProvider.HostKey(),
+		Virtual:     true,
+		Internal:    true,
+	}
+	if err := s.cfg.Channel.Create(
+		ctx,
+		&ch,
+		channel.OverwriteIfNameExistsAndDifferentProperties(),
+		channel.RetrieveIfNameExists(true),
+	); err != nil {
+		return err
+	}
+	w, err := s.cfg.Framer.NewStreamWriter(ctx, framer.WriterConfig{
+		Start: telem.Now(),
+		Keys:  channel.Keys{ch.Key()},
+	})
+	if err != nil {
+		return err
+	}
+	sCtx, cancel := signal.Isolated(signal.WithInstrumentation(s.cfg.Instrumentation))
+	requests := confluence.NewStream[framer.WriterRequest](mirrorBufferSize)
+	w.InFrom(requests)
+	obs := confluence.NewObservableSubscriber[framer.WriterResponse]()
+	obs.OnChange(func(ctx context.Context, r framer.WriterResponse) {
+		s.cfg.L.Error("failed to mirror audit log entry", zap.Error(r.Err))
+	})
+	responses := confluence.NewStream[framer.WriterResponse](1)
+	obs.InFrom(responses)
+	w.OutTo(responses)
+	w.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+	obs.Flow(sCtx)
+	s.mirror = &mirror{key: ch.Key(), requests: requests}
+	s.closer = append(s.closer, xio.CloserFunc(func() error {
+		defer cancel()
+		requests.Close()
+		return sCtx.Wait()
+	}))
+	return nil
+}
+
+// Record appends the given entry to the audit log, assigning it a key and, if it has
+// none, the current time.
+func (s *Service) Record(ctx context.Context, e *Entry) error {
+	key, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+	e.Key = key
+	if e.Time.IsZero() {
+		e.Time = telem.Now()
+	}
+	if err = gorp.NewCreate[uuid.UUID, Entry]().Entry(e).Exec(ctx, s.cfg.DB); err != nil {
+		return err
+	}
+	if s.mirror != nil {
+		s.mirror.write(s.cfg.Instrumentation, *e)
+	}
+	return nil
+}
+
+// write sends the entry to the mirror channel without blocking. Entries are dropped
+// from the mirror if it falls behind, as they are already stored in the audit log.
+func (m *mirror) write(ins alamos.Instrumentation, e Entry) {
+	req := framer.WriterRequest{
+		Command: writer.Write,
+		Frame:   core.UnaryFrame(m.key, telem.NewSeriesStaticJSONV(e)),
+	}
+	select {
+	case m.requests.Inlet() <- req:
+	default:
+		ins.L.Warn("audit log mirror is falling behind, dropping entry", zap.Stringer("key", e.Key))
+	}
+}
+
+// Close shuts down the service, returning any error encountered.
+func (s *Service) Close() error { return s.closer.Close() }
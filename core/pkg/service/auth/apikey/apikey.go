@@ -0,0 +1,123 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package apikey implements long-lived API keys that automation, such as CI pipelines
+// and rack controllers, can use in place of a username and password. Each key belongs
+// to a user, usually a service account, and authenticates requests as that user.
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// Key is an API key. Only a hash of the key's secret is stored, so the token for a key
+// is only available when the key is created or rotated.
+type Key struct {
+	// Key is the unique identifier for the API key.
+	Key uuid.UUID `json:"key" msgpack:"key"`
+	// Name is a human-readable name for the key, such as the pipeline that uses it.
+	Name string `json:"name" msgpack:"name"`
+	// User is the key of the user that the key authenticates as.
+	User uuid.UUID `json:"user" msgpack:"user"`
+	// Hash is the SHA-256 hash of the key's secret.
+	Hash []byte `json:"-" msgpack:"hash"`
+	// CreatedAt is the time the key was created or last rotated.
+	CreatedAt telem.TimeStamp `json:"created_at" msgpack:"created_at"`
+	// ExpiresAt is the time after which the key is no longer valid. A zero value means
+	// the key never expires.
+	ExpiresAt telem.TimeStamp `json:"expires_at" msgpack:"expires_at"`
+	// Actions scopes the key to the given actions. Requests authenticated with the key
+	// may only take these actions, regardless of the policies of its user. An empty
+	// list allows all actions.
+	Actions []access.Action `json:"actions" msgpack:"actions"`
+}
+
+var _ gorp.Entry[uuid.UUID] = Key{}
+
+// GorpKey implements gorp.Entry.
+func (k Key) GorpKey() uuid.UUID { return k.Key }
+
+// SetOptions implements gorp.Entry.
+func (k Key) SetOptions() []any { return nil }
+
+// Expired returns true if the key has expired at the given time.
+func (k Key) Expired(now telem.TimeStamp) bool {
+	return !k.ExpiresAt.IsZero() && now.After(k.ExpiresAt)
+}
+
+// Allows returns true if the key is scoped to allow the given action.
+func (k Key) Allows(action access.Action) bool {
+	return len(k.Actions) == 0 ||
+		lo.Contains(k.Actions, action) ||
+		lo.Contains(k.Actions, access.All)
+}
+
+// Prefix is the prefix of every API key token. It distinguishes API keys from session
+// tokens when both are passed as bearer credentials.
+const Prefix = "sy_"
+
+// secretLength is the number of random bytes in the secret of a key.
+const secretLength = 32
+
+// IsToken returns true if the given bearer credential is an API key token rather than a
+// session token.
+func IsToken(token string) bool { return strings.HasPrefix(token, Prefix) }
+
+// newSecret generates a random secret for a key, returning it along with its hash.
+func newSecret() (string, []byte, error) {
+	b := make([]byte, secretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(b)
+	return secret, hash(secret), nil
+}
+
+func hash(secret string) []byte {
+	h := sha256.Sum256([]byte(secret))
+	return h[:]
+}
+
+// formatToken formats the token for the key with the given secret. Tokens have the
+// form sy_<key>_<secret>, where the key is the hex encoded uuid of the key.
+func formatToken(key uuid.UUID, secret string) string {
+	return Prefix + hex.EncodeToString(key[:]) + "_" + secret
+}
+
+// parseToken parses the key and secret from the given token.
+func parseToken(token string) (uuid.UUID, string, error) {
+	rest, ok := strings.CutPrefix(token, Prefix)
+	if !ok {
+		return uuid.Nil, "", auth.InvalidToken
+	}
+	rawKey, secret, ok := strings.Cut(rest, "_")
+	if !ok || secret == "" {
+		return uuid.Nil, "", auth.InvalidToken
+	}
+	b, err := hex.DecodeString(rawKey)
+	if err != nil {
+		return uuid.Nil, "", auth.InvalidToken
+	}
+	key, err := uuid.FromBytes(b)
+	if err != nil {
+		return uuid.Nil, "", auth.InvalidToken
+	}
+	return key, secret, nil
+}
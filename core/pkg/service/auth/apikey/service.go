@@ -0,0 +1,217 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package apikey
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Config is the configuration for opening an API key Service.
+type Config struct {
+	// DB is the database that API keys are stored in.
+	// [REQUIRED]
+	DB *gorp.DB
+	// Now returns the current time. It is used to set the creation time of keys and to
+	// check whether they have expired.
+	// [OPTIONAL] [DEFAULT: telem.Now]
+	Now func() telem.TimeStamp
+}
+
+var (
+	_ config.Config[Config] = Config{}
+	// DefaultConfig is the default configuration for an API key Service.
+	DefaultConfig = Config{Now: telem.Now}
+)
+
+// Override implements config.Config.
+func (c Config) Override(other Config) Config {
+	c.DB = override.Nil(c.DB, other.DB)
+	c.Now = override.Nil(c.Now, other.Now)
+	return c
+}
+
+// Validate implements config.Config.
+func (c Config) Validate() error {
+	v := validate.New("api_key")
+	validate.NotNil(v, "db", c.DB)
+	validate.NotNil(v, "now", c.Now)
+	return v.Error()
+}
+
+// Service creates, rotates, revokes, and authenticates API keys.
+type Service struct{ Config }
+
+// NewService opens a new Service using the provided configuration.
+func NewService(cfgs ...Config) (*Service, error) {
+	cfg, err := config.New(DefaultConfig, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{Config: cfg}, nil
+}
+
+// Authenticate validates the given API key token, returning the key it belongs to.
+// Returns auth.InvalidToken if the token does not match a key, and auth.ExpiredToken if
+// the key has expired.
+func (s *Service) Authenticate(ctx context.Context, token string) (Key, error) {
+	key, secret, err := parseToken(token)
+	if err != nil {
+		return Key{}, err
+	}
+	var k Key
+	if err = s.NewRetrieve().WhereKeys(key).Entry(&k).Exec(ctx, nil); err != nil {
+		if errors.Is(err, query.NotFound) {
+			return Key{}, auth.InvalidToken
+		}
+		return Key{}, err
+	}
+	if subtle.ConstantTimeCompare(k.Hash, hash(secret)) != 1 {
+		return Key{}, auth.InvalidToken
+	}
+	if k.Expired(s.Now()) {
+		return Key{}, auth.ExpiredToken
+	}
+	return k, nil
+}
+
+// NewRetrieve opens a new Retrieve query to look up API keys.
+func (s *Service) NewRetrieve() Retrieve {
+	return Retrieve{baseTx: s.DB, gorp: gorp.NewRetrieve[uuid.UUID, Key]()}
+}
+
+// NewWriter opens a new Writer to create, rotate, and delete API keys. If tx is nil,
+// the writer executes directly against the database.
+func (s *Service) NewWriter(tx gorp.Tx) Writer {
+	return Writer{tx: gorp.OverrideTx(s.DB, tx), now: s.Now}
+}
+
+// Retrieve is a query to look up API keys.
+type Retrieve struct {
+	baseTx gorp.Tx
+	gorp   gorp.Retrieve[uuid.UUID, Key]
+}
+
+// WhereKeys filters API keys by their keys.
+func (r Retrieve) WhereKeys(keys ...uuid.UUID) Retrieve {
+	r.gorp = r.gorp.WhereKeys(keys...)
+	return r
+}
+
+// WhereUsers filters API keys by the users they belong to.
+func (r Retrieve) WhereUsers(users ...uuid.UUID) Retrieve {
+	r.gorp = r.gorp.Where(func(k *Key) bool {
+		for _, u := range users {
+			if k.User == u {
+				return true
+			}
+		}
+		return false
+	})
+	return r
+}
+
+// Entry binds the key that the query will fill results into.
+func (r Retrieve) Entry(k *Key) Retrieve {
+	r.gorp = r.gorp.Entry(k)
+	return r
+}
+
+// Entries binds the slice that the query will fill results into.
+func (r Retrieve) Entries(ks *[]Key) Retrieve {
+	r.gorp = r.gorp.Entries(ks)
+	return r
+}
+
+// Exec executes the query.
+func (r Retrieve) Exec(ctx context.Context, tx gorp.Tx) error {
+	return r.gorp.Exec(ctx, gorp.OverrideTx(r.baseTx, tx))
+}
+
+// Writer creates, rotates, and deletes API keys.
+type Writer struct {
+	tx  gorp.Tx
+	now func() telem.TimeStamp
+}
+
+// Create creates the given API key, assigning it a key if it does not have one, and
+// returns its token. The token is not stored and cannot be retrieved again.
+func (w Writer) Create(ctx context.Context, k *Key) (string, error) {
+	v := validate.New("api_key")
+	validate.NotEmptyString(v, "name", k.Name)
+	v.Ternary("user", k.User == uuid.Nil, "must be provided")
+	if err := v.Error(); err != nil {
+		return "", err
+	}
+	if k.Key == uuid.Nil {
+		k.Key = uuid.New()
+	}
+	secret, h, err := newSecret()
+	if err != nil {
+		return "", err
+	}
+	k.Hash = h
+	k.CreatedAt = w.now()
+	if err = gorp.NewCreate[uuid.UUID, Key]().Entry(k).Exec(ctx, w.tx); err != nil {
+		return "", err
+	}
+	return formatToken(k.Key, secret), nil
+}
+
+// Rotate replaces the secret of the API key with the given key, returning its new
+// token. The previous token is no longer valid once the key is rotated.
+func (w Writer) Rotate(ctx context.Context, key uuid.UUID) (Key, string, error) {
+	secret, h, err := newSecret()
+	if err != nil {
+		return Key{}, "", err
+	}
+	var k Key
+	if err = gorp.NewUpdate[uuid.UUID, Key]().
+		WhereKeys(key).
+		Change(func(e Key) Key {
+			e.Hash = h
+			e.CreatedAt = w.now()
+			k = e
+			return e
+		}).
+		Exec(ctx, w.tx); err != nil {
+		return Key{}, "", err
+	}
+	return k, formatToken(key, secret), nil
+}
+
+// Delete revokes the API keys with the given keys.
+func (w Writer) Delete(ctx context.Context, keys ...uuid.UUID) error {
+	return gorp.NewDelete[uuid.UUID, Key]().WhereKeys(keys...).Exec(ctx, w.tx)
+}
+
+// DeleteByUsers revokes all API keys belonging to the given users.
+func (w Writer) DeleteByUsers(ctx context.Context, users ...uuid.UUID) error {
+	return gorp.NewDelete[uuid.UUID, Key]().
+		Where(func(k *Key) bool {
+			for _, u := range users {
+				if k.User == u {
+					return true
+				}
+			}
+			return false
+		}).
+		Exec(ctx, w.tx)
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package apikey_test
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/apikey"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/kv/memkv"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Service", func() {
+	var (
+		db   *gorp.DB
+		svc  *apikey.Service
+		now  telem.TimeStamp
+		user = uuid.New()
+	)
+	BeforeEach(func() {
+		db = gorp.Wrap(memkv.New())
+		now = telem.SecondTS
+		svc = MustSucceed(apikey.NewService(apikey.Config{
+			DB:  db,
+			Now: func() telem.TimeStamp { return now },
+		}))
+	})
+	AfterEach(func() {
+		Expect(db.Close()).To(Succeed())
+	})
+	Describe("Create", func() {
+		It("Should create a key that authenticates as its user", func() {
+			k := apikey.Key{Name: "ci", User: user}
+			token := MustSucceed(svc.NewWriter(nil).Create(ctx, &k))
+			Expect(apikey.IsToken(token)).To(BeTrue())
+			Expect(k.Key).ToNot(Equal(uuid.Nil))
+			Expect(k.CreatedAt).To(Equal(now))
+			res := MustSucceed(svc.Authenticate(ctx, token))
+			Expect(res.Key).To(Equal(k.Key))
+			Expect(res.User).To(Equal(user))
+		})
+		It("Should not store the token", func() {
+			k := apikey.Key{Name: "ci", User: user}
+			token := MustSucceed(svc.NewWriter(nil).Create(ctx, &k))
+			var stored apikey.Key
+			Expect(svc.NewRetrieve().WhereKeys(k.Key).Entry(&stored).Exec(ctx, nil)).To(Succeed())
+			Expect(stored.Hash).ToNot(BeEmpty())
+			Expect(token).ToNot(ContainSubstring(string(stored.Hash)))
+		})
+		It("Should require a name and a user", func() {
+			Expect(svc.NewWriter(nil).Create(ctx, &apikey.Key{User: user})).
+				Error().To(MatchError(ContainSubstring("name")))
+			Expect(svc.NewWriter(nil).Create(ctx, &apikey.Key{Name: "ci"})).
+				Error().To(MatchError(ContainSubstring("user")))
+		})
+	})
+	Describe("Authenticate", func() {
+		It("Should reject a token with the wrong secret", func() {
+			k := apikey.Key{Name: "ci", User: user}
+			token := MustSucceed(svc.NewWriter(nil).Create(ctx, &k))
+			Expect(svc.Authenticate(ctx, token[:len(token)-1]+"x")).
+				Error().To(MatchError(auth.InvalidToken))
+		})
+		It("Should reject malformed tokens", func() {
+			Expect(svc.Authenticate(ctx, "sy_nothex_secret")).
+				Error().To(MatchError(auth.InvalidToken))
+			Expect(svc.Authenticate(ctx, "not-a-key")).
+				Error().To(MatchError(auth.InvalidToken))
+		})
+		It("Should reject an expired key", func() {
+			k := apikey.Key{Name: "ci", User: user, ExpiresAt: now.Add(telem.Minute)}
+			token := MustSucceed(svc.NewWriter(nil).Create(ctx, &k))
+			Expect(svc.Authenticate(ctx, token)).Error().ToNot(HaveOccurred())
+			now = now.Add(telem.Hour)
+			Expect(svc.Authenticate(ctx, token)).
+				Error().To(MatchError(auth.ExpiredToken))
+		})
+	})
+	Describe("Rotate", func() {
+		It("Should invalidate the previous token", func() {
+			k := apikey.Key{Name: "ci", User: user}
+			w := svc.NewWriter(nil)
+			oldToken := MustSucceed(w.Create(ctx, &k))
+			rotated, newToken, err := w.Rotate(ctx, k.Key)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotated.Key).To(Equal(k.Key))
+			Expect(svc.Authenticate(ctx, oldToken)).
+				Error().To(MatchError(auth.InvalidToken))
+			Expect(MustSucceed(svc.Authenticate(ctx, newToken)).Key).To(Equal(k.Key))
+		})
+		It("Should return an error if the key does not exist", func() {
+			_, _, err := svc.NewWriter(nil).Rotate(ctx, uuid.New())
+			Expect(err).To(MatchError(query.NotFound))
+		})
+	})
+	Describe("Delete", func() {
+		It("Should revoke a key", func() {
+			k := apikey.Key{Name: "ci", User: user}
+			w := svc.NewWriter(nil)
+			token := MustSucceed(w.Create(ctx, &k))
+			Expect(w.Delete(ctx, k.Key)).To(Succeed())
+			Expect(svc.Authenticate(ctx, token)).
+				Error().To(MatchError(auth.InvalidToken))
+		})
+		It("Should revoke all keys belonging to a user", func() {
+			w := svc.NewWriter(nil)
+			other := apikey.Key{Name: "other", User: uuid.New()}
+			MustSucceed(w.Create(ctx, &apikey.Key{Name: "a", User: user}))
+			MustSucceed(w.Create(ctx, &apikey.Key{Name: "b", User: user}))
+			MustSucceed(w.Create(ctx, &other))
+			Expect(w.DeleteByUsers(ctx, user)).To(Succeed())
+			var keys []apikey.Key
+			Expect(svc.NewRetrieve().Entries(&keys).Exec(ctx, nil)).To(Succeed())
+			Expect(keys).To(HaveLen(1))
+			Expect(keys[0].Key).To(Equal(other.Key))
+		})
+	})
+	Describe("Allows", func() {
+		It("Should allow all actions when the key is not scoped", func() {
+			Expect(apikey.Key{}.Allows(access.Delete)).To(BeTrue())
+		})
+		It("Should only allow the actions the key is scoped to", func() {
+			k := apikey.Key{Actions: []access.Action{access.Retrieve}}
+			Expect(k.Allows(access.Retrieve)).To(BeTrue())
+			Expect(k.Allows(access.Delete)).To(BeFalse())
+		})
+	})
+})
@@ -10,6 +10,8 @@
 package auth_test
 
 import (
+	"context"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/synnaxlabs/synnax/pkg/service/auth"
@@ -18,6 +20,47 @@ import (
 	"github.com/synnaxlabs/x/kv/memkv"
 )
 
+// deactivationRecorder is an Authenticator that records the users it deactivates and
+// returns the given error from InsecureDeactivate.
+type deactivationRecorder struct {
+	auth.Authenticator
+	err         error
+	deactivated []string
+}
+
+func (r *deactivationRecorder) NewWriter(gorp.Tx) auth.Writer {
+	return deactivationWriter{recorder: r}
+}
+
+type deactivationWriter struct {
+	auth.Writer
+	recorder *deactivationRecorder
+}
+
+func (w deactivationWriter) InsecureDeactivate(_ context.Context, usernames ...string) error {
+	w.recorder.deactivated = append(w.recorder.deactivated, usernames...)
+	return w.recorder.err
+}
+
+var _ = Describe("MultiAuthenticator", func() {
+	Describe("InsecureDeactivate", func() {
+		It("Should deactivate the users in every authenticator", func() {
+			first, second := &deactivationRecorder{}, &deactivationRecorder{}
+			a := auth.MultiAuthenticator{first, second}
+			Expect(a.NewWriter(nil).InsecureDeactivate(ctx, "alice")).To(Succeed())
+			Expect(first.deactivated).To(Equal([]string{"alice"}))
+			Expect(second.deactivated).To(Equal([]string{"alice"}))
+		})
+		It("Should return the errors of the authenticators that failed", func() {
+			first := &deactivationRecorder{err: auth.Error}
+			second := &deactivationRecorder{}
+			a := auth.MultiAuthenticator{first, second}
+			Expect(a.NewWriter(nil).InsecureDeactivate(ctx, "alice")).To(MatchError(auth.Error))
+			Expect(second.deactivated).To(Equal([]string{"alice"}))
+		})
+	})
+})
+
 var _ = Describe("KV", Ordered, Serial, func() {
 	var (
 		authenticator  auth.Authenticator
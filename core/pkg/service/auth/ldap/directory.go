@@ -0,0 +1,118 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package ldap
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/provision"
+	"github.com/synnaxlabs/x/errors"
+)
+
+// unreachable is returned when the directory can't be reached, which makes
+// Authenticate fall back to cached credentials.
+var unreachable = errors.Wrap(auth.Error, "directory unreachable")
+
+// lookup checks the given credentials against the directory, returning the identity of
+// the user they belong to.
+func (s *Service) lookup(creds auth.InsecureCredentials) (provision.Identity, error) {
+	conn, err := goldap.DialURL(
+		s.cfg.URL,
+		goldap.DialWithDialer(&net.Dialer{Timeout: s.cfg.Timeout}),
+		goldap.DialWithTLSConfig(s.tls),
+	)
+	if err != nil {
+		return provision.Identity{}, translateErr(err)
+	}
+	defer func() { _ = conn.Close() }()
+	conn.SetTimeout(s.cfg.Timeout)
+	if *s.cfg.StartTLS {
+		if err = conn.StartTLS(s.tls); err != nil {
+			return provision.Identity{}, translateErr(err)
+		}
+	}
+	if s.cfg.BindDN != "" {
+		if err = conn.Bind(s.cfg.BindDN, s.cfg.BindPassword); err != nil {
+			if goldap.IsErrorWithCode(err, goldap.ErrorNetwork) {
+				return provision.Identity{}, translateErr(err)
+			}
+			// The search user is misconfigured, which says nothing about the
+			// credentials of the user logging in.
+			return provision.Identity{}, errors.Wrapf(
+				auth.Error,
+				"failed to bind as the search user: %v",
+				err,
+			)
+		}
+	}
+	res, err := conn.Search(goldap.NewSearchRequest(
+		s.cfg.BaseDN,
+		goldap.ScopeWholeSubtree,
+		goldap.NeverDerefAliases,
+		2,
+		int(s.cfg.Timeout.Seconds()),
+		false,
+		fmt.Sprintf(s.cfg.UserFilter, goldap.EscapeFilter(creds.Username)),
+		[]string{s.cfg.FirstNameAttribute, s.cfg.LastNameAttribute, s.cfg.GroupAttribute},
+		nil,
+	))
+	if err != nil && !goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) {
+		return provision.Identity{}, translateErr(err)
+	}
+	// A search matching more than one entry is ambiguous, so it is treated the same as
+	// one that matches none.
+	if res == nil || len(res.Entries) != 1 {
+		return provision.Identity{}, auth.InvalidCredentials
+	}
+	entry := res.Entries[0]
+	if err = conn.Bind(entry.DN, string(creds.Password)); err != nil {
+		return provision.Identity{}, translateErr(err)
+	}
+	return provision.Identity{
+		Key:       "ldap#" + creds.Username,
+		Username:  creds.Username,
+		FirstName: entry.GetAttributeValue(s.cfg.FirstNameAttribute),
+		LastName:  entry.GetAttributeValue(s.cfg.LastNameAttribute),
+		Groups:    groups(entry.GetAttributeValues(s.cfg.GroupAttribute)),
+	}, nil
+}
+
+// groups returns both the DN and the common name of each of the given group DNs, so
+// that role mappings can refer to groups by either.
+func groups(dns []string) []string {
+	out := make([]string, 0, 2*len(dns))
+	for _, dn := range dns {
+		out = append(out, dn)
+		parsed, err := goldap.ParseDN(dn)
+		if err != nil || len(parsed.RDNs) == 0 {
+			continue
+		}
+		for _, attr := range parsed.RDNs[0].Attributes {
+			if strings.EqualFold(attr.Type, "cn") {
+				out = append(out, attr.Value)
+			}
+		}
+	}
+	return out
+}
+
+func translateErr(err error) error {
+	switch {
+	case goldap.IsErrorWithCode(err, goldap.ErrorNetwork):
+		return errors.Wrapf(unreachable, "%v", err)
+	case goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials):
+		return auth.InvalidCredentials
+	}
+	return errors.Wrapf(auth.Error, "directory error: %v", err)
+}
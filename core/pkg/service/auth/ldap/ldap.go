@@ -0,0 +1,331 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package ldap implements an auth.Authenticator that authenticates users against an
+// LDAP directory, such as OpenLDAP or Active Directory. Users are looked up with a
+// search, and their passwords are checked by binding as the entry that the search
+// finds. Users are provisioned on their first login, and their roles are updated on
+// every login to match their directory groups.
+//
+// Credentials that the directory accepts are cached in memory for a limited time, so
+// that users who logged in recently can keep logging in while the directory is
+// unreachable.
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/synnaxlabs/alamos"
+	"github.com/synnaxlabs/synnax/pkg/service/access/rbac"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/password"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/provision"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/validate"
+	"go.uber.org/zap"
+)
+
+// Config is the configuration for opening an LDAP Service.
+type Config struct {
+	// Instrumentation is used for logging.
+	alamos.Instrumentation
+	// URL is the URL of the directory server, using the ldap:// or ldaps:// scheme.
+	// [REQUIRED]
+	URL string
+	// StartTLS upgrades ldap:// connections to TLS before sending any credentials.
+	// [OPTIONAL] [DEFAULT: false]
+	StartTLS *bool
+	// TLS is the TLS configuration used for ldaps:// and StartTLS connections.
+	// [OPTIONAL] [DEFAULT: verify against the system certificate pool]
+	TLS *tls.Config
+	// BindDN is the DN of the service account used to search for users. If empty,
+	// searches are made anonymously.
+	// [OPTIONAL]
+	BindDN string
+	// BindPassword is the password of the service account.
+	// [OPTIONAL]
+	BindPassword string
+	// BaseDN is the DN that searches for users start at.
+	// [REQUIRED]
+	BaseDN string
+	// UserFilter is the filter used to search for a user, with %s standing for the
+	// escaped username. The search must match exactly one entry.
+	// [OPTIONAL] [DEFAULT: (uid=%s)]
+	UserFilter string
+	// FirstNameAttribute is the attribute of a user entry that holds their given name.
+	// [OPTIONAL] [DEFAULT: givenName]
+	FirstNameAttribute string
+	// LastNameAttribute is the attribute of a user entry that holds their surname.
+	// [OPTIONAL] [DEFAULT: sn]
+	LastNameAttribute string
+	// GroupAttribute is the attribute of a user entry that lists the DNs of the groups
+	// the user belongs to.
+	// [OPTIONAL] [DEFAULT: memberOf]
+	GroupAttribute string
+	// RoleMappings maps groups in the directory to the names of the Synnax roles
+	// assigned to their members. Groups can be given by their full DN or by their
+	// common name.
+	// [OPTIONAL]
+	RoleMappings map[string]string
+	// CacheTTL is how long credentials remain usable after the directory last accepted
+	// them, for use while the directory is unreachable. A negative value disables the
+	// cache.
+	// [OPTIONAL] [DEFAULT: 1 hour]
+	CacheTTL time.Duration
+	// Timeout is the timeout for connecting to and making requests of the directory.
+	// [OPTIONAL] [DEFAULT: 5 seconds]
+	Timeout time.Duration
+	// DB is the database that links between directory users and Synnax users are
+	// stored in.
+	// [REQUIRED]
+	DB *gorp.DB
+	// User is used to provision users on their first login.
+	// [REQUIRED]
+	User *user.Service
+	// RBAC is used to assign roles to users.
+	// [REQUIRED]
+	RBAC *rbac.Service
+	// Now returns the current time.
+	// [OPTIONAL] [DEFAULT: time.Now]
+	Now func() time.Time
+}
+
+var (
+	_ config.Config[Config] = Config{}
+	// DefaultConfig is the default configuration for an LDAP Service.
+	DefaultConfig = Config{
+		StartTLS:           config.False(),
+		UserFilter:         "(uid=%s)",
+		FirstNameAttribute: "givenName",
+		LastNameAttribute:  "sn",
+		GroupAttribute:     "memberOf",
+		CacheTTL:           time.Hour,
+		Timeout:            5 * time.Second,
+		Now:                time.Now,
+	}
+)
+
+// Override implements config.Config.
+func (c Config) Override(other Config) Config {
+	c.Instrumentation = override.Zero(c.Instrumentation, other.Instrumentation)
+	c.URL = override.String(c.URL, other.URL)
+	c.StartTLS = override.Nil(c.StartTLS, other.StartTLS)
+	c.TLS = override.Nil(c.TLS, other.TLS)
+	c.BindDN = override.String(c.BindDN, other.BindDN)
+	c.BindPassword = override.String(c.BindPassword, other.BindPassword)
+	c.BaseDN = override.String(c.BaseDN, other.BaseDN)
+	c.UserFilter = override.String(c.UserFilter, other.UserFilter)
+	c.FirstNameAttribute = override.String(c.FirstNameAttribute, other.FirstNameAttribute)
+	c.LastNameAttribute = override.String(c.LastNameAttribute, other.LastNameAttribute)
+	c.GroupAttribute = override.String(c.GroupAttribute, other.GroupAttribute)
+	if len(other.RoleMappings) > 0 {
+		c.RoleMappings = other.RoleMappings
+	}
+	c.CacheTTL = override.Numeric(c.CacheTTL, other.CacheTTL)
+	c.Timeout = override.Numeric(c.Timeout, other.Timeout)
+	c.DB = override.Nil(c.DB, other.DB)
+	c.User = override.Nil(c.User, other.User)
+	c.RBAC = override.Nil(c.RBAC, other.RBAC)
+	c.Now = override.Nil(c.Now, other.Now)
+	return c
+}
+
+// Validate implements config.Config.
+func (c Config) Validate() error {
+	v := validate.New("ldap")
+	validate.NotEmptyString(v, "url", c.URL)
+	validate.NotEmptyString(v, "base_dn", c.BaseDN)
+	validate.NotNil(v, "start_tls", c.StartTLS)
+	validate.Positive(v, "timeout", c.Timeout)
+	validate.NotNil(v, "db", c.DB)
+	validate.NotNil(v, "user", c.User)
+	validate.NotNil(v, "rbac", c.RBAC)
+	validate.NotNil(v, "now", c.Now)
+	v.Ternary("user_filter", strings.Count(c.UserFilter, "%s") != 1, "must contain %s exactly once")
+	u, err := url.Parse(c.URL)
+	v.Ternary(
+		"url",
+		err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps"),
+		"must use the ldap:// or ldaps:// scheme",
+	)
+	v.Ternary(
+		"start_tls",
+		c.StartTLS != nil && *c.StartTLS && strings.HasPrefix(c.URL, "ldaps://"),
+		"cannot be used with an ldaps:// URL",
+	)
+	return v.Error()
+}
+
+// cachedCredentials are credentials that the directory accepted.
+type cachedCredentials struct {
+	hash      password.Hashed
+	expiresAt time.Time
+}
+
+// Service authenticates users against an LDAP directory.
+type Service struct {
+	cfg         Config
+	tls         *tls.Config
+	provisioner *provision.Provisioner
+	mu          struct {
+		sync.Mutex
+		cache map[string]cachedCredentials
+	}
+}
+
+var _ auth.Authenticator = (*Service)(nil)
+
+// NewService opens a new LDAP Service. The directory is not contacted until the first
+// user logs in.
+func NewService(cfgs ...Config) (*Service, error) {
+	cfg, err := config.New(DefaultConfig, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	provisioner, err := provision.New(provision.Config{
+		Instrumentation: cfg.Instrumentation,
+		DB:              cfg.DB,
+		User:            cfg.User,
+		RBAC:            cfg.RBAC,
+		RoleMappings:    cfg.RoleMappings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s := &Service{cfg: cfg, tls: &tls.Config{MinVersion: tls.VersionTLS12}, provisioner: provisioner}
+	if cfg.TLS != nil {
+		s.tls = cfg.TLS.Clone()
+	}
+	// StartTLS needs the server name to verify the certificate of the directory.
+	if u, err := url.Parse(cfg.URL); err == nil && s.tls.ServerName == "" {
+		s.tls.ServerName = u.Hostname()
+	}
+	s.mu.cache = make(map[string]cachedCredentials)
+	return s, nil
+}
+
+// Authenticate implements auth.Authenticator, checking the credentials against the
+// directory and provisioning the user if this is their first login. If the directory
+// is unreachable, the credentials are checked against the cache instead.
+func (s *Service) Authenticate(ctx context.Context, creds auth.InsecureCredentials) error {
+	// An empty password would make the bind unauthenticated, which most directories
+	// accept for any DN.
+	if creds.Username == "" || creds.Password == "" {
+		return auth.InvalidCredentials
+	}
+	id, err := s.lookup(creds)
+	if errors.Is(err, unreachable) {
+		if s.checkCache(creds) {
+			s.cfg.L.Warn(
+				"directory unreachable, authenticated user with cached credentials",
+				zap.String("username", creds.Username),
+				zap.Error(err),
+			)
+			return nil
+		}
+		return err
+	}
+	if err != nil {
+		// Only discard the cache when the directory rejects the cached password
+		// itself, so that failed attempts at guessing a password can't lock a user
+		// out during an outage.
+		if errors.Is(err, auth.InvalidCredentials) && s.checkCache(creds) {
+			s.forget(creds.Username)
+		}
+		return err
+	}
+	if _, err = s.provisioner.Provision(ctx, id); err != nil {
+		return err
+	}
+	s.remember(creds)
+	return nil
+}
+
+func (s *Service) checkCache(creds auth.InsecureCredentials) bool {
+	s.mu.Lock()
+	c, ok := s.mu.cache[creds.Username]
+	s.mu.Unlock()
+	return ok && s.cfg.Now().Before(c.expiresAt) && c.hash.Validate(creds.Password) == nil
+}
+
+func (s *Service) remember(creds auth.InsecureCredentials) {
+	if s.cfg.CacheTTL < 0 {
+		return
+	}
+	hash, err := creds.Password.Hash()
+	if err != nil {
+		s.cfg.L.Warn("failed to cache credentials", zap.Error(err))
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.cache[creds.Username] = cachedCredentials{
+		hash:      hash,
+		expiresAt: s.cfg.Now().Add(s.cfg.CacheTTL),
+	}
+}
+
+func (s *Service) forget(usernames ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range usernames {
+		delete(s.mu.cache, u)
+	}
+}
+
+// NewWriter implements auth.Authenticator. The credentials of directory users are
+// managed by the directory, so they can't be changed through Synnax.
+func (s *Service) NewWriter(gorp.Tx) auth.Writer { return writer{svc: s} }
+
+// managedByDirectory is returned when trying to change the credentials of a user that
+// logs in with the directory.
+var managedByDirectory = errors.Wrap(
+	auth.Error,
+	"the credentials of LDAP users are managed by the directory",
+)
+
+type writer struct{ svc *Service }
+
+var _ auth.Writer = writer{}
+
+// Register implements auth.Writer.
+func (writer) Register(context.Context, auth.InsecureCredentials) error {
+	return managedByDirectory
+}
+
+// UpdateUsername implements auth.Writer.
+func (writer) UpdateUsername(context.Context, auth.InsecureCredentials, string) error {
+	return managedByDirectory
+}
+
+// UpdatePassword implements auth.Writer.
+func (writer) UpdatePassword(context.Context, auth.InsecureCredentials, password.Raw) error {
+	return managedByDirectory
+}
+
+// InsecureUpdateUsername implements auth.Writer.
+func (writer) InsecureUpdateUsername(context.Context, string, string) error {
+	return managedByDirectory
+}
+
+// InsecureDeactivate implements auth.Writer, discarding the cached credentials of the
+// given users.
+func (w writer) InsecureDeactivate(_ context.Context, usernames ...string) error {
+	w.svc.forget(usernames...)
+	return nil
+}
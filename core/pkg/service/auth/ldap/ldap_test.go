@@ -0,0 +1,214 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package ldap_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution/group"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access/rbac"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/ldap"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/ldap/mock"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/kv/memkv"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("LDAP", func() {
+	var (
+		db     *gorp.DB
+		otg    *ontology.Ontology
+		users  *user.Service
+		roles  *rbac.Service
+		server *mock.Server
+		svc    *ldap.Service
+		now    time.Time
+		alice  = auth.InsecureCredentials{Username: "alice", Password: "alice-password"}
+	)
+	BeforeEach(func() {
+		db = gorp.Wrap(memkv.New())
+		otg = MustSucceed(ontology.Open(ctx, ontology.Config{DB: db}))
+		g := MustSucceed(group.OpenService(ctx, group.Config{DB: db, Ontology: otg}))
+		users = MustSucceed(user.NewService(ctx, user.Config{DB: db, Ontology: otg, Group: g}))
+		roles = MustSucceed(rbac.NewService(rbac.Config{DB: db}))
+		server = MustSucceed(mock.NewServer(
+			mock.Entry{DN: "cn=search,dc=synnax,dc=io", Password: "search-password"},
+			mock.Entry{
+				DN:       "uid=alice,ou=people,dc=synnax,dc=io",
+				Password: "alice-password",
+				Attributes: map[string][]string{
+					"uid":       {"alice"},
+					"givenName": {"Alice"},
+					"sn":        {"Smith"},
+					"memberOf":  {"cn=ops,ou=groups,dc=synnax,dc=io"},
+				},
+			},
+		))
+		now = time.Now()
+		svc = MustSucceed(ldap.NewService(ldap.Config{
+			URL:          server.URL(),
+			BindDN:       "cn=search,dc=synnax,dc=io",
+			BindPassword: "search-password",
+			BaseDN:       "dc=synnax,dc=io",
+			RoleMappings: map[string]string{"ops": "operator"},
+			DB:           db,
+			User:         users,
+			RBAC:         roles,
+			Now:          func() time.Time { return now },
+		}))
+	})
+	AfterEach(func() {
+		_ = server.Close()
+		Expect(otg.Close()).To(Succeed())
+		Expect(db.Close()).To(Succeed())
+	})
+
+	Describe("Authenticate", func() {
+		It("Should authenticate a directory user and provision them", func() {
+			Expect(svc.Authenticate(ctx, alice)).To(Succeed())
+			var u user.User
+			Expect(users.NewRetrieve().WhereUsernames("alice").Entry(&u).Exec(ctx, nil)).To(Succeed())
+			Expect(u.FirstName).To(Equal("Alice"))
+			Expect(u.LastName).To(Equal("Smith"))
+			Expect(svc.Authenticate(ctx, alice)).To(Succeed())
+		})
+		It("Should reject an incorrect password", func() {
+			creds := alice
+			creds.Password = "wrong"
+			Expect(svc.Authenticate(ctx, creds)).To(MatchError(auth.InvalidCredentials))
+		})
+		It("Should reject an empty password", func() {
+			creds := alice
+			creds.Password = ""
+			Expect(svc.Authenticate(ctx, creds)).To(MatchError(auth.InvalidCredentials))
+		})
+		It("Should reject a user that is not in the directory", func() {
+			Expect(svc.Authenticate(ctx, auth.InsecureCredentials{
+				Username: "bob",
+				Password: "alice-password",
+			})).To(MatchError(auth.InvalidCredentials))
+		})
+		It("Should escape the username in the search filter", func() {
+			Expect(svc.Authenticate(ctx, auth.InsecureCredentials{
+				Username: "*",
+				Password: "alice-password",
+			})).To(MatchError(auth.InvalidCredentials))
+		})
+		It("Should not authenticate as a local user with the same username", func() {
+			Expect(users.NewWriter(nil).Create(ctx, &user.User{Username: "alice"})).To(Succeed())
+			Expect(svc.Authenticate(ctx, alice)).To(MatchError(auth.RepeatedUsername))
+		})
+		It("Should not reject users when the search user is misconfigured", func() {
+			svc = MustSucceed(ldap.NewService(ldap.Config{
+				URL:          server.URL(),
+				BindDN:       "cn=search,dc=synnax,dc=io",
+				BindPassword: "wrong",
+				BaseDN:       "dc=synnax,dc=io",
+				DB:           db,
+				User:         users,
+				RBAC:         roles,
+			}))
+			err := svc.Authenticate(ctx, alice)
+			Expect(err).To(MatchError(auth.Error))
+			Expect(err).ToNot(MatchError(auth.InvalidCredentials))
+		})
+		It("Should authenticate as part of a MultiAuthenticator", func() {
+			multi := auth.MultiAuthenticator{&auth.KV{DB: db}, svc}
+			Expect(multi.Authenticate(ctx, alice)).To(Succeed())
+		})
+	})
+
+	Describe("Cache", func() {
+		It("Should authenticate with cached credentials while the directory is unreachable", func() {
+			Expect(svc.Authenticate(ctx, alice)).To(Succeed())
+			Expect(server.Close()).To(Succeed())
+			Expect(svc.Authenticate(ctx, alice)).To(Succeed())
+			creds := alice
+			creds.Password = "wrong"
+			Expect(svc.Authenticate(ctx, creds)).To(HaveOccurred())
+		})
+		It("Should not authenticate uncached users while the directory is unreachable", func() {
+			Expect(server.Close()).To(Succeed())
+			Expect(svc.Authenticate(ctx, alice)).To(MatchError(auth.Error))
+		})
+		It("Should expire cached credentials", func() {
+			Expect(svc.Authenticate(ctx, alice)).To(Succeed())
+			Expect(server.Close()).To(Succeed())
+			now = now.Add(2 * time.Hour)
+			Expect(svc.Authenticate(ctx, alice)).To(MatchError(auth.Error))
+		})
+		It("Should discard cached credentials that the directory rejects", func() {
+			Expect(svc.Authenticate(ctx, alice)).To(Succeed())
+			server.Add(mock.Entry{
+				DN:         "uid=alice,ou=contractors,dc=synnax,dc=io",
+				Attributes: map[string][]string{"uid": {"alice"}},
+			})
+			Expect(svc.Authenticate(ctx, alice)).To(MatchError(auth.InvalidCredentials))
+			Expect(server.Close()).To(Succeed())
+			Expect(svc.Authenticate(ctx, alice)).To(MatchError(auth.Error))
+		})
+		It("Should not discard cached credentials after a failed login", func() {
+			Expect(svc.Authenticate(ctx, alice)).To(Succeed())
+			creds := alice
+			creds.Password = "wrong"
+			Expect(svc.Authenticate(ctx, creds)).To(MatchError(auth.InvalidCredentials))
+			Expect(server.Close()).To(Succeed())
+			Expect(svc.Authenticate(ctx, alice)).To(Succeed())
+		})
+		It("Should discard cached credentials when the user is deactivated", func() {
+			Expect(svc.Authenticate(ctx, alice)).To(Succeed())
+			Expect(svc.NewWriter(nil).InsecureDeactivate(ctx, "alice")).To(Succeed())
+			Expect(server.Close()).To(Succeed())
+			Expect(svc.Authenticate(ctx, alice)).To(MatchError(auth.Error))
+		})
+	})
+
+	Describe("Roles", func() {
+		It("Should assign roles mapped from the common names of directory groups", func() {
+			operator := rbac.Role{Name: "operator"}
+			Expect(roles.NewWriter(nil).CreateRole(ctx, &operator)).To(Succeed())
+			Expect(svc.Authenticate(ctx, alice)).To(Succeed())
+			var u user.User
+			Expect(users.NewRetrieve().WhereUsernames("alice").Entry(&u).Exec(ctx, nil)).To(Succeed())
+			var assigned []rbac.Role
+			Expect(roles.NewRoleRetrieve().
+				WhereSubjects(user.OntologyID(u.Key)).
+				Entries(&assigned).
+				Exec(ctx, nil)).To(Succeed())
+			Expect(assigned).To(HaveLen(1))
+			Expect(assigned[0].Key).To(Equal(operator.Key))
+		})
+	})
+
+	Describe("Writer", func() {
+		It("Should not allow changing the credentials of directory users", func() {
+			Expect(svc.NewWriter(nil).UpdatePassword(ctx, alice, "new")).To(MatchError(auth.Error))
+		})
+	})
+
+	Describe("Config", func() {
+		It("Should require the filter to contain the username placeholder", func() {
+			_, err := ldap.NewService(ldap.Config{
+				URL:        server.URL(),
+				BaseDN:     "dc=synnax,dc=io",
+				UserFilter: "(uid=alice)",
+				DB:         db,
+				User:       users,
+				RBAC:       roles,
+			})
+			Expect(err).To(MatchError(ContainSubstring("user_filter")))
+		})
+	})
+})
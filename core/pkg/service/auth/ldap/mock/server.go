@@ -0,0 +1,287 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package mock implements a minimal in-memory LDAP directory server for testing LDAP
+// authentication without a real directory. It supports simple binds and searches
+// with equality, presence, and boolean filters, which is all that Synnax uses.
+package mock
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+)
+
+// Entry is an entry in the directory.
+type Entry struct {
+	// DN is the distinguished name of the entry.
+	DN string
+	// Password is the password used to bind as the entry. Entries without a password
+	// can't be bound as.
+	Password string
+	// Attributes are the attributes of the entry.
+	Attributes map[string][]string
+}
+
+// Server is an in-memory LDAP directory served over TCP.
+type Server struct {
+	listener net.Listener
+	mu       sync.Mutex
+	entries  []Entry
+	conns    map[net.Conn]struct{}
+	wg       sync.WaitGroup
+}
+
+// NewServer starts a new Server on a random local port, serving the given entries.
+func NewServer(entries ...Entry) (*Server, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{listener: l, entries: entries, conns: make(map[net.Conn]struct{})}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// URL returns the ldap:// URL of the server.
+func (s *Server) URL() string { return "ldap://" + s.listener.Addr().String() }
+
+// Add adds an entry to the directory.
+func (s *Server) Add(e Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, e)
+}
+
+// Close stops the server and closes all open connections, making the directory
+// unreachable.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.mu.Lock()
+	for c := range s.conns {
+		_ = c.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[c] = struct{}{}
+		s.mu.Unlock()
+		s.wg.Add(1)
+		go s.handle(c)
+	}
+}
+
+const (
+	bindRequest       ber.Tag = 0
+	bindResponse      ber.Tag = 1
+	unbindRequest     ber.Tag = 2
+	searchRequest     ber.Tag = 3
+	searchResultEntry ber.Tag = 4
+	searchResultDone  ber.Tag = 5
+)
+
+const (
+	resultSuccess            = 0
+	resultNoSuchObject       = 32
+	resultInvalidCredentials = 49
+	resultUnwillingToPerform = 53
+)
+
+func (s *Server) handle(c net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		_ = c.Close()
+		s.wg.Done()
+	}()
+	for {
+		p, err := ber.ReadPacket(c)
+		if err != nil || len(p.Children) < 2 {
+			return
+		}
+		id := p.Children[0].Value
+		op := p.Children[1]
+		var responses []*ber.Packet
+		switch op.Tag {
+		case bindRequest:
+			responses = []*ber.Packet{s.bind(op)}
+		case searchRequest:
+			responses = s.search(op)
+		case unbindRequest:
+			return
+		default:
+			responses = []*ber.Packet{result(bindResponse, resultUnwillingToPerform)}
+		}
+		for _, r := range responses {
+			msg := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "message")
+			msg.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "id"))
+			msg.AppendChild(r)
+			if _, err = c.Write(msg.Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func result(tag ber.Tag, code int) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "result")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "code"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matched"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "message"))
+	return p
+}
+
+func (s *Server) bind(op *ber.Packet) *ber.Packet {
+	if len(op.Children) < 3 || op.Children[2].Tag != 0 {
+		return result(bindResponse, resultUnwillingToPerform)
+	}
+	dn, _ := op.Children[1].Value.(string)
+	pwd := op.Children[2].Data.String()
+	// Anonymous binds are always allowed.
+	if dn == "" && pwd == "" {
+		return result(bindResponse, resultSuccess)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.entries {
+		if strings.EqualFold(e.DN, dn) && e.Password != "" && e.Password == pwd {
+			return result(bindResponse, resultSuccess)
+		}
+	}
+	return result(bindResponse, resultInvalidCredentials)
+}
+
+func (s *Server) search(op *ber.Packet) []*ber.Packet {
+	if len(op.Children) < 8 {
+		return []*ber.Packet{result(searchResultDone, resultUnwillingToPerform)}
+	}
+	base, _ := op.Children[0].Value.(string)
+	filter := op.Children[6]
+	var requested []string
+	for _, a := range op.Children[7].Children {
+		if v, ok := a.Value.(string); ok {
+			requested = append(requested, v)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var (
+		out       []*ber.Packet
+		baseFound bool
+	)
+	for _, e := range s.entries {
+		// Entries are stored without their parents, so the base is taken to exist if
+		// any entry is below it.
+		if !strings.HasSuffix(strings.ToLower(e.DN), strings.ToLower(base)) {
+			continue
+		}
+		baseFound = true
+		if matches(e, filter) {
+			out = append(out, encodeEntry(e, requested))
+		}
+	}
+	if !baseFound {
+		return []*ber.Packet{result(searchResultDone, resultNoSuchObject)}
+	}
+	return append(out, result(searchResultDone, resultSuccess))
+}
+
+func encodeEntry(e Entry, requested []string) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, searchResultEntry, nil, "entry")
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.DN, "dn"))
+	attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "attributes")
+	for name, values := range e.Attributes {
+		if len(requested) > 0 && !containsFold(requested, name) {
+			continue
+		}
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "values")
+		for _, v := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "value"))
+		}
+		attr.AppendChild(set)
+		attrs.AppendChild(attr)
+	}
+	p.AppendChild(attrs)
+	return p
+}
+
+// Filter choices, as defined in RFC 4511.
+const (
+	filterAnd      ber.Tag = 0
+	filterOr       ber.Tag = 1
+	filterNot      ber.Tag = 2
+	filterEquality ber.Tag = 3
+	filterPresent  ber.Tag = 7
+)
+
+func matches(e Entry, f *ber.Packet) bool {
+	switch f.Tag {
+	case filterAnd:
+		for _, c := range f.Children {
+			if !matches(e, c) {
+				return false
+			}
+		}
+		return true
+	case filterOr:
+		for _, c := range f.Children {
+			if matches(e, c) {
+				return true
+			}
+		}
+		return false
+	case filterNot:
+		return len(f.Children) == 1 && !matches(e, f.Children[0])
+	case filterEquality:
+		if len(f.Children) != 2 {
+			return false
+		}
+		attr, _ := f.Children[0].Value.(string)
+		value, _ := f.Children[1].Value.(string)
+		return containsFold(attribute(e, attr), value)
+	case filterPresent:
+		attr := f.Data.String()
+		return strings.EqualFold(attr, "objectClass") || len(attribute(e, attr)) > 0
+	}
+	return false
+}
+
+func attribute(e Entry, name string) []string {
+	for k, v := range e.Attributes {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+func containsFold(values []string, v string) bool {
+	for _, x := range values {
+		if strings.EqualFold(x, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -11,7 +11,9 @@ package auth
 
 import (
 	"context"
+
 	"github.com/synnaxlabs/synnax/pkg/service/auth/password"
+	"github.com/synnaxlabs/x/errors"
 	"github.com/synnaxlabs/x/gorp"
 )
 
@@ -103,16 +105,16 @@ func (w multiWriter) InsecureUpdateUsername(
 	return err
 }
 
-// InsecureDeactivate implements the Authenticator interface.
+// InsecureDeactivate implements the Authenticator interface. Unlike the other
+// operations, the users are deactivated in every Authenticator, so that none of them
+// keep state for the users, and the errors of all Authenticator(s) are combined.
 func (w multiWriter) InsecureDeactivate(
 	ctx context.Context,
 	usernames ...string,
 ) error {
 	var err error
 	for _, auth := range w {
-		if err = auth.InsecureDeactivate(ctx, usernames...); err == nil {
-			return nil
-		}
+		err = errors.Combine(err, auth.InsecureDeactivate(ctx, usernames...))
 	}
 	return err
 }
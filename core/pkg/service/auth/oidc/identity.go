@@ -0,0 +1,49 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package oidc
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/provision"
+	"github.com/synnaxlabs/x/errors"
+)
+
+// identity extracts the identity of a user from the claims of their ID token. The
+// identity is keyed by the issuer joined with the subject of the token, which the
+// provider guarantees to be stable.
+func (s *Service) identity(claims jwt.MapClaims) (provision.Identity, error) {
+	str := func(key string) string { v, _ := claims[key].(string); return v }
+	sub := str("sub")
+	if sub == "" {
+		return provision.Identity{}, errors.Wrap(auth.InvalidToken, "ID token has no subject")
+	}
+	id := provision.Identity{
+		Key:       s.provider.issuer + "#" + sub,
+		Username:  str(s.cfg.UsernameClaim),
+		FirstName: str("given_name"),
+		LastName:  str("family_name"),
+	}
+	if id.Username == "" {
+		id.Username = lo.CoalesceOrEmpty(str("email"), sub)
+	}
+	switch groups := claims[s.cfg.GroupsClaim].(type) {
+	case string:
+		id.Groups = []string{groups}
+	case []any:
+		for _, g := range groups {
+			if gs, ok := g.(string); ok {
+				id.Groups = append(id.Groups, gs)
+			}
+		}
+	}
+	return id, nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package mock implements a local OpenID Connect identity provider for testing single
+// sign-on without a real provider.
+package mock
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/synnaxlabs/x/errors"
+)
+
+const keyID = "mock"
+
+type grant struct {
+	claims      jwt.MapClaims
+	challenge   string
+	redirectURI string
+}
+
+// Provider is a local OpenID Connect identity provider. It serves the discovery
+// document, key set, and token endpoint of a provider over HTTP, and users sign in
+// with SignIn instead of a sign-in page.
+type Provider struct {
+	*httptest.Server
+	// ClientID is the ID of the only client registered with the provider.
+	ClientID string
+	// ClientSecret is the secret of the client. If empty, the client is public.
+	ClientSecret string
+	key          *rsa.PrivateKey
+	mu           sync.Mutex
+	grants       map[string]grant
+}
+
+// NewProvider starts a new Provider with a single registered client.
+func NewProvider(clientID, clientSecret string) (*Provider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	p := &Provider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		grants:       make(map[string]grant),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("GET /jwks", p.jwks)
+	mux.HandleFunc("POST /token", p.token)
+	p.Server = httptest.NewServer(mux)
+	return p, nil
+}
+
+// Issuer returns the issuer URL of the provider.
+func (p *Provider) Issuer() string { return p.URL }
+
+// SignIn signs a user with the given claims in at the sign-in URL that Synnax
+// generated, returning the authorization code and state that the provider would pass
+// to the redirect URL. The claims should include at least a subject.
+func (p *Provider) SignIn(signInURL string, claims map[string]any) (code, state string, err error) {
+	u, err := url.Parse(signInURL)
+	if err != nil {
+		return "", "", err
+	}
+	q := u.Query()
+	if q.Get("client_id") != p.ClientID {
+		return "", "", errors.Newf("unknown client %q", q.Get("client_id"))
+	}
+	if q.Get("code_challenge_method") != "S256" {
+		return "", "", errors.New("code challenge must use S256")
+	}
+	c := jwt.MapClaims{}
+	for k, v := range claims {
+		c[k] = v
+	}
+	c["nonce"] = q.Get("nonce")
+	code = rand.Text()
+	p.mu.Lock()
+	p.grants[code] = grant{
+		claims:      c,
+		challenge:   q.Get("code_challenge"),
+		redirectURI: q.Get("redirect_uri"),
+	}
+	p.mu.Unlock()
+	return code, q.Get("state"), nil
+}
+
+func (p *Provider) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                 p.URL,
+		"authorization_endpoint": p.URL + "/authorize",
+		"token_endpoint":         p.URL + "/token",
+		"jwks_uri":               p.URL + "/jwks",
+	})
+}
+
+func (p *Provider) jwks(w http.ResponseWriter, _ *http.Request) {
+	pub := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, "invalid_request")
+		return
+	}
+	if p.ClientSecret != "" {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != p.ClientID || secret != p.ClientSecret {
+			tokenError(w, "invalid_client")
+			return
+		}
+	}
+	code := r.PostForm.Get("code")
+	p.mu.Lock()
+	g, ok := p.grants[code]
+	delete(p.grants, code)
+	p.mu.Unlock()
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok ||
+		r.PostForm.Get("grant_type") != "authorization_code" ||
+		r.PostForm.Get("redirect_uri") != g.redirectURI ||
+		base64.RawURLEncoding.EncodeToString(challenge[:]) != g.challenge {
+		tokenError(w, "invalid_grant")
+		return
+	}
+	now := time.Now()
+	g.claims["iss"] = p.URL
+	g.claims["aud"] = p.ClientID
+	g.claims["iat"] = now.Unix()
+	g.claims["exp"] = now.Add(time.Hour).Unix()
+	t := jwt.NewWithClaims(jwt.SigningMethodRS256, g.claims)
+	t.Header["kid"] = keyID
+	idToken, err := t.SignedString(p.key)
+	if err != nil {
+		tokenError(w, "server_error")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": rand.Text(),
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func tokenError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
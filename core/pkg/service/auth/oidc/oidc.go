@@ -0,0 +1,417 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package oidc implements single sign-on with an OpenID Connect identity provider.
+//
+// Users sign in with the authorization code flow: a client asks Synnax for the URL of
+// the provider's sign-in page, the provider redirects the user back to the client with
+// an authorization code, and the client hands the code back to Synnax. Synnax exchanges
+// the code for an ID token, provisions a user for the identity on its first login, and
+// assigns the user the roles mapped from their groups at the provider.
+//
+// The Service implements auth.Authenticator so that it can be combined with other
+// authenticators in an auth.MultiAuthenticator. Completing the code flow issues a
+// short-lived, single-use login ticket, and the ticket is accepted as the password of
+// the user it was issued to, so the login then proceeds like any other.
+package oidc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/alamos"
+	"github.com/synnaxlabs/synnax/pkg/service/access/rbac"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/password"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/provision"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Config is the configuration for opening an OIDC Service.
+type Config struct {
+	// Instrumentation is used for logging.
+	alamos.Instrumentation
+	// Issuer is the issuer URL of the identity provider. Its discovery document must be
+	// served at the /.well-known/openid-configuration path under the issuer.
+	// [REQUIRED]
+	Issuer string
+	// ClientID is the ID of the client registered for Synnax at the provider.
+	// [REQUIRED]
+	ClientID string
+	// ClientSecret is the secret of the client registered for Synnax at the provider.
+	// Public clients that rely on PKCE alone can leave it empty.
+	// [OPTIONAL]
+	ClientSecret string
+	// RedirectURL is the URL that the provider redirects users back to after they sign
+	// in. It must be registered with the provider.
+	// [REQUIRED]
+	RedirectURL string
+	// Scopes are the scopes requested from the provider.
+	// [OPTIONAL] [DEFAULT: openid, profile, email]
+	Scopes []string
+	// UsernameClaim is the claim of the ID token used as the username of the user. If
+	// the claim is missing, the email and then the subject of the token are used.
+	// [OPTIONAL] [DEFAULT: preferred_username]
+	UsernameClaim string
+	// GroupsClaim is the claim of the ID token that lists the groups of the user at the
+	// provider.
+	// [OPTIONAL] [DEFAULT: groups]
+	GroupsClaim string
+	// RoleMappings maps the names of groups at the provider to the names of the Synnax
+	// roles assigned to their members. Roles are assigned and unassigned on every
+	// login to match the groups of the user.
+	// [OPTIONAL]
+	RoleMappings map[string]string
+	// DB is the database that links between identities and users are stored in.
+	// [REQUIRED]
+	DB *gorp.DB
+	// User is used to provision users on their first login.
+	// [REQUIRED]
+	User *user.Service
+	// RBAC is used to assign roles to users.
+	// [REQUIRED]
+	RBAC *rbac.Service
+	// HTTPClient is used to make requests to the provider.
+	// [OPTIONAL] [DEFAULT: a client with a 10 second timeout]
+	HTTPClient *http.Client
+	// Now returns the current time.
+	// [OPTIONAL] [DEFAULT: time.Now]
+	Now func() time.Time
+}
+
+var (
+	_ config.Config[Config] = Config{}
+	// DefaultConfig is the default configuration for an OIDC Service.
+	DefaultConfig = Config{
+		Scopes:        []string{"openid", "profile", "email"},
+		UsernameClaim: "preferred_username",
+		GroupsClaim:   "groups",
+		HTTPClient:    &http.Client{Timeout: 10 * time.Second},
+		Now:           time.Now,
+	}
+)
+
+// Override implements config.Config.
+func (c Config) Override(other Config) Config {
+	c.Instrumentation = override.Zero(c.Instrumentation, other.Instrumentation)
+	c.Issuer = override.String(c.Issuer, other.Issuer)
+	c.ClientID = override.String(c.ClientID, other.ClientID)
+	c.ClientSecret = override.String(c.ClientSecret, other.ClientSecret)
+	c.RedirectURL = override.String(c.RedirectURL, other.RedirectURL)
+	c.Scopes = override.Slice(c.Scopes, other.Scopes)
+	c.UsernameClaim = override.String(c.UsernameClaim, other.UsernameClaim)
+	c.GroupsClaim = override.String(c.GroupsClaim, other.GroupsClaim)
+	if len(other.RoleMappings) > 0 {
+		c.RoleMappings = other.RoleMappings
+	}
+	c.DB = override.Nil(c.DB, other.DB)
+	c.User = override.Nil(c.User, other.User)
+	c.RBAC = override.Nil(c.RBAC, other.RBAC)
+	c.HTTPClient = override.Nil(c.HTTPClient, other.HTTPClient)
+	c.Now = override.Nil(c.Now, other.Now)
+	return c
+}
+
+// Validate implements config.Config.
+func (c Config) Validate() error {
+	v := validate.New("oidc")
+	validate.NotEmptyString(v, "issuer", c.Issuer)
+	validate.NotEmptyString(v, "client_id", c.ClientID)
+	validate.NotEmptyString(v, "redirect_url", c.RedirectURL)
+	validate.NotEmptyString(v, "username_claim", c.UsernameClaim)
+	validate.NotNil(v, "db", c.DB)
+	validate.NotNil(v, "user", c.User)
+	validate.NotNil(v, "rbac", c.RBAC)
+	validate.NotNil(v, "http_client", c.HTTPClient)
+	validate.NotNil(v, "now", c.Now)
+	v.Ternary("scopes", !lo.Contains(c.Scopes, "openid"), "must include openid")
+	return v.Error()
+}
+
+const (
+	// authorizationTTL is how long a user has to sign in at the provider after the
+	// sign-in URL is issued.
+	authorizationTTL = 10 * time.Minute
+	// ticketTTL is how long a login ticket remains valid after the code flow completes.
+	ticketTTL = time.Minute
+	// stateIDSize is the number of random bytes that identify a sign-in request in its
+	// state.
+	stateIDSize = 16
+	// statePayloadSize is the size of the signed part of a state: the random ID of the
+	// sign-in request followed by its expiry in Unix seconds.
+	statePayloadSize = stateIDSize + 8
+)
+
+// ticket is a single-use login ticket issued to a user after they sign in at the
+// provider.
+type ticket struct {
+	username  string
+	expiresAt time.Time
+}
+
+// Service implements single sign-on with an OpenID Connect identity provider.
+// Pending sign-in requests are not stored: the state passed to the provider is signed
+// and carries its expiry, and the nonce and PKCE verifier of the request are derived
+// from it. Login tickets are held in memory, and states are signed with a key
+// generated when the service opens, so a user must complete the flow against the same
+// node that started it.
+type Service struct {
+	cfg         Config
+	provider    *provider
+	provisioner *provision.Provisioner
+	// key signs states and derives the nonces and verifiers of sign-in requests.
+	key []byte
+	mu  struct {
+		sync.Mutex
+		tickets map[string]ticket
+	}
+}
+
+var _ auth.Authenticator = (*Service)(nil)
+
+// NewService opens a new OIDC Service. The provider is not contacted until the first
+// user signs in.
+func NewService(cfgs ...Config) (*Service, error) {
+	cfg, err := config.New(DefaultConfig, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	provisioner, err := provision.New(provision.Config{
+		Instrumentation: cfg.Instrumentation,
+		DB:              cfg.DB,
+		User:            cfg.User,
+		RBAC:            cfg.RBAC,
+		RoleMappings:    cfg.RoleMappings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	key := make([]byte, sha256.Size)
+	if _, err = rand.Read(key); err != nil {
+		return nil, err
+	}
+	s := &Service{
+		cfg:         cfg,
+		provider:    newProvider(cfg.Issuer, cfg.HTTPClient),
+		provisioner: provisioner,
+		key:         key,
+	}
+	s.mu.tickets = make(map[string]ticket)
+	return s, nil
+}
+
+// Authorize starts a sign-in, returning the URL of the provider's sign-in page along
+// with the state that the provider passes back to the redirect URL.
+func (s *Service) Authorize(ctx context.Context) (string, string, error) {
+	md, err := s.provider.metadata(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	state, err := s.newState()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, verifier := s.derive("nonce", state), s.derive("verifier", state)
+	challenge := sha256.Sum256([]byte(verifier))
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {s.cfg.ClientID},
+		"redirect_uri":          {s.cfg.RedirectURL},
+		"scope":                 {strings.Join(s.cfg.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(md.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return md.AuthorizationEndpoint + sep + q.Encode(), state, nil
+}
+
+// Login completes a sign-in with the authorization code and state that the provider
+// passed to the redirect URL. The user is provisioned if this is their first login,
+// and their roles are updated to match their groups at the provider. Login returns
+// credentials that authenticate the user exactly once, within a minute.
+func (s *Service) Login(ctx context.Context, code, state string) (auth.InsecureCredentials, error) {
+	if !s.validState(state) {
+		return auth.InsecureCredentials{}, errors.Wrap(
+			auth.InvalidCredentials,
+			"unknown or expired sign-in request",
+		)
+	}
+	idToken, err := s.provider.exchange(ctx, s.cfg, code, s.derive("verifier", state))
+	if err != nil {
+		return auth.InsecureCredentials{}, err
+	}
+	claims, err := s.provider.verify(ctx, s.cfg, idToken)
+	if err != nil {
+		return auth.InsecureCredentials{}, err
+	}
+	if nonce, _ := claims["nonce"].(string); nonce != s.derive("nonce", state) {
+		return auth.InsecureCredentials{}, errors.Wrap(auth.InvalidToken, "ID token nonce mismatch")
+	}
+	id, err := s.identity(claims)
+	if err != nil {
+		return auth.InsecureCredentials{}, err
+	}
+	u, err := s.provisioner.Provision(ctx, id)
+	if err != nil {
+		return auth.InsecureCredentials{}, err
+	}
+	t, err := randomString()
+	if err != nil {
+		return auth.InsecureCredentials{}, err
+	}
+	s.mu.Lock()
+	s.mu.tickets[hashTicket(t)] = ticket{
+		username:  u.Username,
+		expiresAt: s.cfg.Now().Add(ticketTTL),
+	}
+	s.mu.Unlock()
+	return auth.InsecureCredentials{Username: u.Username, Password: password.Raw(t)}, nil
+}
+
+// Authenticate implements auth.Authenticator, accepting the login tickets issued by
+// Login as the passwords of the users they were issued to. Each ticket can only be
+// used once.
+func (s *Service) Authenticate(_ context.Context, creds auth.InsecureCredentials) error {
+	h := hashTicket(string(creds.Password))
+	now := s.cfg.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, t := range s.mu.tickets {
+		if now.After(t.expiresAt) {
+			delete(s.mu.tickets, k)
+		}
+	}
+	t, ok := s.mu.tickets[h]
+	if !ok || t.username != creds.Username {
+		return auth.InvalidCredentials
+	}
+	delete(s.mu.tickets, h)
+	return nil
+}
+
+// NewWriter implements auth.Authenticator. The credentials of users that sign in with
+// the provider are managed by the provider, so they can't be changed through Synnax.
+func (s *Service) NewWriter(gorp.Tx) auth.Writer { return writer{svc: s} }
+
+// managedByProvider is returned when trying to change the credentials of a user that
+// signs in with the provider.
+var managedByProvider = errors.Wrap(
+	auth.Error,
+	"the credentials of single sign-on users are managed by the identity provider",
+)
+
+type writer struct{ svc *Service }
+
+var _ auth.Writer = writer{}
+
+// Register implements auth.Writer.
+func (writer) Register(context.Context, auth.InsecureCredentials) error {
+	return managedByProvider
+}
+
+// UpdateUsername implements auth.Writer.
+func (writer) UpdateUsername(context.Context, auth.InsecureCredentials, string) error {
+	return managedByProvider
+}
+
+// UpdatePassword implements auth.Writer.
+func (writer) UpdatePassword(context.Context, auth.InsecureCredentials, password.Raw) error {
+	return managedByProvider
+}
+
+// InsecureUpdateUsername implements auth.Writer.
+func (writer) InsecureUpdateUsername(context.Context, string, string) error {
+	return managedByProvider
+}
+
+// InsecureDeactivate implements auth.Writer, discarding the outstanding login tickets
+// of the given users.
+func (w writer) InsecureDeactivate(_ context.Context, usernames ...string) error {
+	w.svc.mu.Lock()
+	defer w.svc.mu.Unlock()
+	for k, t := range w.svc.mu.tickets {
+		for _, u := range usernames {
+			if t.username == u {
+				delete(w.svc.mu.tickets, k)
+			}
+		}
+	}
+	return nil
+}
+
+// newState returns the state of a new sign-in request, made up of a random ID and the
+// expiry of the request, signed with the key of the service.
+func (s *Service) newState() (string, error) {
+	payload := make([]byte, statePayloadSize, statePayloadSize+sha256.Size)
+	if _, err := rand.Read(payload[:stateIDSize]); err != nil {
+		return "", err
+	}
+	expiresAt := s.cfg.Now().Add(authorizationTTL).Unix()
+	binary.BigEndian.PutUint64(payload[stateIDSize:], uint64(expiresAt))
+	return base64.RawURLEncoding.EncodeToString(append(payload, s.sign(payload)...)), nil
+}
+
+// validState returns true if the state was issued by the service and has not expired.
+func (s *Service) validState(state string) bool {
+	b, err := base64.RawURLEncoding.DecodeString(state)
+	if err != nil || len(b) != statePayloadSize+sha256.Size {
+		return false
+	}
+	payload, mac := b[:statePayloadSize], b[statePayloadSize:]
+	if !hmac.Equal(mac, s.sign(payload)) {
+		return false
+	}
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[stateIDSize:])), 0)
+	return !s.cfg.Now().After(expiresAt)
+}
+
+// derive derives a secret for the sign-in request with the given state, so that the
+// nonce and PKCE verifier of the request do not need to be stored.
+func (s *Service) derive(label, state string) string {
+	return base64.RawURLEncoding.EncodeToString(s.sign([]byte(label + ":" + state)))
+}
+
+func (s *Service) sign(b []byte) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write(b)
+	return h.Sum(nil)
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashTicket(t string) string {
+	h := sha256.Sum256([]byte(t))
+	return string(h[:])
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package oidc_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution/group"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access/rbac"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/oidc"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/oidc/mock"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/kv/memkv"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("OIDC", func() {
+	var (
+		db       *gorp.DB
+		otg      *ontology.Ontology
+		users    *user.Service
+		roles    *rbac.Service
+		provider *mock.Provider
+		svc      *oidc.Service
+		signIn   = func(claims map[string]any) (auth.InsecureCredentials, error) {
+			u, state := MustSucceed2(svc.Authorize(ctx))
+			Expect(state).ToNot(BeEmpty())
+			code, returnedState := MustSucceed2(provider.SignIn(u, claims))
+			Expect(returnedState).To(Equal(state))
+			return svc.Login(ctx, code, state)
+		}
+	)
+	BeforeEach(func() {
+		db = gorp.Wrap(memkv.New())
+		otg = MustSucceed(ontology.Open(ctx, ontology.Config{DB: db}))
+		g := MustSucceed(group.OpenService(ctx, group.Config{DB: db, Ontology: otg}))
+		users = MustSucceed(user.NewService(ctx, user.Config{DB: db, Ontology: otg, Group: g}))
+		roles = MustSucceed(rbac.NewService(rbac.Config{DB: db}))
+		provider = MustSucceed(mock.NewProvider("synnax", "secret"))
+		svc = MustSucceed(oidc.NewService(oidc.Config{
+			Issuer:       provider.Issuer(),
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			RedirectURL:  "http://localhost:9090/callback",
+			RoleMappings: map[string]string{"ops": "operator"},
+			DB:           db,
+			User:         users,
+			RBAC:         roles,
+		}))
+	})
+	AfterEach(func() {
+		provider.Close()
+		Expect(otg.Close()).To(Succeed())
+		Expect(db.Close()).To(Succeed())
+	})
+
+	Describe("Login", func() {
+		It("Should provision a user on their first login", func() {
+			creds := MustSucceed(signIn(map[string]any{
+				"sub":                "alice-sub",
+				"preferred_username": "alice",
+				"given_name":         "Alice",
+			}))
+			Expect(creds.Username).To(Equal("alice"))
+			var u user.User
+			Expect(users.NewRetrieve().WhereUsernames("alice").Entry(&u).Exec(ctx, nil)).To(Succeed())
+			Expect(u.FirstName).To(Equal("Alice"))
+		})
+		It("Should sign in the same user on later logins", func() {
+			MustSucceed(signIn(map[string]any{"sub": "alice-sub", "preferred_username": "alice"}))
+			MustSucceed(signIn(map[string]any{"sub": "alice-sub", "preferred_username": "alice"}))
+			var us []user.User
+			Expect(users.NewRetrieve().Entries(&us).Exec(ctx, nil)).To(Succeed())
+			Expect(us).To(HaveLen(1))
+		})
+		It("Should fall back to the email of the user for their username", func() {
+			creds := MustSucceed(signIn(map[string]any{"sub": "bob-sub", "email": "bob@example.com"}))
+			Expect(creds.Username).To(Equal("bob@example.com"))
+		})
+		It("Should not sign in as a local user with the same username", func() {
+			Expect(users.NewWriter(nil).Create(ctx, &user.User{Username: "admin"})).To(Succeed())
+			_, err := signIn(map[string]any{"sub": "mallory-sub", "preferred_username": "admin"})
+			Expect(err).To(MatchError(auth.RepeatedUsername))
+		})
+		It("Should reject an unknown state", func() {
+			u, _ := MustSucceed2(svc.Authorize(ctx))
+			code, _ := MustSucceed2(provider.SignIn(u, map[string]any{"sub": "alice-sub"}))
+			_, err := svc.Login(ctx, code, "forged")
+			Expect(err).To(MatchError(auth.InvalidCredentials))
+		})
+		It("Should reject a state issued by another service", func() {
+			other := MustSucceed(oidc.NewService(oidc.Config{
+				Issuer:      provider.Issuer(),
+				ClientID:    provider.ClientID,
+				RedirectURL: "http://localhost:9090/callback",
+				DB:          db,
+				User:        users,
+				RBAC:        roles,
+			}))
+			u, state := MustSucceed2(other.Authorize(ctx))
+			code, _ := MustSucceed2(provider.SignIn(u, map[string]any{"sub": "alice-sub"}))
+			_, err := svc.Login(ctx, code, state)
+			Expect(err).To(MatchError(auth.InvalidCredentials))
+		})
+		It("Should reject an expired state", func() {
+			now := time.Now()
+			expiring := MustSucceed(oidc.NewService(oidc.Config{
+				Issuer:       provider.Issuer(),
+				ClientID:     provider.ClientID,
+				ClientSecret: provider.ClientSecret,
+				RedirectURL:  "http://localhost:9090/callback",
+				DB:           db,
+				User:         users,
+				RBAC:         roles,
+				Now:          func() time.Time { return now },
+			}))
+			u, state := MustSucceed2(expiring.Authorize(ctx))
+			code, _ := MustSucceed2(provider.SignIn(u, map[string]any{"sub": "alice-sub"}))
+			now = now.Add(time.Hour)
+			_, err := expiring.Login(ctx, code, state)
+			Expect(err).To(MatchError(ContainSubstring("expired")))
+		})
+		It("Should reject a code that the provider does not accept", func() {
+			_, state := MustSucceed2(svc.Authorize(ctx))
+			_, err := svc.Login(ctx, "forged", state)
+			Expect(err).To(MatchError(auth.InvalidCredentials))
+		})
+	})
+
+	Describe("Authenticate", func() {
+		It("Should accept a login ticket exactly once", func() {
+			creds := MustSucceed(signIn(map[string]any{"sub": "alice-sub", "preferred_username": "alice"}))
+			Expect(svc.Authenticate(ctx, creds)).To(Succeed())
+			Expect(svc.Authenticate(ctx, creds)).To(MatchError(auth.InvalidCredentials))
+		})
+		It("Should not accept a ticket for a different user", func() {
+			creds := MustSucceed(signIn(map[string]any{"sub": "alice-sub", "preferred_username": "alice"}))
+			creds.Username = "bob"
+			Expect(svc.Authenticate(ctx, creds)).To(MatchError(auth.InvalidCredentials))
+		})
+		It("Should authenticate as part of a MultiAuthenticator", func() {
+			multi := auth.MultiAuthenticator{&auth.KV{DB: db}, svc}
+			creds := MustSucceed(signIn(map[string]any{"sub": "alice-sub", "preferred_username": "alice"}))
+			Expect(multi.Authenticate(ctx, creds)).To(Succeed())
+		})
+		It("Should not allow changing the credentials of single sign-on users", func() {
+			creds := MustSucceed(signIn(map[string]any{"sub": "alice-sub", "preferred_username": "alice"}))
+			Expect(svc.NewWriter(nil).UpdatePassword(ctx, creds, "new")).To(MatchError(auth.Error))
+		})
+	})
+
+	Describe("Roles", func() {
+		It("Should assign and unassign roles to match the groups of the user", func() {
+			operator := rbac.Role{Name: "operator"}
+			Expect(roles.NewWriter(nil).CreateRole(ctx, &operator)).To(Succeed())
+			claims := map[string]any{
+				"sub":                "alice-sub",
+				"preferred_username": "alice",
+				"groups":             []any{"ops"},
+			}
+			MustSucceed(signIn(claims))
+			var u user.User
+			Expect(users.NewRetrieve().WhereUsernames("alice").Entry(&u).Exec(ctx, nil)).To(Succeed())
+			var assigned []rbac.Role
+			Expect(roles.NewRoleRetrieve().
+				WhereSubjects(user.OntologyID(u.Key)).
+				Entries(&assigned).
+				Exec(ctx, nil)).To(Succeed())
+			Expect(assigned).To(HaveLen(1))
+			Expect(assigned[0].Key).To(Equal(operator.Key))
+
+			claims["groups"] = []any{}
+			MustSucceed(signIn(claims))
+			assigned = nil
+			Expect(roles.NewRoleRetrieve().
+				WhereSubjects(user.OntologyID(u.Key)).
+				Entries(&assigned).
+				Exec(ctx, nil)).To(Succeed())
+			Expect(assigned).To(BeEmpty())
+		})
+	})
+})
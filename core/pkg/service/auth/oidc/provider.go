@@ -0,0 +1,283 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/x/errors"
+)
+
+// discoveryPath is the path of the OpenID Connect discovery document relative to the
+// issuer.
+const discoveryPath = "/.well-known/openid-configuration"
+
+// metadata is the subset of the OpenID Connect discovery document used by Synnax.
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// provider is a client for the endpoints of an identity provider. The discovery
+// document and signing keys of the provider are fetched lazily and cached, so that
+// Synnax can start while the provider is unreachable.
+type provider struct {
+	issuer string
+	client *http.Client
+	mu     struct {
+		sync.Mutex
+		metadata *metadata
+		keys     map[string]crypto.PublicKey
+	}
+}
+
+func newProvider(issuer string, client *http.Client) *provider {
+	return &provider{issuer: strings.TrimSuffix(issuer, "/"), client: client}
+}
+
+func (p *provider) metadata(ctx context.Context) (metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.mu.metadata != nil {
+		return *p.mu.metadata, nil
+	}
+	var md metadata
+	if err := p.getJSON(ctx, p.issuer+discoveryPath, &md); err != nil {
+		return md, err
+	}
+	if strings.TrimSuffix(md.Issuer, "/") != p.issuer {
+		return md, errors.Wrapf(
+			auth.Error,
+			"identity provider reported issuer %q, expected %q",
+			md.Issuer,
+			p.issuer,
+		)
+	}
+	p.mu.metadata = &md
+	return md, nil
+}
+
+// key returns the signing key with the given ID. The key set of the provider is
+// refetched when the key is unknown, so that keys the provider rotates in are picked up.
+func (p *provider) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	k, ok := p.mu.keys[kid]
+	p.mu.Unlock()
+	if ok {
+		return k, nil
+	}
+	md, err := p.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err = p.getJSON(ctx, md.JWKSURI, &set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, j := range set.Keys {
+		if j.Use != "" && j.Use != "sig" {
+			continue
+		}
+		// Keys of unsupported types are skipped rather than failing the whole set.
+		if pub, err := j.publicKey(); err == nil {
+			keys[j.Kid] = pub
+		}
+	}
+	p.mu.Lock()
+	p.mu.keys = keys
+	p.mu.Unlock()
+	if k, ok = keys[kid]; !ok {
+		return nil, errors.Wrapf(auth.InvalidToken, "unknown signing key %q", kid)
+	}
+	return k, nil
+}
+
+// tokenResponse is the response of the token endpoint of an identity provider.
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// exchange exchanges an authorization code for the ID token of the user that
+// authorized it.
+func (p *provider) exchange(
+	ctx context.Context,
+	cfg Config,
+	code string,
+	verifier string,
+) (string, error) {
+	md, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {cfg.RedirectURL},
+		"client_id":     {cfg.ClientID},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		md.TokenEndpoint,
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(cfg.ClientID), url.QueryEscape(cfg.ClientSecret))
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = res.Body.Close() }()
+	var tr tokenResponse
+	if err = json.NewDecoder(io.LimitReader(res.Body, maxResponseSize)).Decode(&tr); err != nil {
+		return "", errors.Wrapf(auth.Error, "invalid token response: %v", err)
+	}
+	if tr.Error != "" {
+		return "", errors.Wrapf(auth.InvalidCredentials, "%s: %s", tr.Error, tr.ErrorDescription)
+	}
+	if tr.IDToken == "" {
+		return "", errors.Wrap(auth.Error, "identity provider did not return an ID token")
+	}
+	return tr.IDToken, nil
+}
+
+// validMethods are the signing methods accepted for ID tokens.
+var validMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+// verify verifies the signature, issuer, audience, and expiration of the given ID
+// token, returning its claims.
+func (p *provider) verify(
+	ctx context.Context,
+	cfg Config,
+	idToken string,
+) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(
+		idToken,
+		claims,
+		func(t *jwt.Token) (any, error) {
+			kid, _ := t.Header["kid"].(string)
+			return p.key(ctx, kid)
+		},
+		jwt.WithValidMethods(validMethods),
+		jwt.WithIssuer(p.issuer),
+		jwt.WithAudience(cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(cfg.Now),
+		jwt.WithLeeway(time.Minute),
+	); err != nil {
+		return nil, errors.Wrapf(auth.InvalidToken, "invalid ID token: %v", err)
+	}
+	return claims, nil
+}
+
+// maxResponseSize is the maximum size of a response read from an identity provider.
+const maxResponseSize = 1 << 20
+
+func (p *provider) getJSON(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(auth.Error, "failed to reach identity provider: %v", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		return errors.Wrapf(auth.Error, "identity provider returned %s for %s", res.Status, u)
+	}
+	return json.NewDecoder(io.LimitReader(res.Body, maxResponseSize)).Decode(v)
+}
+
+// jwk is a JSON Web Key as published in the key set of an identity provider.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (j jwk) publicKey() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := decodeBigInt(j.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(j.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.Newf("unsupported curve %q", j.Crv)
+		}
+		x, err := decodeBigInt(j.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(j.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, errors.Newf("unsupported key type %q", j.Kty)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
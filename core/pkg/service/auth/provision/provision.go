@@ -0,0 +1,226 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package provision creates and maintains the Synnax users of identities managed by an
+// external identity source, such as an OpenID Connect provider or an LDAP directory.
+package provision
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/alamos"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/access/rbac"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/validate"
+	"go.uber.org/zap"
+)
+
+// Identity is the identity of a user at an external identity source.
+type Identity struct {
+	// Key uniquely identifies the identity across all identity sources, and must not
+	// change between logins.
+	Key string
+	// Username is the username of the Synnax user provisioned for the identity.
+	Username string
+	// FirstName is the given name of the user.
+	FirstName string
+	// LastName is the family name of the user.
+	LastName string
+	// Groups are the groups the user belongs to at the identity source.
+	Groups []string
+}
+
+// Link links an external identity to the Synnax user provisioned for it. Users are
+// looked up by link rather than by username, so that an identity can never sign in as
+// a local user that happens to share its username.
+type Link struct {
+	// Key is the key of the identity.
+	Key string `json:"key" msgpack:"key"`
+	// User is the key of the user provisioned for the identity.
+	User uuid.UUID `json:"user" msgpack:"user"`
+}
+
+var _ gorp.Entry[string] = Link{}
+
+// GorpKey implements gorp.Entry.
+func (l Link) GorpKey() string { return l.Key }
+
+// SetOptions implements gorp.Entry.
+func (l Link) SetOptions() []any { return nil }
+
+// CustomTypeName implements types.CustomTypeName.
+func (l Link) CustomTypeName() string { return "IdentityLink" }
+
+// Config is the configuration for creating a Provisioner.
+type Config struct {
+	// Instrumentation is used for logging.
+	alamos.Instrumentation
+	// DB is the database that links between identities and users are stored in.
+	// [REQUIRED]
+	DB *gorp.DB
+	// User is used to create users.
+	// [REQUIRED]
+	User *user.Service
+	// RBAC is used to assign roles to users.
+	// [REQUIRED]
+	RBAC *rbac.Service
+	// RoleMappings maps the names of groups at the identity source to the names of the
+	// Synnax roles assigned to their members. Roles are assigned and unassigned on
+	// every login to match the groups of the user.
+	// [OPTIONAL]
+	RoleMappings map[string]string
+}
+
+var (
+	_ config.Config[Config] = Config{}
+	// DefaultConfig is the default configuration for a Provisioner.
+	DefaultConfig = Config{}
+)
+
+// Override implements config.Config.
+func (c Config) Override(other Config) Config {
+	c.Instrumentation = override.Zero(c.Instrumentation, other.Instrumentation)
+	c.DB = override.Nil(c.DB, other.DB)
+	c.User = override.Nil(c.User, other.User)
+	c.RBAC = override.Nil(c.RBAC, other.RBAC)
+	if len(other.RoleMappings) > 0 {
+		c.RoleMappings = other.RoleMappings
+	}
+	return c
+}
+
+// Validate implements config.Config.
+func (c Config) Validate() error {
+	v := validate.New("provision")
+	validate.NotNil(v, "db", c.DB)
+	validate.NotNil(v, "user", c.User)
+	validate.NotNil(v, "rbac", c.RBAC)
+	return v.Error()
+}
+
+// Provisioner provisions users for external identities.
+type Provisioner struct{ cfg Config }
+
+// New creates a new Provisioner.
+func New(cfgs ...Config) (*Provisioner, error) {
+	cfg, err := config.New(DefaultConfig, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	return &Provisioner{cfg: cfg}, nil
+}
+
+// Provision returns the user linked to the given identity, creating and linking a new
+// user if the identity has not signed in before. The roles of the user are updated to
+// match the groups of the identity.
+func (p *Provisioner) Provision(ctx context.Context, id Identity) (user.User, error) {
+	var u user.User
+	return u, p.cfg.DB.WithTx(ctx, func(tx gorp.Tx) error {
+		var link Link
+		err := gorp.NewRetrieve[string, Link]().
+			WhereKeys(id.Key).
+			Entry(&link).
+			Exec(ctx, tx)
+		if err != nil && !errors.Is(err, query.NotFound) {
+			return err
+		}
+		if err == nil {
+			if err = p.cfg.User.NewRetrieve().WhereKeys(link.User).Entry(&u).Exec(ctx, tx); err == nil {
+				return p.syncRoles(ctx, tx, u, id)
+			}
+			// The user was deleted since the identity last signed in, so provision a
+			// new one.
+			if !errors.Is(err, query.NotFound) {
+				return err
+			}
+		}
+		exists, err := p.cfg.User.UsernameExists(ctx, id.Username)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.Wrapf(
+				auth.RepeatedUsername,
+				"username %q already belongs to a user that does not sign in with this identity",
+				id.Username,
+			)
+		}
+		u = user.User{Username: id.Username, FirstName: id.FirstName, LastName: id.LastName}
+		if err = p.cfg.User.NewWriter(tx).Create(ctx, &u); err != nil {
+			return err
+		}
+		// Let the user update information about themselves.
+		if err = p.cfg.RBAC.NewWriter(tx).Create(ctx, &rbac.Policy{
+			Subjects: []ontology.ID{user.OntologyID(u.Key)},
+			Actions:  []access.Action{access.Update},
+			Objects:  []ontology.ID{user.OntologyID(u.Key)},
+		}); err != nil {
+			return err
+		}
+		if err = gorp.NewCreate[string, Link]().
+			Entry(&Link{Key: id.Key, User: u.Key}).
+			Exec(ctx, tx); err != nil {
+			return err
+		}
+		p.cfg.L.Info("provisioned user", zap.String("username", u.Username))
+		return p.syncRoles(ctx, tx, u, id)
+	})
+}
+
+// syncRoles assigns the user the roles mapped from the groups of the identity, and
+// unassigns the mapped roles of groups the identity no longer belongs to. Roles that
+// are not mapped from any group are left untouched.
+func (p *Provisioner) syncRoles(ctx context.Context, tx gorp.Tx, u user.User, id Identity) error {
+	if len(p.cfg.RoleMappings) == 0 {
+		return nil
+	}
+	var roles []rbac.Role
+	if err := p.cfg.RBAC.NewRoleRetrieve().
+		WhereNames(lo.Values(p.cfg.RoleMappings)...).
+		Entries(&roles).
+		Exec(ctx, tx); err != nil {
+		return err
+	}
+	byName := lo.SliceToMap(roles, func(r rbac.Role) (string, rbac.Role) { return r.Name, r })
+	granted := make(map[string]bool, len(p.cfg.RoleMappings))
+	for group, role := range p.cfg.RoleMappings {
+		granted[role] = granted[role] || lo.Contains(id.Groups, group)
+	}
+	w := p.cfg.RBAC.NewWriter(tx)
+	subject := user.OntologyID(u.Key)
+	for name, grant := range granted {
+		r, ok := byName[name]
+		if !ok {
+			p.cfg.L.Warn("role mapped from identity source group does not exist", zap.String("role", name))
+			continue
+		}
+		assigned := lo.Contains(r.Subjects, subject)
+		switch {
+		case grant && !assigned:
+			if err := w.AssignRole(ctx, r.Key, subject); err != nil {
+				return err
+			}
+		case !grant && assigned:
+			if err := w.UnassignRole(ctx, r.Key, subject); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
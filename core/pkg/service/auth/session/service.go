@@ -0,0 +1,247 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/token"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Revoked is returned when authenticating a token whose session has been revoked. It
+// is an auth.InvalidToken error, so clients treat it as a signal to log in again.
+var Revoked = errors.Wrap(auth.InvalidToken, "session revoked")
+
+// Config is the configuration for opening a session Service.
+type Config struct {
+	// DB is the database that sessions are stored in.
+	// [REQUIRED]
+	DB *gorp.DB
+	// Token is used to issue and validate the tokens of sessions.
+	// [REQUIRED]
+	Token *token.Service
+	// MaxAge is the maximum lifetime of a session. Tokens for a session are refreshed
+	// until the session is this old, after which the user must log in again.
+	// [OPTIONAL] [DEFAULT: 7 days]
+	MaxAge time.Duration
+	// TouchInterval is how often the last seen time of a session is updated. Updating
+	// it on every request would write to the cluster-wide store on every request.
+	// [OPTIONAL] [DEFAULT: 1 minute]
+	TouchInterval time.Duration
+	// Now returns the current time.
+	// [OPTIONAL] [DEFAULT: telem.Now]
+	Now func() telem.TimeStamp
+}
+
+var (
+	_ config.Config[Config] = Config{}
+	// DefaultConfig is the default configuration for a session Service.
+	DefaultConfig = Config{
+		MaxAge:        7 * 24 * time.Hour,
+		TouchInterval: time.Minute,
+		Now:           telem.Now,
+	}
+)
+
+// Override implements config.Config.
+func (c Config) Override(other Config) Config {
+	c.DB = override.Nil(c.DB, other.DB)
+	c.Token = override.Nil(c.Token, other.Token)
+	c.MaxAge = override.Numeric(c.MaxAge, other.MaxAge)
+	c.TouchInterval = override.Numeric(c.TouchInterval, other.TouchInterval)
+	c.Now = override.Nil(c.Now, other.Now)
+	return c
+}
+
+// Validate implements config.Config.
+func (c Config) Validate() error {
+	v := validate.New("session")
+	validate.NotNil(v, "db", c.DB)
+	validate.NotNil(v, "token", c.Token)
+	validate.Positive(v, "max_age", c.MaxAge)
+	validate.Positive(v, "touch_interval", c.TouchInterval)
+	validate.NotNil(v, "now", c.Now)
+	return v.Error()
+}
+
+// Service opens, authenticates, and revokes sessions.
+type Service struct{ Config }
+
+// NewService opens a new Service using the provided configuration.
+func NewService(cfgs ...Config) (*Service, error) {
+	cfg, err := config.New(DefaultConfig, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{Config: cfg}, nil
+}
+
+// Open opens a new session for the given user, returning the session along with its
+// first token. Expired sessions of the user are removed.
+func (s *Service) Open(ctx context.Context, user uuid.UUID, client Client) (Session, string, error) {
+	now := s.Now()
+	if err := gorp.NewDelete[uuid.UUID, Session]().
+		Where(func(e *Session) bool { return e.User == user && e.Expired(now) }).
+		Exec(ctx, s.DB); err != nil {
+		return Session{}, "", err
+	}
+	sess := Session{
+		Key:       uuid.New(),
+		User:      user,
+		Client:    client,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(telem.TimeSpan(s.MaxAge)),
+		LastSeen:  now,
+	}
+	if err := gorp.NewCreate[uuid.UUID, Session]().Entry(&sess).Exec(ctx, s.DB); err != nil {
+		return Session{}, "", err
+	}
+	tk, err := s.Token.NewForSession(user, sess.Key, sess.ExpiresAt.Time())
+	return sess, tk, err
+}
+
+// Authenticate validates the given token and the session it belongs to, returning the
+// session. If the token is close to expiration and the session has not reached its
+// maximum age, a new token is returned as well. Returns Revoked if the session has been
+// revoked, and auth.ExpiredToken if the session has expired.
+func (s *Service) Authenticate(ctx context.Context, tk string) (Session, string, error) {
+	claims, err := s.Token.ValidateClaims(tk)
+	if err != nil {
+		return Session{}, "", err
+	}
+	// Tokens issued before sessions were introduced can't be revoked, so they are
+	// rejected.
+	if claims.Session == uuid.Nil {
+		return Session{}, "", auth.InvalidToken
+	}
+	var sess Session
+	if err = s.NewRetrieve().WhereKeys(claims.Session).Entry(&sess).Exec(ctx, nil); err != nil {
+		if errors.Is(err, query.NotFound) {
+			return Session{}, "", Revoked
+		}
+		return Session{}, "", err
+	}
+	if sess.User != claims.Issuer {
+		return Session{}, "", auth.InvalidToken
+	}
+	now := s.Now()
+	if sess.Expired(now) {
+		return Session{}, "", auth.ExpiredToken
+	}
+	if sess.LastSeen.Span(now) >= telem.TimeSpan(s.TouchInterval) {
+		if err = s.touch(ctx, &sess, now); err != nil {
+			return Session{}, "", err
+		}
+	}
+	// Tokens are issued so that they expire no later than their session, so a token
+	// that already expires with its session is not refreshed.
+	if !s.Token.ShouldRefresh(claims) ||
+		sess.ExpiresAt.Time().Sub(claims.ExpiresAt) < time.Second {
+		return sess, "", nil
+	}
+	newTK, err := s.Token.NewForSession(sess.User, sess.Key, sess.ExpiresAt.Time())
+	return sess, newTK, err
+}
+
+func (s *Service) touch(ctx context.Context, sess *Session, now telem.TimeStamp) error {
+	err := gorp.NewUpdate[uuid.UUID, Session]().
+		WhereKeys(sess.Key).
+		Change(func(e Session) Session {
+			e.LastSeen = now
+			return e
+		}).
+		Exec(ctx, s.DB)
+	if errors.Is(err, query.NotFound) {
+		return Revoked
+	}
+	sess.LastSeen = now
+	return err
+}
+
+// NewRetrieve opens a new Retrieve query to look up sessions.
+func (s *Service) NewRetrieve() Retrieve {
+	return Retrieve{baseTx: s.DB, gorp: gorp.NewRetrieve[uuid.UUID, Session]()}
+}
+
+// NewWriter opens a new Writer to revoke sessions. If tx is nil, the writer executes
+// directly against the database.
+func (s *Service) NewWriter(tx gorp.Tx) Writer {
+	return Writer{tx: gorp.OverrideTx(s.DB, tx)}
+}
+
+// Retrieve is a query to look up sessions.
+type Retrieve struct {
+	baseTx gorp.Tx
+	gorp   gorp.Retrieve[uuid.UUID, Session]
+}
+
+// WhereKeys filters sessions by their keys.
+func (r Retrieve) WhereKeys(keys ...uuid.UUID) Retrieve {
+	r.gorp = r.gorp.WhereKeys(keys...)
+	return r
+}
+
+// WhereUsers filters sessions by the users they belong to.
+func (r Retrieve) WhereUsers(users ...uuid.UUID) Retrieve {
+	r.gorp = r.gorp.Where(func(s *Session) bool { return belongsTo(*s, users) })
+	return r
+}
+
+// Entry binds the session that the query will fill results into.
+func (r Retrieve) Entry(s *Session) Retrieve {
+	r.gorp = r.gorp.Entry(s)
+	return r
+}
+
+// Entries binds the slice that the query will fill results into.
+func (r Retrieve) Entries(s *[]Session) Retrieve {
+	r.gorp = r.gorp.Entries(s)
+	return r
+}
+
+// Exec executes the query.
+func (r Retrieve) Exec(ctx context.Context, tx gorp.Tx) error {
+	return r.gorp.Exec(ctx, gorp.OverrideTx(r.baseTx, tx))
+}
+
+// Writer revokes sessions.
+type Writer struct{ tx gorp.Tx }
+
+// Revoke revokes the sessions with the given keys. Tokens for the sessions are
+// rejected from then on, even if they have not expired.
+func (w Writer) Revoke(ctx context.Context, keys ...uuid.UUID) error {
+	return gorp.NewDelete[uuid.UUID, Session]().WhereKeys(keys...).Exec(ctx, w.tx)
+}
+
+// RevokeByUsers revokes all sessions of the given users.
+func (w Writer) RevokeByUsers(ctx context.Context, users ...uuid.UUID) error {
+	return gorp.NewDelete[uuid.UUID, Session]().
+		Where(func(s *Session) bool { return belongsTo(*s, users) }).
+		Exec(ctx, w.tx)
+}
+
+func belongsTo(s Session, users []uuid.UUID) bool {
+	for _, u := range users {
+		if s.User == u {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package session_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/session"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/token"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/kv/memkv"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+type mockKeyService struct{ key *rsa.PrivateKey }
+
+func (m *mockKeyService) NodePrivate() crypto.PrivateKey { return m.key }
+
+var _ = Describe("Service", func() {
+	var (
+		db     *gorp.DB
+		svc    *session.Service
+		now    telem.TimeStamp
+		user   = uuid.New()
+		client = session.Client{Protocol: "http", UserAgent: "test"}
+		open   = func(cfg session.Config) {
+			k := MustSucceed(rsa.GenerateKey(rand.Reader, 1024))
+			tokens := MustSucceed(token.NewService(token.ServiceConfig{
+				KeyProvider:      &mockKeyService{key: k},
+				Expiration:       time.Hour,
+				RefreshThreshold: 30 * time.Minute,
+				Now:              func() time.Time { return now.Time() },
+			}))
+			cfg.DB = db
+			cfg.Token = tokens
+			cfg.Now = func() telem.TimeStamp { return now }
+			svc = MustSucceed(session.NewService(cfg))
+		}
+	)
+	BeforeEach(func() {
+		db = gorp.Wrap(memkv.New())
+		now = telem.Now()
+		open(session.Config{})
+	})
+	AfterEach(func() {
+		Expect(db.Close()).To(Succeed())
+	})
+	Describe("Open", func() {
+		It("Should open a session whose token authenticates its user", func() {
+			sess, tk := MustSucceed2(svc.Open(ctx, user, client))
+			Expect(sess.User).To(Equal(user))
+			Expect(sess.Client).To(Equal(client))
+			res, newTK := MustSucceed2(svc.Authenticate(ctx, tk))
+			Expect(res.Key).To(Equal(sess.Key))
+			Expect(newTK).To(BeEmpty())
+		})
+		It("Should remove the expired sessions of the user", func() {
+			expired, _ := MustSucceed2(svc.Open(ctx, user, client))
+			now = now.Add(8 * telem.Day)
+			_, _ = MustSucceed2(svc.Open(ctx, user, client))
+			var sessions []session.Session
+			Expect(svc.NewRetrieve().WhereUsers(user).Entries(&sessions).Exec(ctx, nil)).To(Succeed())
+			Expect(sessions).To(HaveLen(1))
+			Expect(sessions[0].Key).ToNot(Equal(expired.Key))
+		})
+	})
+	Describe("Authenticate", func() {
+		It("Should reject tokens that do not belong to a session", func() {
+			tk := MustSucceed(svc.Token.New(user))
+			_, _, err := svc.Authenticate(ctx, tk)
+			Expect(err).To(MatchError(auth.InvalidToken))
+		})
+		It("Should reject the tokens of an expired session", func() {
+			_, tk := MustSucceed2(svc.Open(ctx, user, client))
+			now = now.Add(8 * telem.Day)
+			_, _, err := svc.Authenticate(ctx, tk)
+			Expect(err).To(MatchError(auth.ExpiredToken))
+		})
+		It("Should refresh a token that is close to expiration", func() {
+			_, tk := MustSucceed2(svc.Open(ctx, user, client))
+			now = now.Add(45 * telem.Minute)
+			_, newTK := MustSucceed2(svc.Authenticate(ctx, tk))
+			Expect(newTK).ToNot(BeEmpty())
+		})
+		It("Should not refresh a token past the maximum age of its session", func() {
+			open(session.Config{MaxAge: 20 * time.Minute})
+			_, tk := MustSucceed2(svc.Open(ctx, user, client))
+			now = now.Add(10 * telem.Minute)
+			_, newTK := MustSucceed2(svc.Authenticate(ctx, tk))
+			Expect(newTK).To(BeEmpty())
+		})
+		It("Should periodically update when the session was last seen", func() {
+			sess, tk := MustSucceed2(svc.Open(ctx, user, client))
+			now = now.Add(10 * telem.Second)
+			res, _ := MustSucceed2(svc.Authenticate(ctx, tk))
+			Expect(res.LastSeen).To(Equal(sess.LastSeen))
+			now = now.Add(2 * telem.Minute)
+			res, _ = MustSucceed2(svc.Authenticate(ctx, tk))
+			Expect(res.LastSeen).To(Equal(now))
+			var stored session.Session
+			Expect(svc.NewRetrieve().WhereKeys(sess.Key).Entry(&stored).Exec(ctx, nil)).To(Succeed())
+			Expect(stored.LastSeen).To(Equal(now))
+		})
+	})
+	Describe("Revoke", func() {
+		It("Should reject the tokens of a revoked session", func() {
+			sess, tk := MustSucceed2(svc.Open(ctx, user, client))
+			Expect(svc.NewWriter(nil).Revoke(ctx, sess.Key)).To(Succeed())
+			_, _, err := svc.Authenticate(ctx, tk)
+			Expect(err).To(MatchError(session.Revoked))
+			Expect(err).To(MatchError(auth.InvalidToken))
+		})
+		It("Should revoke all sessions of a user", func() {
+			other := uuid.New()
+			_, tk1 := MustSucceed2(svc.Open(ctx, user, client))
+			_, tk2 := MustSucceed2(svc.Open(ctx, user, client))
+			_, tk3 := MustSucceed2(svc.Open(ctx, other, client))
+			Expect(svc.NewWriter(nil).RevokeByUsers(ctx, user)).To(Succeed())
+			_, _, err := svc.Authenticate(ctx, tk1)
+			Expect(err).To(MatchError(session.Revoked))
+			_, _, err = svc.Authenticate(ctx, tk2)
+			Expect(err).To(MatchError(session.Revoked))
+			_, _ = MustSucceed2(svc.Authenticate(ctx, tk3))
+		})
+	})
+})
@@ -0,0 +1,58 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package session implements server-side sessions for the tokens issued to users when
+// they log in. Every token belongs to a session, and a token is only valid while its
+// session exists, so sessions can be revoked before their tokens expire. Sessions are
+// stored in the cluster-wide key-value store, so revoking a session on one node
+// revokes it on every node.
+package session
+
+import (
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// Client describes the client that opened a session.
+type Client struct {
+	// Protocol is the protocol the session was opened over, such as http or flight.
+	Protocol string `json:"protocol" msgpack:"protocol"`
+	// UserAgent identifies the software the session was opened with.
+	UserAgent string `json:"user_agent" msgpack:"user_agent"`
+}
+
+// Session is a login session of a user.
+type Session struct {
+	// Key is the unique identifier for the session.
+	Key uuid.UUID `json:"key" msgpack:"key"`
+	// User is the key of the user that the session belongs to.
+	User uuid.UUID `json:"user" msgpack:"user"`
+	// Client describes the client that opened the session.
+	Client Client `json:"client" msgpack:"client"`
+	// IssuedAt is the time the session was opened.
+	IssuedAt telem.TimeStamp `json:"issued_at" msgpack:"issued_at"`
+	// ExpiresAt is the time the session expires. Tokens for the session are not
+	// refreshed past this time, so the user must log in again.
+	ExpiresAt telem.TimeStamp `json:"expires_at" msgpack:"expires_at"`
+	// LastSeen is the approximate time the session was last used to authenticate a
+	// request.
+	LastSeen telem.TimeStamp `json:"last_seen" msgpack:"last_seen"`
+}
+
+var _ gorp.Entry[uuid.UUID] = Session{}
+
+// GorpKey implements gorp.Entry.
+func (s Session) GorpKey() uuid.UUID { return s.Key }
+
+// SetOptions implements gorp.Entry.
+func (s Session) SetOptions() []any { return nil }
+
+// Expired returns true if the session has expired at the given time.
+func (s Session) Expired(now telem.TimeStamp) bool { return now.After(s.ExpiresAt) }
@@ -102,20 +102,67 @@ func NewService(cfgs ...ServiceConfig) (*Service, error) {
 // New issues a new token for the given issuer. Returns the token as a string, and
 // any errors encountered during signing.
 func (s *Service) New(issuer uuid.UUID) (string, error) {
+	return s.NewForSession(issuer, uuid.Nil, time.Time{})
+}
+
+// NewForSession issues a new token for the given issuer that belongs to the session
+// with the given key. The token expires at the configured expiration or at notAfter,
+// whichever is sooner. A zero notAfter places no limit on the expiration.
+func (s *Service) NewForSession(issuer, session uuid.UUID, notAfter time.Time) (string, error) {
 	method, key := s.signingMethodAndKey()
 	now := s.cfg.Now().UTC()
-	claims := jwt.NewWithClaims(method, jwt.RegisteredClaims{
+	expiresAt := now.Add(s.cfg.Expiration)
+	if !notAfter.IsZero() && notAfter.Before(expiresAt) {
+		expiresAt = notAfter.UTC()
+	}
+	registered := jwt.RegisteredClaims{
 		IssuedAt:  jwt.NewNumericDate(now),
 		Issuer:    issuer.String(),
-		ExpiresAt: jwt.NewNumericDate(now.Add(s.cfg.Expiration)),
-	})
-	v, err := claims.SignedString(key)
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+	if session != uuid.Nil {
+		registered.ID = session.String()
+	}
+	v, err := jwt.NewWithClaims(method, registered).SignedString(key)
 	if err != nil {
 		return v, auth.InvalidToken
 	}
 	return v, nil
 }
 
+// Claims are the claims of a validated token.
+type Claims struct {
+	// Issuer is the key of the user the token was issued to.
+	Issuer uuid.UUID
+	// Session is the key of the session the token belongs to, or uuid.Nil if the token
+	// was not issued for a session.
+	Session uuid.UUID
+	// ExpiresAt is the time the token expires.
+	ExpiresAt time.Time
+}
+
+// ValidateClaims validates the given token, returning its claims.
+func (s *Service) ValidateClaims(token string) (Claims, error) {
+	id, registered, err := s.validate(token)
+	if err != nil {
+		return Claims{}, err
+	}
+	c := Claims{Issuer: id, ExpiresAt: registered.ExpiresAt.Time}
+	if registered.ID != "" {
+		if c.Session, err = uuid.Parse(registered.ID); err != nil {
+			return Claims{}, auth.InvalidToken
+		}
+	}
+	return c, nil
+}
+
+// ShouldRefresh returns true if a token with the given claims is close enough to
+// expiration that a new token should be issued, as defined by the RefreshThreshold.
+func (s *Service) ShouldRefresh(c Claims) bool {
+	remaining := c.ExpiresAt.Sub(s.cfg.Now().UTC())
+	return remaining >= 0 && remaining < s.cfg.RefreshThreshold
+}
+
 // Validate validates the given token. Returns the UUID of the issuer along with any
 // errors encountered.
 func (s *Service) Validate(token string) (uuid.UUID, error) {
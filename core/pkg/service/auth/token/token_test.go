@@ -64,6 +64,22 @@ var _ = Describe("token", func() {
 				Expect(issuer).To(Equal(issuer2))
 			})
 		})
+
+		Describe("Sessions", func() {
+			It("Should include the session in the claims of the token", func() {
+				issuer, sess := uuid.New(), uuid.New()
+				tk := MustSucceed(svc.NewForSession(issuer, sess, time.Time{}))
+				claims := MustSucceed(svc.ValidateClaims(tk))
+				Expect(claims.Issuer).To(Equal(issuer))
+				Expect(claims.Session).To(Equal(sess))
+			})
+			It("Should not issue a token that expires after the given time", func() {
+				notAfter := time.Now().Add(time.Minute).Truncate(time.Second)
+				tk := MustSucceed(svc.NewForSession(uuid.New(), uuid.New(), notAfter))
+				claims := MustSucceed(svc.ValidateClaims(tk))
+				Expect(claims.ExpiresAt).To(BeTemporally("==", notAfter))
+			})
+		})
 	})
 
 	Context("Token Expiration Inside Refresh Interval", func() {
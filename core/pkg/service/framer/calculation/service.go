@@ -92,10 +92,47 @@ type entry struct {
 	ch channel.Channel
 	// count is the number of active requests for the calculation.
 	count int
-	// calculation is used to gracefully stop the calculation.
-	calculation confluence.Closable
+	// calculation is used to update the channels streamed by the calculation and to
+	// gracefully stop it.
+	calculation confluence.Inlet[framer.StreamerRequest]
+	// transform is the segment that evaluates the calculation, and is used to swap
+	// in a new calculator when the channel's expression changes.
+	transform *streamCalculationTransform
 	// shutdown is used to force stop the calculation by cancelling the context.
 	shutdown io.Closer
+	// requests guards sends to calculation, so that it is not closed while the
+	// requirements of the calculation are being updated.
+	requests struct {
+		sync.Mutex
+		closed bool
+	}
+}
+
+// close gracefully stops the calculation, waiting for any in-flight update of its
+// requirements to complete.
+func (e *entry) close() {
+	e.requests.Lock()
+	defer e.requests.Unlock()
+	if e.requests.closed {
+		return
+	}
+	e.requests.closed = true
+	e.calculation.Close()
+}
+
+// setRequires updates the channels streamed by the calculation. It is a no-op if the
+// calculation has already been stopped.
+func (e *entry) setRequires(ctx context.Context, keys channel.Keys) error {
+	e.requests.Lock()
+	defer e.requests.Unlock()
+	if e.requests.closed {
+		return nil
+	}
+	return signal.SendUnderContext(
+		ctx,
+		e.calculation.Inlet(),
+		framer.StreamerRequest{Keys: keys},
+	)
 }
 
 type Status = status.Status[types.Nil]
@@ -172,67 +209,98 @@ func (s *Service) setStatus(
 	}
 }
 
+// calculationUpdate is a change to the channel of a running calculation.
+type calculationUpdate struct {
+	e          *entry
+	prev, next channel.Channel
+}
+
 func (s *Service) handleChange(
 	ctx context.Context,
 	reader gorp.TxReader[channel.Key, channel.Channel],
 ) {
-	c, ok := reader.Next(ctx)
-	if !ok {
-		return
-	}
-	// Don't stop calculating if the channel is deleted. The calculation will be
-	// automatically shut down when it is no longer needed.
-	if c.Variant != change.Set || !c.Value.IsCalculated() {
-		return
-	}
-	existing, found := s.mu.entries[c.Key]
-	if !found {
-		s.update(ctx, c.Value)
-		return
+	// Collect the changed calculations under the lock, but update them after
+	// releasing it, as updating involves retrieving channels and waiting on the
+	// calculation pipelines.
+	var updates []calculationUpdate
+	s.mu.Lock()
+	for c, ok := reader.Next(ctx); ok; c, ok = reader.Next(ctx) {
+		// Don't stop calculating if the channel is deleted. The calculation will be
+		// automatically shut down when it is no longer needed.
+		if c.Variant != change.Set || !c.Value.IsCalculated() {
+			continue
+		}
+		e, found := s.mu.entries[c.Key]
+		if !found || e.ch.Equals(c.Value, "Name", "Units", "Description", "Scale", "Limits") {
+			continue
+		}
+		updates = append(updates, calculationUpdate{e: e, prev: e.ch, next: c.Value})
+		// Even if the update is not successful, we still want to store the latest
+		// requirements and expression in the entry.
+		e.ch = c.Value
 	}
-	if existing.ch.Equals(c.Value, "Name") {
-		return
+	s.mu.Unlock()
+	for _, u := range updates {
+		s.update(ctx, u)
 	}
-	s.update(ctx, c.Value)
 }
 
-func (s *Service) update(ctx context.Context, ch channel.Channel) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	e, found := s.mu.entries[ch.Key()]
-	if !found {
+// update swaps the calculator of a running calculation for one that evaluates the
+// latest expression of its channel, updating the channels streamed by the calculation
+// if its requirements changed. If the new calculator cannot be opened, the previous
+// one continues to run and an error status is published.
+func (s *Service) update(ctx context.Context, u calculationUpdate) {
+	ch := u.next
+	err := func() error {
+		var requires []channel.Channel
+		if err := s.cfg.Channel.NewRetrieve().
+			WhereKeys(ch.Requires...).
+			Entries(&requires).
+			Exec(ctx, nil); err != nil {
+			return err
+		}
+		c, err := OpenCalculator(ch, requires)
+		if err != nil {
+			return err
+		}
+		if !u.prev.Equals(ch, "Name", "Expression", "Units", "Description", "Scale", "Limits") {
+			if err = u.e.setRequires(ctx, ch.Requires); err != nil {
+				return err
+			}
+		}
+		u.e.transform.swap(c)
+		return nil
+	}()
+	if err != nil {
+		s.cfg.L.Error("failed to update calculated channel", zap.Error(err), zap.Stringer("key", ch))
+		s.setStatus(ctx, Status{
+			Key:         ch.Key().String(),
+			Variant:     status.ErrorVariant,
+			Message:     fmt.Sprintf("Failed to update calculation for %s", ch),
+			Description: err.Error(),
+		})
 		return
 	}
-	e.calculation.Close()
-	if err := e.shutdown.Close(); err != nil {
-		s.cfg.L.Error("failed to close calculated channel", zap.Error(err), zap.Stringer("key", ch))
-	}
-	delete(s.mu.entries, ch.Key())
-	if _, err := s.startCalculation(ctx, ch.Key(), e.count); err != nil {
-		s.cfg.L.Error("failed to restart calculated channel", zap.Error(err), zap.Stringer("key", ch))
-		// Even if the operation is not successful, we still want to store the
-		// latest requirements and expression in the entry.
-		e.ch.Requires = ch.Requires
-		e.ch.Expression = ch.Expression
-		s.mu.entries[ch.Key()] = e
-	}
+	s.cfg.L.Debug("updated calculated channel", zap.Stringer("key", ch))
 }
 
 func (s *Service) releaseEntryCloser(key channel.Key) io.Closer {
 	return xio.CloserFunc(func() (err error) {
 		s.mu.Lock()
-		defer s.mu.Unlock()
 		e, found := s.mu.entries[key]
 		if !found {
+			s.mu.Unlock()
 			return
 		}
 		e.count--
 		if e.count != 0 {
+			s.mu.Unlock()
 			return
 		}
-		s.cfg.L.Debug("closing calculated channel", zap.Stringer("key", key))
-		e.calculation.Close()
 		delete(s.mu.entries, key)
+		s.mu.Unlock()
+		s.cfg.L.Debug("closing calculated channel", zap.Stringer("key", key))
+		e.close()
 		return
 	})
 }
@@ -244,7 +312,7 @@ func (s *Service) Close() error {
 	defer s.mu.Unlock()
 	s.disconnectFromChannelChanges()
 	for _, e := range s.mu.entries {
-		e.calculation.Close()
+		e.close()
 	}
 	c := errors.NewCatcher(errors.WithAggregation())
 	for _, e := range s.mu.entries {
@@ -341,6 +409,7 @@ func (s *Service) startCalculation(
 			ch:          ch,
 			count:       initialCount,
 			calculation: streamerRequests,
+			transform:   sc,
 			shutdown:    signal.NewHardShutdown(sCtx, cancel),
 		}
 		p.Flow(sCtx, confluence.CloseOutputInletsOnExit(), confluence.WithRetryOnPanic())
@@ -362,6 +431,7 @@ type onStatusChange func(ctx context.Context, status Status)
 
 type streamCalculationTransform struct {
 	confluence.LinearTransform[framer.StreamerResponse, framer.WriterRequest]
+	mu            sync.Mutex
 	calculators   []*Calculator
 	onStateChange onStatusChange
 }
@@ -381,6 +451,8 @@ func (t *streamCalculationTransform) transform(
 	req framer.StreamerResponse,
 ) (res framer.WriterRequest, send bool, err error) {
 	res.Command = writer.Write
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	for _, c := range t.calculators {
 		s, err := c.Next(req.Frame)
 		if err != nil {
@@ -398,7 +470,23 @@ func (t *streamCalculationTransform) transform(
 	return res, send, nil
 }
 
+// swap replaces the calculator for the same channel as c, closing the previous one.
+func (t *streamCalculationTransform) swap(c *Calculator) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, prev := range t.calculators {
+		if prev.ch.Key() == c.ch.Key() {
+			prev.Close()
+			t.calculators[i] = c
+			return
+		}
+	}
+	t.calculators = append(t.calculators, c)
+}
+
 func (t *streamCalculationTransform) close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	for _, c := range t.calculators {
 		c.Close()
 	}
@@ -373,4 +373,61 @@ var _ = Describe("Calculation", Ordered, func() {
 		Expect(telem.ValueAt[int64](series, 1)).To(Equal(int64(6)))
 		Expect(w.Close()).To(Succeed())
 	})
+
+	It("Should update a calculation in place when its expression and requirements change", func() {
+		baseCH := channel.Channel{
+			Name:     "in_place_base",
+			DataType: telem.Int64T,
+			Virtual:  true,
+		}
+		otherCH := channel.Channel{
+			Name:     "in_place_other",
+			DataType: telem.Int64T,
+			Virtual:  true,
+		}
+		Expect(dist.Channel.Create(ctx, &baseCH)).To(Succeed())
+		Expect(dist.Channel.Create(ctx, &otherCH)).To(Succeed())
+		calculatedCH := channel.Channel{
+			Name:        "in_place_calculated",
+			DataType:    telem.Int64T,
+			Virtual:     true,
+			Leaseholder: cluster.Free,
+			Requires:    []channel.Key{baseCH.Key()},
+			Expression:  "return in_place_base * 2",
+		}
+		Expect(dist.Channel.Create(ctx, &calculatedCH)).To(Succeed())
+		closer := MustSucceed(c.Request(ctx, calculatedCH.Key()))
+		defer func() { Expect(closer.Close()).To(Succeed()) }()
+		sCtx, cancel := signal.WithCancel(ctx)
+		defer cancel()
+		w := MustSucceed(dist.Framer.OpenWriter(
+			ctx,
+			framer.WriterConfig{
+				Start: telem.Now(),
+				Keys:  []channel.Key{baseCH.Key(), otherCH.Key()},
+			},
+		))
+		streamer := MustSucceed(dist.Framer.NewStreamer(
+			ctx,
+			framer.StreamerConfig{Keys: []channel.Key{calculatedCH.Key()}},
+		))
+		_, sOutlet := confluence.Attach(streamer, 1, 1)
+		streamer.Flow(sCtx)
+		time.Sleep(sleepInterval)
+
+		calculatedCH.Requires = []channel.Key{baseCH.Key(), otherCH.Key()}
+		calculatedCH.Expression = "return in_place_base + in_place_other"
+		Expect(dist.Channel.Update(ctx, &calculatedCH, false)).To(Succeed())
+		time.Sleep(sleepInterval)
+		fr := core.UnaryFrame(baseCH.Key(), telem.NewSeriesV[int64](1, 2))
+		fr = fr.Append(otherCH.Key(), telem.NewSeriesV[int64](10, 20))
+		MustSucceed(w.Write(fr))
+		var res framer.StreamerResponse
+		Eventually(sOutlet.Outlet(), 5*time.Second).Should(Receive(&res))
+		Expect(res.Frame.KeysSlice()).To(Equal([]channel.Key{calculatedCH.Key()}))
+		series := res.Frame.SeriesAt(0)
+		Expect(telem.ValueAt[int64](series, 0)).To(Equal(int64(11)))
+		Expect(telem.ValueAt[int64](series, 1)).To(Equal(int64(22)))
+		Expect(w.Close()).To(Succeed())
+	})
 })
@@ -0,0 +1,161 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package export
+
+import (
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// RecordWriter writes Arrow record batches.
+type RecordWriter interface {
+	// Write writes a record batch.
+	Write(rec arrow.RecordBatch) error
+	// Close flushes any buffered data and closes the writer.
+	Close() error
+}
+
+// arrowEncoder encodes batches of rows as Arrow record batches, which are written as
+// either an Arrow IPC stream, a Parquet file, or to a RecordWriter.
+type arrowEncoder struct {
+	builder *array.RecordBuilder
+	writer  RecordWriter
+}
+
+// arrowSchema returns the Arrow schema of an export with the given columns.
+func arrowSchema(columns []column) *arrow.Schema {
+	fields := make([]arrow.Field, len(columns))
+	for i, c := range columns {
+		fields[i] = arrow.Field{Name: c.name, Type: ArrowType(c.dataType), Nullable: true}
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+func newArrowEncoder(f Format, w io.Writer, columns []column) (*arrowEncoder, error) {
+	schema := arrowSchema(columns)
+	e := &arrowEncoder{builder: array.NewRecordBuilder(memory.DefaultAllocator, schema)}
+	if f == FormatArrow {
+		e.writer = ipc.NewWriter(w, ipc.WithSchema(schema))
+		return e, nil
+	}
+	pw, err := pqarrow.NewFileWriter(
+		schema,
+		w,
+		parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy)),
+		pqarrow.DefaultWriterProps(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	e.writer = pw
+	return e, nil
+}
+
+func (e *arrowEncoder) encode(b *batch) error {
+	for i := range b.columns {
+		appendSamples(e.builder.Field(i), b.cells[i][:b.rows])
+	}
+	rec := e.builder.NewRecordBatch()
+	defer rec.Release()
+	return e.writer.Write(rec)
+}
+
+func (e *arrowEncoder) close() error {
+	e.builder.Release()
+	return e.writer.Close()
+}
+
+// ArrowType returns the Arrow data type used to represent samples of the given data
+// type. Timestamps are represented as nanosecond precision UTC timestamps, and UUIDs
+// as 16 byte fixed size binary values.
+func ArrowType(dt telem.DataType) arrow.DataType {
+	switch dt {
+	case telem.Float64T:
+		return arrow.PrimitiveTypes.Float64
+	case telem.Float32T:
+		return arrow.PrimitiveTypes.Float32
+	case telem.Int64T:
+		return arrow.PrimitiveTypes.Int64
+	case telem.Int32T:
+		return arrow.PrimitiveTypes.Int32
+	case telem.Int16T:
+		return arrow.PrimitiveTypes.Int16
+	case telem.Int8T:
+		return arrow.PrimitiveTypes.Int8
+	case telem.Uint64T:
+		return arrow.PrimitiveTypes.Uint64
+	case telem.Uint32T:
+		return arrow.PrimitiveTypes.Uint32
+	case telem.Uint16T:
+		return arrow.PrimitiveTypes.Uint16
+	case telem.Uint8T:
+		return arrow.PrimitiveTypes.Uint8
+	case telem.TimeStampT:
+		return arrow.FixedWidthTypes.Timestamp_ns
+	case telem.UUIDT:
+		return &arrow.FixedSizeBinaryType{ByteWidth: 16}
+	case telem.BytesT:
+		return arrow.BinaryTypes.Binary
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+// appendSamples appends the binary representation of samples to an Arrow array builder
+// created for the samples' ArrowType. Nil samples are appended as nulls.
+func appendSamples(b array.Builder, samples [][]byte) {
+	b.Reserve(len(samples))
+	for _, s := range samples {
+		if s == nil {
+			b.AppendNull()
+			continue
+		}
+		switch b := b.(type) {
+		case *array.Float64Builder:
+			b.UnsafeAppend(telem.UnmarshalFloat64[float64](s))
+		case *array.Float32Builder:
+			b.UnsafeAppend(telem.UnmarshalFloat32[float32](s))
+		case *array.Int64Builder:
+			b.UnsafeAppend(telem.UnmarshalInt64[int64](s))
+		case *array.Int32Builder:
+			b.UnsafeAppend(telem.UnmarshalInt32[int32](s))
+		case *array.Int16Builder:
+			b.UnsafeAppend(telem.UnmarshalInt16[int16](s))
+		case *array.Int8Builder:
+			b.UnsafeAppend(telem.UnmarshalInt8[int8](s))
+		case *array.Uint64Builder:
+			b.UnsafeAppend(telem.UnmarshalUint64[uint64](s))
+		case *array.Uint32Builder:
+			b.UnsafeAppend(telem.UnmarshalUint32[uint32](s))
+		case *array.Uint16Builder:
+			b.UnsafeAppend(telem.UnmarshalUint16[uint16](s))
+		case *array.Uint8Builder:
+			b.UnsafeAppend(telem.UnmarshalUint8[uint8](s))
+		case *array.TimestampBuilder:
+			b.UnsafeAppend(arrow.Timestamp(telem.UnmarshalInt64[int64](s)))
+		case *array.FixedSizeBinaryBuilder:
+			b.Append(s)
+		case *array.BinaryBuilder:
+			b.Append(s)
+		case *array.StringBuilder:
+			b.BinaryBuilder.Append(s)
+		default:
+			b.AppendNull()
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// encoder encodes batches of rows into a file.
+type encoder interface {
+	// encode writes a batch of rows to the file.
+	encode(b *batch) error
+	// close writes any remaining data to the file.
+	close() error
+}
+
+func newEncoder(f Format, w io.Writer, columns []column) (encoder, error) {
+	switch f {
+	case FormatCSV:
+		return newCSVEncoder(w, columns)
+	case FormatParquet, FormatArrow:
+		return newArrowEncoder(f, w, columns)
+	default:
+		return nil, errors.Newf("unsupported export format %q", f)
+	}
+}
+
+// csvEncoder encodes rows as CSV records. Timestamps are written as nanoseconds since
+// the unix epoch so that they can be parsed without loss of precision.
+type csvEncoder struct {
+	w      *csv.Writer
+	record []string
+}
+
+func newCSVEncoder(w io.Writer, columns []column) (*csvEncoder, error) {
+	e := &csvEncoder{w: csv.NewWriter(w), record: make([]string, len(columns))}
+	for i, c := range columns {
+		e.record[i] = c.name
+	}
+	return e, e.w.Write(e.record)
+}
+
+func (e *csvEncoder) encode(b *batch) error {
+	for row := range b.rows {
+		for i, c := range b.columns {
+			e.record[i] = FormatSample(c.dataType, b.cells[i][row])
+		}
+		if err := e.w.Write(e.record); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// FormatSample returns the string representation of a sample. Empty samples are
+// formatted as an empty string.
+func FormatSample(dt telem.DataType, sample []byte) string {
+	if sample == nil {
+		return ""
+	}
+	switch dt {
+	case telem.Float64T:
+		return strconv.FormatFloat(telem.UnmarshalFloat64[float64](sample), 'g', -1, 64)
+	case telem.Float32T:
+		return strconv.FormatFloat(telem.UnmarshalFloat32[float64](sample), 'g', -1, 32)
+	case telem.Int64T, telem.TimeStampT:
+		return strconv.FormatInt(telem.UnmarshalInt64[int64](sample), 10)
+	case telem.Int32T:
+		return strconv.FormatInt(telem.UnmarshalInt32[int64](sample), 10)
+	case telem.Int16T:
+		return strconv.FormatInt(telem.UnmarshalInt16[int64](sample), 10)
+	case telem.Int8T:
+		return strconv.FormatInt(telem.UnmarshalInt8[int64](sample), 10)
+	case telem.Uint64T:
+		return strconv.FormatUint(telem.UnmarshalUint64[uint64](sample), 10)
+	case telem.Uint32T:
+		return strconv.FormatUint(telem.UnmarshalUint32[uint64](sample), 10)
+	case telem.Uint16T:
+		return strconv.FormatUint(telem.UnmarshalUint16[uint64](sample), 10)
+	case telem.Uint8T:
+		return strconv.FormatUint(telem.UnmarshalUint8[uint64](sample), 10)
+	case telem.UUIDT:
+		id, err := uuid.FromBytes(sample)
+		if err != nil {
+			return ""
+		}
+		return id.String()
+	default:
+		return string(sample)
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package export implements bulk export of channel data to CSV, Parquet and Arrow IPC
+// files.
+package export
+
+import (
+	"context"
+	"io"
+	"slices"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/alamos"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/iterator"
+	"github.com/synnaxlabs/synnax/pkg/service/ranger"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Format is the file format of an export.
+type Format string
+
+const (
+	// FormatCSV exports a comma-separated values file with a header row.
+	FormatCSV Format = "csv"
+	// FormatParquet exports an Apache Parquet file.
+	FormatParquet Format = "parquet"
+	// FormatArrow exports an Apache Arrow IPC stream.
+	FormatArrow Format = "arrow"
+)
+
+// Request is a request to export the data for a set of channels.
+type Request struct {
+	// Keys are the keys of the channels to export.
+	Keys channel.Keys `json:"keys" msgpack:"keys"`
+	// Names are the names of the channels to export. If Range is set, names are first
+	// resolved as aliases on the range.
+	Names []string `json:"names" msgpack:"names"`
+	// TimeRange is the time range to export. If Range is set and TimeRange is zero,
+	// the time range of the range is exported.
+	TimeRange telem.TimeRange `json:"time_range" msgpack:"time_range"`
+	// Range is the key of a range to export data for. Channels that have an alias on
+	// the range use the alias as their column name.
+	Range uuid.UUID `json:"range" msgpack:"range"`
+	// Format is the file format of the export.
+	Format Format `json:"format" msgpack:"format"`
+}
+
+// Validate checks that the request is well-formed.
+func (r Request) Validate() error {
+	v := validate.New("export")
+	v.Ternary("keys", len(r.Keys) == 0 && len(r.Names) == 0, "must provide at least one channel key or name")
+	v.Ternary("time_range", r.TimeRange.IsZero() && r.Range == uuid.Nil, "must provide a time range or range")
+	v.Ternary("time_range", !r.TimeRange.Valid(), "time range end cannot be before its start")
+	v.Ternaryf("format", !r.Format.valid(), "invalid export format %q", r.Format)
+	return v.Error()
+}
+
+func (f Format) valid() bool {
+	return f == FormatCSV || f == FormatParquet || f == FormatArrow
+}
+
+// ServiceConfig is the configuration for opening an export service.
+type ServiceConfig struct {
+	alamos.Instrumentation
+	// Channel is used to retrieve information about the exported channels.
+	// [REQUIRED]
+	Channel channel.Readable
+	// Iterator is used to read the exported data.
+	// [REQUIRED]
+	Iterator *iterator.Service
+	// Ranger is used to retrieve ranges and their aliases.
+	// [REQUIRED]
+	Ranger *ranger.Service
+	// BatchSize is the maximum number of rows encoded at once. Larger batches improve
+	// compression for columnar formats at the cost of memory.
+	// [OPTIONAL] - defaults to 65536.
+	BatchSize int
+}
+
+var (
+	_ config.Config[ServiceConfig] = ServiceConfig{}
+	// DefaultServiceConfig is the default configuration for an export service.
+	DefaultServiceConfig = ServiceConfig{BatchSize: 1 << 16}
+)
+
+// Override implements config.Config.
+func (cfg ServiceConfig) Override(other ServiceConfig) ServiceConfig {
+	cfg.Instrumentation = override.Zero(cfg.Instrumentation, other.Instrumentation)
+	cfg.Channel = override.Nil(cfg.Channel, other.Channel)
+	cfg.Iterator = override.Nil(cfg.Iterator, other.Iterator)
+	cfg.Ranger = override.Nil(cfg.Ranger, other.Ranger)
+	cfg.BatchSize = override.Numeric(cfg.BatchSize, other.BatchSize)
+	return cfg
+}
+
+// Validate implements config.Config.
+func (cfg ServiceConfig) Validate() error {
+	v := validate.New("export")
+	validate.NotNil(v, "channel", cfg.Channel)
+	validate.NotNil(v, "iterator", cfg.Iterator)
+	validate.NotNil(v, "ranger", cfg.Ranger)
+	validate.Positive(v, "batch_size", cfg.BatchSize)
+	return v.Error()
+}
+
+// Service exports channel data to files.
+type Service struct{ cfg ServiceConfig }
+
+// NewService opens a new export service using the provided configurations. Later
+// configurations override earlier ones.
+func NewService(cfgs ...ServiceConfig) (*Service, error) {
+	cfg, err := config.New(DefaultServiceConfig, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{cfg: cfg}, nil
+}
+
+// Export writes the data for the requested channels to w in the requested format.
+//
+// Channels are aligned on their index: each row holds the samples written at a
+// single timestamp, and channels with different indexes are merged in timestamp
+// order. The index of every exported channel is included as a column, and cells for
+// channels that have no sample at a row's timestamp are left empty. Data is read and
+// encoded in batches, so the size of an export is not limited by available memory.
+func (s *Service) Export(ctx context.Context, req Request, w io.Writer) error {
+	req, err := s.Resolve(ctx, req)
+	if err != nil {
+		return err
+	}
+	p, err := s.plan(ctx, req)
+	if err != nil {
+		return err
+	}
+	enc, err := newEncoder(req.Format, w, p.columns)
+	if err != nil {
+		return err
+	}
+	if err = s.merge(ctx, p, enc); err != nil {
+		return err
+	}
+	return enc.close()
+}
+
+// ExportRecords exports the data for the requested channels as Arrow record batches,
+// aligning channels in the same way as Export. open is called with the schema of the
+// export and returns the writer that record batches are written to. The format of the
+// request is ignored.
+func (s *Service) ExportRecords(
+	ctx context.Context,
+	req Request,
+	open func(schema *arrow.Schema) (RecordWriter, error),
+) error {
+	req.Format = FormatArrow
+	req, err := s.Resolve(ctx, req)
+	if err != nil {
+		return err
+	}
+	p, err := s.plan(ctx, req)
+	if err != nil {
+		return err
+	}
+	schema := arrowSchema(p.columns)
+	w, err := open(schema)
+	if err != nil {
+		return err
+	}
+	enc := &arrowEncoder{
+		builder: array.NewRecordBuilder(memory.DefaultAllocator, schema),
+		writer:  w,
+	}
+	if err = s.merge(ctx, p, enc); err != nil {
+		return errors.Combine(err, enc.close())
+	}
+	return enc.close()
+}
+
+// column is a column in an export.
+type column struct {
+	name     string
+	dataType telem.DataType
+}
+
+// plan describes the columns of an export and how to read them.
+type plan struct {
+	timeRange telem.TimeRange
+	columns   []column
+	groups    []*group
+}
+
+// Resolve validates the request, resolves its channel names into keys, and sets its
+// time range to the time range of its range if it is not already set.
+func (s *Service) Resolve(ctx context.Context, req Request) (Request, error) {
+	if err := req.Validate(); err != nil {
+		return req, err
+	}
+	if len(req.Names) == 0 && (req.Range == uuid.Nil || !req.TimeRange.IsZero()) {
+		return req, nil
+	}
+	var rng ranger.Range
+	if req.Range != uuid.Nil {
+		if err := s.cfg.Ranger.NewRetrieve().WhereKeys(req.Range).Entry(&rng).Exec(ctx, nil); err != nil {
+			return req, err
+		}
+		if req.TimeRange.IsZero() {
+			req.TimeRange = rng.TimeRange
+		}
+	}
+	keys := slices.Clone(req.Keys)
+	for _, name := range req.Names {
+		if req.Range != uuid.Nil {
+			key, err := rng.ResolveAlias(ctx, name)
+			if err == nil {
+				keys = append(keys, key)
+				continue
+			}
+			if !errors.Is(err, query.NotFound) {
+				return req, err
+			}
+		}
+		var ch channel.Channel
+		if err := s.cfg.Channel.NewRetrieve().WhereNames(name).Entry(&ch).Exec(ctx, nil); err != nil {
+			return req, err
+		}
+		keys = append(keys, ch.Key())
+	}
+	req.Keys, req.Names = keys.Unique(), nil
+	return req, nil
+}
+
+// plan resolves the columns of a resolved request.
+func (s *Service) plan(ctx context.Context, req Request) (p plan, err error) {
+	p.timeRange = req.TimeRange
+	aliases := make(map[channel.Key]string)
+	if req.Range != uuid.Nil {
+		var rng ranger.Range
+		if err = s.cfg.Ranger.NewRetrieve().WhereKeys(req.Range).Entry(&rng).Exec(ctx, nil); err != nil {
+			return p, err
+		}
+		if aliases, err = rng.RetrieveAliases(ctx); err != nil {
+			return p, err
+		}
+	}
+	var channels []channel.Channel
+	if err = s.cfg.Channel.NewRetrieve().
+		WhereKeys(req.Keys...).
+		Entries(&channels).
+		Exec(ctx, nil); err != nil {
+		return p, err
+	}
+	var (
+		indexes  []channel.Key
+		byKey    = make(map[channel.Key]channel.Channel, len(channels))
+		children = make(map[channel.Key]channel.Keys)
+	)
+	for _, ch := range channels {
+		byKey[ch.Key()] = ch
+		if ch.Virtual {
+			return p, errors.Wrapf(validate.Error, "cannot export virtual channel %s", ch)
+		}
+		idx := ch.Index()
+		if idx == 0 {
+			return p, errors.Wrapf(validate.Error, "cannot export channel %s with no index", ch)
+		}
+		if _, ok := children[idx]; !ok {
+			indexes = append(indexes, idx)
+			children[idx] = nil
+		}
+		if idx != ch.Key() {
+			children[idx] = append(children[idx], ch.Key())
+		}
+	}
+	var missing channel.Keys
+	for _, idx := range indexes {
+		if _, ok := byKey[idx]; !ok {
+			missing = append(missing, idx)
+		}
+	}
+	if len(missing) > 0 {
+		var idxChannels []channel.Channel
+		if err = s.cfg.Channel.NewRetrieve().
+			WhereKeys(missing...).
+			Entries(&idxChannels).
+			Exec(ctx, nil); err != nil {
+			return p, err
+		}
+		for _, ch := range idxChannels {
+			byKey[ch.Key()] = ch
+		}
+	}
+	name := func(ch channel.Channel) string {
+		if al, ok := aliases[ch.Key()]; ok {
+			return al
+		}
+		return ch.Name
+	}
+	for _, idx := range indexes {
+		g := &group{offset: len(p.columns), keys: append(channel.Keys{idx}, children[idx]...)}
+		for _, key := range g.keys {
+			ch := byKey[key]
+			p.columns = append(p.columns, column{name: name(ch), dataType: ch.DataType})
+		}
+		p.groups = append(p.groups, g)
+	}
+	return p, nil
+}
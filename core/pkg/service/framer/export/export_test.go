@@ -0,0 +1,269 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package export_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/synnax/pkg/distribution/mock"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/export"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/iterator"
+	"github.com/synnaxlabs/synnax/pkg/service/label"
+	"github.com/synnaxlabs/synnax/pkg/service/ranger"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+func ns(s int) string { return strconv.FormatInt(int64(telem.TimeStamp(s)*telem.SecondTS), 10) }
+
+// recordCollector is an export.RecordWriter that counts the record batches written
+// to it.
+type recordCollector struct {
+	schema  *arrow.Schema
+	batches int
+	rows    int64
+	closed  bool
+}
+
+func (c *recordCollector) Write(rec arrow.RecordBatch) error {
+	c.batches++
+	c.rows += rec.NumRows()
+	return nil
+}
+
+func (c *recordCollector) Close() error {
+	c.closed = true
+	return nil
+}
+
+var _ = Describe("Export", Ordered, func() {
+	var (
+		builder   = mock.NewCluster()
+		dist      mock.Node
+		rangerSvc *ranger.Service
+		svc       *export.Service
+		idxA      *channel.Channel
+		dataA     *channel.Channel
+		idxB      *channel.Channel
+		dataB     *channel.Channel
+		tr        = telem.TimeRange{Start: 0, End: 10 * telem.SecondTS}
+	)
+	write := func(keys channel.Keys, series ...telem.Series) {
+		w := MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{
+			Start:            telem.SecondTS,
+			Keys:             keys,
+			EnableAutoCommit: config.True(),
+		}))
+		MustSucceed(w.Write(core.MultiFrame(keys, series)))
+		Expect(w.Close()).To(Succeed())
+	}
+	exportCSV := func(req export.Request) [][]string {
+		req.Format = export.FormatCSV
+		var buf bytes.Buffer
+		Expect(svc.Export(ctx, req, &buf)).To(Succeed())
+		return MustSucceed(csv.NewReader(&buf).ReadAll())
+	}
+	BeforeAll(func() {
+		dist = builder.Provision(ctx)
+		lab := MustSucceed(label.OpenService(ctx, label.Config{
+			DB:       dist.DB,
+			Ontology: dist.Ontology,
+			Group:    dist.Group,
+		}))
+		rangerSvc = MustSucceed(ranger.OpenService(ctx, ranger.Config{
+			DB:       dist.DB,
+			Ontology: dist.Ontology,
+			Group:    dist.Group,
+			Label:    lab,
+		}))
+		iteratorSvc := MustSucceed(iterator.NewService(iterator.ServiceConfig{
+			DistFramer: dist.Framer,
+			Channel:    dist.Channel,
+		}))
+		svc = MustSucceed(export.NewService(export.ServiceConfig{
+			Channel:   dist.Channel,
+			Iterator:  iteratorSvc,
+			Ranger:    rangerSvc,
+			BatchSize: 2,
+		}))
+		idxA = &channel.Channel{Name: "time_a", DataType: telem.TimeStampT, IsIndex: true}
+		idxB = &channel.Channel{Name: "time_b", DataType: telem.TimeStampT, IsIndex: true}
+		Expect(dist.Channel.CreateMany(ctx, &[]channel.Channel{*idxA, *idxB})).To(Succeed())
+		var idxs []channel.Channel
+		Expect(dist.Channel.NewRetrieve().WhereNames("time_a", "time_b").Entries(&idxs).Exec(ctx, nil)).To(Succeed())
+		for _, ch := range idxs {
+			if ch.Name == "time_a" {
+				idxA = &ch
+			} else {
+				idxB = &ch
+			}
+		}
+		dataA = &channel.Channel{Name: "temperature", DataType: telem.Float64T, LocalIndex: idxA.LocalKey}
+		Expect(dist.Channel.Create(ctx, dataA)).To(Succeed())
+		dataB = &channel.Channel{Name: "pressure", DataType: telem.Int32T, LocalIndex: idxB.LocalKey}
+		Expect(dist.Channel.Create(ctx, dataB)).To(Succeed())
+		write(
+			channel.Keys{idxA.Key(), dataA.Key()},
+			telem.NewSeriesSecondsTSV(1, 2, 3),
+			telem.NewSeriesV[float64](1.5, 2.5, 3.5),
+		)
+		write(
+			channel.Keys{idxB.Key(), dataB.Key()},
+			telem.NewSeriesSecondsTSV(2, 4),
+			telem.NewSeriesV[int32](20, 40),
+		)
+	})
+	AfterAll(func() {
+		Expect(builder.Close()).To(Succeed())
+	})
+
+	Describe("CSV", func() {
+		It("Should merge channels with different indexes in timestamp order", func() {
+			records := exportCSV(export.Request{
+				Keys:      channel.Keys{dataA.Key(), dataB.Key()},
+				TimeRange: tr,
+			})
+			Expect(records).To(Equal([][]string{
+				{"time_a", "temperature", "time_b", "pressure"},
+				{ns(1), "1.5", "", ""},
+				{ns(2), "2.5", ns(2), "20"},
+				{ns(3), "3.5", "", ""},
+				{"", "", ns(4), "40"},
+			}))
+		})
+
+		It("Should only export data within the time range", func() {
+			records := exportCSV(export.Request{
+				Names:     []string{"temperature"},
+				TimeRange: telem.TimeRange{Start: 2 * telem.SecondTS, End: 3 * telem.SecondTS},
+			})
+			Expect(records).To(Equal([][]string{
+				{"time_a", "temperature"},
+				{ns(2), "2.5"},
+			}))
+		})
+
+		It("Should use range aliases as column names", func() {
+			rng := ranger.Range{Name: "Test", TimeRange: telem.TimeRange{Start: telem.SecondTS, End: 10 * telem.SecondTS}}
+			Expect(rangerSvc.NewWriter(dist.DB).Create(ctx, &rng)).To(Succeed())
+			Expect(rangerSvc.NewRetrieve().WhereKeys(rng.Key).Entry(&rng).Exec(ctx, nil)).To(Succeed())
+			Expect(rng.SetAlias(ctx, dataA.Key(), "alpha")).To(Succeed())
+			records := exportCSV(export.Request{Names: []string{"alpha"}, Range: rng.Key})
+			Expect(records).To(HaveLen(4))
+			Expect(records[0]).To(Equal([]string{"time_a", "alpha"}))
+		})
+	})
+
+	Describe("Arrow", func() {
+		It("Should export an Arrow IPC stream", func() {
+			var buf bytes.Buffer
+			Expect(svc.Export(ctx, export.Request{
+				Keys:      channel.Keys{dataA.Key(), dataB.Key()},
+				TimeRange: tr,
+				Format:    export.FormatArrow,
+			}, &buf)).To(Succeed())
+			r := MustSucceed(ipc.NewReader(&buf))
+			defer r.Release()
+			Expect(r.Schema().NumFields()).To(Equal(4))
+			Expect(r.Schema().Field(1).Name).To(Equal("temperature"))
+			var (
+				rows        int64
+				temperature []float64
+			)
+			for r.Next() {
+				rec := r.Record()
+				rows += rec.NumRows()
+				col := rec.Column(1).(*array.Float64)
+				for i := range col.Len() {
+					if col.IsValid(i) {
+						temperature = append(temperature, col.Value(i))
+					}
+				}
+			}
+			Expect(r.Err()).ToNot(HaveOccurred())
+			Expect(rows).To(Equal(int64(4)))
+			Expect(temperature).To(Equal([]float64{1.5, 2.5, 3.5}))
+		})
+
+		It("Should export record batches to a record writer", func() {
+			w := &recordCollector{}
+			Expect(svc.ExportRecords(ctx, export.Request{
+				Keys:      channel.Keys{dataA.Key(), dataB.Key()},
+				TimeRange: tr,
+			}, func(schema *arrow.Schema) (export.RecordWriter, error) {
+				w.schema = schema
+				return w, nil
+			})).To(Succeed())
+			Expect(w.schema.NumFields()).To(Equal(4))
+			Expect(w.closed).To(BeTrue())
+			Expect(w.rows).To(Equal(int64(4)))
+			Expect(w.batches).To(Equal(2))
+		})
+	})
+
+	Describe("Parquet", func() {
+		It("Should export a Parquet file", func() {
+			var buf bytes.Buffer
+			Expect(svc.Export(ctx, export.Request{
+				Keys:      channel.Keys{dataA.Key(), dataB.Key()},
+				TimeRange: tr,
+				Format:    export.FormatParquet,
+			}, &buf)).To(Succeed())
+			pf := MustSucceed(file.NewParquetReader(bytes.NewReader(buf.Bytes())))
+			defer func() { Expect(pf.Close()).To(Succeed()) }()
+			fr := MustSucceed(pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator))
+			tbl := MustSucceed(fr.ReadTable(ctx))
+			defer tbl.Release()
+			Expect(tbl.NumRows()).To(Equal(int64(4)))
+			Expect(tbl.Schema().Field(3).Name).To(Equal("pressure"))
+			Expect(tbl.Column(3).Data().NullN()).To(Equal(2))
+		})
+	})
+
+	Describe("Validation", func() {
+		It("Should return an error when no channels are provided", func() {
+			Expect(svc.Export(ctx, export.Request{TimeRange: tr, Format: export.FormatCSV}, &bytes.Buffer{})).
+				To(MatchError(ContainSubstring("must provide at least one channel key or name")))
+		})
+
+		It("Should return an error when the format is invalid", func() {
+			Expect(svc.Export(ctx, export.Request{
+				Keys:      channel.Keys{dataA.Key()},
+				TimeRange: tr,
+				Format:    "xlsx",
+			}, &bytes.Buffer{})).To(MatchError(ContainSubstring("invalid export format")))
+		})
+
+		It("Should return an error when exporting a virtual channel", func() {
+			virtual := &channel.Channel{Name: "virtual", DataType: telem.Float32T, Virtual: true}
+			Expect(dist.Channel.Create(ctx, virtual)).To(Succeed())
+			Expect(svc.Export(ctx, export.Request{
+				Keys:      channel.Keys{virtual.Key()},
+				TimeRange: tr,
+				Format:    export.FormatCSV,
+			}, &bytes.Buffer{})).To(HaveOccurredAs(validate.Error))
+		})
+	})
+})
@@ -0,0 +1,175 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package export
+
+import (
+	"context"
+
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/iterator"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// group is a set of channels that share an index. Each group is read with its own
+// iterator, one chunk at a time.
+type group struct {
+	// offset is the position of the group's first column in the export.
+	offset int
+	// keys are the keys of the channels in the group, starting with the index.
+	keys channel.Keys
+	it   *iterator.Iterator
+	// stamps are the timestamps of the current chunk.
+	stamps []telem.TimeStamp
+	// samples are the samples of each channel in the current chunk.
+	samples [][][]byte
+	// pos is the position of the next row in the current chunk.
+	pos  int
+	done bool
+}
+
+// open opens the group's iterator and reads its first chunk.
+func (g *group) open(ctx context.Context, svc *iterator.Service, tr telem.TimeRange) (err error) {
+	if g.it, err = svc.Open(ctx, iterator.Config{Keys: g.keys, Bounds: tr}); err != nil {
+		return err
+	}
+	if !g.it.SeekFirst() {
+		g.done = true
+		return g.it.Error()
+	}
+	return g.next()
+}
+
+// next reads the next non-empty chunk from the group's iterator.
+func (g *group) next() error {
+	for g.it.Next(iterator.AutoSpan) {
+		fr := g.it.Value()
+		idx := fr.Get(g.keys[0])
+		if idx.Len() == 0 {
+			continue
+		}
+		g.pos = 0
+		g.stamps = g.stamps[:0]
+		for _, s := range idx.Series {
+			g.stamps = append(g.stamps, telem.UnmarshalSeries[telem.TimeStamp](s)...)
+		}
+		g.samples = make([][][]byte, len(g.keys))
+		for i, key := range g.keys {
+			for _, s := range fr.Get(key).Series {
+				for sample := range s.Samples() {
+					g.samples[i] = append(g.samples[i], sample)
+				}
+			}
+		}
+		return nil
+	}
+	g.done = true
+	return g.it.Error()
+}
+
+// peek returns the timestamp of the group's next row.
+func (g *group) peek() telem.TimeStamp { return g.stamps[g.pos] }
+
+// pop writes the group's next row into the batch and advances to the following row.
+func (g *group) pop(b *batch) error {
+	for i, samples := range g.samples {
+		// A channel in the group may have fewer samples than its index if it was
+		// written separately from the index. In that case, we leave its cells empty.
+		if g.pos < len(samples) {
+			b.cells[g.offset+i][b.rows] = samples[g.pos]
+		}
+	}
+	if g.pos++; g.pos < len(g.stamps) {
+		return nil
+	}
+	return g.next()
+}
+
+func (g *group) close() error {
+	if g.it == nil {
+		return nil
+	}
+	return g.it.Close()
+}
+
+// batch is a batch of rows to encode. A nil cell is empty.
+type batch struct {
+	columns []column
+	// cells holds the binary representation of each sample, indexed by column and
+	// then by row.
+	cells [][][]byte
+	rows  int
+}
+
+func newBatch(columns []column, size int) *batch {
+	b := &batch{columns: columns, cells: make([][][]byte, len(columns))}
+	for i := range b.cells {
+		b.cells[i] = make([][]byte, size)
+	}
+	return b
+}
+
+func (b *batch) reset() {
+	for _, col := range b.cells {
+		clear(col[:b.rows])
+	}
+	b.rows = 0
+}
+
+// merge reads the data for each group, merges the groups into rows in timestamp
+// order, and writes the rows to the encoder in batches.
+func (s *Service) merge(ctx context.Context, p plan, enc encoder) (err error) {
+	defer func() {
+		for _, g := range p.groups {
+			err = errors.Combine(err, g.close())
+		}
+	}()
+	for _, g := range p.groups {
+		if err = g.open(ctx, s.cfg.Iterator, p.timeRange); err != nil {
+			return err
+		}
+	}
+	b := newBatch(p.columns, s.cfg.BatchSize)
+	for {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		var (
+			next  = telem.TimeStampMax
+			found bool
+		)
+		for _, g := range p.groups {
+			if !g.done && !g.peek().After(next) {
+				next, found = g.peek(), true
+			}
+		}
+		if !found {
+			break
+		}
+		for _, g := range p.groups {
+			if g.done || g.peek() != next {
+				continue
+			}
+			if err = g.pop(b); err != nil {
+				return err
+			}
+		}
+		if b.rows++; b.rows == s.cfg.BatchSize {
+			if err = enc.encode(b); err != nil {
+				return err
+			}
+			b.reset()
+		}
+	}
+	if b.rows == 0 {
+		return nil
+	}
+	return enc.encode(b)
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// arrowDecoder decodes Arrow IPC streams with a flat schema.
+type arrowDecoder struct {
+	file   File
+	schema *arrow.Schema
+	names  []string
+	meta   map[string]string
+}
+
+func newArrowDecoder(file File) (*arrowDecoder, error) {
+	d := &arrowDecoder{file: file, meta: make(map[string]string)}
+	r, err := d.open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Release()
+	d.schema = r.Schema()
+	for _, field := range d.schema.Fields() {
+		d.names = append(d.names, field.Name)
+	}
+	md := d.schema.Metadata()
+	addArrowMetadata(d.meta, md.Keys(), md.Values())
+	return d, nil
+}
+
+// open seeks to the start of the file and returns a reader positioned at the first
+// record batch.
+func (d *arrowDecoder) open() (*ipc.Reader, error) {
+	if _, err := d.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	r, err := ipc.NewReader(d.file)
+	if err != nil {
+		return nil, errors.Wrapf(validate.Error, "invalid arrow stream: %s", err)
+	}
+	return r, nil
+}
+
+func (d *arrowDecoder) columns() []string { return d.names }
+
+func (d *arrowDecoder) metadata() map[string]string { return d.meta }
+
+// infer infers the data type of each column from the Arrow type of its field in the
+// stream's schema.
+func (d *arrowDecoder) infer(context.Context) ([]telem.DataType, error) {
+	return inferFromSchema(d.schema), nil
+}
+
+func (d *arrowDecoder) read(
+	ctx context.Context,
+	positions []int,
+	dataTypes []telem.DataType,
+	f func(cells [][][]byte, rows int) error,
+) error {
+	r, err := d.open()
+	if err != nil {
+		return err
+	}
+	defer r.Release()
+	return readRecords(ctx, r, d.names, positions, dataTypes, f)
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package importer
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// csvDecoder decodes CSV files. Lines at the start of the file that begin with '#' are
+// parsed as "key: value" or "key=value" metadata, and the first line after them is the
+// header row.
+type csvDecoder struct {
+	file      File
+	batchSize int
+	header    []string
+	meta      map[string]string
+}
+
+func newCSVDecoder(file File, batchSize int) (*csvDecoder, error) {
+	d := &csvDecoder{file: file, batchSize: batchSize, meta: make(map[string]string)}
+	r, err := d.open()
+	if err != nil {
+		return nil, err
+	}
+	if d.header, err = r.Read(); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.Wrap(validate.Error, "csv file has no header row")
+		}
+		return nil, err
+	}
+	return d, nil
+}
+
+// open seeks to the start of the file, parses its metadata, and returns a reader
+// positioned at the header row.
+func (d *csvDecoder) open() (*csv.Reader, error) {
+	if _, err := d.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(d.file)
+	for {
+		b, err := br.Peek(1)
+		if err != nil || b[0] != '#' {
+			break
+		}
+		line, err := br.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		sep := strings.IndexAny(line, ":=")
+		if sep < 0 {
+			continue
+		}
+		if key := strings.TrimSpace(line[:sep]); key != "" {
+			d.meta[key] = strings.TrimSpace(line[sep+1:])
+		}
+	}
+	r := csv.NewReader(br)
+	r.ReuseRecord = true
+	return r, nil
+}
+
+func (d *csvDecoder) columns() []string { return d.header }
+
+func (d *csvDecoder) metadata() map[string]string { return d.meta }
+
+// infer infers the data type of each column from its values. Columns where every value
+// is an integer are inferred as int64, where every value is a number as float64, and
+// where every value is an RFC3339 timestamp as timestamps. All other columns are
+// inferred as strings, which cannot be imported.
+func (d *csvDecoder) infer(ctx context.Context) ([]telem.DataType, error) {
+	r, err := d.open()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = r.Read(); err != nil {
+		return nil, err
+	}
+	var (
+		n                    = len(d.header)
+		ints, floats, stamps = make([]bool, n), make([]bool, n), make([]bool, n)
+		empty                = make([]bool, n)
+	)
+	for i := range n {
+		ints[i], floats[i], stamps[i], empty[i] = true, true, true, true
+	}
+	for row := 0; ; row++ {
+		if row%d.batchSize == 0 {
+			if err = ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i, v := range record {
+			if v == "" {
+				continue
+			}
+			empty[i] = false
+			if ints[i] {
+				_, err := strconv.ParseInt(v, 10, 64)
+				ints[i] = err == nil
+			}
+			if floats[i] {
+				_, err := strconv.ParseFloat(v, 64)
+				floats[i] = err == nil
+			}
+			if stamps[i] {
+				_, err := time.Parse(time.RFC3339Nano, v)
+				stamps[i] = err == nil
+			}
+		}
+	}
+	dataTypes := make([]telem.DataType, n)
+	for i := range dataTypes {
+		switch {
+		case empty[i]:
+			dataTypes[i] = telem.UnknownT
+		case ints[i]:
+			dataTypes[i] = telem.Int64T
+		case floats[i]:
+			dataTypes[i] = telem.Float64T
+		case stamps[i]:
+			dataTypes[i] = telem.TimeStampT
+		default:
+			dataTypes[i] = telem.StringT
+		}
+	}
+	return dataTypes, nil
+}
+
+func (d *csvDecoder) read(
+	ctx context.Context,
+	positions []int,
+	dataTypes []telem.DataType,
+	f func(cells [][][]byte, rows int) error,
+) error {
+	r, err := d.open()
+	if err != nil {
+		return err
+	}
+	if _, err = r.Read(); err != nil {
+		return err
+	}
+	var (
+		cells = newCells(len(positions), d.batchSize)
+		rows  int
+		row   int
+	)
+	flush := func() error {
+		if rows == 0 {
+			return nil
+		}
+		err := f(cells, rows)
+		for _, col := range cells {
+			clear(col[:rows])
+		}
+		rows = 0
+		return err
+	}
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		row++
+		for i, pos := range positions {
+			if record[pos] == "" {
+				continue
+			}
+			if cells[i][rows], err = ParseSample(dataTypes[i], record[pos]); err != nil {
+				return errors.Wrapf(err, "row %d, column %s", row, d.header[pos])
+			}
+		}
+		if rows++; rows == d.batchSize {
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// decoder decodes the rows of an imported file.
+type decoder interface {
+	// columns returns the names of the columns in the file.
+	columns() []string
+	// metadata returns the key-value metadata in the header of the file.
+	metadata() map[string]string
+	// infer returns the inferred data type of each column in the file, or UnknownT if
+	// the data type of a column cannot be inferred.
+	infer(ctx context.Context) ([]telem.DataType, error)
+	// read reads the file from the start in batches of rows, decoding the column at
+	// each of the provided positions into samples of the corresponding data type. f is
+	// called with the binary representation of each sample, indexed by position and
+	// then by row. Empty cells are nil.
+	read(
+		ctx context.Context,
+		positions []int,
+		dataTypes []telem.DataType,
+		f func(cells [][][]byte, rows int) error,
+	) error
+}
+
+func newDecoder(ctx context.Context, f Format, file File, batchSize int) (decoder, error) {
+	switch f {
+	case FormatCSV:
+		return newCSVDecoder(file, batchSize)
+	case FormatParquet:
+		return newParquetDecoder(ctx, file, batchSize)
+	case FormatArrow:
+		return newArrowDecoder(file)
+	default:
+		return nil, errors.Newf("unsupported import format %q", f)
+	}
+}
+
+func newCells(n, rows int) [][][]byte {
+	cells := make([][][]byte, n)
+	for i := range cells {
+		cells[i] = make([][]byte, rows)
+	}
+	return cells
+}
+
+// parseTimeStamp parses a timestamp as either nanoseconds since the unix epoch or an
+// RFC3339 string.
+func parseTimeStamp(s string) (telem.TimeStamp, error) {
+	if ns, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return telem.TimeStamp(ns), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return 0, errors.Wrapf(validate.Error, "invalid timestamp %q", s)
+	}
+	return telem.NewTimeStamp(t), nil
+}
+
+// ParseSample parses the string representation of a sample of the given data
+// type.
+func ParseSample(dt telem.DataType, s string) ([]byte, error) {
+	switch dt {
+	case telem.TimeStampT:
+		ts, err := parseTimeStamp(s)
+		if err != nil {
+			return nil, err
+		}
+		return marshalInt(dt, int64(ts))
+	case telem.UUIDT:
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, errors.Wrapf(validate.Error, "invalid uuid %q", s)
+		}
+		return id[:], nil
+	case telem.Float64T, telem.Float32T:
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, errors.Wrapf(validate.Error, "invalid %s %q", dt, s)
+		}
+		return marshalFloat(dt, v)
+	case telem.Int64T, telem.Int32T, telem.Int16T, telem.Int8T:
+		v, err := strconv.ParseInt(s, 10, int(dt.Density())*8)
+		if err != nil {
+			return nil, errors.Wrapf(validate.Error, "invalid %s %q", dt, s)
+		}
+		return marshalInt(dt, v)
+	case telem.Uint64T, telem.Uint32T, telem.Uint16T, telem.Uint8T:
+		v, err := strconv.ParseUint(s, 10, int(dt.Density())*8)
+		if err != nil {
+			return nil, errors.Wrapf(validate.Error, "invalid %s %q", dt, s)
+		}
+		return marshalUint(dt, v)
+	default:
+		return nil, errors.Wrapf(validate.Error, "unsupported data type %s", dt)
+	}
+}
+
+func isNumeric(dt telem.DataType) bool {
+	return dt.Density() != telem.UnknownDensity && dt != telem.UUIDT
+}
+
+func marshalFloat(dt telem.DataType, v float64) ([]byte, error) {
+	if !isNumeric(dt) {
+		return nil, errors.Wrapf(validate.Error, "cannot convert %v to %s", v, dt)
+	}
+	b := make([]byte, dt.Density())
+	telem.MarshalF[float64](dt)(b, v)
+	return b, nil
+}
+
+func marshalInt(dt telem.DataType, v int64) ([]byte, error) {
+	if !isNumeric(dt) {
+		return nil, errors.Wrapf(validate.Error, "cannot convert %v to %s", v, dt)
+	}
+	b := make([]byte, dt.Density())
+	telem.MarshalF[int64](dt)(b, v)
+	return b, nil
+}
+
+func marshalUint(dt telem.DataType, v uint64) ([]byte, error) {
+	if !isNumeric(dt) {
+		return nil, errors.Wrapf(validate.Error, "cannot convert %v to %s", v, dt)
+	}
+	b := make([]byte, dt.Density())
+	telem.MarshalF[uint64](dt)(b, v)
+	return b, nil
+}
+
+func marshalBytes(dt telem.DataType, v []byte) ([]byte, error) {
+	if dt == telem.UUIDT && len(v) == 16 {
+		return append([]byte(nil), v...), nil
+	}
+	return nil, errors.Wrapf(validate.Error, "cannot convert binary value to %s", dt)
+}
@@ -0,0 +1,277 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/writer"
+	"github.com/synnaxlabs/synnax/pkg/service/ranger"
+	"github.com/synnaxlabs/synnax/pkg/storage/ts"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// group is an index column and the data columns it indexes. Each group is written
+// with its own writer.
+type group struct {
+	// columns are the positions of the group's columns in the import, starting with
+	// the index.
+	columns []int
+	// bounds spans the timestamps in the index column.
+	bounds telem.TimeRange
+	// rows is the number of timestamps in the index column.
+	rows int
+	w    *writer.Writer
+}
+
+// Exec validates the contents of the file, creates any channels that do not exist,
+// writes the data in the file, and creates the requested range. The file is read in
+// full and validated before any channels are created or any data is written, so
+// imports of invalid files have no effect.
+func (imp *Import) Exec(ctx context.Context) (res Response, err error) {
+	groups := imp.groups()
+	if res.Rows, err = imp.scan(ctx, groups); err != nil {
+		return res, err
+	}
+	if err = imp.createChannels(ctx); err != nil {
+		return res, err
+	}
+	res.Channels = imp.Channels()
+	if res.TimeRange, err = imp.write(ctx, groups); err != nil {
+		return res, err
+	}
+	if imp.req.Range == "" || res.TimeRange.IsZero() {
+		return res, nil
+	}
+	rng := ranger.Range{Name: imp.req.Range, TimeRange: res.TimeRange}
+	err = imp.svc.cfg.Ranger.DB.WithTx(ctx, func(tx gorp.Tx) error {
+		if err := imp.svc.cfg.Ranger.NewWriter(tx).Create(ctx, &rng); err != nil {
+			return err
+		}
+		for _, k := range slices.Sorted(maps.Keys(imp.metadata)) {
+			if err := rng.SetKV(ctx, k, imp.metadata[k]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	res.Range = rng.Key
+	return res, err
+}
+
+func (imp *Import) groups() []*group {
+	var groups []*group
+	byIndex := make(map[int]*group)
+	for i, c := range imp.columns {
+		if c.isIndex() {
+			g := &group{columns: []int{i}}
+			byIndex[i] = g
+			groups = append(groups, g)
+		}
+	}
+	for i, c := range imp.columns {
+		if !c.isIndex() {
+			byIndex[c.index].columns = append(byIndex[c.index].columns, i)
+		}
+	}
+	return groups
+}
+
+func (imp *Import) read(ctx context.Context, f func(cells [][][]byte, rows int) error) error {
+	positions := make([]int, len(imp.columns))
+	dataTypes := make([]telem.DataType, len(imp.columns))
+	for i, c := range imp.columns {
+		positions[i], dataTypes[i] = c.pos, c.channel.DataType
+	}
+	return imp.dec.read(ctx, positions, dataTypes, f)
+}
+
+// scan reads the file, checking that the timestamps in each index column are strictly
+// increasing and that every row with a timestamp has a value for each of the columns
+// it indexes.
+func (imp *Import) scan(ctx context.Context, groups []*group) (int, error) {
+	var (
+		offset int
+		prev   = make([]telem.TimeStamp, len(groups))
+	)
+	err := imp.read(ctx, func(cells [][][]byte, rows int) error {
+		for gi, g := range groups {
+			idx := imp.columns[g.columns[0]]
+			for row := range rows {
+				stamp := cells[g.columns[0]][row]
+				if stamp == nil {
+					for _, c := range g.columns[1:] {
+						if cells[c][row] != nil {
+							return errors.Wrapf(
+								validate.Error,
+								"row %d has a value for column %s but no timestamp in index column %s",
+								offset+row+1,
+								imp.columns[c].Name,
+								idx.Name,
+							)
+						}
+					}
+					continue
+				}
+				for _, c := range g.columns[1:] {
+					if cells[c][row] == nil {
+						return errors.Wrapf(
+							validate.Error,
+							"row %d is missing a value for column %s",
+							offset+row+1,
+							imp.columns[c].Name,
+						)
+					}
+				}
+				ts := telem.UnmarshalTimeStamp[telem.TimeStamp](stamp)
+				if g.rows > 0 && ts <= prev[gi] {
+					return errors.Wrapf(
+						validate.Error,
+						"timestamps in index column %s must be strictly increasing, but %s at row %d is not after %s",
+						idx.Name,
+						ts,
+						offset+row+1,
+						prev[gi],
+					)
+				}
+				if g.rows == 0 {
+					g.bounds.Start = ts
+				}
+				prev[gi], g.bounds.End = ts, ts+1
+				g.rows++
+			}
+		}
+		offset += rows
+		return nil
+	})
+	return offset, err
+}
+
+// createChannels creates the index channels of the import and then the data channels
+// that they index.
+func (imp *Import) createChannels(ctx context.Context) error {
+	for _, indexes := range []bool{true, false} {
+		var (
+			toCreate  []channel.Channel
+			positions []int
+		)
+		for i, c := range imp.columns {
+			if c.isIndex() != indexes || c.channel.Key() != 0 {
+				continue
+			}
+			if !indexes {
+				c.channel.LocalIndex = imp.columns[c.index].channel.LocalKey
+			}
+			toCreate = append(toCreate, c.channel)
+			positions = append(positions, i)
+		}
+		if len(toCreate) == 0 {
+			continue
+		}
+		if err := imp.svc.cfg.Channel.CreateMany(ctx, &toCreate); err != nil {
+			return err
+		}
+		for i, pos := range positions {
+			imp.columns[pos].channel = toCreate[i]
+		}
+	}
+	return nil
+}
+
+// write reads the file again, writing the rows of each group with a persist-only
+// writer and committing after each batch.
+func (imp *Import) write(ctx context.Context, groups []*group) (tr telem.TimeRange, err error) {
+	defer func() {
+		for _, g := range groups {
+			if g.w != nil {
+				err = errors.Combine(err, g.w.Close())
+			}
+		}
+	}()
+	for _, g := range groups {
+		if g.rows == 0 {
+			continue
+		}
+		keys := make(channel.Keys, len(g.columns))
+		for i, c := range g.columns {
+			keys[i] = imp.columns[c].channel.Key()
+		}
+		if g.w, err = imp.svc.cfg.Framer.OpenWriter(ctx, writer.Config{
+			Keys:              keys,
+			Start:             g.bounds.Start,
+			Mode:              ts.WriterPersistOnly,
+			ErrOnUnauthorized: config.True(),
+		}); err != nil {
+			return tr, err
+		}
+		if tr.IsZero() {
+			tr = g.bounds
+		} else {
+			tr = tr.Union(g.bounds)
+		}
+	}
+	err = imp.read(ctx, func(cells [][][]byte, rows int) error {
+		for _, g := range groups {
+			if g.w == nil {
+				continue
+			}
+			fr := imp.frame(g, cells, rows)
+			if fr.Empty() {
+				continue
+			}
+			authorized, err := g.w.Write(fr)
+			if err != nil {
+				return err
+			}
+			if !authorized {
+				return errors.Newf("writer is not authorized to write to channels %v", fr.KeysSlice())
+			}
+			if _, err = g.w.Commit(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return tr, err
+}
+
+// frame builds a frame from the rows of a batch that have a timestamp in the group's
+// index column.
+func (imp *Import) frame(g *group, cells [][][]byte, rows int) core.Frame {
+	var (
+		keys   = make(channel.Keys, len(g.columns))
+		series = make([]telem.Series, len(g.columns))
+	)
+	for i, c := range g.columns {
+		ch := imp.columns[c].channel
+		keys[i] = ch.Key()
+		series[i] = telem.Series{DataType: ch.DataType}
+	}
+	for row := range rows {
+		if cells[g.columns[0]][row] == nil {
+			continue
+		}
+		for i, c := range g.columns {
+			series[i].Data = append(series[i].Data, cells[c][row]...)
+		}
+	}
+	if len(series[0].Data) == 0 {
+		return core.Frame{}
+	}
+	return core.MultiFrame(keys, series)
+}
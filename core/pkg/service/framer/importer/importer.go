@@ -0,0 +1,387 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package importer implements bulk import of CSV, Parquet and Arrow IPC files into
+// channels.
+package importer
+
+import (
+	"context"
+	"io"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/alamos"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/synnax/pkg/service/ranger"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Format is the file format of an import.
+type Format string
+
+const (
+	// FormatCSV imports a comma-separated values file with a header row. Lines at the
+	// start of the file beginning with '#' are parsed as "key: value" metadata.
+	FormatCSV Format = "csv"
+	// FormatParquet imports an Apache Parquet file. The key-value metadata of the file
+	// is used as its metadata.
+	FormatParquet Format = "parquet"
+	// FormatArrow imports an Apache Arrow IPC stream. The metadata of the stream's
+	// schema is used as its metadata.
+	FormatArrow Format = "arrow"
+)
+
+func (f Format) valid() bool { return f == FormatCSV || f == FormatParquet || f == FormatArrow }
+
+// Column maps a column in an imported file to a channel.
+type Column struct {
+	// Name is the name of the column in the file.
+	Name string `json:"name" msgpack:"name"`
+	// Channel is the name of the channel to write the column to. Defaults to the name
+	// of the column.
+	Channel string `json:"channel" msgpack:"channel"`
+	// IsIndex marks the column as holding the timestamps of other columns. Columns
+	// mapped to existing index channels are always treated as index columns.
+	IsIndex bool `json:"is_index" msgpack:"is_index"`
+	// Index is the name of the index column holding the timestamps for this column. If
+	// not provided, the index is resolved from the column's existing channel, or the
+	// only index column in the import.
+	Index string `json:"index" msgpack:"index"`
+	// DataType is the data type of the channel created for the column. If not provided,
+	// the data type is inferred from the contents of the file. Ignored for columns
+	// mapped to existing channels.
+	DataType telem.DataType `json:"data_type" msgpack:"data_type"`
+}
+
+func (c Column) channelName() string {
+	if c.Channel != "" {
+		return c.Channel
+	}
+	return c.Name
+}
+
+// Request is a request to import a file.
+type Request struct {
+	// Format is the file format of the import.
+	Format Format `json:"format" msgpack:"format"`
+	// Columns are the columns to import and the channels to write them to.
+	Columns []Column `json:"columns" msgpack:"columns"`
+	// CreateChannels sets whether channels that do not exist should be created. If
+	// false, importing a column mapped to a channel that does not exist fails.
+	CreateChannels bool `json:"create_channels" msgpack:"create_channels"`
+	// Range is the name of a range to create spanning the imported data. The metadata
+	// of the file is stored as key-value pairs on the range. If empty, no range is
+	// created.
+	Range string `json:"range" msgpack:"range"`
+}
+
+// Validate checks that the request is well-formed.
+func (r Request) Validate() error {
+	v := validate.New("import")
+	v.Ternaryf("format", !r.Format.valid(), "invalid import format %q", r.Format)
+	v.Ternary("columns", len(r.Columns) == 0, "must provide at least one column")
+	for i, c := range r.Columns {
+		v.Ternaryf("columns", c.Name == "", "column %d must have a name", i)
+		v.Ternaryf("columns", c.IsIndex && c.Index != "", "index column %s cannot have an index", c.Name)
+	}
+	return v.Error()
+}
+
+// Response is the result of an import.
+type Response struct {
+	// Channels are the channels written to, in the order of the columns in the
+	// request.
+	Channels []channel.Channel `json:"channels" msgpack:"channels"`
+	// Rows is the number of rows read from the file.
+	Rows int `json:"rows" msgpack:"rows"`
+	// TimeRange spans the imported data.
+	TimeRange telem.TimeRange `json:"time_range" msgpack:"time_range"`
+	// Range is the key of the created range, or the zero UUID if no range was created.
+	Range uuid.UUID `json:"range" msgpack:"range"`
+}
+
+// File is an imported file. Files are read more than once, so they must be seekable.
+type File interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+}
+
+// ServiceConfig is the configuration for opening an import service.
+type ServiceConfig struct {
+	alamos.Instrumentation
+	// Channel is used to retrieve and create the imported channels.
+	// [REQUIRED]
+	Channel channel.Service
+	// Framer is used to write the imported data.
+	// [REQUIRED]
+	Framer *framer.Service
+	// Ranger is used to create ranges spanning imported data.
+	// [REQUIRED]
+	Ranger *ranger.Service
+	// BatchSize is the maximum number of rows written at once.
+	// [OPTIONAL] - defaults to 65536.
+	BatchSize int
+	// MaxFileSize is the maximum size of a file spooled for import.
+	// [OPTIONAL] - defaults to 1 GB.
+	MaxFileSize telem.Size
+}
+
+var (
+	_ config.Config[ServiceConfig] = ServiceConfig{}
+	// DefaultServiceConfig is the default configuration for an import service.
+	DefaultServiceConfig = ServiceConfig{BatchSize: 1 << 16, MaxFileSize: telem.Gigabyte}
+)
+
+// Override implements config.Config.
+func (cfg ServiceConfig) Override(other ServiceConfig) ServiceConfig {
+	cfg.Instrumentation = override.Zero(cfg.Instrumentation, other.Instrumentation)
+	cfg.Channel = override.Nil(cfg.Channel, other.Channel)
+	cfg.Framer = override.Nil(cfg.Framer, other.Framer)
+	cfg.Ranger = override.Nil(cfg.Ranger, other.Ranger)
+	cfg.BatchSize = override.Numeric(cfg.BatchSize, other.BatchSize)
+	cfg.MaxFileSize = override.Numeric(cfg.MaxFileSize, other.MaxFileSize)
+	return cfg
+}
+
+// Validate implements config.Config.
+func (cfg ServiceConfig) Validate() error {
+	v := validate.New("import")
+	validate.NotNil(v, "channel", cfg.Channel)
+	validate.NotNil(v, "framer", cfg.Framer)
+	validate.NotNil(v, "ranger", cfg.Ranger)
+	validate.Positive(v, "batch_size", cfg.BatchSize)
+	validate.Positive(v, "max_file_size", cfg.MaxFileSize)
+	return v.Error()
+}
+
+// Service imports files into channels.
+type Service struct{ cfg ServiceConfig }
+
+// NewService opens a new import service using the provided configurations. Later
+// configurations override earlier ones.
+func NewService(cfgs ...ServiceConfig) (*Service, error) {
+	cfg, err := config.New(DefaultServiceConfig, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{cfg: cfg}, nil
+}
+
+// Import opens and executes an import of the provided file.
+func (s *Service) Import(ctx context.Context, req Request, f File) (Response, error) {
+	imp, err := s.Open(ctx, req, f)
+	if err != nil {
+		return Response{}, err
+	}
+	return imp.Exec(ctx)
+}
+
+// Targets returns the channels an import of the request would write to, in the order of
+// the columns in the request, without reading the file. Channels that do not exist are
+// returned with a zero key and only their name set. Targets allows access to the
+// channels to be checked before a file is received.
+func (s *Service) Targets(ctx context.Context, req Request) ([]channel.Channel, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	names := make([]string, len(req.Columns))
+	for i, c := range req.Columns {
+		names[i] = c.channelName()
+	}
+	var existing []channel.Channel
+	if err := s.cfg.Channel.NewRetrieve().
+		WhereNames(names...).
+		Entries(&existing).
+		Exec(ctx, nil); err != nil && !errors.Is(err, query.NotFound) {
+		return nil, err
+	}
+	targets := make([]channel.Channel, len(names))
+	for i, name := range names {
+		idx := slices.IndexFunc(existing, func(ch channel.Channel) bool {
+			return ch.Name == name
+		})
+		if idx >= 0 {
+			targets[i] = existing[idx]
+			continue
+		}
+		if !req.CreateChannels {
+			return nil, errors.Wrapf(query.NotFound, "channel %s not found", name)
+		}
+		targets[i] = channel.Channel{Name: name}
+	}
+	return targets, nil
+}
+
+// column is a column of an import that has been resolved to a channel.
+type column struct {
+	Column
+	// pos is the position of the column in the file.
+	pos int
+	// channel is the channel the column is written to. Channels that have not been
+	// created yet have a zero key.
+	channel channel.Channel
+	// index is the position of the column's index column in the import, or -1 if the
+	// column is an index.
+	index int
+}
+
+func (c column) isIndex() bool { return c.index < 0 }
+
+// Import is an import that has been resolved against the cluster and is ready to be
+// executed.
+type Import struct {
+	svc      *Service
+	req      Request
+	dec      decoder
+	columns  []column
+	metadata map[string]string
+}
+
+// Open decodes the header of the file and resolves the channel for each column in the
+// request, inferring the data types of channels that need to be created. Open does not
+// create any channels or write any data.
+func (s *Service) Open(ctx context.Context, req Request, f File) (*Import, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	dec, err := newDecoder(ctx, req.Format, f, s.cfg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	imp := &Import{svc: s, req: req, dec: dec, metadata: dec.metadata()}
+	if err = imp.resolve(ctx); err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+// Channels returns the channels the import writes to, in the order of the columns in
+// the request. Channels that will be created by the import have a zero key.
+func (imp *Import) Channels() []channel.Channel {
+	channels := make([]channel.Channel, len(imp.columns))
+	for i, c := range imp.columns {
+		channels[i] = c.channel
+	}
+	return channels
+}
+
+func (imp *Import) resolve(ctx context.Context) error {
+	fileColumns := imp.dec.columns()
+	imp.columns = make([]column, len(imp.req.Columns))
+	for i, c := range imp.req.Columns {
+		pos := slices.Index(fileColumns, c.Name)
+		if pos < 0 {
+			return errors.Wrapf(validate.Error, "column %s not found in file", c.Name)
+		}
+		imp.columns[i] = column{Column: c, pos: pos, index: -1}
+	}
+	targets, err := imp.svc.Targets(ctx, imp.req)
+	if err != nil {
+		return err
+	}
+	var inferred []telem.DataType
+	for i := range imp.columns {
+		c := &imp.columns[i]
+		if targets[i].Key() != 0 {
+			c.channel = targets[i]
+			if c.channel.Virtual {
+				return errors.Wrapf(validate.Error, "cannot import into virtual channel %s", c.channel)
+			}
+			if c.IsIndex && !c.channel.IsIndex {
+				return errors.Wrapf(validate.Error, "column %s is an index, but channel %s is not", c.Name, c.channel)
+			}
+			c.IsIndex = c.channel.IsIndex
+			continue
+		}
+		c.channel = channel.Channel{Name: c.channelName(), DataType: c.DataType}
+		if c.IsIndex {
+			c.channel.DataType = telem.TimeStampT
+		}
+		if c.channel.DataType == telem.UnknownT {
+			if inferred == nil {
+				if inferred, err = imp.dec.infer(ctx); err != nil {
+					return err
+				}
+			}
+			c.channel.DataType = inferred[c.pos]
+		}
+		if c.channel.DataType == telem.UnknownT {
+			return errors.Wrapf(validate.Error, "unable to infer the data type of column %s", c.Name)
+		}
+		if c.channel.DataType.IsVariable() {
+			return errors.Wrapf(
+				validate.Error,
+				"column %s has data type %s, but persisted channels cannot have variable density data types",
+				c.Name,
+				c.channel.DataType,
+			)
+		}
+		c.IsIndex = c.IsIndex || (c.channel.DataType == telem.TimeStampT && c.Index == "")
+		c.channel.IsIndex = c.IsIndex
+	}
+	var indexes []int
+	for i, c := range imp.columns {
+		if c.IsIndex {
+			indexes = append(indexes, i)
+		}
+	}
+	for i := range imp.columns {
+		c := &imp.columns[i]
+		if c.IsIndex {
+			continue
+		}
+		if c.index, err = imp.resolveIndex(*c, indexes); err != nil {
+			return err
+		}
+		idx := imp.columns[c.index].channel
+		if c.channel.Key() != 0 {
+			if c.channel.Index() != idx.Key() || idx.Key() == 0 {
+				return errors.Wrapf(
+					validate.Error,
+					"channel %s is not indexed by channel %s",
+					c.channel,
+					idx,
+				)
+			}
+			continue
+		}
+		c.channel.LocalIndex = idx.LocalKey
+	}
+	return nil
+}
+
+func (imp *Import) resolveIndex(c column, indexes []int) (int, error) {
+	if c.Index != "" {
+		for _, i := range indexes {
+			if imp.columns[i].Name == c.Index {
+				return i, nil
+			}
+		}
+		return 0, errors.Wrapf(validate.Error, "index column %s for column %s not found", c.Index, c.Name)
+	}
+	if c.channel.Key() != 0 {
+		for _, i := range indexes {
+			if imp.columns[i].channel.Key() == c.channel.Index() {
+				return i, nil
+			}
+		}
+	}
+	if len(indexes) == 1 {
+		return indexes[0], nil
+	}
+	return 0, errors.Wrapf(validate.Error, "unable to determine the index column for column %s", c.Name)
+}
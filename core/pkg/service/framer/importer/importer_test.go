@@ -0,0 +1,385 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package importer_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/mock"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/importer"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/iterator"
+	"github.com/synnaxlabs/synnax/pkg/service/label"
+	"github.com/synnaxlabs/synnax/pkg/service/ranger"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+func csvFile(lines ...string) *bytes.Reader {
+	return bytes.NewReader([]byte(strings.Join(lines, "\n") + "\n"))
+}
+
+func ns(s int) string { return fmt.Sprint(int64(telem.TimeStamp(s) * telem.SecondTS)) }
+
+var _ = Describe("Importer", Ordered, func() {
+	var (
+		builder     = mock.NewCluster()
+		dist        mock.Node
+		rangerSvc   *ranger.Service
+		iteratorSvc *iterator.Service
+		svc         *importer.Service
+	)
+	read := func(key channel.Key, tr telem.TimeRange) telem.MultiSeries {
+		it := MustSucceed(iteratorSvc.Open(ctx, iterator.Config{Keys: channel.Keys{key}, Bounds: tr}))
+		defer func() { Expect(it.Close()).To(Succeed()) }()
+		var series []telem.Series
+		for it.SeekFirst(); it.Next(iterator.AutoSpan); {
+			series = append(series, it.Value().Get(key).Series...)
+		}
+		return telem.NewMultiSeries(series)
+	}
+	BeforeAll(func() {
+		dist = builder.Provision(ctx)
+		lab := MustSucceed(label.OpenService(ctx, label.Config{
+			DB:       dist.DB,
+			Ontology: dist.Ontology,
+			Group:    dist.Group,
+		}))
+		rangerSvc = MustSucceed(ranger.OpenService(ctx, ranger.Config{
+			DB:       dist.DB,
+			Ontology: dist.Ontology,
+			Group:    dist.Group,
+			Label:    lab,
+		}))
+		iteratorSvc = MustSucceed(iterator.NewService(iterator.ServiceConfig{
+			DistFramer: dist.Framer,
+			Channel:    dist.Channel,
+		}))
+		svc = MustSucceed(importer.NewService(importer.ServiceConfig{
+			Channel:   dist.Channel,
+			Framer:    dist.Framer,
+			Ranger:    rangerSvc,
+			BatchSize: 2,
+		}))
+	})
+	AfterAll(func() {
+		Expect(builder.Close()).To(Succeed())
+	})
+
+	Describe("CSV", func() {
+		It("Should create channels with inferred data types and write the file", func() {
+			res := MustSucceed(svc.Import(ctx, importer.Request{
+				Format: importer.FormatCSV,
+				Columns: []importer.Column{
+					{Name: "time", Channel: "csv_time", IsIndex: true},
+					{Name: "temperature", Channel: "csv_temperature"},
+					{Name: "pressure", Channel: "csv_pressure"},
+				},
+				CreateChannels: true,
+			}, csvFile(
+				"time,temperature,pressure,ignored",
+				ns(1)+",1.5,10,a",
+				ns(2)+",2.5,20,b",
+				ns(3)+",3.5,30,c",
+			)))
+			Expect(res.Rows).To(Equal(3))
+			Expect(res.TimeRange).To(Equal(telem.TimeRange{Start: telem.SecondTS, End: 3*telem.SecondTS + 1}))
+			Expect(res.Channels).To(HaveLen(3))
+			idx, temp, press := res.Channels[0], res.Channels[1], res.Channels[2]
+			Expect(idx.IsIndex).To(BeTrue())
+			Expect(temp.DataType).To(Equal(telem.Float64T))
+			Expect(temp.Index()).To(Equal(idx.Key()))
+			Expect(press.DataType).To(Equal(telem.Int64T))
+			Expect(read(temp.Key(), telem.TimeRangeMax).Series[0]).
+				To(telem.MatchSeriesData(telem.NewSeriesV(1.5, 2.5, 3.5)))
+			Expect(read(press.Key(), telem.TimeRangeMax).Series[0]).
+				To(telem.MatchSeriesData(telem.NewSeriesV[int64](10, 20, 30)))
+		})
+
+		It("Should write to existing channels", func() {
+			res := MustSucceed(svc.Import(ctx, importer.Request{
+				Format: importer.FormatCSV,
+				Columns: []importer.Column{
+					{Name: "csv_time"},
+					{Name: "csv_temperature"},
+				},
+			}, csvFile(
+				"csv_time,csv_temperature",
+				"1970-01-01T00:00:10Z,10.5",
+				"1970-01-01T00:00:11Z,11.5",
+			)))
+			Expect(res.Channels[0].IsIndex).To(BeTrue())
+			Expect(read(res.Channels[1].Key(), telem.TimeRange{
+				Start: 10 * telem.SecondTS,
+				End:   12 * telem.SecondTS,
+			}).Series[0]).To(telem.MatchSeriesData(telem.NewSeriesV(10.5, 11.5)))
+		})
+
+		It("Should create a range with the metadata in the file header", func() {
+			res := MustSucceed(svc.Import(ctx, importer.Request{
+				Format: importer.FormatCSV,
+				Columns: []importer.Column{
+					{Name: "time", Channel: "range_time", IsIndex: true},
+					{Name: "value", Channel: "range_value"},
+				},
+				CreateChannels: true,
+				Range:          "Test 12",
+			}, csvFile(
+				"# test: 12",
+				"# operator=jane",
+				"time,value",
+				ns(5)+",1",
+				ns(6)+",2",
+			)))
+			var rng ranger.Range
+			Expect(rangerSvc.NewRetrieve().WhereKeys(res.Range).Entry(&rng).Exec(ctx, nil)).To(Succeed())
+			Expect(rng.Name).To(Equal("Test 12"))
+			Expect(rng.TimeRange).To(Equal(telem.TimeRange{Start: 5 * telem.SecondTS, End: 6*telem.SecondTS + 1}))
+			Expect(rng.Get(ctx, "test")).To(Equal("12"))
+			Expect(rng.Get(ctx, "operator")).To(Equal("jane"))
+		})
+
+		It("Should write channels with different indexes", func() {
+			res := MustSucceed(svc.Import(ctx, importer.Request{
+				Format: importer.FormatCSV,
+				Columns: []importer.Column{
+					{Name: "time_a", IsIndex: true},
+					{Name: "a", Index: "time_a"},
+					{Name: "time_b", IsIndex: true},
+					{Name: "b", Index: "time_b"},
+				},
+				CreateChannels: true,
+			}, csvFile(
+				"time_a,a,time_b,b",
+				ns(1)+",1,,",
+				ns(2)+",2,"+ns(2)+",20",
+				",,"+ns(4)+",40",
+			)))
+			Expect(res.Channels[1].Index()).To(Equal(res.Channels[0].Key()))
+			Expect(res.Channels[3].Index()).To(Equal(res.Channels[2].Key()))
+			Expect(read(res.Channels[3].Key(), telem.TimeRangeMax).Series[0]).
+				To(telem.MatchSeriesData(telem.NewSeriesV[int64](20, 40)))
+		})
+	})
+
+	Describe("Parquet", func() {
+		It("Should import a Parquet file", func() {
+			schema := arrow.NewSchema([]arrow.Field{
+				{Name: "time", Type: arrow.FixedWidthTypes.Timestamp_ms},
+				{Name: "value", Type: arrow.PrimitiveTypes.Float32},
+				{Name: "ok", Type: arrow.FixedWidthTypes.Boolean},
+			}, nil)
+			b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+			defer b.Release()
+			b.Field(0).(*array.TimestampBuilder).AppendValues([]arrow.Timestamp{1000, 2000}, nil)
+			b.Field(1).(*array.Float32Builder).AppendValues([]float32{1, 2}, nil)
+			b.Field(2).(*array.BooleanBuilder).AppendValues([]bool{true, false}, nil)
+			rec := b.NewRecordBatch()
+			defer rec.Release()
+			var buf bytes.Buffer
+			w := MustSucceed(pqarrow.NewFileWriter(schema, &buf, parquet.NewWriterProperties(), pqarrow.DefaultWriterProps()))
+			Expect(w.Write(rec)).To(Succeed())
+			Expect(w.AppendKeyValueMetadata("operator", "jane")).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+
+			res := MustSucceed(svc.Import(ctx, importer.Request{
+				Format: importer.FormatParquet,
+				Columns: []importer.Column{
+					{Name: "time", Channel: "pq_time"},
+					{Name: "value", Channel: "pq_value"},
+					{Name: "ok", Channel: "pq_ok"},
+				},
+				CreateChannels: true,
+				Range:          "Parquet",
+			}, bytes.NewReader(buf.Bytes())))
+			Expect(res.Channels[0].IsIndex).To(BeTrue())
+			Expect(res.Channels[1].DataType).To(Equal(telem.Float32T))
+			Expect(res.Channels[2].DataType).To(Equal(telem.Uint8T))
+			Expect(res.TimeRange.Start).To(Equal(telem.SecondTS))
+			Expect(read(res.Channels[2].Key(), telem.TimeRangeMax).Series[0]).
+				To(telem.MatchSeriesData(telem.NewSeriesV[uint8](1, 0)))
+			var rng ranger.Range
+			Expect(rangerSvc.NewRetrieve().WhereKeys(res.Range).Entry(&rng).Exec(ctx, nil)).To(Succeed())
+			Expect(rng.Get(ctx, "operator")).To(Equal("jane"))
+		})
+	})
+
+	Describe("Arrow", func() {
+		It("Should import an Arrow IPC stream", func() {
+			md := arrow.NewMetadata([]string{"operator"}, []string{"john"})
+			schema := arrow.NewSchema([]arrow.Field{
+				{Name: "time", Type: arrow.FixedWidthTypes.Timestamp_ns},
+				{Name: "value", Type: arrow.PrimitiveTypes.Int32},
+			}, &md)
+			b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+			defer b.Release()
+			var buf bytes.Buffer
+			w := ipc.NewWriter(&buf, ipc.WithSchema(schema))
+			for i := range 2 {
+				start := arrow.Timestamp(telem.SecondTS * telem.TimeStamp(2*i+1))
+				b.Field(0).(*array.TimestampBuilder).AppendValues([]arrow.Timestamp{start, start + arrow.Timestamp(telem.SecondTS)}, nil)
+				b.Field(1).(*array.Int32Builder).AppendValues([]int32{int32(2 * i), int32(2*i + 1)}, nil)
+				rec := b.NewRecordBatch()
+				Expect(w.Write(rec)).To(Succeed())
+				rec.Release()
+			}
+			Expect(w.Close()).To(Succeed())
+
+			res := MustSucceed(svc.Import(ctx, importer.Request{
+				Format: importer.FormatArrow,
+				Columns: []importer.Column{
+					{Name: "time", Channel: "arrow_time"},
+					{Name: "value", Channel: "arrow_value"},
+				},
+				CreateChannels: true,
+				Range:          "Arrow",
+			}, bytes.NewReader(buf.Bytes())))
+			Expect(res.Rows).To(Equal(4))
+			Expect(res.Channels[1].DataType).To(Equal(telem.Int32T))
+			Expect(res.TimeRange).To(Equal((1 * telem.SecondTS).Range(4*telem.SecondTS + 1)))
+			Expect(read(res.Channels[1].Key(), telem.TimeRangeMax).Data()).
+				To(Equal(telem.NewSeriesV[int32](0, 1, 2, 3).Data))
+			var rng ranger.Range
+			Expect(rangerSvc.NewRetrieve().WhereKeys(res.Range).Entry(&rng).Exec(ctx, nil)).To(Succeed())
+			Expect(rng.Get(ctx, "operator")).To(Equal("john"))
+		})
+	})
+
+	Describe("Targets", func() {
+		It("Should resolve the channels of an import without reading the file", func() {
+			targets := MustSucceed(svc.Targets(ctx, importer.Request{
+				Format: importer.FormatCSV,
+				Columns: []importer.Column{
+					{Name: "time", Channel: "csv_time"},
+					{Name: "new_column"},
+				},
+				CreateChannels: true,
+			}))
+			Expect(targets).To(HaveLen(2))
+			Expect(targets[0].Key()).ToNot(BeZero())
+			Expect(targets[0].Name).To(Equal("csv_time"))
+			Expect(targets[1].Key()).To(BeZero())
+			Expect(targets[1].Name).To(Equal("new_column"))
+		})
+
+		It("Should return an error when a channel does not exist", func() {
+			Expect(svc.Targets(ctx, importer.Request{
+				Format:  importer.FormatCSV,
+				Columns: []importer.Column{{Name: "new_column"}},
+			})).Error().To(HaveOccurredAs(query.NotFound))
+		})
+	})
+
+	Describe("Spool", func() {
+		It("Should reject writes beyond the maximum file size", func() {
+			small := MustSucceed(importer.NewService(importer.ServiceConfig{
+				Channel:     dist.Channel,
+				Framer:      dist.Framer,
+				Ranger:      rangerSvc,
+				MaxFileSize: 4 * telem.Byte,
+			}))
+			sp := MustSucceed(small.NewSpool())
+			Expect(sp.Write([]byte("abc"))).To(Equal(3))
+			Expect(sp.Write([]byte("de"))).Error().To(HaveOccurredAs(validate.Error))
+			Expect(sp.Write([]byte("d"))).To(Equal(1))
+			name := sp.Name()
+			Expect(sp.Close()).To(Succeed())
+			Expect(os.Stat(name)).Error().To(MatchError(os.ErrNotExist))
+		})
+	})
+
+	Describe("Validation", func() {
+		It("Should reject timestamps that are not strictly increasing", func() {
+			Expect(svc.Import(ctx, importer.Request{
+				Format: importer.FormatCSV,
+				Columns: []importer.Column{
+					{Name: "time", Channel: "unordered_time", IsIndex: true},
+					{Name: "value", Channel: "unordered_value"},
+				},
+				CreateChannels: true,
+			}, csvFile(
+				"time,value",
+				ns(2)+",1",
+				ns(1)+",2",
+			))).Error().To(MatchError(And(
+				ContainSubstring("strictly increasing"),
+				ContainSubstring("row 2"),
+			)))
+			var ch channel.Channel
+			Expect(dist.Channel.NewRetrieve().WhereNames("unordered_time").Entry(&ch).Exec(ctx, nil)).
+				To(HaveOccurredAs(query.NotFound))
+		})
+
+		It("Should reject rows that are missing a value", func() {
+			Expect(svc.Import(ctx, importer.Request{
+				Format: importer.FormatCSV,
+				Columns: []importer.Column{
+					{Name: "time", Channel: "missing_time", IsIndex: true},
+					{Name: "value", Channel: "missing_value"},
+				},
+				CreateChannels: true,
+			}, csvFile(
+				"time,value",
+				ns(1)+",1",
+				ns(2)+",",
+			))).Error().To(HaveOccurredAs(validate.Error))
+		})
+
+		It("Should return an error when a channel does not exist", func() {
+			Expect(svc.Import(ctx, importer.Request{
+				Format:  importer.FormatCSV,
+				Columns: []importer.Column{{Name: "time", Channel: "does_not_exist", IsIndex: true}},
+			}, csvFile("time", ns(1)))).Error().To(HaveOccurredAs(query.NotFound))
+		})
+
+		It("Should return an error when a column is not in the file", func() {
+			Expect(svc.Import(ctx, importer.Request{
+				Format:  importer.FormatCSV,
+				Columns: []importer.Column{{Name: "missing", IsIndex: true}},
+			}, csvFile("time", ns(1)))).Error().To(HaveOccurredAs(validate.Error))
+		})
+
+		It("Should return an error when a column holds text", func() {
+			Expect(svc.Import(ctx, importer.Request{
+				Format: importer.FormatCSV,
+				Columns: []importer.Column{
+					{Name: "time", Channel: "text_time", IsIndex: true},
+					{Name: "label", Channel: "text_label"},
+				},
+				CreateChannels: true,
+			}, csvFile("time,label", ns(1)+",a"))).Error().To(MatchError(ContainSubstring("variable density")))
+		})
+
+		It("Should return an error when the index of a column is ambiguous", func() {
+			Expect(svc.Import(ctx, importer.Request{
+				Format: importer.FormatCSV,
+				Columns: []importer.Column{
+					{Name: "a", IsIndex: true},
+					{Name: "b", IsIndex: true},
+					{Name: "c"},
+				},
+				CreateChannels: true,
+			}, csvFile("a,b,c", ns(1)+","+ns(1)+",1"))).Error().To(HaveOccurredAs(validate.Error))
+		})
+	})
+})
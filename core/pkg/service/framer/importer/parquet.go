@@ -0,0 +1,226 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// parquetDecoder decodes Parquet files with a flat schema.
+type parquetDecoder struct {
+	reader *pqarrow.FileReader
+	schema *arrow.Schema
+	names  []string
+	meta   map[string]string
+}
+
+func newParquetDecoder(ctx context.Context, f File, batchSize int) (*parquetDecoder, error) {
+	pf, err := file.NewParquetReader(f)
+	if err != nil {
+		return nil, errors.Wrapf(validate.Error, "invalid parquet file: %s", err)
+	}
+	reader, err := pqarrow.NewFileReader(
+		pf,
+		pqarrow.ArrowReadProperties{BatchSize: int64(batchSize)},
+		memory.DefaultAllocator,
+	)
+	if err != nil {
+		return nil, err
+	}
+	d := &parquetDecoder{reader: reader, meta: make(map[string]string)}
+	if d.schema, err = reader.Schema(); err != nil {
+		return nil, err
+	}
+	for _, field := range d.schema.Fields() {
+		d.names = append(d.names, field.Name)
+	}
+	kv := pf.MetaData().KeyValueMetadata()
+	addArrowMetadata(d.meta, kv.Keys(), kv.Values())
+	return d, ctx.Err()
+}
+
+// addArrowMetadata adds the key-value pairs to meta, skipping the reserved keys used by
+// Arrow to store serialized schemas.
+func addArrowMetadata(meta map[string]string, keys, values []string) {
+	for i, k := range keys {
+		if !strings.HasPrefix(k, "ARROW:") {
+			meta[k] = values[i]
+		}
+	}
+}
+
+func (d *parquetDecoder) columns() []string { return d.names }
+
+func (d *parquetDecoder) metadata() map[string]string { return d.meta }
+
+// infer infers the data type of each column from the Arrow type of its field in the
+// file's schema.
+func (d *parquetDecoder) infer(context.Context) ([]telem.DataType, error) {
+	return inferFromSchema(d.schema), nil
+}
+
+func inferFromSchema(schema *arrow.Schema) []telem.DataType {
+	dataTypes := make([]telem.DataType, schema.NumFields())
+	for i, field := range schema.Fields() {
+		dataTypes[i] = dataTypeFromArrow(field.Type)
+	}
+	return dataTypes
+}
+
+func dataTypeFromArrow(t arrow.DataType) telem.DataType {
+	switch t := t.(type) {
+	case *arrow.Float64Type:
+		return telem.Float64T
+	case *arrow.Float32Type:
+		return telem.Float32T
+	case *arrow.Int64Type:
+		return telem.Int64T
+	case *arrow.Int32Type:
+		return telem.Int32T
+	case *arrow.Int16Type:
+		return telem.Int16T
+	case *arrow.Int8Type:
+		return telem.Int8T
+	case *arrow.Uint64Type:
+		return telem.Uint64T
+	case *arrow.Uint32Type:
+		return telem.Uint32T
+	case *arrow.Uint16Type:
+		return telem.Uint16T
+	case *arrow.Uint8Type, *arrow.BooleanType:
+		return telem.Uint8T
+	case *arrow.TimestampType:
+		return telem.TimeStampT
+	case *arrow.StringType, *arrow.LargeStringType:
+		return telem.StringT
+	case *arrow.BinaryType, *arrow.LargeBinaryType:
+		return telem.BytesT
+	case *arrow.FixedSizeBinaryType:
+		if t.ByteWidth == 16 {
+			return telem.UUIDT
+		}
+		return telem.BytesT
+	default:
+		return telem.UnknownT
+	}
+}
+
+func (d *parquetDecoder) read(
+	ctx context.Context,
+	positions []int,
+	dataTypes []telem.DataType,
+	f func(cells [][][]byte, rows int) error,
+) error {
+	rr, err := d.reader.GetRecordReader(ctx, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer rr.Release()
+	return readRecords(ctx, rr, d.names, positions, dataTypes, f)
+}
+
+// recordReader reads a sequence of Arrow record batches.
+type recordReader interface {
+	Next() bool
+	RecordBatch() arrow.RecordBatch
+	Err() error
+}
+
+// readRecords decodes each record batch read from rr into cells, calling f with the
+// cells of each batch.
+func readRecords(
+	ctx context.Context,
+	rr recordReader,
+	names []string,
+	positions []int,
+	dataTypes []telem.DataType,
+	f func(cells [][][]byte, rows int) error,
+) (err error) {
+	for rr.Next() {
+		rec := rr.RecordBatch()
+		rows := int(rec.NumRows())
+		cells := newCells(len(positions), rows)
+		for i, pos := range positions {
+			col := rec.Column(pos)
+			for row := range rows {
+				if col.IsNull(row) {
+					continue
+				}
+				if cells[i][row], err = sampleFromArrow(col, row, dataTypes[i]); err != nil {
+					return errors.Wrapf(err, "column %s", names[pos])
+				}
+			}
+		}
+		if err = f(cells, rows); err != nil {
+			return err
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return rr.Err()
+}
+
+// sampleFromArrow converts the value at index i of an Arrow array into a sample of the
+// given data type.
+func sampleFromArrow(arr arrow.Array, i int, dt telem.DataType) ([]byte, error) {
+	switch a := arr.(type) {
+	case *array.Float64:
+		return marshalFloat(dt, a.Value(i))
+	case *array.Float32:
+		return marshalFloat(dt, float64(a.Value(i)))
+	case *array.Int64:
+		return marshalInt(dt, a.Value(i))
+	case *array.Int32:
+		return marshalInt(dt, int64(a.Value(i)))
+	case *array.Int16:
+		return marshalInt(dt, int64(a.Value(i)))
+	case *array.Int8:
+		return marshalInt(dt, int64(a.Value(i)))
+	case *array.Uint64:
+		return marshalUint(dt, a.Value(i))
+	case *array.Uint32:
+		return marshalUint(dt, uint64(a.Value(i)))
+	case *array.Uint16:
+		return marshalUint(dt, uint64(a.Value(i)))
+	case *array.Uint8:
+		return marshalUint(dt, uint64(a.Value(i)))
+	case *array.Boolean:
+		if a.Value(i) {
+			return marshalUint(dt, 1)
+		}
+		return marshalUint(dt, 0)
+	case *array.Timestamp:
+		unit := a.DataType().(*arrow.TimestampType).Unit
+		return marshalInt(dt, int64(a.Value(i))*int64(unit.Multiplier()))
+	case *array.String:
+		return ParseSample(dt, a.Value(i))
+	case *array.LargeString:
+		return ParseSample(dt, a.Value(i))
+	case *array.Binary:
+		return marshalBytes(dt, a.Value(i))
+	case *array.LargeBinary:
+		return marshalBytes(dt, a.Value(i))
+	case *array.FixedSizeBinary:
+		return marshalBytes(dt, a.Value(i))
+	default:
+		return nil, errors.Wrapf(validate.Error, "unsupported arrow column type %s", arr.DataType())
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package importer
+
+import (
+	"os"
+
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Spool is a temporary file that a file received from a client is written to before it
+// is imported, as imports read files more than once. Writes that would grow the spool
+// beyond the maximum file size of the service fail.
+type Spool struct {
+	*os.File
+	size    telem.Size
+	maxSize telem.Size
+}
+
+var _ File = (*Spool)(nil)
+
+// NewSpool creates a new, empty spool. The spool must be closed when the import is
+// complete.
+func (s *Service) NewSpool() (*Spool, error) {
+	f, err := os.CreateTemp("", "synnax-import-*")
+	if err != nil {
+		return nil, err
+	}
+	return &Spool{File: f, maxSize: s.cfg.MaxFileSize}, nil
+}
+
+// Write implements io.Writer.
+func (sp *Spool) Write(p []byte) (int, error) {
+	if sp.size+telem.Size(len(p)) > sp.maxSize {
+		return 0, errors.Wrapf(
+			validate.Error,
+			"import cannot be larger than %s",
+			sp.maxSize,
+		)
+	}
+	n, err := sp.File.Write(p)
+	sp.size += telem.Size(n)
+	return n, err
+}
+
+// Close closes and removes the spool.
+func (sp *Spool) Close() error {
+	return errors.Combine(sp.File.Close(), os.Remove(sp.Name()))
+}
@@ -44,6 +44,7 @@ type (
 	StreamerRequest  = streamer.Request
 	StreamerResponse = streamer.Response
 	Streamer         = streamer.Streamer
+	StreamerStats    = streamer.Stats
 	Deleter          = deleter.Deleter
 )
 
@@ -124,18 +125,19 @@ func OpenService(ctx context.Context, cfgs ...Config) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	iteratorSvc, err := iterator.NewService(iterator.ServiceConfig{
+		DistFramer: cfg.Framer,
+		Channel:    cfg.Channel,
+	})
+	if err != nil {
+		return nil, err
+	}
 	streamerSvc, err := streamer.NewService(streamer.ServiceConfig{
 		Instrumentation: cfg.Instrumentation.Child("streamer"),
 		DistFramer:      cfg.Framer,
 		Channel:         cfg.Channel,
 		Calculation:     calcSvc,
-	})
-	if err != nil {
-		return nil, err
-	}
-	iteratorSvc, err := iterator.NewService(iterator.ServiceConfig{
-		DistFramer: cfg.Framer,
-		Channel:    cfg.Channel,
+		Iterator:        iteratorSvc,
 	})
 	if err != nil {
 		return nil, err
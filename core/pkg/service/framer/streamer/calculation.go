@@ -11,6 +11,7 @@ package streamer
 
 import (
 	"context"
+	"slices"
 
 	"github.com/synnaxlabs/alamos"
 	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
@@ -27,6 +28,11 @@ type calculationUpdaterTransform struct {
 	c        *calculation.Service
 	readable channel.Readable
 	closer   xio.MultiCloser
+	// filter is updated with the filters in each request.
+	filter *filter
+	// internal are channels that are added to each request for internal use by the
+	// streamer.
+	internal channel.Keys
 	confluence.LinearTransform[Request, framer.StreamerRequest]
 }
 
@@ -52,11 +58,20 @@ func (t *calculationUpdaterTransform) update(ctx context.Context, keys []channel
 	return nil
 }
 
+// transform updates the filters and calculated channels of the streamer. Requests with
+// invalid filters fail the streamer, returning the validation error to the client.
 func (t *calculationUpdaterTransform) transform(ctx context.Context, req Request) (framer.StreamerRequest, bool, error) {
+	if err := t.filter.update(req.Filters); err != nil {
+		return framer.StreamerRequest{}, false, err
+	}
 	if err := t.update(ctx, req.Keys); err != nil {
 		t.L.Error("failed to update calculated channels", zap.Error(err))
 	}
-	return framer.StreamerRequest{Keys: req.Keys}, true, nil
+	keys := req.Keys
+	if len(t.internal) > 0 {
+		keys = append(slices.Clone(keys), t.internal...)
+	}
+	return framer.StreamerRequest{Keys: keys}, true, nil
 }
 
 func (t *calculationUpdaterTransform) Flow(ctx signal.Context, opts ...confluence.Option) {
@@ -0,0 +1,259 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package streamer
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// FilterType is the type of report-by-exception filter applied to a channel.
+type FilterType string
+
+const (
+	// FilterTypeDeadband only sends samples that differ from the last sent sample by
+	// more than an absolute deadband.
+	FilterTypeDeadband FilterType = "deadband"
+	// FilterTypePercentDeadband only sends samples that differ from the last sent
+	// sample by more than a percentage of the last sent sample.
+	FilterTypePercentDeadband FilterType = "percent_deadband"
+	// FilterTypeOnChange only sends samples that differ from the last sent sample.
+	FilterTypeOnChange FilterType = "on_change"
+	// FilterTypePredicate only sends samples that satisfy a comparison against a
+	// constant value.
+	FilterTypePredicate FilterType = "predicate"
+)
+
+// Operator is a comparison operator used by predicate filters.
+type Operator string
+
+const (
+	OperatorGreaterThan        Operator = ">"
+	OperatorGreaterThanOrEqual Operator = ">="
+	OperatorLessThan           Operator = "<"
+	OperatorLessThanOrEqual    Operator = "<="
+	OperatorEqual              Operator = "=="
+	OperatorNotEqual           Operator = "!="
+)
+
+func (o Operator) compare(a, b float64) bool {
+	switch o {
+	case OperatorGreaterThan:
+		return a > b
+	case OperatorGreaterThanOrEqual:
+		return a >= b
+	case OperatorLessThan:
+		return a < b
+	case OperatorLessThanOrEqual:
+		return a <= b
+	case OperatorEqual:
+		return a == b
+	case OperatorNotEqual:
+		return a != b
+	default:
+		return true
+	}
+}
+
+// Filter is a server-side filter applied to the samples of a single channel before
+// they are sent to the client. Deadband and predicate filters only apply to numeric
+// channels, and are ignored for channels with variable density data types. On change
+// filters apply to all channels.
+type Filter struct {
+	// Channel is the channel to filter.
+	Channel channel.Key `json:"channel" msgpack:"channel"`
+	// Type is the type of filter to apply.
+	Type FilterType `json:"type" msgpack:"type"`
+	// Deadband is the absolute deadband for FilterTypeDeadband, or the percentage
+	// (0-100) deadband for FilterTypePercentDeadband.
+	Deadband float64 `json:"deadband" msgpack:"deadband"`
+	// Operator is the comparison operator for FilterTypePredicate.
+	Operator Operator `json:"operator" msgpack:"operator"`
+	// Value is the value that samples are compared against for FilterTypePredicate.
+	Value float64 `json:"value" msgpack:"value"`
+}
+
+// Validate checks that the filter is well-formed.
+func (f Filter) Validate() error {
+	v := validate.New("filter")
+	validate.NonZero(v, "channel", f.Channel)
+	switch f.Type {
+	case FilterTypeDeadband:
+		validate.GreaterThanEq(v, "deadband", f.Deadband, 0)
+	case FilterTypePercentDeadband:
+		validate.GreaterThanEq(v, "deadband", f.Deadband, 0)
+		validate.LessThanEq(v, "deadband", f.Deadband, 100)
+	case FilterTypeOnChange:
+	case FilterTypePredicate:
+		switch f.Operator {
+		case OperatorGreaterThan, OperatorGreaterThanOrEqual, OperatorLessThan,
+			OperatorLessThanOrEqual, OperatorEqual, OperatorNotEqual:
+		default:
+			v.Ternaryf("operator", true, "invalid operator %q", f.Operator)
+		}
+	default:
+		v.Ternaryf("type", true, "invalid filter type %q", f.Type)
+	}
+	return v.Error()
+}
+
+// validateFilters validates each of the filters, returning an error pathed to the
+// first invalid filter.
+func validateFilters(filters []Filter) error {
+	for i, f := range filters {
+		if err := f.Validate(); err != nil {
+			return validate.PathedError(err, "filters."+strconv.Itoa(i))
+		}
+	}
+	return nil
+}
+
+// filterState tracks the last sample sent for a filtered channel.
+type filterState struct {
+	Filter
+	last []byte
+}
+
+// pass returns true if the sample should be sent to the client.
+func (s *filterState) pass(dt telem.DataType, sample []byte) bool {
+	if s.Type == FilterTypeOnChange {
+		if s.last != nil && bytes.Equal(sample, s.last) {
+			return false
+		}
+		s.last = append(s.last[:0], sample...)
+		return true
+	}
+	if dt.IsVariable() {
+		return true
+	}
+	v := telem.UnmarshalF[float64](dt)(sample)
+	if s.Type == FilterTypePredicate {
+		return s.Operator.compare(v, s.Value)
+	}
+	if s.last != nil {
+		last := telem.UnmarshalF[float64](dt)(s.last)
+		threshold := s.Deadband
+		if s.Type == FilterTypePercentDeadband {
+			threshold = math.Abs(last) * s.Deadband / 100
+		}
+		if math.Abs(v-last) <= threshold {
+			return false
+		}
+	}
+	s.last = append(s.last[:0], sample...)
+	return true
+}
+
+// apply filters the samples in the series.
+func (s *filterState) apply(in telem.Series) []telem.Series {
+	return runs(in, func(_ uint32, sample []byte) bool { return s.pass(in.DataType, sample) })
+}
+
+// runs returns the samples in the series for which keep returns true. Because the kept
+// samples may not be contiguous, runs returns one series for each contiguous run of
+// kept samples, with alignments that still correspond to the original series. This
+// allows clients to match the samples with their timestamps.
+func runs(in telem.Series, keep func(i uint32, sample []byte) bool) []telem.Series {
+	var (
+		out   []telem.Series
+		run   *telem.Series
+		i     uint32
+		delim = in.DataType.IsVariable()
+	)
+	for sample := range in.Samples() {
+		if !keep(i, sample) {
+			run = nil
+			i++
+			continue
+		}
+		if run == nil {
+			out = append(out, telem.Series{
+				TimeRange: in.TimeRange,
+				DataType:  in.DataType,
+				Alignment: in.Alignment.AddSamples(i),
+			})
+			run = &out[len(out)-1]
+		}
+		run.Data = append(run.Data, sample...)
+		if delim {
+			run.Data = append(run.Data, '\n')
+		}
+		i++
+	}
+	return out
+}
+
+// filter is a transform that applies report-by-exception filters to the frames
+// received from the relay.
+type filter struct {
+	confluence.LinearTransform[Response, Response]
+	mu     sync.Mutex
+	states map[channel.Key]*filterState
+}
+
+func newFilter(filters []Filter) (*filter, error) {
+	f := &filter{}
+	f.Transform = f.transform
+	return f, f.update(filters)
+}
+
+// update replaces the filters applied by the transform. The last sent sample is
+// retained for channels whose filter has not changed. If any of the filters are
+// invalid, update returns an error and the active filters are left unchanged.
+func (f *filter) update(filters []Filter) error {
+	if err := validateFilters(filters); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	states := make(map[channel.Key]*filterState, len(filters))
+	for _, flt := range filters {
+		if existing, ok := f.states[flt.Channel]; ok && existing.Filter == flt {
+			states[flt.Channel] = existing
+			continue
+		}
+		states[flt.Channel] = &filterState{Filter: flt}
+	}
+	f.states = states
+	return nil
+}
+
+func (f *filter) transform(
+	_ context.Context,
+	in Response,
+) (out Response, ok bool, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.states) == 0 || in.Frame.Empty() {
+		return in, true, nil
+	}
+	out = in
+	out.Frame = core.AllocFrame(in.Frame.Count())
+	for key, s := range in.Frame.Entries() {
+		state, filtered := f.states[key]
+		if !filtered {
+			out.Frame = out.Frame.Append(key, s)
+			continue
+		}
+		for _, run := range state.apply(s) {
+			out.Frame = out.Frame.Append(key, run)
+		}
+	}
+	return out, !out.Frame.Empty(), nil
+}
@@ -0,0 +1,255 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package streamer
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// SlowConsumerPolicy determines what a streamer does when the client consumes frames
+// slower than they are produced.
+type SlowConsumerPolicy string
+
+const (
+	// SlowConsumerPolicyBlock applies backpressure to the relay. If the client does
+	// not keep up for longer than the relay's slow consumer timeout, the relay drops
+	// frames for the streamer. This is the default policy.
+	SlowConsumerPolicyBlock SlowConsumerPolicy = "block"
+	// SlowConsumerPolicyDropOldest buffers up to BufferSize frames for the client,
+	// discarding the oldest buffered frame when the buffer is full.
+	SlowConsumerPolicyDropOldest SlowConsumerPolicy = "drop_oldest"
+	// SlowConsumerPolicyCoalesce merges the frames the client has not yet received
+	// into a single frame that holds the most recent data for each channel.
+	SlowConsumerPolicyCoalesce SlowConsumerPolicy = "coalesce"
+)
+
+func (p SlowConsumerPolicy) valid() bool {
+	return p == SlowConsumerPolicyBlock ||
+		p == SlowConsumerPolicyDropOldest ||
+		p == SlowConsumerPolicyCoalesce
+}
+
+// Stats are statistics on the frames delivered by an open streamer.
+type Stats struct {
+	// Key uniquely identifies the streamer.
+	Key uuid.UUID `json:"key" msgpack:"key"`
+	// Keys are the channels the streamer was opened with.
+	Keys channel.Keys `json:"keys" msgpack:"keys"`
+	// Policy is the slow consumer policy of the streamer.
+	Policy SlowConsumerPolicy `json:"policy" msgpack:"policy"`
+	// OpenedAt is the time the streamer started.
+	OpenedAt telem.TimeStamp `json:"opened_at" msgpack:"opened_at"`
+	// Delivered is the number of frames sent to the client.
+	Delivered uint64 `json:"delivered" msgpack:"delivered"`
+	// Dropped is the number of frames that were discarded or coalesced into another
+	// frame because the client could not keep up. Frames dropped by the relay under
+	// SlowConsumerPolicyBlock are not included.
+	Dropped uint64 `json:"dropped" msgpack:"dropped"`
+}
+
+// counters are the live counters for the Stats of a streamer.
+type counters struct {
+	Stats
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
+}
+
+func (c *counters) snapshot() Stats {
+	s := c.Stats
+	s.Delivered = c.delivered.Load()
+	s.Dropped = c.dropped.Load()
+	return s
+}
+
+// registry tracks the counters of all open streamers.
+type registry struct {
+	mu sync.RWMutex
+	m  map[uuid.UUID]*counters
+}
+
+func (r *registry) add(c *counters) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.m[c.Key] = c
+}
+
+func (r *registry) remove(key uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.m, key)
+}
+
+func (r *registry) snapshot() []Stats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	stats := make([]Stats, 0, len(r.m))
+	for _, c := range r.m {
+		stats = append(stats, c.snapshot())
+	}
+	return stats
+}
+
+// Stats returns statistics on the frames delivered by each open streamer.
+func (s *Service) Stats() []Stats { return s.registry.snapshot() }
+
+// policy is a segment that applies a streamer's slow consumer policy. It reads frames
+// as soon as they arrive and queues them until the client is ready to receive them,
+// so that a slow client does not apply backpressure to the relay.
+type policy struct {
+	confluence.AbstractLinear[Response, Response]
+	policy   SlowConsumerPolicy
+	size     int
+	registry *registry
+	counters *counters
+	queue    []Response
+}
+
+func newPolicy(cfg Config, r *registry) *policy {
+	return &policy{
+		policy:   cfg.SlowConsumerPolicy,
+		size:     cfg.BufferSize,
+		registry: r,
+		counters: &counters{Stats: Stats{
+			Key:    uuid.New(),
+			Keys:   slices.Clone(cfg.Keys),
+			Policy: cfg.SlowConsumerPolicy,
+		}},
+	}
+}
+
+// Flow implements confluence.Flow.
+func (p *policy) Flow(ctx signal.Context, opts ...confluence.Option) {
+	o := confluence.NewOptions(opts)
+	o.AttachClosables(p.Out)
+	ctx.Go(func(ctx context.Context) error {
+		p.counters.OpenedAt = telem.Now()
+		p.registry.add(p.counters)
+		defer p.registry.remove(p.counters.Key)
+		if p.policy == SlowConsumerPolicyBlock {
+			return p.block(ctx)
+		}
+		return p.buffer(ctx)
+	}, o.Signal...)
+}
+
+// block forwards each frame to the client, waiting until the client receives it.
+func (p *policy) block(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case res, ok := <-p.In.Outlet():
+			if !ok {
+				return nil
+			}
+			if err := p.send(ctx, res); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// buffer queues frames until the client is ready to receive them, applying the
+// policy when the client falls behind.
+func (p *policy) buffer(ctx context.Context) error {
+	for {
+		var (
+			out  chan<- Response
+			next Response
+		)
+		if len(p.queue) > 0 {
+			out, next = p.Out.Inlet(), p.queue[0]
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case res, ok := <-p.In.Outlet():
+			if !ok {
+				return p.flush(ctx)
+			}
+			p.push(res)
+		case out <- next:
+			p.queue = p.queue[1:]
+			p.counters.delivered.Add(1)
+		}
+	}
+}
+
+// push adds a response to the queue.
+func (p *policy) push(res Response) {
+	// Acknowledgements have no data, so they are always queued as-is.
+	if res.Frame.Empty() {
+		p.queue = append(p.queue, res)
+		return
+	}
+	if p.policy == SlowConsumerPolicyCoalesce {
+		if n := len(p.queue); n > 0 && !p.queue[n-1].Frame.Empty() {
+			p.queue[n-1].Frame = coalesce(p.queue[n-1].Frame, res.Frame)
+			p.counters.dropped.Add(1)
+			return
+		}
+		p.queue = append(p.queue, res)
+		return
+	}
+	if len(p.queue) >= p.size {
+		if i := slices.IndexFunc(p.queue, func(r Response) bool {
+			return !r.Frame.Empty()
+		}); i >= 0 {
+			p.queue = slices.Delete(p.queue, i, i+1)
+			p.counters.dropped.Add(1)
+		}
+	}
+	p.queue = append(p.queue, res)
+}
+
+// flush sends all queued responses to the client.
+func (p *policy) flush(ctx context.Context) error {
+	for _, res := range p.queue {
+		if err := p.send(ctx, res); err != nil {
+			return err
+		}
+	}
+	p.queue = nil
+	return nil
+}
+
+func (p *policy) send(ctx context.Context, res Response) error {
+	if err := signal.SendUnderContext(ctx, p.Out.Inlet(), res); err != nil {
+		return err
+	}
+	p.counters.delivered.Add(1)
+	return nil
+}
+
+// coalesce returns a frame with the data in next, along with the data in prev for any
+// channels that are not in next.
+func coalesce(prev, next core.Frame) core.Frame {
+	out := core.AllocFrame(prev.Count() + next.Count())
+	for key, s := range prev.Entries() {
+		if len(next.Get(key).Series) > 0 {
+			continue
+		}
+		out = out.Append(key, s)
+	}
+	for key, s := range next.Entries() {
+		out = out.Append(key, s)
+	}
+	return out
+}
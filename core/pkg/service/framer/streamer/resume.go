@@ -0,0 +1,128 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package streamer
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"maps"
+	"slices"
+
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// resumePositionSize is the encoded size of the position of a single index channel in a
+// resume token: a uint32 channel key followed by an int64 timestamp.
+const resumePositionSize = 12
+
+// encodeResumeToken encodes the last timestamp delivered for each index channel into
+// a resume token.
+//
+// Live samples have alignments that are only resolved once the samples are persisted,
+// so tokens hold the timestamp of the last sample delivered for each index channel,
+// which is then used to read the missed samples from storage. Tokens hold all of the
+// state needed to resume, so a client can resume on any node in the cluster.
+func encodeResumeToken(last map[channel.Key]telem.TimeStamp) string {
+	keys := slices.Sorted(maps.Keys(last))
+	b := make([]byte, 0, len(keys)*resumePositionSize)
+	for _, k := range keys {
+		b = binary.LittleEndian.AppendUint32(b, uint32(k))
+		b = binary.LittleEndian.AppendUint64(b, uint64(last[k]))
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeResumeToken decodes the last timestamp delivered for each index channel from
+// a resume token.
+func decodeResumeToken(token string) (map[channel.Key]telem.TimeStamp, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(b)%resumePositionSize != 0 {
+		return nil, validate.PathedError(
+			errors.Wrap(validate.Error, "invalid resume token"),
+			"resume_token",
+		)
+	}
+	last := make(map[channel.Key]telem.TimeStamp, len(b)/resumePositionSize)
+	for ; len(b) > 0; b = b[resumePositionSize:] {
+		k := channel.Key(binary.LittleEndian.Uint32(b))
+		last[k] = telem.TimeStamp(binary.LittleEndian.Uint64(b[4:]))
+	}
+	return last, nil
+}
+
+// recorder is the last segment in a streamer pipeline. It records the data delivered
+// by resumable streamers, attaching a resume token to each response, and removes any
+// index channels that were added to the streamer for internal use but were not
+// requested by the caller.
+type recorder struct {
+	confluence.AbstractLinear[Response, Response]
+	internal channel.Keys
+	indexes  map[channel.Key]channel.Key
+	// last is the timestamp of the last sample delivered for each index channel. It
+	// is nil if the streamer is not resumable.
+	last map[channel.Key]telem.TimeStamp
+}
+
+// Flow implements confluence.Flow.
+func (r *recorder) Flow(ctx signal.Context, opts ...confluence.Option) {
+	o := confluence.NewOptions(opts)
+	o.AttachClosables(r.Out)
+	ctx.Go(func(ctx context.Context) error {
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case res, ok := <-r.In.Outlet():
+				if !ok {
+					return nil
+				}
+				if res.Frame.Empty() {
+					if err := signal.SendUnderContext(ctx, r.Out.Inlet(), res); err != nil {
+						return err
+					}
+					continue
+				}
+				if res.Frame = r.process(res.Frame); res.Frame.Empty() {
+					continue
+				}
+				if r.last != nil {
+					res.ResumeToken = encodeResumeToken(r.last)
+				}
+				if err := signal.SendUnderContext(ctx, r.Out.Inlet(), res); err != nil {
+					return err
+				}
+			}
+		}
+	}, o.Signal...)
+}
+
+// process records the last timestamp of each index channel in the frame, and removes
+// any internal channels from it.
+func (r *recorder) process(fr core.Frame) core.Frame {
+	out := core.AllocFrame(fr.Count())
+	for key, s := range fr.Entries() {
+		if r.last != nil && r.indexes[key] == key && s.Len() > 0 {
+			if ts := telem.ValueAt[telem.TimeStamp](s, -1); ts.After(r.last[key]) {
+				r.last[key] = ts
+			}
+		}
+		if !r.internal.Contains(key) {
+			out = out.Append(key, s)
+		}
+	}
+	return out
+}
@@ -11,16 +11,20 @@ package streamer
 
 import (
 	"context"
+	"slices"
 
+	"github.com/google/uuid"
 	"github.com/synnaxlabs/alamos"
 	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
 	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
 	"github.com/synnaxlabs/synnax/pkg/service/framer/calculation"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/iterator"
 	"github.com/synnaxlabs/x/address"
 	"github.com/synnaxlabs/x/config"
 	"github.com/synnaxlabs/x/confluence"
 	"github.com/synnaxlabs/x/confluence/plumber"
 	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/telem"
 	"github.com/synnaxlabs/x/validate"
 )
 
@@ -35,18 +39,69 @@ type Config struct {
 	Keys             channel.Keys `json:"keys" msgpack:"keys"`
 	SendOpenAck      bool         `json:"send_open_ack" msgpack:"send_open_ack"`
 	DownsampleFactor int          `json:"downsample_factor" msgpack:"downsample_factor"`
+	// Filters are report-by-exception filters applied to the channels being streamed.
+	// Sending a new request replaces the active filters.
+	Filters []Filter `json:"filters" msgpack:"filters"`
+	// ThrottleRate caps the rate at which frames are sent to the client. At each
+	// interval, the samples received for each channel are reduced to the samples
+	// holding the minimum, maximum, and last values. A zero value disables throttling.
+	ThrottleRate telem.Rate `json:"throttle_rate" msgpack:"throttle_rate"`
+	// LookBack is the span of historical data to replay before streaming live data.
+	// Only applies when opening the streamer. A zero value disables the replay.
+	LookBack telem.TimeSpan `json:"look_back" msgpack:"look_back"`
+	// Start is the timestamp from which to replay historical data before streaming
+	// live data. Only applies when opening the streamer, and cannot be set along with
+	// LookBack. A zero value disables the replay.
+	Start telem.TimeStamp `json:"start" msgpack:"start"`
+	// Resumable sets whether the streamer attaches a resume token to each response
+	// it sends. The token encodes the data delivered up to and including the
+	// response, so that a client can reconnect with the latest token it received to
+	// back-fill any data it missed. Only applies when opening the streamer.
+	Resumable bool `json:"resumable" msgpack:"resumable"`
+	// ResumeToken is the latest resume token received from a resumable streamer. When
+	// set, the persisted data written after the data delivered up to the token is
+	// back-filled before streaming live data. Streamers opened with a resume token are
+	// always resumable. Only applies when opening the streamer.
+	ResumeToken string `json:"resume_token" msgpack:"resume_token"`
+	// SlowConsumerPolicy determines what the streamer does when the client receives
+	// frames slower than they are produced. See SlowConsumerPolicy for the available
+	// policies.
+	// [OPTIONAL] - defaults to SlowConsumerPolicyBlock.
+	SlowConsumerPolicy SlowConsumerPolicy `json:"slow_consumer_policy" msgpack:"slow_consumer_policy"`
+	// BufferSize is the maximum number of frames buffered for the client under
+	// SlowConsumerPolicyDropOldest.
+	// [OPTIONAL] - defaults to 100.
+	BufferSize int `json:"buffer_size" msgpack:"buffer_size"`
 }
 
 var (
 	_             config.Config[Config] = Config{}
-	DefaultConfig                       = Config{}
+	DefaultConfig                       = Config{
+		SlowConsumerPolicy: SlowConsumerPolicyBlock,
+		BufferSize:         100,
+	}
 )
 
 // Validate implements config.Config.
 func (cfg Config) Validate() error {
 	v := validate.New("streamer.config")
 	validate.GreaterThanEq(v, "downsample_factor", cfg.DownsampleFactor, 0)
-	return v.Error()
+	validate.GreaterThanEq(v, "throttle_rate", cfg.ThrottleRate, 0)
+	validate.GreaterThanEq(v, "look_back", cfg.LookBack, 0)
+	validate.GreaterThanEq(v, "start", cfg.Start, 0)
+	v.Ternary("start", !cfg.Start.IsZero() && cfg.LookBack != 0, "cannot set both start and look_back")
+	v.Ternary("resume_token", cfg.ResumeToken != "" && cfg.tail(), "cannot resume while setting start or look_back")
+	v.Ternaryf(
+		"slow_consumer_policy",
+		!cfg.SlowConsumerPolicy.valid(),
+		"invalid slow consumer policy %q",
+		cfg.SlowConsumerPolicy,
+	)
+	validate.Positive(v, "buffer_size", cfg.BufferSize)
+	if err := v.Error(); err != nil {
+		return err
+	}
+	return validateFilters(cfg.Filters)
 }
 
 // Override implements config.Config.
@@ -54,6 +109,14 @@ func (cfg Config) Override(other Config) Config {
 	cfg.Keys = override.Slice(cfg.Keys, other.Keys)
 	cfg.SendOpenAck = other.SendOpenAck
 	cfg.DownsampleFactor = override.Numeric(cfg.DownsampleFactor, other.DownsampleFactor)
+	cfg.Filters = override.Slice(cfg.Filters, other.Filters)
+	cfg.ThrottleRate = override.Numeric(cfg.ThrottleRate, other.ThrottleRate)
+	cfg.LookBack = override.Numeric(cfg.LookBack, other.LookBack)
+	cfg.Start = override.Numeric(cfg.Start, other.Start)
+	cfg.Resumable = other.Resumable
+	cfg.ResumeToken = override.String(cfg.ResumeToken, other.ResumeToken)
+	cfg.SlowConsumerPolicy = override.String(cfg.SlowConsumerPolicy, other.SlowConsumerPolicy)
+	cfg.BufferSize = override.Numeric(cfg.BufferSize, other.BufferSize)
 	return cfg
 }
 
@@ -61,12 +124,17 @@ func (cfg Config) distribution() framer.StreamerConfig {
 	return framer.StreamerConfig{Keys: cfg.Keys, SendOpenAck: &cfg.SendOpenAck}
 }
 
+func (cfg Config) tail() bool { return cfg.LookBack > 0 || !cfg.Start.IsZero() }
+
+func (cfg Config) resumable() bool { return cfg.Resumable || cfg.ResumeToken != "" }
+
 // ServiceConfig is the configuration for opening a new streamer service.
 type ServiceConfig struct {
 	alamos.Instrumentation
 	Calculation *calculation.Service
 	Channel     channel.Readable
 	DistFramer  *framer.Service
+	Iterator    *iterator.Service
 }
 
 var (
@@ -79,6 +147,7 @@ func (cfg ServiceConfig) Override(other ServiceConfig) ServiceConfig {
 	cfg.Calculation = override.Nil(cfg.Calculation, other.Calculation)
 	cfg.Channel = override.Nil(cfg.Channel, other.Channel)
 	cfg.DistFramer = override.Nil(cfg.DistFramer, other.DistFramer)
+	cfg.Iterator = override.Nil(cfg.Iterator, other.Iterator)
 	return cfg
 }
 
@@ -87,11 +156,13 @@ func (cfg ServiceConfig) Validate() error {
 	validate.NotNil(v, "calculation", cfg.Calculation)
 	validate.NotNil(v, "channel", cfg.Channel)
 	validate.NotNil(v, "dist_framer", cfg.DistFramer)
+	validate.NotNil(v, "iterator", cfg.Iterator)
 	return v.Error()
 }
 
 type Service struct {
-	cfg ServiceConfig
+	cfg      ServiceConfig
+	registry *registry
 }
 
 func NewService(cfgs ...ServiceConfig) (*Service, error) {
@@ -99,13 +170,21 @@ func NewService(cfgs ...ServiceConfig) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Service{cfg: cfg}, nil
+	return &Service{
+		cfg:      cfg,
+		registry: &registry{m: make(map[uuid.UUID]*counters)},
+	}, nil
 }
 
 var (
 	distAddr       address.Address = "distribution"
 	utAddr         address.Address = "updater_transform"
 	downsampleAddr address.Address = "downsample"
+	filterAddr     address.Address = "filter"
+	throttleAddr   address.Address = "throttle"
+	tailAddr       address.Address = "tail"
+	recorderAddr   address.Address = "recorder"
+	policyAddr     address.Address = "policy"
 )
 
 const (
@@ -113,29 +192,83 @@ const (
 	requestBufferSize  = 10
 )
 
-func (s *Service) New(ctx context.Context, cfgs ...Config) (Streamer, error) {
+func (s *Service) New(ctx context.Context, cfgs ...Config) (_ Streamer, err error) {
 	cfg, err := config.New(DefaultConfig, cfgs...)
 	if err != nil {
 		return nil, err
 	}
+	var resumeFrom map[channel.Key]telem.TimeStamp
+	if cfg.ResumeToken != "" {
+		if resumeFrom, err = decodeResumeToken(cfg.ResumeToken); err != nil {
+			return nil, err
+		}
+	}
+	idx, err := s.resolveIndexes(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
 	p := plumber.New()
-	dist, err := s.cfg.DistFramer.NewStreamer(ctx, cfg.distribution())
+	t := s.newTail(cfg, idx, resumeFrom)
+	distCfg := cfg.distribution()
+	distCfg.Keys = append(slices.Clone(distCfg.Keys), idx.internal...)
+	if t != nil {
+		// The tail needs to know when the relay streamer is connected, regardless of
+		// whether the caller requested an acknowledgement.
+		distCfg.SendOpenAck = config.True()
+	}
+	dist, err := s.cfg.DistFramer.NewStreamer(ctx, distCfg)
 	if err != nil {
 		return nil, err
 	}
 	plumber.SetSegment(p, distAddr, dist)
-	ut, err := s.newCalculationUpdaterTransform(ctx, cfg)
+	var filterFrom = distAddr
+	if t != nil {
+		plumber.SetSegment[Response, Response](p, tailAddr, t)
+		plumber.MustConnect[Response](p, distAddr, tailAddr, responseBufferSize)
+		filterFrom = tailAddr
+	}
+	flt, err := newFilter(cfg.Filters)
+	if err != nil {
+		return nil, err
+	}
+	ut, err := s.newCalculationUpdaterTransform(ctx, cfg, flt, idx.internal)
 	if err != nil {
 		return nil, err
 	}
 	plumber.SetSegment(p, utAddr, ut)
 	plumber.MustConnect[framer.StreamerRequest](p, utAddr, distAddr, requestBufferSize)
-	var routeOutletFrom = distAddr
+	plumber.SetSegment[Response, Response](p, filterAddr, flt)
+	plumber.MustConnect[Response](p, filterFrom, filterAddr, responseBufferSize)
+	var routeOutletFrom = filterAddr
 	if cfg.DownsampleFactor > 1 {
 		plumber.SetSegment(p, downsampleAddr, newDownsampler(cfg))
 		plumber.MustConnect[Response](p, routeOutletFrom, downsampleAddr, responseBufferSize)
 		routeOutletFrom = downsampleAddr
 	}
+	if cfg.ThrottleRate > 0 {
+		plumber.SetSegment[Response, Response](p, throttleAddr, newThrottle(cfg, s.cfg.Channel))
+		plumber.MustConnect[Response](p, routeOutletFrom, throttleAddr, responseBufferSize)
+		routeOutletFrom = throttleAddr
+	}
+	plumber.SetSegment[Response, Response](p, policyAddr, newPolicy(cfg, s.registry))
+	plumber.MustConnect[Response](p, routeOutletFrom, policyAddr, responseBufferSize)
+	routeOutletFrom = policyAddr
+	if len(idx.internal) > 0 || cfg.resumable() {
+		rec := &recorder{internal: idx.internal, indexes: idx.of}
+		if cfg.resumable() {
+			// Carry over the positions of the indexes that received no data since
+			// the streamer was resumed, so that the next token still holds them.
+			rec.last = make(map[channel.Key]telem.TimeStamp, len(resumeFrom))
+			for i, ts := range resumeFrom {
+				if idx.of[i] == i {
+					rec.last[i] = ts
+				}
+			}
+		}
+		plumber.SetSegment[Response, Response](p, recorderAddr, rec)
+		plumber.MustConnect[Response](p, routeOutletFrom, recorderAddr, responseBufferSize)
+		routeOutletFrom = recorderAddr
+	}
 	return &plumber.Segment[Request, Response]{
 		Pipeline:         p,
 		RouteInletsTo:    []address.Address{utAddr},
@@ -143,14 +276,116 @@ func (s *Service) New(ctx context.Context, cfgs ...Config) (Streamer, error) {
 	}, nil
 }
 
+// indexes holds information about the index channels of the channels in a streamer.
+type indexes struct {
+	// persisted are the keys of the channels that can be read from storage.
+	persisted channel.Keys
+	// of maps each persisted channel with an index to the key of its index. Index
+	// channels map to themselves.
+	of map[channel.Key]channel.Key
+	// internal are the index channels that are added to the streamer to resolve the
+	// timestamps of live data, but were not requested by the caller.
+	internal channel.Keys
+}
+
+// resolveIndexes resolves the indexes of the channels in the streamer. Index
+// information is only needed when tailing or when the streamer is resumable, so
+// resolveIndexes returns an empty result otherwise.
+func (s *Service) resolveIndexes(
+	ctx context.Context,
+	cfg Config,
+) (idx indexes, err error) {
+	idx.of = make(map[channel.Key]channel.Key)
+	if !cfg.tail() && !cfg.resumable() {
+		return idx, nil
+	}
+	var channels []channel.Channel
+	if err = s.cfg.Channel.NewRetrieve().
+		WhereKeys(cfg.Keys...).
+		Entries(&channels).
+		Exec(ctx, nil); err != nil {
+		return idx, err
+	}
+	for _, ch := range channels {
+		if ch.IsCalculated() {
+			idx.persisted = append(idx.persisted, ch.Key())
+			continue
+		}
+		if ch.Virtual {
+			continue
+		}
+		idx.persisted = append(idx.persisted, ch.Key())
+		i := ch.Index()
+		if i == 0 {
+			continue
+		}
+		idx.of[ch.Key()] = i
+		idx.of[i] = i
+		if !cfg.Keys.Contains(i) && !idx.internal.Contains(i) {
+			idx.internal = append(idx.internal, i)
+			idx.persisted = append(idx.persisted, i)
+		}
+	}
+	return idx, nil
+}
+
+// newTail returns a segment that replays historical data for the channels in the
+// streamer, or nil if the configuration does not request a replay or none of the
+// channels have persisted data. When resuming, the tail replays the data written after
+// the last samples delivered up to the resume token.
+func (s *Service) newTail(
+	cfg Config,
+	idx indexes,
+	resumeFrom map[channel.Key]telem.TimeStamp,
+) *tail {
+	if len(idx.persisted) == 0 {
+		return nil
+	}
+	t := &tail{
+		iterator:    s.cfg.Iterator,
+		keys:        idx.persisted,
+		indexes:     idx.of,
+		start:       cfg.Start,
+		sendOpenAck: cfg.SendOpenAck,
+		last:        make(map[channel.Key]telem.TimeStamp),
+	}
+	if cfg.ResumeToken != "" {
+		t.start = telem.TimeStampMax
+		for i, ts := range resumeFrom {
+			if _, ok := idx.of[i]; !ok {
+				continue
+			}
+			t.last[i] = ts
+			if ts.Before(t.start) {
+				t.start = ts
+			}
+		}
+		if len(t.last) == 0 {
+			return nil
+		}
+		return t
+	}
+	if !cfg.tail() {
+		return nil
+	}
+	if t.start.IsZero() {
+		t.start = telem.Now().Sub(cfg.LookBack)
+	}
+	return t
+}
+
 func (s *Service) newCalculationUpdaterTransform(
 	ctx context.Context,
 	cfg Config,
+	flt *filter,
+	internal channel.Keys,
 ) (confluence.Segment[Request, framer.StreamerRequest], error) {
 	ut := &calculationUpdaterTransform{
 		Instrumentation: s.cfg.Instrumentation,
 		c:               s.cfg.Calculation,
 		readable:        s.cfg.Channel,
+		filter:          flt,
+		internal:        internal,
 	}
 	ut.Transform = ut.transform
 	return ut, ut.update(ctx, cfg.Keys)
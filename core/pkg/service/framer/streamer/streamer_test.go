@@ -10,6 +10,8 @@
 package streamer_test
 
 import (
+	"context"
+	"slices"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -18,6 +20,7 @@ import (
 	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
 	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
 	"github.com/synnaxlabs/synnax/pkg/service/framer/calculation"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/iterator"
 	"github.com/synnaxlabs/synnax/pkg/service/framer/streamer"
 	"github.com/synnaxlabs/x/config"
 	"github.com/synnaxlabs/x/confluence"
@@ -45,6 +48,10 @@ var _ = Describe("Streamer", Ordered, func() {
 			DistFramer:  dist.Framer,
 			Channel:     dist.Channel,
 			Calculation: calc,
+			Iterator: MustSucceed(iterator.NewService(iterator.ServiceConfig{
+				DistFramer: dist.Framer,
+				Channel:    dist.Channel,
+			})),
 		}))
 	})
 
@@ -316,4 +323,557 @@ var _ = Describe("Streamer", Ordered, func() {
 			Expect(w.Close()).To(Succeed())
 		})
 	})
+	Describe("Filtering", func() {
+		var (
+			ch     *channel.Channel
+			w      *framer.Writer
+			inlet  confluence.Inlet[streamer.Request]
+			outlet confluence.Outlet[streamer.Response]
+			cancel context.CancelFunc
+		)
+		open := func(dt telem.DataType, filters ...streamer.Filter) {
+			ch = &channel.Channel{Name: "filtered", DataType: dt, Virtual: true}
+			Expect(dist.Channel.Create(ctx, ch)).To(Succeed())
+			w = MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{
+				Start: telem.Now(),
+				Keys:  []channel.Key{ch.Key()},
+			}))
+			for i := range filters {
+				filters[i].Channel = ch.Key()
+			}
+			s := MustSucceed(streamerSvc.New(ctx, streamer.Config{
+				Keys:        []channel.Key{ch.Key()},
+				SendOpenAck: true,
+				Filters:     filters,
+			}))
+			var sCtx signal.Context
+			sCtx, cancel = signal.Isolated()
+			in, out := confluence.Attach(s)
+			inlet, outlet = in, out
+			s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+			Eventually(outlet.Outlet()).Should(Receive())
+		}
+		write := func(s telem.Series) {
+			MustSucceed(w.Write(core.UnaryFrame(ch.Key(), s)))
+		}
+		receive := func() core.Frame {
+			var res streamer.Response
+			Eventually(outlet.Outlet()).Should(Receive(&res))
+			return res.Frame
+		}
+		AfterEach(func() {
+			inlet.Close()
+			Eventually(outlet.Outlet()).Should(BeClosed())
+			Expect(w.Close()).To(Succeed())
+			cancel()
+		})
+
+		It("Should only send samples outside of an absolute deadband", func() {
+			open(telem.Float64T, streamer.Filter{Type: streamer.FilterTypeDeadband, Deadband: 1})
+			write(telem.NewSeriesV[float64](10, 10.5, 11.5, 11, 9))
+			fr := receive()
+			series := fr.Get(ch.Key()).Series
+			Expect(series).To(HaveLen(3))
+			Expect(series[0]).To(telem.MatchSeriesDataV[float64](10))
+			Expect(series[1]).To(telem.MatchSeriesDataV[float64](11.5))
+			Expect(series[1].Alignment).To(Equal(series[0].Alignment.AddSamples(2)))
+			Expect(series[2]).To(telem.MatchSeriesDataV[float64](9))
+			Expect(series[2].Alignment).To(Equal(series[0].Alignment.AddSamples(4)))
+			write(telem.NewSeriesV[float64](9.5, 11))
+			fr = receive()
+			Expect(fr.Get(ch.Key()).Series[0]).To(telem.MatchSeriesDataV[float64](11))
+		})
+
+		It("Should only send samples outside of a percent deadband", func() {
+			open(telem.Float32T, streamer.Filter{Type: streamer.FilterTypePercentDeadband, Deadband: 10})
+			write(telem.NewSeriesV[float32](100, 105, 111, 120))
+			fr := receive()
+			series := fr.Get(ch.Key()).Series
+			Expect(series).To(HaveLen(2))
+			Expect(series[0]).To(telem.MatchSeriesDataV[float32](100))
+			Expect(series[1]).To(telem.MatchSeriesDataV[float32](111))
+		})
+
+		It("Should only send samples that change", func() {
+			open(telem.StringT, streamer.Filter{Type: streamer.FilterTypeOnChange})
+			write(telem.NewSeriesStringsV("a", "a", "b", "c", "c"))
+			fr := receive()
+			series := fr.Get(ch.Key()).Series
+			Expect(series).To(HaveLen(2))
+			Expect(telem.UnmarshalStrings(series[0].Data)).To(Equal([]string{"a"}))
+			Expect(telem.UnmarshalStrings(series[1].Data)).To(Equal([]string{"b", "c"}))
+			Expect(series[1].Alignment).To(Equal(series[0].Alignment.AddSamples(2)))
+			write(telem.NewSeriesStringsV("c", "d"))
+			fr = receive()
+			Expect(telem.UnmarshalStrings(fr.Get(ch.Key()).Series[0].Data)).To(Equal([]string{"d"}))
+		})
+
+		It("Should only send samples that satisfy a predicate", func() {
+			open(telem.Int64T, streamer.Filter{
+				Type:     streamer.FilterTypePredicate,
+				Operator: streamer.OperatorGreaterThan,
+				Value:    5,
+			})
+			write(telem.NewSeriesV[int64](1, 6, 7, 2, 8))
+			fr := receive()
+			series := fr.Get(ch.Key()).Series
+			Expect(series).To(HaveLen(2))
+			Expect(series[0]).To(telem.MatchSeriesDataV[int64](6, 7))
+			Expect(series[1]).To(telem.MatchSeriesDataV[int64](8))
+			Expect(series[1].Alignment).To(Equal(series[0].Alignment.AddSamples(3)))
+		})
+
+		It("Should not send a frame when every sample is filtered", func() {
+			open(telem.Int64T, streamer.Filter{
+				Type:     streamer.FilterTypePredicate,
+				Operator: streamer.OperatorLessThan,
+				Value:    0,
+			})
+			write(telem.NewSeriesV[int64](1, 2, 3))
+			Consistently(outlet.Outlet(), 50*time.Millisecond).ShouldNot(Receive())
+			write(telem.NewSeriesV[int64](-1))
+			Expect(receive().Get(ch.Key()).Series[0]).To(telem.MatchSeriesDataV[int64](-1))
+		})
+
+		It("Should allow the caller to update filters with a request", func() {
+			open(telem.Int64T)
+			inlet.Inlet() <- streamer.Request{
+				Keys: []channel.Key{ch.Key()},
+				Filters: []streamer.Filter{{
+					Channel:  ch.Key(),
+					Type:     streamer.FilterTypePredicate,
+					Operator: streamer.OperatorEqual,
+					Value:    2,
+				}},
+			}
+			time.Sleep(5 * time.Millisecond)
+			write(telem.NewSeriesV[int64](1, 2, 3))
+			Expect(receive().Get(ch.Key()).Series[0]).To(telem.MatchSeriesDataV[int64](2))
+		})
+	})
+
+	Describe("Throttling", func() {
+		It("Should reduce each interval to the minimum, maximum, and last samples", func() {
+			ch := &channel.Channel{Name: "throttled", DataType: telem.Float64T, Virtual: true}
+			Expect(dist.Channel.Create(ctx, ch)).To(Succeed())
+			w := MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{
+				Start: telem.Now(),
+				Keys:  []channel.Key{ch.Key()},
+			}))
+			s := MustSucceed(streamerSvc.New(ctx, streamer.Config{
+				Keys:         []channel.Key{ch.Key()},
+				SendOpenAck:  true,
+				ThrottleRate: 20 * telem.Hz,
+			}))
+			sCtx, cancel := signal.Isolated()
+			defer cancel()
+			inlet, outlet := confluence.Attach(s)
+			s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+			Eventually(outlet.Outlet()).Should(Receive())
+			data := make([]float64, 1000)
+			data[200] = -50
+			data[500] = 100
+			data[999] = 3
+			written := telem.NewSeries(data)
+			MustSucceed(w.Write(core.UnaryFrame(ch.Key(), written)))
+			var res streamer.Response
+			Eventually(outlet.Outlet()).Should(Receive(&res))
+			series := res.Frame.Get(ch.Key()).Series
+			Expect(series).To(HaveLen(3))
+			Expect(series[0]).To(telem.MatchSeriesDataV[float64](-50))
+			Expect(series[1]).To(telem.MatchSeriesDataV[float64](100))
+			Expect(series[2]).To(telem.MatchSeriesDataV[float64](3))
+			Expect(series[1].Alignment).To(Equal(series[0].Alignment.AddSamples(300)))
+			Expect(series[2].Alignment).To(Equal(series[0].Alignment.AddSamples(799)))
+			inlet.Close()
+			Eventually(outlet.Outlet()).Should(BeClosed())
+			Expect(w.Close()).To(Succeed())
+		})
+
+		It("Should send the timestamps of the selected samples for index channels", func() {
+			idx := &channel.Channel{Name: "throttled_time", DataType: telem.TimeStampT, IsIndex: true}
+			Expect(dist.Channel.Create(ctx, idx)).To(Succeed())
+			data := &channel.Channel{Name: "throttled_data", DataType: telem.Int32T, LocalIndex: idx.LocalKey}
+			Expect(dist.Channel.Create(ctx, data)).To(Succeed())
+			keys := []channel.Key{idx.Key(), data.Key()}
+			w := MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{
+				Start:            telem.SecondTS,
+				Keys:             keys,
+				EnableAutoCommit: config.True(),
+			}))
+			s := MustSucceed(streamerSvc.New(ctx, streamer.Config{
+				Keys:         keys,
+				SendOpenAck:  true,
+				ThrottleRate: 20 * telem.Hz,
+			}))
+			sCtx, cancel := signal.Isolated()
+			defer cancel()
+			inlet, outlet := confluence.Attach(s)
+			s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+			Eventually(outlet.Outlet()).Should(Receive())
+			MustSucceed(w.Write(core.MultiFrame(keys, []telem.Series{
+				telem.NewSeriesSecondsTSV(1, 2, 3, 4, 5),
+				telem.NewSeriesV[int32](3, 9, 5, -2, 4),
+			})))
+			var res streamer.Response
+			Eventually(outlet.Outlet()).Should(Receive(&res))
+			dataSeries := res.Frame.Get(data.Key()).Series
+			Expect(dataSeries).To(HaveLen(2))
+			Expect(dataSeries[0]).To(telem.MatchSeriesDataV[int32](9))
+			Expect(dataSeries[1]).To(telem.MatchSeriesDataV[int32](-2, 4))
+			idxSeries := res.Frame.Get(idx.Key()).Series
+			Expect(idxSeries).To(HaveLen(2))
+			Expect(idxSeries[0]).To(telem.MatchSeriesData(telem.NewSeriesSecondsTSV(2)))
+			Expect(idxSeries[1]).To(telem.MatchSeriesData(telem.NewSeriesSecondsTSV(4, 5)))
+			Expect(idxSeries[0].Alignment).To(Equal(dataSeries[0].Alignment))
+			Expect(idxSeries[1].Alignment).To(Equal(dataSeries[1].Alignment))
+			inlet.Close()
+			Eventually(outlet.Outlet()).Should(BeClosed())
+			Expect(w.Close()).To(Succeed())
+		})
+
+		It("Should reject a negative throttle rate", func() {
+			_, err := streamerSvc.New(ctx, streamer.Config{
+				Keys:         []channel.Key{1},
+				ThrottleRate: -1,
+			})
+			Expect(err).To(MatchError(ContainSubstring("throttle_rate: must be greater than or equal to 0")))
+		})
+	})
+
+	Describe("Tailing", func() {
+		var (
+			idx  *channel.Channel
+			data *channel.Channel
+			keys []channel.Key
+		)
+		BeforeEach(func() {
+			idx = &channel.Channel{Name: "tail_time", DataType: telem.TimeStampT, IsIndex: true}
+			Expect(dist.Channel.Create(ctx, idx)).To(Succeed())
+			data = &channel.Channel{Name: "tail_data", DataType: telem.Int64T, LocalIndex: idx.LocalKey}
+			Expect(dist.Channel.Create(ctx, data)).To(Succeed())
+			keys = []channel.Key{idx.Key(), data.Key()}
+		})
+		openWriter := func(start telem.TimeStamp) *framer.Writer {
+			return MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{
+				Start:            start,
+				Keys:             keys,
+				EnableAutoCommit: config.True(),
+			}))
+		}
+		write := func(w *framer.Writer, start, end int64) {
+			var (
+				stamps []telem.TimeStamp
+				values []int64
+			)
+			for i := start; i < end; i++ {
+				stamps = append(stamps, telem.TimeStamp(i)*telem.SecondTS)
+				values = append(values, i)
+			}
+			MustSucceed(w.Write(core.MultiFrame(keys, []telem.Series{
+				telem.NewSeries(stamps),
+				telem.NewSeries(values),
+			})))
+		}
+		// resumeToken is the latest resume token received by collect.
+		var resumeToken string
+		collect := func(outlet confluence.Outlet[streamer.Response], n int) []int64 {
+			var values []int64
+			receive := func() []int64 {
+				for {
+					select {
+					case res := <-outlet.Outlet():
+						if res.ResumeToken != "" {
+							resumeToken = res.ResumeToken
+						}
+						for _, s := range res.Frame.Get(data.Key()).Series {
+							values = append(values, telem.UnmarshalSeries[int64](s)...)
+						}
+					default:
+						return values
+					}
+				}
+			}
+			Eventually(receive).Should(HaveLen(n))
+			Consistently(receive, 50*time.Millisecond).Should(HaveLen(n))
+			return values
+		}
+
+		It("Should replay historical data before streaming live data", func() {
+			w := openWriter(telem.SecondTS)
+			write(w, 1, 6)
+			Expect(w.Close()).To(Succeed())
+			s := MustSucceed(streamerSvc.New(ctx, streamer.Config{
+				Keys:        keys,
+				SendOpenAck: true,
+				Start:       3 * telem.SecondTS,
+			}))
+			sCtx, cancel := signal.Isolated()
+			defer cancel()
+			inlet, outlet := confluence.Attach(s)
+			s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+			var ack streamer.Response
+			Eventually(outlet.Outlet()).Should(Receive(&ack))
+			Expect(ack.Frame.Empty()).To(BeTrue())
+			Expect(collect(outlet, 3)).To(Equal([]int64{3, 4, 5}))
+			w = openWriter(10 * telem.SecondTS)
+			write(w, 10, 13)
+			Expect(collect(outlet, 3)).To(Equal([]int64{10, 11, 12}))
+			inlet.Close()
+			Eventually(outlet.Outlet()).Should(BeClosed())
+			Expect(w.Close()).To(Succeed())
+		})
+
+		It("Should not send duplicate samples when switching to live data", func() {
+			w := openWriter(telem.SecondTS)
+			write(w, 1, 4)
+			s := MustSucceed(streamerSvc.New(ctx, streamer.Config{
+				Keys:     keys,
+				LookBack: telem.Since(0),
+			}))
+			sCtx, cancel := signal.Isolated()
+			defer cancel()
+			inlet, outlet := confluence.Attach(s)
+			s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+			for i := int64(4); i < 10; i++ {
+				write(w, i, i+1)
+			}
+			Expect(collect(outlet, 9)).To(Equal([]int64{1, 2, 3, 4, 5, 6, 7, 8, 9}))
+			inlet.Close()
+			Eventually(outlet.Outlet()).Should(BeClosed())
+			Expect(w.Close()).To(Succeed())
+		})
+
+		It("Should not send index channels that were not requested", func() {
+			w := openWriter(telem.SecondTS)
+			write(w, 1, 3)
+			s := MustSucceed(streamerSvc.New(ctx, streamer.Config{
+				Keys:  []channel.Key{data.Key()},
+				Start: telem.SecondTS,
+			}))
+			sCtx, cancel := signal.Isolated()
+			defer cancel()
+			inlet, outlet := confluence.Attach(s)
+			s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+			var res streamer.Response
+			Eventually(outlet.Outlet()).Should(Receive(&res))
+			Expect(res.Frame.KeysSlice()).To(Equal([]channel.Key{data.Key()}))
+			write(w, 3, 4)
+			Eventually(outlet.Outlet()).Should(Receive(&res))
+			Expect(res.Frame.KeysSlice()).To(Equal([]channel.Key{data.Key()}))
+			Expect(res.Frame.Get(data.Key()).Series[0]).To(telem.MatchSeriesDataV[int64](3))
+			inlet.Close()
+			Eventually(outlet.Outlet()).Should(BeClosed())
+			Expect(w.Close()).To(Succeed())
+		})
+
+		It("Should reject setting both a start and look back", func() {
+			_, err := streamerSvc.New(ctx, streamer.Config{
+				Keys:     keys,
+				Start:    telem.SecondTS,
+				LookBack: telem.Second,
+			})
+			Expect(err).To(MatchError(ContainSubstring("cannot set both start and look_back")))
+		})
+
+		Describe("Resume", func() {
+			It("Should back-fill data missed while disconnected when resuming", func() {
+				w := openWriter(telem.SecondTS)
+				cfg := streamer.Config{
+					Keys:        []channel.Key{data.Key()},
+					SendOpenAck: true,
+					Resumable:   true,
+				}
+				s := MustSucceed(streamerSvc.New(ctx, cfg))
+				sCtx, cancel := signal.Isolated()
+				inlet, outlet := confluence.Attach(s)
+				s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+				DeferCleanup(cancel)
+				Eventually(outlet.Outlet()).Should(Receive())
+				time.Sleep(5 * time.Millisecond)
+				resumeToken = ""
+				write(w, 1, 2)
+				Expect(collect(outlet, 1)).To(Equal([]int64{1}))
+				Expect(resumeToken).ToNot(BeEmpty())
+				inlet.Close()
+				Eventually(outlet.Outlet()).Should(BeClosed())
+				write(w, 2, 5)
+
+				cfg.ResumeToken = resumeToken
+				s = MustSucceed(streamerSvc.New(ctx, cfg))
+				sCtx, cancel = signal.Isolated()
+				defer cancel()
+				inlet, outlet = confluence.Attach(s)
+				s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+				Expect(collect(outlet, 3)).To(Equal([]int64{2, 3, 4}))
+				write(w, 5, 7)
+				Expect(collect(outlet, 2)).To(Equal([]int64{5, 6}))
+				inlet.Close()
+				Eventually(outlet.Outlet()).Should(BeClosed())
+				Expect(w.Close()).To(Succeed())
+			})
+
+			It("Should not attach resume tokens to streamers that are not resumable", func() {
+				w := openWriter(telem.SecondTS)
+				s := MustSucceed(streamerSvc.New(ctx, streamer.Config{
+					Keys:        []channel.Key{data.Key()},
+					SendOpenAck: true,
+				}))
+				sCtx, cancel := signal.Isolated()
+				defer cancel()
+				inlet, outlet := confluence.Attach(s)
+				s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+				Eventually(outlet.Outlet()).Should(Receive())
+				time.Sleep(5 * time.Millisecond)
+				resumeToken = ""
+				write(w, 1, 2)
+				Expect(collect(outlet, 1)).To(Equal([]int64{1}))
+				Expect(resumeToken).To(BeEmpty())
+				inlet.Close()
+				Eventually(outlet.Outlet()).Should(BeClosed())
+				Expect(w.Close()).To(Succeed())
+			})
+
+			It("Should return an error when resuming with an invalid token", func() {
+				_, err := streamerSvc.New(ctx, streamer.Config{
+					Keys:        keys,
+					ResumeToken: "not-a-token",
+				})
+				Expect(err).To(MatchError(ContainSubstring("invalid resume token")))
+			})
+
+			It("Should reject resuming with a start", func() {
+				_, err := streamerSvc.New(ctx, streamer.Config{
+					Keys:        keys,
+					ResumeToken: "AAAAAAAAAAAAAAAA",
+					Start:       telem.SecondTS,
+				})
+				Expect(err).To(MatchError(ContainSubstring("cannot resume")))
+			})
+		})
+	})
+
+	Describe("Slow Consumer Policies", func() {
+		var ch *channel.Channel
+		BeforeEach(func() {
+			ch = &channel.Channel{Name: "slow", DataType: telem.Int64T, Virtual: true}
+			Expect(dist.Channel.Create(ctx, ch)).To(Succeed())
+		})
+		stats := func() streamer.Stats {
+			for _, st := range streamerSvc.Stats() {
+				if slices.Equal(st.Keys, []channel.Key{ch.Key()}) {
+					return st
+				}
+			}
+			return streamer.Stats{}
+		}
+		// consume opens a streamer with the given policy, writes n frames without
+		// receiving any of them, and then returns the values the client receives.
+		consume := func(cfg streamer.Config, n int64) []int64 {
+			cfg.Keys = []channel.Key{ch.Key()}
+			cfg.SendOpenAck = true
+			w := MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{
+				Start: telem.Now(),
+				Keys:  cfg.Keys,
+			}))
+			s := MustSucceed(streamerSvc.New(ctx, cfg))
+			sCtx, cancel := signal.Isolated()
+			defer cancel()
+			inlet, outlet := confluence.Attach(s)
+			s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+			Eventually(outlet.Outlet()).Should(Receive())
+			time.Sleep(5 * time.Millisecond)
+			for i := range n {
+				MustSucceed(w.Write(core.UnaryFrame(ch.Key(), telem.NewSeriesV(i))))
+			}
+			Eventually(func() uint64 { return stats().Dropped }).ShouldNot(BeZero())
+			Expect(stats().Policy).To(Equal(cfg.SlowConsumerPolicy))
+			var (
+				values []int64
+				frames uint64
+			)
+			Eventually(func() []int64 {
+				var res streamer.Response
+				Eventually(outlet.Outlet()).Should(Receive(&res))
+				frames++
+				for _, s := range res.Frame.Get(ch.Key()).Series {
+					values = append(values, telem.UnmarshalSeries[int64](s)...)
+				}
+				return values
+			}).Should(ContainElement(n - 1))
+			Eventually(func() uint64 { return stats().Delivered }).Should(Equal(frames + 1))
+			Expect(stats().Delivered + stats().Dropped).To(BeEquivalentTo(n + 1))
+			inlet.Close()
+			Eventually(outlet.Outlet()).Should(BeClosed())
+			Eventually(stats).Should(BeZero())
+			Expect(w.Close()).To(Succeed())
+			return values
+		}
+
+		It("Should drop the oldest frames when the buffer is full", func() {
+			values := consume(streamer.Config{
+				SlowConsumerPolicy: streamer.SlowConsumerPolicyDropOldest,
+				BufferSize:         2,
+			}, 50)
+			Expect(len(values)).To(BeNumerically("<", 50))
+			Expect(slices.IsSorted(values)).To(BeTrue())
+			Expect(values[len(values)-1]).To(BeEquivalentTo(49))
+		})
+
+		It("Should coalesce frames to the latest data for each channel", func() {
+			values := consume(streamer.Config{
+				SlowConsumerPolicy: streamer.SlowConsumerPolicyCoalesce,
+			}, 50)
+			Expect(len(values)).To(BeNumerically("<", 50))
+			Expect(slices.IsSorted(values)).To(BeTrue())
+			Expect(values[len(values)-1]).To(BeEquivalentTo(49))
+		})
+
+		It("Should reject an invalid policy", func() {
+			_, err := streamerSvc.New(ctx, streamer.Config{
+				Keys:               []channel.Key{ch.Key()},
+				SlowConsumerPolicy: "cat",
+			})
+			Expect(err).To(MatchError(ContainSubstring("invalid slow consumer policy")))
+		})
+	})
+
+	Describe("Filter Validation", func() {
+		DescribeTable("Should reject invalid filters", func(f streamer.Filter, msg string) {
+			_, err := streamerSvc.New(ctx, streamer.Config{
+				Keys:    []channel.Key{1},
+				Filters: []streamer.Filter{f},
+			})
+			Expect(err).To(MatchError(ContainSubstring(msg)))
+		},
+			Entry("missing channel", streamer.Filter{Type: streamer.FilterTypeOnChange}, "channel"),
+			Entry("invalid type", streamer.Filter{Channel: 1, Type: "cat"}, "invalid filter type"),
+			Entry("negative deadband", streamer.Filter{Channel: 1, Type: streamer.FilterTypeDeadband, Deadband: -1}, "deadband"),
+			Entry("percent deadband over 100", streamer.Filter{Channel: 1, Type: streamer.FilterTypePercentDeadband, Deadband: 101}, "deadband"),
+			Entry("invalid operator", streamer.Filter{Channel: 1, Type: streamer.FilterTypePredicate, Operator: "~"}, "invalid operator"),
+		)
+		It("Should fail the streamer when a request updates it with an invalid filter", func() {
+			ch := &channel.Channel{Name: "invalid_filter", DataType: telem.Int64T, Virtual: true}
+			Expect(dist.Channel.Create(ctx, ch)).To(Succeed())
+			s := MustSucceed(streamerSvc.New(ctx, streamer.Config{
+				Keys:        []channel.Key{ch.Key()},
+				SendOpenAck: true,
+			}))
+			sCtx, cancel := signal.Isolated()
+			defer cancel()
+			inlet, outlet := confluence.Attach(s)
+			s.Flow(sCtx, confluence.CloseOutputInletsOnExit(), confluence.CancelOnFail())
+			Eventually(outlet.Outlet()).Should(Receive())
+			inlet.Inlet() <- streamer.Request{
+				Keys: []channel.Key{ch.Key()},
+				Filters: []streamer.Filter{{
+					Channel:  ch.Key(),
+					Type:     streamer.FilterTypeDeadband,
+					Deadband: -1,
+				}},
+			}
+			Expect(sCtx.Wait()).To(MatchError(ContainSubstring("filters.0.deadband")))
+			inlet.Close()
+		})
+	})
 })
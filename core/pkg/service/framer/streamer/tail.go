@@ -0,0 +1,237 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package streamer
+
+import (
+	"context"
+
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/iterator"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// tail replays historical data for the channels in a streamer before switching over
+// to live data from the relay.
+//
+// The tail waits until the relay streamer is connected before reading history, and
+// buffers any live frames received during the replay. A sample written around the
+// time the streamer opens may be both persisted and buffered. Live data has
+// alignments that are only resolved once the data is persisted, so the tail instead
+// tracks the last timestamp sent for each index and discards any samples at or before
+// it. This means the handover from history to live data has no gaps or duplicates.
+//
+// When resuming, the tail starts with the last timestamps delivered up to the resume
+// token, and only replays the samples after them.
+type tail struct {
+	confluence.AbstractLinear[Response, Response]
+	iterator    *iterator.Service
+	start       telem.TimeStamp
+	sendOpenAck bool
+	// keys are the keys of the channels to replay.
+	keys channel.Keys
+	// indexes maps each replayed channel that has an index to the key of its index.
+	indexes map[channel.Key]channel.Key
+	// last is the last timestamp sent for each index channel. Indexes are removed
+	// once live data has caught up to the replay.
+	last map[channel.Key]telem.TimeStamp
+}
+
+// Flow implements confluence.Flow.
+func (t *tail) Flow(ctx signal.Context, opts ...confluence.Option) {
+	o := confluence.NewOptions(opts)
+	o.AttachClosables(t.Out)
+	ctx.Go(t.run, o.Signal...)
+}
+
+func (t *tail) run(ctx context.Context) error {
+	// The relay streamer is always opened with an acknowledgement, which tells us
+	// that any data written from this point onwards will be received as live data.
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case _, ok := <-t.In.Outlet():
+		if !ok {
+			return nil
+		}
+	}
+	if t.sendOpenAck {
+		if err := signal.SendUnderContext(ctx, t.Out.Inlet(), Response{}); err != nil {
+			return err
+		}
+	}
+	var (
+		history = make(chan core.Frame)
+		errs    = make(chan error, 1)
+		live    []Response
+	)
+	rCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		defer close(history)
+		errs <- t.replay(rCtx, history)
+	}()
+	defer func() {
+		// Stop the replay if we exit before it completes, and wait for it to shut
+		// down so the iterator is closed.
+		cancel()
+		if history != nil {
+			for range history {
+			}
+		}
+	}()
+	for history != nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case fr, ok := <-history:
+			if !ok {
+				history = nil
+				if err := <-errs; err != nil {
+					return err
+				}
+				continue
+			}
+			fr = t.dedupe(fr)
+			t.advance(fr)
+			if err := t.send(ctx, Response{Frame: fr}); err != nil {
+				return err
+			}
+		case res, ok := <-t.In.Outlet():
+			if !ok {
+				return nil
+			}
+			live = append(live, res)
+		}
+	}
+	for _, res := range live {
+		res.Frame = t.dedupe(res.Frame)
+		if err := t.send(ctx, res); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case res, ok := <-t.In.Outlet():
+			if !ok {
+				return nil
+			}
+			res.Frame = t.dedupe(res.Frame)
+			if err := t.send(ctx, res); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// replay reads all persisted data from the start of the tail onwards, sending it to
+// the provided channel.
+func (t *tail) replay(ctx context.Context, frames chan<- core.Frame) error {
+	it, err := t.iterator.Open(ctx, iterator.Config{
+		Keys:   t.keys,
+		Bounds: telem.TimeRange{Start: t.start, End: telem.TimeStampMax},
+	})
+	if err != nil {
+		return err
+	}
+	if it.SeekFirst() {
+		for it.Next(iterator.AutoSpan) {
+			select {
+			case <-ctx.Done():
+				return errors.Combine(ctx.Err(), it.Close())
+			case frames <- it.Value():
+			}
+		}
+	}
+	return errors.Combine(it.Error(), it.Close())
+}
+
+// advance updates the last replayed timestamp of each index in the frame.
+func (t *tail) advance(fr core.Frame) {
+	for key, s := range fr.Entries() {
+		if t.indexes[key] != key || s.Len() == 0 {
+			continue
+		}
+		if end := telem.ValueAt[telem.TimeStamp](s, -1); end.After(t.last[key]) {
+			t.last[key] = end
+		}
+	}
+}
+
+// dedupe removes any samples from the frame that were already sent.
+func (t *tail) dedupe(fr core.Frame) core.Frame {
+	if len(t.last) == 0 || fr.Empty() {
+		return fr
+	}
+	var (
+		caughtUp channel.Keys
+		out      = core.AllocFrame(fr.Count())
+		visited  = make(map[channel.Key]bool, fr.Count())
+	)
+	for key := range fr.Keys() {
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+		series := fr.Get(key).Series
+		idx, ok := t.indexes[key]
+		last, sent := t.last[idx]
+		stamps := fr.Get(idx).Series
+		// Without matching index series in the frame, there's no way to know the
+		// timestamps of the samples.
+		if !ok || !sent || !aligned(series, stamps) {
+			for _, s := range series {
+				out = out.Append(key, s)
+			}
+			continue
+		}
+		for i, s := range series {
+			for _, run := range runs(s, func(j uint32, _ []byte) bool {
+				return telem.ValueAt[telem.TimeStamp](stamps[i], int(j)).After(last)
+			}) {
+				out = out.Append(key, run)
+			}
+		}
+		if len(stamps) > 0 && stamps[0].Len() > 0 &&
+			telem.ValueAt[telem.TimeStamp](stamps[0], 0).After(last) {
+			caughtUp = append(caughtUp, idx)
+		}
+	}
+	for _, idx := range caughtUp {
+		delete(t.last, idx)
+	}
+	return out
+}
+
+// aligned returns true if each series in data has a corresponding index series of the
+// same length.
+func aligned(data, index []telem.Series) bool {
+	if len(data) != len(index) {
+		return false
+	}
+	for i := range data {
+		if data[i].Len() != index[i].Len() {
+			return false
+		}
+	}
+	return true
+}
+
+// send sends the response to the client if it has any data.
+func (t *tail) send(ctx context.Context, res Response) error {
+	if res.Frame.Empty() {
+		return nil
+	}
+	return signal.SendUnderContext(ctx, t.Out.Inlet(), res)
+}
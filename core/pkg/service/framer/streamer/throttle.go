@@ -0,0 +1,211 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package streamer
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/set"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// throttle caps the rate at which frames are sent to the client. Frames received
+// within each interval are buffered and, at the end of the interval, reduced to the
+// samples holding the minimum, maximum, and last value of each channel. Unlike
+// decimation, this keeps transient spikes visible regardless of the rate of the
+// source.
+//
+// Reduced samples keep their original alignments. For index channels, the throttle
+// sends the timestamps of every sample selected for the channels that the index
+// belongs to, so that clients can still match data with its timestamps.
+type throttle struct {
+	confluence.AbstractLinear[Response, Response]
+	interval time.Duration
+	channels channel.Readable
+	// indexes maps each channel that has been received to the key of its index
+	// channel, or zero if the channel has no index. Index channels map to themselves.
+	indexes map[channel.Key]channel.Key
+	buf     core.Frame
+}
+
+func newThrottle(cfg Config, channels channel.Readable) *throttle {
+	return &throttle{
+		interval: cfg.ThrottleRate.Period().Duration(),
+		channels: channels,
+		indexes:  make(map[channel.Key]channel.Key),
+	}
+}
+
+// Flow implements confluence.Flow.
+func (t *throttle) Flow(ctx signal.Context, opts ...confluence.Option) {
+	o := confluence.NewOptions(opts)
+	o.AttachClosables(t.Out)
+	ctx.Go(func(ctx context.Context) error {
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case res, ok := <-t.In.Outlet():
+				if !ok {
+					return t.flush(ctx)
+				}
+				// Open acknowledgements carry no data and are sent immediately.
+				if res.Frame.Empty() {
+					if err := signal.SendUnderContext(ctx, t.Out.Inlet(), res); err != nil {
+						return err
+					}
+					continue
+				}
+				for key, series := range res.Frame.Entries() {
+					t.buf = t.buf.Append(key, series)
+				}
+			case <-ticker.C:
+				if err := t.flush(ctx); err != nil {
+					return err
+				}
+			}
+		}
+	}, o.Signal...)
+}
+
+func (t *throttle) flush(ctx context.Context) error {
+	if t.buf.Empty() {
+		return nil
+	}
+	fr := t.reduce(ctx)
+	t.buf = core.Frame{}
+	return signal.SendUnderContext(ctx, t.Out.Inlet(), Response{Frame: fr})
+}
+
+// resolve looks up the index of any channels in the buffer that have not been seen
+// before.
+func (t *throttle) resolve(ctx context.Context) {
+	var unknown channel.Keys
+	for key := range t.buf.Keys() {
+		if _, ok := t.indexes[key]; !ok && !lo.Contains(unknown, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return
+	}
+	var channels []channel.Channel
+	// If the retrieval fails, the channels are treated as having no index, which
+	// only affects how their timestamps are matched.
+	_ = t.channels.NewRetrieve().WhereKeys(unknown...).Entries(&channels).Exec(ctx, nil)
+	for _, ch := range channels {
+		t.indexes[ch.Key()] = ch.Index()
+	}
+	for _, key := range unknown {
+		if _, ok := t.indexes[key]; !ok {
+			t.indexes[key] = 0
+		}
+	}
+}
+
+func (t *throttle) reduce(ctx context.Context) core.Frame {
+	t.resolve(ctx)
+	selected := make(map[channel.Key]set.Set[telem.Alignment])
+	for key := range t.buf.Keys() {
+		if _, ok := selected[key]; ok || t.indexes[key] == key {
+			continue
+		}
+		sel := aggregate(t.buf.Get(key))
+		selected[key] = sel
+		if idx := t.indexes[key]; idx != 0 {
+			if _, ok := selected[idx]; !ok {
+				selected[idx] = make(set.Set[telem.Alignment])
+			}
+			selected[idx].Add(sel.Keys()...)
+		}
+	}
+	// Index channels that are streamed without any of their data channels are
+	// reduced to their last sample.
+	for key := range t.buf.Keys() {
+		if _, ok := selected[key]; !ok {
+			selected[key] = last(t.buf.Get(key))
+		}
+	}
+	out := core.AllocFrame(t.buf.Count())
+	for key, s := range t.buf.Entries() {
+		sel := selected[key]
+		for _, run := range runs(s, func(i uint32, _ []byte) bool {
+			return sel.Contains(s.Alignment.AddSamples(i))
+		}) {
+			out = out.Append(key, run)
+		}
+	}
+	return out
+}
+
+// aggregate returns the alignments of the samples holding the minimum, maximum, and
+// last values in the series. Only the last sample is selected for non-numeric
+// channels.
+func aggregate(ms telem.MultiSeries) set.Set[telem.Alignment] {
+	sel := last(ms)
+	if len(ms.Series) == 0 || !numeric(ms.Series[0].DataType) {
+		return sel
+	}
+	var (
+		minV, maxV float64
+		minA, maxA telem.Alignment
+		first      = true
+	)
+	for _, s := range ms.Series {
+		unmarshal := telem.UnmarshalF[float64](s.DataType)
+		var i uint32
+		for sample := range s.Samples() {
+			v := unmarshal(sample)
+			a := s.Alignment.AddSamples(i)
+			if first || v < minV {
+				minV, minA = v, a
+			}
+			if first || v > maxV {
+				maxV, maxA = v, a
+			}
+			first = false
+			i++
+		}
+	}
+	if !first {
+		sel.Add(minA, maxA)
+	}
+	return sel
+}
+
+// last returns the alignment of the last sample in the series.
+func last(ms telem.MultiSeries) set.Set[telem.Alignment] {
+	sel := make(set.Set[telem.Alignment])
+	for i := len(ms.Series) - 1; i >= 0; i-- {
+		if l := ms.Series[i].Len(); l > 0 {
+			sel.Add(ms.Series[i].Alignment.AddSamples(uint32(l - 1)))
+			break
+		}
+	}
+	return sel
+}
+
+func numeric(dt telem.DataType) bool {
+	switch dt {
+	case telem.Float64T, telem.Float32T, telem.Int64T, telem.Int32T, telem.Int16T,
+		telem.Int8T, telem.Uint64T, telem.Uint32T, telem.Uint16T, telem.Uint8T,
+		telem.TimeStampT:
+		return true
+	}
+	return false
+}
@@ -15,16 +15,28 @@ import (
 
 	"github.com/synnaxlabs/alamos"
 	"github.com/synnaxlabs/synnax/pkg/distribution"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
 	"github.com/synnaxlabs/synnax/pkg/security"
 	"github.com/synnaxlabs/synnax/pkg/service/access/rbac"
+	"github.com/synnaxlabs/synnax/pkg/service/alarm"
+	"github.com/synnaxlabs/synnax/pkg/service/audit"
 	"github.com/synnaxlabs/synnax/pkg/service/auth"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/apikey"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/ldap"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/oidc"
+	"github.com/synnaxlabs/synnax/pkg/service/auth/session"
 	"github.com/synnaxlabs/synnax/pkg/service/auth/token"
 	"github.com/synnaxlabs/synnax/pkg/service/console"
 	"github.com/synnaxlabs/synnax/pkg/service/framer"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/export"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/importer"
 	"github.com/synnaxlabs/synnax/pkg/service/hardware"
 	"github.com/synnaxlabs/synnax/pkg/service/label"
+	"github.com/synnaxlabs/synnax/pkg/service/limit"
 	"github.com/synnaxlabs/synnax/pkg/service/metrics"
 	"github.com/synnaxlabs/synnax/pkg/service/ranger"
+	"github.com/synnaxlabs/synnax/pkg/service/sql"
 	"github.com/synnaxlabs/synnax/pkg/service/user"
 	"github.com/synnaxlabs/synnax/pkg/service/workspace"
 	"github.com/synnaxlabs/synnax/pkg/service/workspace/lineplot"
@@ -35,6 +47,7 @@ import (
 	xio "github.com/synnaxlabs/x/io"
 	"github.com/synnaxlabs/x/override"
 	"github.com/synnaxlabs/x/service"
+	"github.com/synnaxlabs/x/telem"
 	"github.com/synnaxlabs/x/validate"
 )
 
@@ -53,6 +66,31 @@ type Config struct {
 	//
 	// [REQUIRED]
 	Security security.Provider
+	// OIDC configures single sign-on through an OpenID Connect identity provider. The
+	// storage and service fields of the configuration are filled in by the service
+	// layer.
+	//
+	// [OPTIONAL] - Single sign-on is disabled if nil.
+	OIDC *oidc.Config
+	// LDAP configures authentication against an LDAP directory. The storage and
+	// service fields of the configuration are filled in by the service layer.
+	//
+	// [OPTIONAL] - LDAP authentication is disabled if nil.
+	LDAP *ldap.Config
+	// MirrorAuditLog sets whether entries in the audit log are also written to the
+	// sy_audit channel.
+	//
+	// [OPTIONAL] - Defaults to false.
+	MirrorAuditLog *bool
+	// Limit configures the rate limits and concurrency quotas enforced on the calls
+	// users make to the API.
+	//
+	// [OPTIONAL] - Defaults to no limits.
+	Limit limit.Config
+	// MaxImportSize is the maximum size of a file that can be imported.
+	//
+	// [OPTIONAL] - Defaults to 1 GB.
+	MaxImportSize telem.Size
 }
 
 var (
@@ -60,7 +98,7 @@ var (
 	// DefaultConfig is the default configuration for opening the service layer.
 	// This configuration is not valid on its own and must be overridden by the
 	// required fields specified in Config.
-	DefaultConfig = Config{}
+	DefaultConfig = Config{MirrorAuditLog: config.False()}
 )
 
 // Override implements config.Config.
@@ -68,6 +106,11 @@ func (c Config) Override(other Config) Config {
 	c.Instrumentation = override.Zero(c.Instrumentation, other.Instrumentation)
 	c.Distribution = override.Nil(c.Distribution, other.Distribution)
 	c.Security = override.Nil(c.Security, other.Security)
+	c.OIDC = override.Nil(c.OIDC, other.OIDC)
+	c.LDAP = override.Nil(c.LDAP, other.LDAP)
+	c.MirrorAuditLog = override.Nil(c.MirrorAuditLog, other.MirrorAuditLog)
+	c.Limit = c.Limit.Override(other.Limit)
+	c.MaxImportSize = override.Numeric(c.MaxImportSize, other.MaxImportSize)
 	return c
 }
 
@@ -76,6 +119,7 @@ func (c Config) Validate() error {
 	v := validate.New("service")
 	validate.NotNil(v, "distribution", c.Distribution)
 	validate.NotNil(v, "security", c.Security)
+	validate.NotNil(v, "mirror_audit_log", c.MirrorAuditLog)
 	return v.Error()
 
 }
@@ -92,6 +136,16 @@ type Layer struct {
 	Token *token.Service
 	// Auth is for authenticating users with credentials.
 	Auth auth.Authenticator
+	// APIKey is for creating and authenticating long-lived API keys.
+	APIKey *apikey.Service
+	// Session is for opening, authenticating, and revoking login sessions.
+	Session *session.Service
+	// OIDC is for single sign-on through an OpenID Connect identity provider. It is
+	// nil if single sign-on is not configured.
+	OIDC *oidc.Service
+	// LDAP is for authenticating users against an LDAP directory. It is nil if LDAP
+	// authentication is not configured.
+	LDAP *ldap.Service
 	// Ranger is for working with ranges.
 	Ranger *ranger.Service
 	// Workspace is for working with Workspaces.
@@ -113,10 +167,24 @@ type Layer struct {
 	// Framer is for reading, writing, and streaming frames of telemetry from channels
 	// across the cluster.
 	Framer *framer.Service
+	// Export is for exporting channel data to CSV, Parquet, and Arrow files.
+	Export *export.Service
+	// Import is for importing CSV and Parquet files into channels.
+	Import *importer.Service
+	// SQL is for executing read-only SQL queries against channels and ranges.
+	SQL *sql.Service
 	// Console is for serving the web-based console UI.
 	Console *console.Service
+	// Limit is for enforcing rate limits and concurrency quotas on API calls.
+	Limit *limit.Service
 	// Metrics is used for collecting host machine metrics and publishing them over channels
 	Metrics *metrics.Service
+	// Alarm is for defining alarms on channels, evaluating them against live telemetry,
+	// and recording the alarm log.
+	Alarm *alarm.Service
+	// Audit is for recording and retrieving the log of changes users make to the
+	// cluster.
+	Audit *audit.Service
 	// closer is for properly shutting down the service layer.
 	closer xio.MultiCloser
 }
@@ -148,10 +216,36 @@ func Open(ctx context.Context, cfgs ...Config) (*Layer, error) {
 	}
 	if l.RBAC, err = rbac.NewService(rbac.Config{
 		DB: cfg.Distribution.DB,
-	}); !ok(err, nil) {
+		Proxies: map[ontology.Type]ontology.Type{
+			framer.OntologyType: channel.OntologyType,
+		},
+	}); !ok(err, l.RBAC) {
 		return nil, err
 	}
-	l.Auth = &auth.KV{DB: cfg.Distribution.DB}
+	authenticators := auth.MultiAuthenticator{&auth.KV{DB: cfg.Distribution.DB}}
+	if cfg.OIDC != nil {
+		if l.OIDC, err = oidc.NewService(*cfg.OIDC, oidc.Config{
+			Instrumentation: cfg.Instrumentation.Child("oidc"),
+			DB:              cfg.Distribution.DB,
+			User:            l.User,
+			RBAC:            l.RBAC,
+		}); !ok(err, nil) {
+			return nil, err
+		}
+		authenticators = append(authenticators, l.OIDC)
+	}
+	if cfg.LDAP != nil {
+		if l.LDAP, err = ldap.NewService(*cfg.LDAP, ldap.Config{
+			Instrumentation: cfg.Instrumentation.Child("ldap"),
+			DB:              cfg.Distribution.DB,
+			User:            l.User,
+			RBAC:            l.RBAC,
+		}); !ok(err, nil) {
+			return nil, err
+		}
+		authenticators = append(authenticators, l.LDAP)
+	}
+	l.Auth = authenticators
 	if l.Token, err = token.NewService(token.ServiceConfig{
 		KeyProvider:      cfg.Security,
 		Expiration:       24 * time.Hour,
@@ -159,6 +253,17 @@ func Open(ctx context.Context, cfgs ...Config) (*Layer, error) {
 	}); !ok(err, nil) {
 		return nil, err
 	}
+	if l.Session, err = session.NewService(session.Config{
+		DB:    cfg.Distribution.DB,
+		Token: l.Token,
+	}); !ok(err, nil) {
+		return nil, err
+	}
+	if l.APIKey, err = apikey.NewService(apikey.Config{
+		DB: cfg.Distribution.DB,
+	}); !ok(err, nil) {
+		return nil, err
+	}
 	if l.Label, err = label.OpenService(ctx, label.Config{
 		DB:       cfg.Distribution.DB,
 		Ontology: cfg.Distribution.Ontology,
@@ -233,7 +338,37 @@ func Open(ctx context.Context, cfgs ...Config) (*Layer, error) {
 	); !ok(err, l.Framer) {
 		return nil, err
 	}
+	if l.Export, err = export.NewService(export.ServiceConfig{
+		Instrumentation: cfg.Instrumentation.Child("export"),
+		Channel:         cfg.Distribution.Channel,
+		Iterator:        l.Framer.Iterator,
+		Ranger:          l.Ranger,
+	}); !ok(err, nil) {
+		return nil, err
+	}
+	if l.Import, err = importer.NewService(importer.ServiceConfig{
+		Instrumentation: cfg.Instrumentation.Child("import"),
+		Channel:         cfg.Distribution.Channel,
+		Framer:          cfg.Distribution.Framer,
+		Ranger:          l.Ranger,
+		MaxFileSize:     cfg.MaxImportSize,
+	}); !ok(err, nil) {
+		return nil, err
+	}
+	if l.SQL, err = sql.NewService(sql.ServiceConfig{
+		Instrumentation: cfg.Instrumentation.Child("sql"),
+		Channel:         cfg.Distribution.Channel,
+		Iterator:        l.Framer.Iterator,
+		Ranger:          l.Ranger,
+	}); !ok(err, nil) {
+		return nil, err
+	}
 	l.Console = console.NewService()
+	if l.Limit, err = limit.NewService(cfg.Limit, limit.Config{
+		Instrumentation: cfg.Instrumentation.Child("limit"),
+	}); !ok(err, nil) {
+		return nil, err
+	}
 	if l.Metrics, err = metrics.OpenService(
 		ctx,
 		metrics.Config{
@@ -241,8 +376,32 @@ func Open(ctx context.Context, cfgs ...Config) (*Layer, error) {
 			Framer:          l.Framer,
 			Channel:         cfg.Distribution.Channel,
 			HostProvider:    cfg.Distribution.Cluster,
+			Security:        cfg.Security,
+			Limit:           l.Limit,
 		}); !ok(err, l.Metrics) {
 		return nil, err
 	}
+	if l.Alarm, err = alarm.OpenService(ctx, alarm.Config{
+		Instrumentation: cfg.Instrumentation.Child("alarm"),
+		DB:              cfg.Distribution.DB,
+		Ontology:        cfg.Distribution.Ontology,
+		Group:           cfg.Distribution.Group,
+		Signals:         cfg.Distribution.Signals,
+		Channel:         cfg.Distribution.Channel,
+		Framer:          cfg.Distribution.Framer,
+		HostProvider:    cfg.Distribution.Cluster,
+	}); !ok(err, l.Alarm) {
+		return nil, err
+	}
+	if l.Audit, err = audit.OpenService(ctx, audit.Config{
+		Instrumentation: cfg.Instrumentation.Child("audit"),
+		DB:              cfg.Distribution.DB,
+		Mirror:          cfg.MirrorAuditLog,
+		Channel:         cfg.Distribution.Channel,
+		Framer:          cfg.Distribution.Framer,
+		HostProvider:    cfg.Distribution.Cluster,
+	}); !ok(err, l.Audit) {
+		return nil, err
+	}
 	return l, nil
 }
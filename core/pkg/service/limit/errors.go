@@ -0,0 +1,63 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package limit
+
+import (
+	"context"
+	"strings"
+
+	"github.com/synnaxlabs/x/errors"
+)
+
+var (
+	// Error is the base error for all calls rejected for exceeding a limit. Clients
+	// should back off before retrying calls that fail with it.
+	Error = errors.New("limit exceeded")
+	// RateLimited is returned when a user calls an endpoint faster than its rate limit.
+	RateLimited = errors.Wrap(Error, "rate limit exceeded")
+	// QuotaExceeded is returned when a user opens more streams than its quota.
+	QuotaExceeded = errors.Wrap(Error, "quota exceeded")
+)
+
+const (
+	errorType         = "sy.limit"
+	rateLimitedType   = errorType + ".rate_limited"
+	quotaExceededType = errorType + ".quota_exceeded"
+)
+
+func encode(_ context.Context, err error) (errors.Payload, bool) {
+	if errors.Is(err, RateLimited) {
+		return errors.Payload{Type: rateLimitedType, Data: err.Error()}, true
+	}
+	if errors.Is(err, QuotaExceeded) {
+		return errors.Payload{Type: quotaExceededType, Data: err.Error()}, true
+	}
+	if errors.Is(err, Error) {
+		return errors.Payload{Type: errorType, Data: err.Error()}, true
+	}
+	return errors.Payload{}, false
+}
+
+func decode(_ context.Context, p errors.Payload) (error, bool) {
+	switch p.Type {
+	case rateLimitedType:
+		return errors.Wrap(RateLimited, p.Data), true
+	case quotaExceededType:
+		return errors.Wrap(QuotaExceeded, p.Data), true
+	}
+	if strings.HasPrefix(p.Type, errorType) {
+		return errors.Wrap(Error, p.Data), true
+	}
+	return nil, false
+}
+
+func init() {
+	errors.Register(encode, decode)
+}
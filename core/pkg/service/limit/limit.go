@@ -0,0 +1,303 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package limit enforces rate limits and concurrency quotas on the calls users make to
+// the API, so that a single misbehaving client cannot starve the node. Calls that
+// exceed a limit fail with an error wrapping Error, which clients should treat as a
+// signal to back off and retry later.
+package limit
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/synnaxlabs/alamos"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Resource is a kind of long-lived stream that counts against a concurrency quota
+// while it is open.
+type Resource string
+
+const (
+	// Streamer is a stream of live telemetry.
+	Streamer Resource = "streamer"
+	// Writer is a stream that writes telemetry.
+	Writer Resource = "writer"
+	// Iterator is a stream that reads historical telemetry.
+	Iterator Resource = "iterator"
+)
+
+// EndpointLimit is the rate limit for calls to a single endpoint.
+type EndpointLimit struct {
+	// RequestsPerSecond is the sustained rate at which each user may call the
+	// endpoint. A rate of zero disables rate limiting for the endpoint.
+	RequestsPerSecond float64
+	// Burst is the number of calls each user may make to the endpoint at once before
+	// RequestsPerSecond applies.
+	// [OPTIONAL] [DEFAULT: RequestsPerSecond rounded up]
+	Burst int
+}
+
+// Config is the configuration for a limit Service. A limit of zero disables it.
+type Config struct {
+	// Instrumentation is used for logging.
+	alamos.Instrumentation
+	// RequestsPerSecond is the sustained rate at which each user may call each
+	// endpoint. Opening a stream counts as a single call.
+	// [OPTIONAL] [DEFAULT: unlimited]
+	RequestsPerSecond float64
+	// Burst is the number of calls each user may make to each endpoint at once before
+	// RequestsPerSecond applies.
+	// [OPTIONAL] [DEFAULT: RequestsPerSecond rounded up]
+	Burst int
+	// Endpoints overrides RequestsPerSecond and Burst for particular endpoints, so
+	// that expensive endpoints can be limited more strictly than the rest.
+	// [OPTIONAL]
+	Endpoints map[string]EndpointLimit
+	// MaxStreamers is the maximum number of streamers each user may have open.
+	// [OPTIONAL] [DEFAULT: unlimited]
+	MaxStreamers int
+	// MaxWriters is the maximum number of writers each user may have open.
+	// [OPTIONAL] [DEFAULT: unlimited]
+	MaxWriters int
+	// MaxIterators is the maximum number of iterators each user may have open.
+	// [OPTIONAL] [DEFAULT: unlimited]
+	MaxIterators int
+	// Now returns the current time. It exists for testing.
+	// [OPTIONAL] [DEFAULT: time.Now]
+	Now func() time.Time
+}
+
+var (
+	_ config.Config[Config] = Config{}
+	// DefaultConfig is the default configuration for a limit Service, which enforces
+	// no limits.
+	DefaultConfig = Config{Now: time.Now}
+)
+
+// Override implements config.Config.
+func (c Config) Override(other Config) Config {
+	c.Instrumentation = override.Zero(c.Instrumentation, other.Instrumentation)
+	c.RequestsPerSecond = override.Numeric(c.RequestsPerSecond, other.RequestsPerSecond)
+	c.Burst = override.Numeric(c.Burst, other.Burst)
+	if len(other.Endpoints) > 0 {
+		c.Endpoints = other.Endpoints
+	}
+	c.MaxStreamers = override.Numeric(c.MaxStreamers, other.MaxStreamers)
+	c.MaxWriters = override.Numeric(c.MaxWriters, other.MaxWriters)
+	c.MaxIterators = override.Numeric(c.MaxIterators, other.MaxIterators)
+	c.Now = override.Nil(c.Now, other.Now)
+	return c
+}
+
+// Validate implements config.Config.
+func (c Config) Validate() error {
+	v := validate.New("limit")
+	validate.GreaterThanEq(v, "requests_per_second", c.RequestsPerSecond, 0)
+	validate.GreaterThanEq(v, "burst", c.Burst, 0)
+	for endpoint, l := range c.Endpoints {
+		validate.GreaterThanEq(v, "endpoints."+endpoint+".requests_per_second", l.RequestsPerSecond, 0)
+		validate.GreaterThanEq(v, "endpoints."+endpoint+".burst", l.Burst, 0)
+	}
+	validate.GreaterThanEq(v, "max_streamers", c.MaxStreamers, 0)
+	validate.GreaterThanEq(v, "max_writers", c.MaxWriters, 0)
+	validate.GreaterThanEq(v, "max_iterators", c.MaxIterators, 0)
+	validate.NotNil(v, "now", c.Now)
+	return v.Error()
+}
+
+// endpoint returns the rate limit for calls to the given endpoint.
+func (c Config) endpoint(endpoint string) EndpointLimit {
+	if l, ok := c.Endpoints[endpoint]; ok {
+		return l
+	}
+	return EndpointLimit{RequestsPerSecond: c.RequestsPerSecond, Burst: c.Burst}
+}
+
+func (c Config) max(r Resource) int {
+	switch r {
+	case Streamer:
+		return c.MaxStreamers
+	case Writer:
+		return c.MaxWriters
+	case Iterator:
+		return c.MaxIterators
+	}
+	return 0
+}
+
+// Usage is the current usage of the limits enforced by a Service, summed across all
+// users.
+type Usage struct {
+	// Open is the number of streams of each resource that are currently open.
+	Open map[Resource]int
+	// Rejected is the number of calls that have been rejected for exceeding a limit
+	// since the Service was created.
+	Rejected int
+}
+
+type bucketKey struct {
+	subject  ontology.ID
+	endpoint string
+}
+
+// bucket is a token bucket holding the calls a user may make to an endpoint.
+type bucket struct {
+	EndpointLimit
+	tokens float64
+	last   time.Time
+}
+
+type openKey struct {
+	subject  ontology.ID
+	resource Resource
+}
+
+// pruneInterval is how often buckets that have refilled are discarded.
+const pruneInterval = time.Minute
+
+// Service enforces rate limits and concurrency quotas on users. It is safe for
+// concurrent use.
+type Service struct {
+	cfg Config
+	mu  struct {
+		sync.Mutex
+		buckets   map[bucketKey]*bucket
+		lastPrune time.Time
+		open      map[openKey]int
+		rejected  int
+	}
+}
+
+// NewService creates a new limit Service using the provided configuration.
+func NewService(cfgs ...Config) (*Service, error) {
+	cfg, err := config.New(DefaultConfig, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Burst == 0 {
+		cfg.Burst = int(math.Ceil(cfg.RequestsPerSecond))
+	}
+	endpoints := make(map[string]EndpointLimit, len(cfg.Endpoints))
+	for endpoint, l := range cfg.Endpoints {
+		if l.Burst == 0 {
+			l.Burst = int(math.Ceil(l.RequestsPerSecond))
+		}
+		endpoints[endpoint] = l
+	}
+	cfg.Endpoints = endpoints
+	s := &Service{cfg: cfg}
+	s.mu.buckets = make(map[bucketKey]*bucket)
+	s.mu.open = make(map[openKey]int)
+	s.mu.lastPrune = cfg.Now()
+	return s, nil
+}
+
+// Allow consumes a call by the given subject to the given endpoint, returning an error
+// wrapping RateLimited if the subject has exceeded its rate limit for the endpoint.
+func (s *Service) Allow(subject ontology.ID, endpoint string) error {
+	l := s.cfg.endpoint(endpoint)
+	if l.RequestsPerSecond == 0 {
+		return nil
+	}
+	now := s.cfg.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked(now)
+	key := bucketKey{subject: subject, endpoint: endpoint}
+	b, ok := s.mu.buckets[key]
+	if !ok {
+		b = &bucket{EndpointLimit: l, tokens: float64(l.Burst), last: now}
+		s.mu.buckets[key] = b
+	}
+	b.tokens = b.refill(now)
+	b.last = now
+	if b.tokens < 1 {
+		s.mu.rejected++
+		retryIn := time.Duration((1 - b.tokens) / l.RequestsPerSecond * float64(time.Second))
+		return errors.Wrapf(
+			RateLimited,
+			"more than %v requests per second to %s, retry in %s",
+			l.RequestsPerSecond,
+			endpoint,
+			retryIn.Round(time.Millisecond),
+		)
+	}
+	b.tokens--
+	return nil
+}
+
+func (b *bucket) refill(now time.Time) float64 {
+	return min(
+		float64(b.Burst),
+		b.tokens+now.Sub(b.last).Seconds()*b.RequestsPerSecond,
+	)
+}
+
+// pruneLocked discards buckets that have refilled completely, as they are equivalent
+// to a bucket that does not exist. This keeps the number of buckets bounded by the
+// number of users that are actively calling the API.
+func (s *Service) pruneLocked(now time.Time) {
+	if now.Sub(s.mu.lastPrune) < pruneInterval {
+		return
+	}
+	s.mu.lastPrune = now
+	for k, b := range s.mu.buckets {
+		if b.refill(now) >= float64(b.Burst) {
+			delete(s.mu.buckets, k)
+		}
+	}
+}
+
+// Acquire counts a stream of the given resource opened by the given subject against its
+// quota, returning an error wrapping QuotaExceeded if the subject already has the
+// maximum number of streams of the resource open. If Acquire succeeds, the returned
+// function must be called exactly once when the stream closes.
+func (s *Service) Acquire(subject ontology.ID, r Resource) (release func(), err error) {
+	key := openKey{subject: subject, resource: r}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if maxOpen := s.cfg.max(r); maxOpen > 0 && s.mu.open[key] >= maxOpen {
+		s.mu.rejected++
+		return nil, errors.Wrapf(
+			QuotaExceeded,
+			"cannot open more than %d %ss at once",
+			maxOpen,
+			r,
+		)
+	}
+	s.mu.open[key]++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.mu.open[key]--; s.mu.open[key] <= 0 {
+				delete(s.mu.open, key)
+			}
+		})
+	}, nil
+}
+
+// Usage returns the current usage of the limits enforced by the Service.
+func (s *Service) Usage() Usage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := Usage{Open: make(map[Resource]int), Rejected: s.mu.rejected}
+	for k, n := range s.mu.open {
+		u.Open[k.resource] += n
+	}
+	return u
+}
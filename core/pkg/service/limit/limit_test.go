@@ -0,0 +1,145 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package limit_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/service/limit"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/errors"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Limit", func() {
+	var (
+		now        time.Time
+		clock      = func() time.Time { return now }
+		alice, bob = user.OntologyID(uuid.New()), user.OntologyID(uuid.New())
+	)
+	BeforeEach(func() { now = time.Now() })
+	Describe("Rate Limits", func() {
+		It("Should allow bursts up to the burst size", func() {
+			svc := MustSucceed(limit.NewService(limit.Config{
+				RequestsPerSecond: 1,
+				Burst:             3,
+				Now:               clock,
+			}))
+			for range 3 {
+				Expect(svc.Allow(alice, "/frame/write")).To(Succeed())
+			}
+			Expect(svc.Allow(alice, "/frame/write")).To(MatchError(limit.RateLimited))
+		})
+		It("Should refill at the configured rate", func() {
+			svc := MustSucceed(limit.NewService(limit.Config{
+				RequestsPerSecond: 2,
+				Now:               clock,
+			}))
+			Expect(svc.Allow(alice, "/channel/create")).To(Succeed())
+			Expect(svc.Allow(alice, "/channel/create")).To(Succeed())
+			err := svc.Allow(alice, "/channel/create")
+			Expect(err).To(MatchError(ContainSubstring("retry in 500ms")))
+			now = now.Add(500 * time.Millisecond)
+			Expect(svc.Allow(alice, "/channel/create")).To(Succeed())
+			Expect(svc.Allow(alice, "/channel/create")).To(MatchError(limit.RateLimited))
+		})
+		It("Should limit each user and endpoint separately", func() {
+			svc := MustSucceed(limit.NewService(limit.Config{
+				RequestsPerSecond: 1,
+				Now:               clock,
+			}))
+			Expect(svc.Allow(alice, "/channel/create")).To(Succeed())
+			Expect(svc.Allow(alice, "/channel/create")).ToNot(Succeed())
+			Expect(svc.Allow(alice, "/channel/retrieve")).To(Succeed())
+			Expect(svc.Allow(bob, "/channel/create")).To(Succeed())
+		})
+		It("Should apply the limits of specific endpoints", func() {
+			svc := MustSucceed(limit.NewService(limit.Config{
+				RequestsPerSecond: 1,
+				Endpoints: map[string]limit.EndpointLimit{
+					"/frame/export":   {RequestsPerSecond: 1, Burst: 2},
+					"/channel/create": {},
+				},
+				Now: clock,
+			}))
+			Expect(svc.Allow(alice, "/frame/export")).To(Succeed())
+			Expect(svc.Allow(alice, "/frame/export")).To(Succeed())
+			Expect(svc.Allow(alice, "/frame/export")).To(MatchError(limit.RateLimited))
+			for range 10 {
+				Expect(svc.Allow(alice, "/channel/create")).To(Succeed())
+			}
+			Expect(svc.Allow(alice, "/channel/retrieve")).To(Succeed())
+			Expect(svc.Allow(alice, "/channel/retrieve")).To(MatchError(limit.RateLimited))
+		})
+		It("Should apply endpoint limits when no default rate is configured", func() {
+			svc := MustSucceed(limit.NewService(limit.Config{
+				Endpoints: map[string]limit.EndpointLimit{"/frame/export": {RequestsPerSecond: 1}},
+				Now:       clock,
+			}))
+			Expect(svc.Allow(alice, "/frame/export")).To(Succeed())
+			Expect(svc.Allow(alice, "/frame/export")).To(MatchError(limit.RateLimited))
+			for range 10 {
+				Expect(svc.Allow(alice, "/channel/create")).To(Succeed())
+			}
+		})
+		It("Should not limit calls when no rate is configured", func() {
+			svc := MustSucceed(limit.NewService())
+			for range 100 {
+				Expect(svc.Allow(alice, "/channel/create")).To(Succeed())
+			}
+		})
+	})
+	Describe("Quotas", func() {
+		It("Should limit the number of open streams of each resource", func() {
+			svc := MustSucceed(limit.NewService(limit.Config{MaxWriters: 2}))
+			r1 := MustSucceed(svc.Acquire(alice, limit.Writer))
+			MustSucceed(svc.Acquire(alice, limit.Writer))
+			Expect(svc.Acquire(alice, limit.Writer)).Error().To(MatchError(limit.QuotaExceeded))
+			MustSucceed(svc.Acquire(bob, limit.Writer))
+			MustSucceed(svc.Acquire(alice, limit.Streamer))
+			r1()
+			r1()
+			MustSucceed(svc.Acquire(alice, limit.Writer))
+			Expect(svc.Acquire(alice, limit.Writer)).Error().To(MatchError(limit.QuotaExceeded))
+		})
+		It("Should report the current usage", func() {
+			svc := MustSucceed(limit.NewService(limit.Config{MaxIterators: 1}))
+			release := MustSucceed(svc.Acquire(alice, limit.Iterator))
+			MustSucceed(svc.Acquire(bob, limit.Iterator))
+			MustSucceed(svc.Acquire(bob, limit.Streamer))
+			Expect(svc.Acquire(alice, limit.Iterator)).Error().To(HaveOccurred())
+			u := svc.Usage()
+			Expect(u.Open).To(Equal(map[limit.Resource]int{limit.Iterator: 2, limit.Streamer: 1}))
+			Expect(u.Rejected).To(Equal(1))
+			release()
+			Expect(svc.Usage().Open[limit.Iterator]).To(Equal(1))
+		})
+	})
+	Describe("Errors", func() {
+		It("Should encode and decode limit errors", func() {
+			svc := MustSucceed(limit.NewService(limit.Config{MaxStreamers: 1}))
+			MustSucceed(svc.Acquire(alice, limit.Streamer))
+			_, err := svc.Acquire(alice, limit.Streamer)
+			decoded := errors.Decode(context.Background(), errors.Encode(context.Background(), err, false))
+			Expect(decoded).To(MatchError(limit.QuotaExceeded))
+			Expect(decoded).To(MatchError(limit.Error))
+		})
+	})
+	Describe("Validation", func() {
+		It("Should not allow negative limits", func() {
+			Expect(limit.NewService(limit.Config{RequestsPerSecond: -1})).Error().
+				To(MatchError(ContainSubstring("requests_per_second")))
+		})
+	})
+})
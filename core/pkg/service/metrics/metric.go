@@ -10,9 +10,13 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/shirou/gopsutil/v4/cpu"
 	"github.com/shirou/gopsutil/v4/mem"
 	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/security"
+	"github.com/synnaxlabs/synnax/pkg/service/limit"
 	"github.com/synnaxlabs/x/errors"
 	"github.com/synnaxlabs/x/telem"
 )
@@ -22,7 +26,7 @@ type metric struct {
 	collect func() (float32, error)
 }
 
-var all = []metric{
+var hostMetrics = []metric{
 	{
 		ch: channel.Channel{
 			Name:     "mem_percentage",
@@ -53,3 +57,47 @@ var all = []metric{
 		},
 	},
 }
+
+// certExpiryMetric reports the number of days until the node's TLS certificate expires.
+// The value is negative once the certificate has expired.
+func certExpiryMetric(sec security.CertProvider) metric {
+	return metric{
+		ch: channel.Channel{
+			Name:     "cert_expiry_days",
+			DataType: telem.Float32T,
+		},
+		collect: func() (float32, error) {
+			c := sec.NodeCert()
+			if c == nil {
+				return 0, errors.New("no node certificate found")
+			}
+			return float32(time.Until(c.NotAfter).Hours() / 24), nil
+		},
+	}
+}
+
+// limitMetrics report the number of streams of each resource that are open and the
+// number of calls that have been rejected for exceeding a rate limit or quota.
+func limitMetrics(l *limit.Service) []metric {
+	open := func(r limit.Resource) metric {
+		return metric{
+			ch: channel.Channel{
+				Name:     "open_" + string(r) + "s",
+				DataType: telem.Float32T,
+			},
+			collect: func() (float32, error) { return float32(l.Usage().Open[r]), nil },
+		}
+	}
+	return []metric{
+		open(limit.Streamer),
+		open(limit.Writer),
+		open(limit.Iterator),
+		{
+			ch: channel.Channel{
+				Name:     "rejected_requests",
+				DataType: telem.Float32T,
+			},
+			collect: func() (float32, error) { return float32(l.Usage().Rejected), nil },
+		},
+	}
+}
@@ -13,13 +13,21 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
 	"github.com/synnaxlabs/synnax/pkg/distribution/mock"
+	"github.com/synnaxlabs/synnax/pkg/security"
+	"github.com/synnaxlabs/synnax/pkg/security/cert"
+	secmock "github.com/synnaxlabs/synnax/pkg/security/mock"
 	"github.com/synnaxlabs/synnax/pkg/service/framer"
+	"github.com/synnaxlabs/synnax/pkg/service/limit"
 	"github.com/synnaxlabs/synnax/pkg/service/metrics"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/x/config"
 	"github.com/synnaxlabs/x/confluence"
+	xfs "github.com/synnaxlabs/x/io/fs"
 	"github.com/synnaxlabs/x/signal"
 	"github.com/synnaxlabs/x/telem"
 	. "github.com/synnaxlabs/x/testutil"
@@ -237,4 +245,71 @@ var _ = Describe("Metrics", Ordered, func() {
 			Expect(nextTime).To(BeNumerically(">", latestTime))
 		})
 	})
+	Describe("Certificate Expiry", func() {
+		It("Should report the days until the node certificate expires", func() {
+			fs := xfs.NewMem()
+			secmock.GenerateCerts(fs)
+			sec := MustSucceed(security.NewProvider(security.ProviderConfig{
+				LoaderConfig: cert.LoaderConfig{FS: fs},
+				KeySize:      secmock.SmallKeySize,
+				Insecure:     config.False(),
+			}))
+			svc := MustSucceed(metrics.OpenService(ctx, metrics.Config{
+				Channel:            dist.Channel,
+				Framer:             svcFramer,
+				HostProvider:       dist.Cluster,
+				Security:           sec,
+				CollectionInterval: 50 * time.Millisecond,
+			}))
+			var ch channel.Channel
+			Expect(dist.Channel.NewRetrieve().
+				WhereNames("sy_node_"+dist.Cluster.HostKey().String()+"_metrics_cert_expiry_days").
+				Entry(&ch).
+				Exec(ctx, nil),
+			).To(Succeed())
+			streamer := MustSucceed(svcFramer.NewStreamer(ctx, framer.StreamerConfig{
+				Keys: channel.Keys{ch.Key()},
+			}))
+			requests, responses := confluence.Attach(streamer)
+			streamer.Flow(signal.Wrap(ctx), confluence.CloseOutputInletsOnExit())
+			var res framer.StreamerResponse
+			Eventually(responses.Outlet()).Should(Receive(&res))
+			days := telem.ValueAt[float32](res.Frame.SeriesAt(0), 0)
+			Expect(days).To(BeNumerically("~", 365, 1))
+			requests.Close()
+			Eventually(responses.Outlet()).Should(BeClosed())
+			Expect(svc.Close()).To(Succeed())
+		})
+	})
+	Describe("Limits", func() {
+		It("Should report the number of open streams", func() {
+			lim := MustSucceed(limit.NewService())
+			release := MustSucceed(lim.Acquire(user.OntologyID(uuid.New()), limit.Writer))
+			defer release()
+			svc := MustSucceed(metrics.OpenService(ctx, metrics.Config{
+				Channel:            dist.Channel,
+				Framer:             svcFramer,
+				HostProvider:       dist.Cluster,
+				Limit:              lim,
+				CollectionInterval: 50 * time.Millisecond,
+			}))
+			var ch channel.Channel
+			Expect(dist.Channel.NewRetrieve().
+				WhereNames("sy_node_"+dist.Cluster.HostKey().String()+"_metrics_open_writers").
+				Entry(&ch).
+				Exec(ctx, nil),
+			).To(Succeed())
+			streamer := MustSucceed(svcFramer.NewStreamer(ctx, framer.StreamerConfig{
+				Keys: channel.Keys{ch.Key()},
+			}))
+			requests, responses := confluence.Attach(streamer)
+			streamer.Flow(signal.Wrap(ctx), confluence.CloseOutputInletsOnExit())
+			var res framer.StreamerResponse
+			Eventually(responses.Outlet()).Should(Receive(&res))
+			Expect(telem.ValueAt[float32](res.Frame.SeriesAt(0), 0)).To(Equal(float32(1)))
+			requests.Close()
+			Eventually(responses.Outlet()).Should(BeClosed())
+			Expect(svc.Close()).To(Succeed())
+		})
+	})
 })
@@ -13,12 +13,15 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
 	"time"
 
 	"github.com/synnaxlabs/alamos"
 	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
 	"github.com/synnaxlabs/synnax/pkg/distribution/cluster"
+	"github.com/synnaxlabs/synnax/pkg/security"
 	"github.com/synnaxlabs/synnax/pkg/service/framer"
+	"github.com/synnaxlabs/synnax/pkg/service/limit"
 	"github.com/synnaxlabs/x/address"
 	"github.com/synnaxlabs/x/config"
 	"github.com/synnaxlabs/x/confluence"
@@ -45,6 +48,16 @@ type Config struct {
 	//
 	// [REQUIRED]
 	HostProvider cluster.HostProvider
+	// Security is used to report the time remaining until the node's TLS certificate
+	// expires. The metric is only collected when the node is running in secure mode.
+	//
+	// [OPTIONAL]
+	Security security.CertProvider
+	// Limit is used to report the number of open streams and rejected calls counted
+	// against the API's rate limits and quotas.
+	//
+	// [OPTIONAL]
+	Limit *limit.Service
 	// CollectionInterval sets the interval at which metrics will be collected
 	// from the host machine.
 	//
@@ -66,6 +79,8 @@ func (c Config) Override(other Config) Config {
 	c.Channel = override.Nil(c.Channel, other.Channel)
 	c.Framer = override.Nil(c.Framer, other.Framer)
 	c.HostProvider = override.Nil(c.HostProvider, other.HostProvider)
+	c.Security = override.Nil(c.Security, other.Security)
+	c.Limit = override.Nil(c.Limit, other.Limit)
 	c.CollectionInterval = override.Numeric(c.CollectionInterval, other.CollectionInterval)
 	return c
 }
@@ -104,6 +119,13 @@ func OpenService(ctx context.Context, cfgs ...Config) (*Service, error) {
 		return nil, err
 	}
 	s := &Service{stopCollector: make(chan struct{})}
+	all := slices.Clone(hostMetrics)
+	if cfg.Security != nil && cfg.Security.NodeCert() != nil {
+		all = append(all, certExpiryMetric(cfg.Security))
+	}
+	if cfg.Limit != nil {
+		all = append(all, limitMetrics(cfg.Limit)...)
+	}
 	nameBase := fmt.Sprintf("sy_node_%s_metrics_", cfg.HostProvider.HostKey())
 	c := &collector{
 		ins:      cfg.Instrumentation.Child("collector"),
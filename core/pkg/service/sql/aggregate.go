@@ -0,0 +1,167 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package sql
+
+import (
+	"github.com/synnaxlabs/x/telem"
+)
+
+// accumulator computes the value of an aggregate over the rows of a group.
+type accumulator interface {
+	// add adds a value to the aggregate. NULL values are never added.
+	add(v any) error
+	// result returns the value of the aggregate.
+	result() any
+}
+
+// aggregates are the supported aggregate functions, keyed by name. Each function
+// returns a new accumulator for arguments of the given type.
+var aggregates = map[string]func(t Type) accumulator{
+	"count": func(Type) accumulator { return &countAcc{} },
+	"sum":   func(t Type) accumulator { return &sumAcc{t: t} },
+	"avg":   func(Type) accumulator { return &avgAcc{} },
+	"min":   func(Type) accumulator { return &extremeAcc{sign: -1} },
+	"max":   func(Type) accumulator { return &extremeAcc{sign: 1} },
+	"first": func(Type) accumulator { return &firstAcc{} },
+	"last":  func(Type) accumulator { return &lastAcc{} },
+}
+
+// aggregate is an aggregate call in a query.
+type aggregate struct {
+	name string
+	// arg is the argument of the aggregate, or nil for count(*).
+	arg expr
+	t   Type
+}
+
+func (a aggregate) new() accumulator { return aggregates[a.name](a.t) }
+
+type countAcc struct{ n int64 }
+
+func (c *countAcc) add(any) error { c.n++; return nil }
+
+func (c *countAcc) result() any { return c.n }
+
+type sumAcc struct {
+	t     Type
+	valid bool
+	i     int64
+	u     uint64
+	f     float64
+}
+
+func (s *sumAcc) add(v any) error {
+	s.valid = true
+	switch s.t {
+	case TypeInt:
+		x, err := coerce(v, TypeInt)
+		if err != nil {
+			return err
+		}
+		s.i += x.(int64)
+	case TypeNumeric:
+		x, err := coerce(v, TypeNumeric)
+		if err != nil {
+			return err
+		}
+		s.u += x.(uint64)
+	case TypeInterval:
+		x, err := coerce(v, TypeInterval)
+		if err != nil {
+			return err
+		}
+		s.i += int64(x.(telem.TimeSpan))
+	default:
+		x, err := coerce(v, TypeFloat)
+		if err != nil {
+			return err
+		}
+		s.f += x.(float64)
+	}
+	return nil
+}
+
+func (s *sumAcc) result() any {
+	if !s.valid {
+		return nil
+	}
+	switch s.t {
+	case TypeInt:
+		return s.i
+	case TypeNumeric:
+		return s.u
+	case TypeInterval:
+		return telem.TimeSpan(s.i)
+	}
+	return s.f
+}
+
+type avgAcc struct {
+	n   int64
+	sum float64
+}
+
+func (a *avgAcc) add(v any) error {
+	x, err := coerce(v, TypeFloat)
+	if err != nil {
+		return err
+	}
+	a.sum += x.(float64)
+	a.n++
+	return nil
+}
+
+func (a *avgAcc) result() any {
+	if a.n == 0 {
+		return nil
+	}
+	return a.sum / float64(a.n)
+}
+
+// extremeAcc computes the minimum of its values if sign is -1, and the maximum if
+// sign is 1.
+type extremeAcc struct {
+	sign int
+	v    any
+}
+
+func (e *extremeAcc) add(v any) error {
+	if e.v == nil {
+		e.v = v
+		return nil
+	}
+	c, err := compare(v, e.v)
+	if err != nil {
+		return err
+	}
+	if c*e.sign > 0 {
+		e.v = v
+	}
+	return nil
+}
+
+func (e *extremeAcc) result() any { return e.v }
+
+type firstAcc struct{ v any }
+
+func (f *firstAcc) add(v any) error {
+	if f.v == nil {
+		f.v = v
+	}
+	return nil
+}
+
+func (f *firstAcc) result() any { return f.v }
+
+type lastAcc struct{ v any }
+
+func (l *lastAcc) add(v any) error { l.v = v; return nil }
+
+func (l *lastAcc) result() any { return l.v }
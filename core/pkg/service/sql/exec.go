@@ -0,0 +1,371 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Exec executes the query with the given parameters, calling emit with each row of
+// its results. Parameters may be values of the query's parameter types, equivalent Go
+// values such as ints and time.Times, or strings holding their text representations.
+// authorize is called with the channels and ranges the query reads before their data is
+// read. Exec returns the command tag of the query, such as 'SELECT 5'.
+func (q *Query) Exec(
+	ctx context.Context,
+	params []any,
+	authorize AuthorizeFunc,
+	emit func(Row) error,
+) (string, error) {
+	if q.tag != "" {
+		return q.tag, nil
+	}
+	if len(params) != len(q.paramTypes) {
+		return "", errors.Wrapf(
+			validate.Error,
+			"query requires %d parameters, but %d were provided",
+			len(q.paramTypes),
+			len(params),
+		)
+	}
+	en := &env{params: make([]any, len(params)), now: telem.Now()}
+	for i, p := range params {
+		v, err := coerce(normalize(p), q.paramTypes[i])
+		if err != nil {
+			return "", err
+		}
+		en.params[i] = v
+	}
+	limit, err := q.evalCount(q.limit, en, "LIMIT")
+	if err != nil {
+		return "", err
+	}
+	offset, err := q.evalCount(q.offset, en, "OFFSET")
+	if err != nil {
+		return "", err
+	}
+	e := &executor{q: q, en: en, limit: limit, offset: offset, emit: emit}
+	if q.stream {
+		err = e.stream(ctx, authorize)
+	} else {
+		err = e.materialize(ctx, authorize)
+	}
+	return "SELECT " + strconv.Itoa(e.emitted), err
+}
+
+// evalCount evaluates the expression of a LIMIT or OFFSET clause, returning -1 if there
+// is no clause.
+func (q *Query) evalCount(e expr, en *env, clause string) (int, error) {
+	if e == nil {
+		return -1, nil
+	}
+	v, err := eval(e, en)
+	if err != nil || v == nil {
+		return -1, err
+	}
+	if v, err = coerce(v, TypeInt); err != nil {
+		return 0, err
+	}
+	n := v.(int64)
+	if n < 0 {
+		return 0, errors.Wrapf(validate.Error, "%s must not be negative", clause)
+	}
+	return int(n), nil
+}
+
+type executor struct {
+	q  *Query
+	en *env
+	// limit and offset are -1 if the query has no LIMIT or OFFSET clause.
+	limit, offset int
+	emit          func(Row) error
+	// skipped is the number of rows skipped so far to satisfy the offset.
+	skipped int
+	emitted int
+}
+
+// scan calls f with each row of the query's table that satisfies its WHERE clause.
+func (e *executor) scan(ctx context.Context, authorize AuthorizeFunc, f func(Row) (bool, error)) error {
+	filter := func(row Row) (bool, error) {
+		e.en.row = row
+		if e.q.where != nil {
+			v, err := eval(e.q.where, e.en)
+			if err != nil {
+				return false, err
+			}
+			if v == nil {
+				return true, nil
+			}
+			ok, err := toBool(v)
+			if err != nil || !ok {
+				return true, err
+			}
+		}
+		return f(row)
+	}
+	if e.q.table == nil {
+		// A query with no FROM clause reads a single row with no columns.
+		_, err := filter(Row{})
+		return err
+	}
+	return e.q.table.scan(ctx, e.q.where, e.en, authorize, filter)
+}
+
+// push emits a row, applying the query's offset and limit. It returns false once the
+// limit has been reached.
+func (e *executor) push(row Row) (bool, error) {
+	if e.limit >= 0 && e.emitted >= e.limit {
+		return false, nil
+	}
+	if e.skipped < e.offset {
+		e.skipped++
+		return true, nil
+	}
+	if err := e.emit(row); err != nil {
+		return false, err
+	}
+	e.emitted++
+	return e.limit < 0 || e.emitted < e.limit, nil
+}
+
+func (e *executor) project() (Row, error) {
+	out := make(Row, len(e.q.items))
+	for i, item := range e.q.items {
+		v, err := eval(item, e.en)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// stream emits rows as they are read.
+func (e *executor) stream(ctx context.Context, authorize AuthorizeFunc) error {
+	if e.limit == 0 {
+		return nil
+	}
+	return e.scan(ctx, authorize, func(row Row) (bool, error) {
+		out, err := e.project()
+		if err != nil {
+			return false, err
+		}
+		return e.push(out)
+	})
+}
+
+// sortedRow is a row of results along with the values of the query's ORDER BY
+// expressions for the row.
+type sortedRow struct {
+	row  Row
+	keys []any
+}
+
+// materialize reads every row, computes aggregates, and sorts the results before
+// emitting them.
+func (e *executor) materialize(ctx context.Context, authorize AuthorizeFunc) error {
+	var (
+		rows []sortedRow
+		err  error
+	)
+	if e.q.aggregate {
+		rows, err = e.aggregate(ctx, authorize)
+	} else {
+		err = e.scan(ctx, authorize, func(Row) (bool, error) {
+			r, err := e.sortedRow()
+			if err != nil {
+				return false, err
+			}
+			rows = append(rows, r)
+			return true, nil
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if err = e.sort(rows); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if ok, err := e.push(r.row); err != nil || !ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedRow projects the current row of the environment and evaluates its ORDER BY
+// expressions.
+func (e *executor) sortedRow() (sortedRow, error) {
+	out, err := e.project()
+	if err != nil {
+		return sortedRow{}, err
+	}
+	r := sortedRow{row: out, keys: make([]any, len(e.q.order))}
+	for i, o := range e.q.order {
+		if r.keys[i], err = eval(o.e, e.en); err != nil {
+			return sortedRow{}, err
+		}
+	}
+	return r, nil
+}
+
+func (e *executor) sort(rows []sortedRow) error {
+	if len(e.q.order) == 0 {
+		return nil
+	}
+	var err error
+	slices.SortStableFunc(rows, func(a, b sortedRow) int {
+		for i, o := range e.q.order {
+			x, y := a.keys[i], b.keys[i]
+			var c int
+			switch {
+			case x == nil && y == nil:
+				continue
+			case x == nil || y == nil:
+				// Nulls sort first or last regardless of the direction.
+				c = 1
+				if (x == nil) == o.nullsFirst {
+					c = -1
+				}
+				return c
+			}
+			c, cErr := compare(x, y)
+			if cErr != nil {
+				err = cErr
+				return 0
+			}
+			if o.desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return err
+}
+
+// group is a group of rows in an aggregate query.
+type group struct {
+	values []any
+	accs   []accumulator
+}
+
+// aggregate reads every row, grouping the rows and accumulating the query's aggregates
+// for each group, and returns a row for each group that satisfies the HAVING clause.
+func (e *executor) aggregate(ctx context.Context, authorize AuthorizeFunc) ([]sortedRow, error) {
+	var (
+		groups = make(map[string]*group)
+		// order holds the groups in the order they were first seen, so that results
+		// are deterministic.
+		order []*group
+		key   strings.Builder
+	)
+	newGroup := func(values []any) *group {
+		g := &group{values: values, accs: make([]accumulator, len(e.q.aggs))}
+		for i, a := range e.q.aggs {
+			g.accs[i] = a.new()
+		}
+		order = append(order, g)
+		return g
+	}
+	err := e.scan(ctx, authorize, func(Row) (bool, error) {
+		values := make([]any, len(e.q.groups))
+		key.Reset()
+		for i, ge := range e.q.groups {
+			v, err := eval(ge, e.en)
+			if err != nil {
+				return false, err
+			}
+			values[i] = v
+			writeGroupKey(&key, v)
+		}
+		g, ok := groups[key.String()]
+		if !ok {
+			g = newGroup(values)
+			groups[key.String()] = g
+		}
+		for i, a := range e.q.aggs {
+			if a.arg == nil {
+				if err := g.accs[i].add(true); err != nil {
+					return false, err
+				}
+				continue
+			}
+			v, err := eval(a.arg, e.en)
+			if err != nil {
+				return false, err
+			}
+			if v == nil {
+				continue
+			}
+			if err = g.accs[i].add(v); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// An aggregate query with no GROUP BY clause always returns a single row.
+	if len(order) == 0 && len(e.q.groups) == 0 {
+		newGroup(nil)
+	}
+	e.en.row = nil
+	rows := make([]sortedRow, 0, len(order))
+	for _, g := range order {
+		e.en.groups = g.values
+		e.en.aggs = make([]any, len(g.accs))
+		for i, acc := range g.accs {
+			e.en.aggs[i] = acc.result()
+		}
+		if e.q.having != nil {
+			v, err := eval(e.q.having, e.en)
+			if err != nil {
+				return nil, err
+			}
+			if v == nil {
+				continue
+			}
+			if ok, err := toBool(v); err != nil || !ok {
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+		r, err := e.sortedRow()
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, r)
+	}
+	return rows, nil
+}
+
+// writeGroupKey writes a representation of v to b that is unique to its type and
+// value.
+func writeGroupKey(b *strings.Builder, v any) {
+	b.WriteByte(byte(valueType(v)))
+	if v != nil {
+		b.WriteString(formatValue(v))
+	}
+	b.WriteByte(0)
+}
@@ -0,0 +1,710 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package sql
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// expr is a node in the tree of a parsed expression.
+type expr interface{ expr() }
+
+type (
+	literal struct {
+		v any
+		t Type
+	}
+	// columnRef is a reference to a column by name. Column references are replaced
+	// with boundColumns when a query is planned.
+	columnRef struct{ name string }
+	paramRef  struct{ i int }
+	unaryExpr struct {
+		op string
+		x  expr
+	}
+	binaryExpr struct {
+		op   string
+		l, r expr
+		// re is the compiled pattern of a LIKE or ILIKE expression whose pattern is
+		// constant.
+		re *regexp.Regexp
+	}
+	betweenExpr struct {
+		x, lo, hi expr
+		not       bool
+	}
+	inExpr struct {
+		x    expr
+		list []expr
+		not  bool
+	}
+	isNullExpr struct {
+		x   expr
+		not bool
+	}
+	// anyExpr compares a value with each element of an array.
+	anyExpr struct {
+		op   string
+		l, r expr
+	}
+	callExpr struct {
+		name string
+		args []expr
+		// star is true for calls such as count(*).
+		star bool
+	}
+	castExpr struct {
+		x expr
+		t Type
+	}
+	// boundColumn is a reference to the column at position i of a table.
+	boundColumn struct {
+		name string
+		i    int
+		t    Type
+	}
+	// aggRef is a reference to the result of the aggregate at position i of a query.
+	aggRef struct {
+		i int
+		t Type
+	}
+	// groupRef is a reference to the value of the GROUP BY expression at position i of
+	// a query.
+	groupRef struct {
+		i int
+		t Type
+	}
+)
+
+func (*literal) expr()     {}
+func (*columnRef) expr()   {}
+func (*paramRef) expr()    {}
+func (*unaryExpr) expr()   {}
+func (*binaryExpr) expr()  {}
+func (*betweenExpr) expr() {}
+func (*inExpr) expr()      {}
+func (*isNullExpr) expr()  {}
+func (*anyExpr) expr()     {}
+func (*callExpr) expr()    {}
+func (*castExpr) expr()    {}
+func (*boundColumn) expr() {}
+func (*aggRef) expr()      {}
+func (*groupRef) expr()    {}
+
+// rewrite calls f on e and, if f doesn't handle it, on each of e's children, returning
+// a copy of e with the nodes replaced by f.
+func rewrite(e expr, f func(expr) (expr, bool, error)) (expr, error) {
+	if e == nil {
+		return nil, nil
+	}
+	if out, handled, err := f(e); err != nil || handled {
+		return out, err
+	}
+	var err error
+	each := func(es ...*expr) error {
+		for _, c := range es {
+			if *c, err = rewrite(*c, f); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	switch n := e.(type) {
+	case *unaryExpr:
+		c := *n
+		return &c, each(&c.x)
+	case *binaryExpr:
+		c := *n
+		return &c, each(&c.l, &c.r)
+	case *betweenExpr:
+		c := *n
+		return &c, each(&c.x, &c.lo, &c.hi)
+	case *inExpr:
+		c := *n
+		c.list = append([]expr(nil), n.list...)
+		if err = each(&c.x); err != nil {
+			return nil, err
+		}
+		for i := range c.list {
+			if err = each(&c.list[i]); err != nil {
+				return nil, err
+			}
+		}
+		return &c, nil
+	case *isNullExpr:
+		c := *n
+		return &c, each(&c.x)
+	case *anyExpr:
+		c := *n
+		return &c, each(&c.l, &c.r)
+	case *callExpr:
+		c := *n
+		c.args = append([]expr(nil), n.args...)
+		for i := range c.args {
+			if err = each(&c.args[i]); err != nil {
+				return nil, err
+			}
+		}
+		return &c, nil
+	case *castExpr:
+		c := *n
+		return &c, each(&c.x)
+	}
+	return e, nil
+}
+
+// walk calls f on e and each of its descendants.
+func walk(e expr, f func(expr)) {
+	_, _ = rewrite(e, func(n expr) (expr, bool, error) {
+		f(n)
+		return n, false, nil
+	})
+}
+
+// isConst returns true if e doesn't depend on the values of a row.
+func isConst(e expr) bool {
+	c := true
+	walk(e, func(n expr) {
+		switch n.(type) {
+		case *columnRef, *boundColumn, *aggRef, *groupRef:
+			c = false
+		}
+	})
+	return c
+}
+
+// env is the environment an expression is evaluated in.
+type env struct {
+	row    Row
+	params []any
+	aggs   []any
+	groups []any
+	now    telem.TimeStamp
+}
+
+// typeOf returns the type of the values e evaluates to, using params as the types of
+// the query's parameters.
+func typeOf(e expr, params []Type) Type {
+	switch n := e.(type) {
+	case *literal:
+		return n.t
+	case *paramRef:
+		if n.i < len(params) {
+			return params[n.i]
+		}
+	case *boundColumn:
+		return n.t
+	case *aggRef:
+		return n.t
+	case *groupRef:
+		return n.t
+	case *castExpr:
+		return n.t
+	case *unaryExpr:
+		if n.op == "not" {
+			return TypeBool
+		}
+		return typeOf(n.x, params)
+	case *betweenExpr, *inExpr, *isNullExpr, *anyExpr:
+		return TypeBool
+	case *binaryExpr:
+		switch n.op {
+		case "||":
+			return TypeText
+		case "+", "-", "*", "/", "%":
+			return arithType(n.op, typeOf(n.l, params), typeOf(n.r, params))
+		}
+		return TypeBool
+	case *callExpr:
+		return callType(n, params)
+	}
+	return TypeUnknown
+}
+
+func isNumeric(t Type) bool { return t == TypeInt || t == TypeNumeric || t == TypeFloat }
+
+func arithType(op string, l, r Type) Type {
+	switch {
+	case l == TypeTimestamp && r == TypeTimestamp && op == "-":
+		return TypeInterval
+	case l == TypeTimestamp || r == TypeTimestamp:
+		return TypeTimestamp
+	case l == TypeInterval || r == TypeInterval:
+		return TypeInterval
+	case l == TypeInt && r == TypeInt:
+		return TypeInt
+	case l == TypeNumeric && r == TypeNumeric:
+		return TypeNumeric
+	case l == TypeUnknown && isNumeric(r):
+		return r
+	case r == TypeUnknown && isNumeric(l):
+		return l
+	}
+	return TypeFloat
+}
+
+func callType(c *callExpr, params []Type) Type {
+	arg := func(i int) Type {
+		if i < len(c.args) {
+			return typeOf(c.args[i], params)
+		}
+		return TypeUnknown
+	}
+	switch c.name {
+	case "now", "current_timestamp", "time_bucket", "date_bin", "date_trunc", "to_timestamp":
+		return TypeTimestamp
+	case "version", "current_database", "current_schema", "lower", "upper":
+		return TypeText
+	case "length", "count":
+		return TypeInt
+	case "round", "floor", "ceil", "ceiling", "avg":
+		return TypeFloat
+	case "abs", "min", "max", "first", "last":
+		return arg(0)
+	case "sum":
+		if t := arg(0); t == TypeInt || t == TypeNumeric || t == TypeInterval {
+			return t
+		}
+		return TypeFloat
+	case "coalesce":
+		for i := range c.args {
+			if t := arg(i); t != TypeUnknown {
+				return t
+			}
+		}
+	}
+	return TypeUnknown
+}
+
+func eval(e expr, en *env) (any, error) {
+	switch n := e.(type) {
+	case *literal:
+		return n.v, nil
+	case *paramRef:
+		return en.params[n.i], nil
+	case *boundColumn:
+		return en.row[n.i], nil
+	case *aggRef:
+		return en.aggs[n.i], nil
+	case *groupRef:
+		return en.groups[n.i], nil
+	case *castExpr:
+		v, err := eval(n.x, en)
+		if err != nil {
+			return nil, err
+		}
+		return coerce(v, n.t)
+	case *unaryExpr:
+		v, err := eval(n.x, en)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		if n.op == "not" {
+			b, err := toBool(v)
+			return !b, err
+		}
+		return negate(v)
+	case *binaryExpr:
+		return evalBinary(n, en)
+	case *betweenExpr:
+		v, err := eval(n.x, en)
+		if err != nil {
+			return nil, err
+		}
+		lo, err := eval(n.lo, en)
+		if err != nil {
+			return nil, err
+		}
+		hi, err := eval(n.hi, en)
+		if err != nil || v == nil || lo == nil || hi == nil {
+			return nil, err
+		}
+		cl, err := compare(v, lo)
+		if err != nil {
+			return nil, err
+		}
+		ch, err := compare(v, hi)
+		if err != nil {
+			return nil, err
+		}
+		return (cl >= 0 && ch <= 0) != n.not, nil
+	case *inExpr:
+		v, err := eval(n.x, en)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		sawNull := false
+		for _, item := range n.list {
+			iv, err := eval(item, en)
+			if err != nil {
+				return nil, err
+			}
+			if iv == nil {
+				sawNull = true
+				continue
+			}
+			c, err := compare(v, iv)
+			if err != nil {
+				return nil, err
+			}
+			if c == 0 {
+				return !n.not, nil
+			}
+		}
+		if sawNull {
+			return nil, nil
+		}
+		return n.not, nil
+	case *isNullExpr:
+		v, err := eval(n.x, en)
+		if err != nil {
+			return nil, err
+		}
+		return (v == nil) != n.not, nil
+	case *anyExpr:
+		l, err := eval(n.l, en)
+		if err != nil {
+			return nil, err
+		}
+		r, err := eval(n.r, en)
+		if err != nil || l == nil || r == nil {
+			return nil, err
+		}
+		arr, ok := r.([]string)
+		if !ok {
+			return nil, errors.Wrap(UnsupportedError, "ANY requires an array")
+		}
+		for _, item := range arr {
+			c, err := compare(l, item)
+			if err != nil {
+				return nil, err
+			}
+			if compareResult(n.op, c) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case *callExpr:
+		return evalCall(n, en)
+	}
+	return nil, errors.Newf("unexpected expression %T", e)
+}
+
+func evalBinary(n *binaryExpr, en *env) (any, error) {
+	l, err := eval(n.l, en)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "and", "or":
+		return evalLogical(n, l, en)
+	}
+	r, err := eval(n.r, en)
+	if err != nil || l == nil || r == nil {
+		return nil, err
+	}
+	switch n.op {
+	case "=", "<>", "<", "<=", ">", ">=":
+		c, err := compare(l, r)
+		if err != nil {
+			return nil, err
+		}
+		return compareResult(n.op, c), nil
+	case "like", "ilike":
+		re := n.re
+		if re == nil {
+			if re, err = likeRegexp(formatValue(r), n.op == "ilike"); err != nil {
+				return nil, err
+			}
+		}
+		return re.MatchString(formatValue(l)), nil
+	case "||":
+		return formatValue(l) + formatValue(r), nil
+	}
+	return arith(n.op, l, r)
+}
+
+// evalLogical evaluates AND and OR using three-valued logic, only evaluating the right
+// operand when the left doesn't decide the result.
+func evalLogical(n *binaryExpr, l any, en *env) (any, error) {
+	var (
+		lb, rb bool
+		err    error
+	)
+	if l != nil {
+		if lb, err = toBool(l); err != nil {
+			return nil, err
+		}
+		if lb == (n.op == "or") {
+			return lb, nil
+		}
+	}
+	r, err := eval(n.r, en)
+	if err != nil {
+		return nil, err
+	}
+	if r != nil {
+		if rb, err = toBool(r); err != nil {
+			return nil, err
+		}
+		if rb == (n.op == "or") {
+			return rb, nil
+		}
+	}
+	if l == nil || r == nil {
+		return nil, nil
+	}
+	return rb, nil
+}
+
+func compareResult(op string, c int) bool {
+	switch op {
+	case "=":
+		return c == 0
+	case "<>":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+// likeRegexp converts a LIKE pattern into a regular expression.
+func likeRegexp(pattern string, insensitive bool) (*regexp.Regexp, error) {
+	var b strings.Builder
+	if insensitive {
+		b.WriteString("(?i)")
+	}
+	b.WriteString("(?s)^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+func negate(v any) (any, error) {
+	switch x := v.(type) {
+	case int64:
+		return -x, nil
+	case uint64:
+		return -float64(x), nil
+	case float64:
+		return -x, nil
+	case telem.TimeSpan:
+		return -x, nil
+	case string:
+		f, err := coerce(x, TypeFloat)
+		if err != nil {
+			return nil, err
+		}
+		return -f.(float64), nil
+	}
+	return nil, errors.Wrapf(validate.Error, "operator does not exist: -%s", typeName(v))
+}
+
+func arith(op string, l, r any) (any, error) {
+	// Coerce untyped strings to the type implied by the other operand.
+	if s, ok := l.(string); ok {
+		var err error
+		if l, err = coerceOperand(op, s, r, true); err != nil {
+			return nil, err
+		}
+	}
+	if s, ok := r.(string); ok {
+		var err error
+		if r, err = coerceOperand(op, s, l, false); err != nil {
+			return nil, err
+		}
+	}
+	switch lv := l.(type) {
+	case telem.TimeStamp:
+		switch rv := r.(type) {
+		case telem.TimeSpan:
+			switch op {
+			case "+":
+				return lv.Add(rv), nil
+			case "-":
+				return lv.Sub(rv), nil
+			}
+		case telem.TimeStamp:
+			if op == "-" {
+				return telem.TimeSpan(lv - rv), nil
+			}
+		}
+	case telem.TimeSpan:
+		switch rv := r.(type) {
+		case telem.TimeStamp:
+			if op == "+" {
+				return rv.Add(lv), nil
+			}
+		case telem.TimeSpan:
+			switch op {
+			case "+":
+				return lv + rv, nil
+			case "-":
+				return lv - rv, nil
+			}
+		default:
+			if f, ok := toFloat(r); ok {
+				switch op {
+				case "*":
+					return telem.TimeSpan(float64(lv) * f), nil
+				case "/":
+					if f == 0 {
+						return nil, divisionByZero
+					}
+					return telem.TimeSpan(float64(lv) / f), nil
+				}
+			}
+		}
+	default:
+		if ts, ok := r.(telem.TimeSpan); ok && op == "*" {
+			if f, ok := toFloat(l); ok {
+				return telem.TimeSpan(f * float64(ts)), nil
+			}
+		}
+		if li, ok := l.(int64); ok {
+			if ri, ok := r.(int64); ok {
+				return intArith(op, li, ri)
+			}
+		}
+		if lu, ok := l.(uint64); ok {
+			if ru, ok := r.(uint64); ok && op != "-" {
+				return uintArith(op, lu, ru)
+			}
+		}
+		lf, lok := toFloat(l)
+		rf, rok := toFloat(r)
+		if lok && rok {
+			return floatArith(op, lf, rf)
+		}
+	}
+	return nil, errors.Wrapf(validate.Error, "operator does not exist: %s %s %s", typeName(l), op, typeName(r))
+}
+
+// coerceOperand coerces a string operand of an arithmetic operator to the type implied
+// by the other operand.
+func coerceOperand(op string, s string, other any, left bool) (any, error) {
+	switch other.(type) {
+	case telem.TimeStamp:
+		// A string subtracted from a timestamp may be either a timestamp or an
+		// interval.
+		if op == "-" && !left {
+			if v, err := coerce(s, TypeInterval); err == nil {
+				return v, nil
+			}
+			return coerce(s, TypeTimestamp)
+		}
+		if op == "+" {
+			return coerce(s, TypeInterval)
+		}
+		return coerce(s, TypeTimestamp)
+	case telem.TimeSpan:
+		if op == "*" || op == "/" {
+			return coerce(s, TypeFloat)
+		}
+		if v, err := coerce(s, TypeInterval); err == nil {
+			return v, nil
+		}
+		return coerce(s, TypeTimestamp)
+	case int64:
+		if v, err := coerce(s, TypeInt); err == nil {
+			return v, nil
+		}
+	}
+	return coerce(s, TypeFloat)
+}
+
+var divisionByZero = errors.Wrap(validate.Error, "division by zero")
+
+func intArith(op string, l, r int64) (any, error) {
+	switch op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, divisionByZero
+		}
+		return l / r, nil
+	default:
+		if r == 0 {
+			return nil, divisionByZero
+		}
+		return l % r, nil
+	}
+}
+
+func uintArith(op string, l, r uint64) (any, error) {
+	switch op {
+	case "+":
+		return l + r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, divisionByZero
+		}
+		return l / r, nil
+	default:
+		if r == 0 {
+			return nil, divisionByZero
+		}
+		return l % r, nil
+	}
+}
+
+func floatArith(op string, l, r float64) (any, error) {
+	switch op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, divisionByZero
+		}
+		return l / r, nil
+	default:
+		if r == 0 {
+			return nil, divisionByZero
+		}
+		return math.Mod(l, r), nil
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package sql
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// ServerVersion is the PostgreSQL version reported to clients.
+const ServerVersion = "15.0"
+
+// arity is the minimum and maximum number of arguments of a function.
+type arity struct{ min, max int }
+
+// functions are the arities of the supported scalar functions.
+var functions = map[string]arity{
+	"now":               {0, 0},
+	"current_timestamp": {0, 0},
+	"version":           {0, 0},
+	"current_database":  {0, 0},
+	"current_schema":    {0, 0},
+	"time_bucket":       {2, 2},
+	"date_bin":          {2, 3},
+	"date_trunc":        {2, 2},
+	"to_timestamp":      {1, 1},
+	"abs":               {1, 1},
+	"round":             {1, 2},
+	"floor":             {1, 1},
+	"ceil":              {1, 1},
+	"ceiling":           {1, 1},
+	"lower":             {1, 1},
+	"upper":             {1, 1},
+	"length":            {1, 1},
+	"coalesce":          {1, math.MaxInt},
+}
+
+func checkCall(c *callExpr) error {
+	if _, ok := aggregates[c.name]; ok {
+		if c.star && c.name != "count" {
+			return errors.Wrapf(validate.Error, "%s(*) is not a valid aggregate", c.name)
+		}
+		if !c.star && len(c.args) != 1 {
+			return errors.Wrapf(validate.Error, "%s takes exactly one argument", c.name)
+		}
+		return nil
+	}
+	a, ok := functions[c.name]
+	if !ok {
+		return errors.Wrapf(UnsupportedError, "function %s does not exist", c.name)
+	}
+	if c.star || len(c.args) < a.min || len(c.args) > a.max {
+		return errors.Wrapf(validate.Error, "wrong number of arguments to %s", c.name)
+	}
+	return nil
+}
+
+func evalCall(c *callExpr, en *env) (any, error) {
+	if c.name == "coalesce" {
+		for _, a := range c.args {
+			v, err := eval(a, en)
+			if err != nil || v != nil {
+				return v, err
+			}
+		}
+		return nil, nil
+	}
+	args := make([]any, len(c.args))
+	for i, a := range c.args {
+		v, err := eval(a, en)
+		if err != nil || v == nil {
+			// Every other function returns NULL if any of its arguments are NULL.
+			return nil, err
+		}
+		args[i] = v
+	}
+	switch c.name {
+	case "now", "current_timestamp":
+		return en.now, nil
+	case "version":
+		return "PostgreSQL " + ServerVersion + " (Synnax)", nil
+	case "current_database":
+		return "synnax", nil
+	case "current_schema":
+		return "public", nil
+	case "time_bucket":
+		return bin(args[0], args[1], telem.TimeStamp(0))
+	case "date_bin":
+		var origin any = telem.TimeStamp(0)
+		if len(args) == 3 {
+			origin = args[2]
+		}
+		return bin(args[0], args[1], origin)
+	case "date_trunc":
+		return dateTrunc(formatValue(args[0]), args[1])
+	case "to_timestamp":
+		f, err := coerce(args[0], TypeFloat)
+		if err != nil {
+			return nil, err
+		}
+		return telem.TimeStamp(f.(float64) * float64(telem.Second)), nil
+	case "abs":
+		switch x := args[0].(type) {
+		case int64:
+			if x < 0 {
+				return -x, nil
+			}
+			return x, nil
+		case telem.TimeSpan:
+			if x < 0 {
+				return -x, nil
+			}
+			return x, nil
+		case uint64:
+			return x, nil
+		}
+		f, err := coerce(args[0], TypeFloat)
+		if err != nil {
+			return nil, err
+		}
+		return math.Abs(f.(float64)), nil
+	case "round", "floor", "ceil", "ceiling":
+		f, err := coerce(args[0], TypeFloat)
+		if err != nil {
+			return nil, err
+		}
+		x := f.(float64)
+		switch c.name {
+		case "floor":
+			return math.Floor(x), nil
+		case "ceil", "ceiling":
+			return math.Ceil(x), nil
+		}
+		scale := 1.0
+		if len(args) == 2 {
+			d, err := coerce(args[1], TypeInt)
+			if err != nil {
+				return nil, err
+			}
+			scale = math.Pow(10, float64(d.(int64)))
+		}
+		return math.Round(x*scale) / scale, nil
+	case "lower":
+		return strings.ToLower(formatValue(args[0])), nil
+	case "upper":
+		return strings.ToUpper(formatValue(args[0])), nil
+	case "length":
+		return int64(utf8.RuneCountInString(formatValue(args[0]))), nil
+	}
+	return nil, errors.Wrapf(UnsupportedError, "function %s does not exist", c.name)
+}
+
+// bin returns the start of the bin of the given stride that the timestamp falls into,
+// with bins aligned to origin.
+func bin(stride, ts, origin any) (any, error) {
+	s, err := coerce(stride, TypeInterval)
+	if err != nil {
+		return nil, err
+	}
+	t, err := coerce(ts, TypeTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	o, err := coerce(origin, TypeTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	span, stamp, start := s.(telem.TimeSpan), t.(telem.TimeStamp), o.(telem.TimeStamp)
+	if span <= 0 {
+		return nil, errors.Wrap(validate.Error, "stride must be greater than zero")
+	}
+	offset := int64(stamp - start)
+	n := offset / int64(span)
+	if offset%int64(span) < 0 {
+		n--
+	}
+	return start + telem.TimeStamp(n*int64(span)), nil
+}
+
+// dateTrunc truncates a timestamp to the given unit in UTC.
+func dateTrunc(unit string, ts any) (any, error) {
+	v, err := coerce(ts, TypeTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	t := v.(telem.TimeStamp).Time().UTC()
+	var out time.Time
+	switch strings.ToLower(unit) {
+	case "microseconds":
+		out = t.Truncate(time.Microsecond)
+	case "milliseconds":
+		out = t.Truncate(time.Millisecond)
+	case "second":
+		out = t.Truncate(time.Second)
+	case "minute":
+		out = t.Truncate(time.Minute)
+	case "hour":
+		out = t.Truncate(time.Hour)
+	case "day":
+		out = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	case "week":
+		d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		out = d.AddDate(0, 0, -((int(d.Weekday()) + 6) % 7))
+	case "month":
+		out = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case "year":
+		out = time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return nil, errors.Wrapf(validate.Error, "unit %q not recognized", unit)
+	}
+	return telem.NewTimeStamp(out), nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package sql
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/synnaxlabs/x/errors"
+)
+
+type tokenKind uint8
+
+const (
+	tokenEOF tokenKind = iota
+	// tokenIdent is an unquoted identifier or keyword. Its value is lower case.
+	tokenIdent
+	// tokenQuotedIdent is a double-quoted identifier. Its value preserves case.
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenParam
+	tokenOp
+)
+
+type token struct {
+	kind tokenKind
+	val  string
+	pos  int
+}
+
+// is returns true if the token is the given operator or unquoted keyword.
+func (t token) is(val string) bool {
+	return (t.kind == tokenOp || t.kind == tokenIdent) && t.val == val
+}
+
+// operators are the operators recognized by the lexer, longest first.
+var operators = []string{"::", "<>", "!=", "<=", ">=", "||", "=", "<", ">", "+", "-", "*", "/", "%", "(", ")", ",", ".", ";"}
+
+// lex splits a query into tokens.
+func lex(text string) ([]token, error) {
+	var (
+		tokens []token
+		i      int
+	)
+	for i < len(text) {
+		c := rune(text[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case strings.HasPrefix(text[i:], "--"):
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				return nil, syntaxErrorf(i, "unterminated comment")
+			}
+			i += end + 4
+		case c == '\'':
+			s, n, err := lexQuoted(text, i, '\'')
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, val: s, pos: i})
+			i += n
+		case c == '"':
+			s, n, err := lexQuoted(text, i, '"')
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenQuotedIdent, val: s, pos: i})
+			i += n
+		case c == '$' && i+1 < len(text) && isDigit(text[i+1]):
+			start := i
+			for i++; i < len(text) && isDigit(text[i]); i++ {
+			}
+			tokens = append(tokens, token{kind: tokenParam, val: text[start+1 : i], pos: start})
+		case isDigit(text[i]) || (c == '.' && i+1 < len(text) && isDigit(text[i+1])):
+			start := i
+			for i < len(text) && (isDigit(text[i]) || text[i] == '.') {
+				i++
+			}
+			if i < len(text) && (text[i] == 'e' || text[i] == 'E') {
+				i++
+				if i < len(text) && (text[i] == '+' || text[i] == '-') {
+					i++
+				}
+				for i < len(text) && isDigit(text[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, val: text[start:i], pos: start})
+		case c == '_' || c >= 0x80 || unicode.IsLetter(c):
+			start := i
+			for i < len(text) && (text[i] == '_' || text[i] == '$' || isDigit(text[i]) || unicode.IsLetter(rune(text[i])) || text[i] >= 0x80) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, val: strings.ToLower(text[start:i]), pos: start})
+		default:
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(text[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, syntaxErrorf(i, "unexpected character %q", c)
+			}
+			tokens = append(tokens, token{kind: tokenOp, val: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(text)}), nil
+}
+
+// lexQuoted reads a string or identifier quoted with q starting at text[start],
+// returning its unescaped value and the number of bytes consumed. Quotes are escaped by
+// doubling them.
+func lexQuoted(text string, start int, q byte) (string, int, error) {
+	var b strings.Builder
+	for i := start + 1; i < len(text); i++ {
+		if text[i] != q {
+			b.WriteByte(text[i])
+			continue
+		}
+		if i+1 < len(text) && text[i+1] == q {
+			b.WriteByte(q)
+			i++
+			continue
+		}
+		return b.String(), i - start + 1, nil
+	}
+	if q == '"' {
+		return "", 0, syntaxErrorf(start, "unterminated quoted identifier")
+	}
+	return "", 0, syntaxErrorf(start, "unterminated string")
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func syntaxErrorf(pos int, format string, args ...any) error {
+	return errors.Wrapf(SyntaxError, "at position %d: "+format, append([]any{pos}, args...)...)
+}
@@ -0,0 +1,745 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package sql
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/synnaxlabs/x/errors"
+)
+
+type statement interface{ statement() }
+
+// selectStmt is a parsed SELECT statement.
+type selectStmt struct {
+	items []selectItem
+	// table is the name of the table in the FROM clause, or empty if there is no FROM
+	// clause.
+	table   string
+	where   expr
+	groupBy []expr
+	having  expr
+	orderBy []orderItem
+	limit   expr
+	offset  expr
+	// params is the number of parameters referenced by the statement.
+	params int
+}
+
+// selectItem is an item in the select list of a SELECT statement.
+type selectItem struct {
+	e     expr
+	alias string
+	// star is true if the item is a * that expands to every column of the table.
+	star bool
+}
+
+type orderItem struct {
+	e          expr
+	desc       bool
+	nullsFirst bool
+}
+
+// commandStmt is a statement that is accepted for compatibility with clients but has
+// no effect, such as SET or BEGIN.
+type commandStmt struct{ tag string }
+
+func (*selectStmt) statement()  {}
+func (*commandStmt) statement() {}
+
+// reserved are the keywords that can't be used as unquoted column names or aliases.
+var reserved = map[string]bool{
+	"select": true, "from": true, "where": true, "group": true, "by": true,
+	"order": true, "limit": true, "offset": true, "and": true, "or": true, "not": true,
+	"as": true, "having": true, "between": true, "in": true, "is": true, "null": true,
+	"true": true, "false": true, "like": true, "ilike": true, "asc": true, "desc": true,
+	"cast": true, "any": true, "union": true, "join": true, "on": true,
+}
+
+// writeStatements are the keywords that start statements that would modify data.
+var writeStatements = map[string]bool{
+	"insert": true, "update": true, "delete": true, "create": true, "drop": true,
+	"alter": true, "truncate": true, "copy": true, "grant": true, "revoke": true,
+	"merge": true, "upsert": true,
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	params int
+}
+
+// parse parses the semicolon separated statements in text.
+func parse(text string) ([]statement, error) {
+	tokens, err := lex(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	var stmts []statement
+	for {
+		for p.peek().is(";") {
+			p.next()
+		}
+		if p.peek().kind == tokenEOF {
+			return stmts, nil
+		}
+		p.params = 0
+		stmt, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, stmt)
+		if t := p.peek(); t.kind != tokenEOF && !t.is(";") {
+			return nil, p.unexpected(t)
+		}
+	}
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token and returns true if it is the given operator or
+// keyword.
+func (p *parser) accept(val string) bool {
+	if p.peek().is(val) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(val string) error {
+	if t := p.next(); !t.is(val) {
+		return p.unexpected(t)
+	}
+	return nil
+}
+
+func (p *parser) unexpected(t token) error {
+	if t.kind == tokenEOF {
+		return syntaxErrorf(t.pos, "unexpected end of query")
+	}
+	return syntaxErrorf(t.pos, "unexpected %q", t.val)
+}
+
+// skipStatement consumes tokens until the end of the current statement.
+func (p *parser) skipStatement() {
+	for t := p.peek(); t.kind != tokenEOF && !t.is(";"); t = p.peek() {
+		p.next()
+	}
+}
+
+func (p *parser) parseStatement() (statement, error) {
+	t := p.peek()
+	if t.kind != tokenIdent {
+		return nil, p.unexpected(t)
+	}
+	switch t.val {
+	case "select":
+		return p.parseSelect()
+	case "set":
+		p.skipStatement()
+		return &commandStmt{tag: "SET"}, nil
+	case "begin", "start":
+		p.skipStatement()
+		return &commandStmt{tag: "BEGIN"}, nil
+	case "commit", "end":
+		p.skipStatement()
+		return &commandStmt{tag: "COMMIT"}, nil
+	case "rollback", "abort":
+		p.skipStatement()
+		return &commandStmt{tag: "ROLLBACK"}, nil
+	}
+	if writeStatements[t.val] {
+		return nil, errors.Wrapf(
+			UnsupportedError,
+			"%s statements are not supported, as the database is read-only",
+			strings.ToUpper(t.val),
+		)
+	}
+	return nil, errors.Wrapf(UnsupportedError, "%s statements are not supported", strings.ToUpper(t.val))
+}
+
+func (p *parser) parseSelect() (*selectStmt, error) {
+	p.next()
+	if p.peek().is("distinct") {
+		return nil, errors.Wrap(UnsupportedError, "SELECT DISTINCT is not supported")
+	}
+	p.accept("all")
+	s := &selectStmt{}
+	for {
+		item, err := p.parseSelectItem()
+		if err != nil {
+			return nil, err
+		}
+		s.items = append(s.items, item)
+		if !p.accept(",") {
+			break
+		}
+	}
+	if p.accept("from") {
+		name, err := p.parseTableName()
+		if err != nil {
+			return nil, err
+		}
+		s.table = name
+		// Skip an optional table alias. Columns qualified with the alias are accepted,
+		// as there is only ever one table.
+		if p.accept("as") || (p.peek().kind == tokenIdent && !reserved[p.peek().val]) || p.peek().kind == tokenQuotedIdent {
+			if _, err = p.parseIdent(); err != nil {
+				return nil, err
+			}
+		}
+		if p.peek().is(",") || p.peek().is("join") {
+			return nil, errors.Wrap(UnsupportedError, "queries can only read from a single table")
+		}
+	}
+	var err error
+	if p.accept("where") {
+		if s.where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept("group") {
+		if err = p.expect("by"); err != nil {
+			return nil, err
+		}
+		if s.groupBy, err = p.parseExprList(); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept("having") {
+		if s.having, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept("order") {
+		if err = p.expect("by"); err != nil {
+			return nil, err
+		}
+		if s.orderBy, err = p.parseOrderBy(); err != nil {
+			return nil, err
+		}
+	}
+	for {
+		if p.accept("limit") {
+			if p.accept("all") {
+				continue
+			}
+			if s.limit, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+		} else if p.accept("offset") {
+			if s.offset, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+			if !p.accept("rows") {
+				p.accept("row")
+			}
+		} else {
+			break
+		}
+	}
+	if p.peek().is("union") {
+		return nil, errors.Wrap(UnsupportedError, "UNION is not supported")
+	}
+	s.params = p.params
+	return s, nil
+}
+
+func (p *parser) parseSelectItem() (selectItem, error) {
+	if p.accept("*") {
+		return selectItem{star: true}, nil
+	}
+	// A qualified star such as t.* is equivalent to *.
+	if (p.peek().kind == tokenIdent || p.peek().kind == tokenQuotedIdent) &&
+		p.peekAt(1).is(".") && p.peekAt(2).is("*") {
+		p.pos += 3
+		return selectItem{star: true}, nil
+	}
+	e, err := p.parseExpr()
+	if err != nil {
+		return selectItem{}, err
+	}
+	item := selectItem{e: e}
+	if p.accept("as") || p.peek().kind == tokenQuotedIdent ||
+		(p.peek().kind == tokenIdent && !reserved[p.peek().val]) {
+		if item.alias, err = p.parseIdent(); err != nil {
+			return selectItem{}, err
+		}
+	}
+	return item, nil
+}
+
+func (p *parser) parseOrderBy() ([]orderItem, error) {
+	var items []orderItem
+	for {
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		item := orderItem{e: e}
+		if p.accept("desc") {
+			item.desc = true
+		} else {
+			p.accept("asc")
+		}
+		// PostgreSQL sorts nulls as larger than any other value by default.
+		item.nullsFirst = item.desc
+		if p.accept("nulls") {
+			if p.accept("first") {
+				item.nullsFirst = true
+			} else if p.accept("last") {
+				item.nullsFirst = false
+			} else {
+				return nil, p.unexpected(p.peek())
+			}
+		}
+		items = append(items, item)
+		if !p.accept(",") {
+			return items, nil
+		}
+	}
+}
+
+func (p *parser) parseExprList() ([]expr, error) {
+	var list []expr
+	for {
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+		if !p.accept(",") {
+			return list, nil
+		}
+	}
+}
+
+// parseTableName parses a possibly schema qualified table name, returning the name
+// without its schema.
+func (p *parser) parseTableName() (string, error) {
+	name, err := p.parseIdent()
+	if err != nil {
+		return "", err
+	}
+	for p.accept(".") {
+		if name, err = p.parseIdent(); err != nil {
+			return "", err
+		}
+	}
+	return name, nil
+}
+
+func (p *parser) parseIdent() (string, error) {
+	t := p.next()
+	if t.kind == tokenQuotedIdent || (t.kind == tokenIdent && !reserved[t.val]) {
+		return t.val, nil
+	}
+	return "", p.unexpected(t)
+}
+
+func (p *parser) parseExpr() (expr, error) { return p.parseOr() }
+
+func (p *parser) parseOr() (expr, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("or") {
+		r, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: "or", l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	l, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("and") {
+		r, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: "and", l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) parseNot() (expr, error) {
+	if p.accept("not") {
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: "not", x: x}, nil
+	}
+	return p.parseComparison()
+}
+
+var comparisonOps = map[string]bool{"=": true, "<>": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true}
+
+func (p *parser) parseComparison() (expr, error) {
+	l, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	if t.kind == tokenOp && comparisonOps[t.val] {
+		p.next()
+		op := t.val
+		if op == "!=" {
+			op = "<>"
+		}
+		if p.accept("any") {
+			if err = p.expect("("); err != nil {
+				return nil, err
+			}
+			r, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			return &anyExpr{op: op, l: l, r: r}, p.expect(")")
+		}
+		r, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &binaryExpr{op: op, l: l, r: r}, nil
+	}
+	not := false
+	if t.is("is") {
+		p.next()
+		not = p.accept("not")
+		if err = p.expect("null"); err != nil {
+			return nil, err
+		}
+		return &isNullExpr{x: l, not: not}, nil
+	}
+	if t.is("not") {
+		if next := p.peekAt(1); next.is("between") || next.is("in") || next.is("like") || next.is("ilike") {
+			p.next()
+			not = true
+		}
+	}
+	switch {
+	case p.accept("between"):
+		lo, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect("and"); err != nil {
+			return nil, err
+		}
+		hi, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &betweenExpr{x: l, lo: lo, hi: hi, not: not}, nil
+	case p.accept("in"):
+		if err = p.expect("("); err != nil {
+			return nil, err
+		}
+		list, err := p.parseExprList()
+		if err != nil {
+			return nil, err
+		}
+		return &inExpr{x: l, list: list, not: not}, p.expect(")")
+	case p.peek().is("like") || p.peek().is("ilike"):
+		op := p.next().val
+		r, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		var e expr = &binaryExpr{op: op, l: l, r: r}
+		if not {
+			e = &unaryExpr{op: "not", x: e}
+		}
+		return e, nil
+	}
+	return l, nil
+}
+
+func (p *parser) parseAdditive() (expr, error) {
+	l, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t.is("+") || t.is("-") || t.is("||"); t = p.peek() {
+		p.next()
+		r, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: t.val, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) parseMultiplicative() (expr, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t.is("*") || t.is("/") || t.is("%"); t = p.peek() {
+		p.next()
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: t.val, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if p.accept("-") {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if lit, ok := x.(*literal); ok {
+			switch v := lit.v.(type) {
+			case int64:
+				return &literal{v: -v, t: TypeInt}, nil
+			case float64:
+				return &literal{v: -v, t: TypeFloat}, nil
+			}
+		}
+		return &unaryExpr{op: "-", x: x}, nil
+	}
+	p.accept("+")
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (expr, error) {
+	e, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("::") {
+		t, err := p.parseTypeName()
+		if err != nil {
+			return nil, err
+		}
+		e = &castExpr{x: e, t: t}
+	}
+	return e, nil
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		if !strings.ContainsAny(t.val, ".eE") {
+			if v, err := strconv.ParseInt(t.val, 10, 64); err == nil {
+				return &literal{v: v, t: TypeInt}, nil
+			}
+		}
+		v, err := strconv.ParseFloat(t.val, 64)
+		if err != nil {
+			return nil, syntaxErrorf(t.pos, "invalid number %q", t.val)
+		}
+		return &literal{v: v, t: TypeFloat}, nil
+	case tokenString:
+		return &literal{v: t.val, t: TypeUnknown}, nil
+	case tokenParam:
+		i, err := strconv.Atoi(t.val)
+		if err != nil || i < 1 {
+			return nil, syntaxErrorf(t.pos, "invalid parameter $%s", t.val)
+		}
+		p.params = max(p.params, i)
+		return &paramRef{i: i - 1}, nil
+	case tokenQuotedIdent:
+		return p.parseColumnRef(t.val)
+	case tokenOp:
+		if t.val == "(" {
+			if p.peek().is("select") {
+				return nil, errors.Wrap(UnsupportedError, "subqueries are not supported")
+			}
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			return e, p.expect(")")
+		}
+		return nil, p.unexpected(t)
+	case tokenIdent:
+		switch t.val {
+		case "null":
+			return &literal{v: nil, t: TypeUnknown}, nil
+		case "true", "false":
+			return &literal{v: t.val == "true", t: TypeBool}, nil
+		case "cast":
+			if err := p.expect("("); err != nil {
+				return nil, err
+			}
+			x, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expect("as"); err != nil {
+				return nil, err
+			}
+			typ, err := p.parseTypeName()
+			if err != nil {
+				return nil, err
+			}
+			return &castExpr{x: x, t: typ}, p.expect(")")
+		case "interval", "timestamp", "timestamptz", "uuid":
+			// Typed string literals such as INTERVAL '1 hour'.
+			if p.peek().kind == tokenString {
+				p.pos--
+				typ, err := p.parseTypeName()
+				if err != nil {
+					return nil, err
+				}
+				return &castExpr{x: &literal{v: p.next().val, t: TypeUnknown}, t: typ}, nil
+			}
+		}
+		if reserved[t.val] {
+			return nil, p.unexpected(t)
+		}
+		if p.peek().is("(") {
+			return p.parseCall(t.val)
+		}
+		return p.parseColumnRef(t.val)
+	}
+	return nil, p.unexpected(t)
+}
+
+// parseColumnRef parses a possibly qualified column reference whose first identifier
+// has already been consumed. Qualifiers are discarded.
+func (p *parser) parseColumnRef(name string) (expr, error) {
+	for p.accept(".") {
+		var err error
+		if name, err = p.parseIdent(); err != nil {
+			return nil, err
+		}
+	}
+	return &columnRef{name: name}, nil
+}
+
+func (p *parser) parseCall(name string) (expr, error) {
+	p.next()
+	c := &callExpr{name: name}
+	if p.accept(")") {
+		return c, nil
+	}
+	if p.peek().is("distinct") {
+		return nil, errors.Wrap(UnsupportedError, "DISTINCT aggregates are not supported")
+	}
+	if p.accept("*") {
+		c.star = true
+		return c, p.expect(")")
+	}
+	args, err := p.parseExprList()
+	if err != nil {
+		return nil, err
+	}
+	c.args = args
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+	if p.peek().is("over") || p.peek().is("filter") {
+		return nil, errors.Wrapf(UnsupportedError, "%s clauses are not supported", strings.ToUpper(p.peek().val))
+	}
+	return c, nil
+}
+
+// typeNames maps PostgreSQL type names to types.
+var typeNames = map[string]Type{
+	"bool":              TypeBool,
+	"boolean":           TypeBool,
+	"int":               TypeInt,
+	"int2":              TypeInt,
+	"int4":              TypeInt,
+	"int8":              TypeInt,
+	"integer":           TypeInt,
+	"smallint":          TypeInt,
+	"bigint":            TypeInt,
+	"numeric":           TypeNumeric,
+	"decimal":           TypeNumeric,
+	"float":             TypeFloat,
+	"float4":            TypeFloat,
+	"float8":            TypeFloat,
+	"real":              TypeFloat,
+	"double precision":  TypeFloat,
+	"text":              TypeText,
+	"varchar":           TypeText,
+	"char":              TypeText,
+	"character":         TypeText,
+	"character varying": TypeText,
+	"name":              TypeText,
+	"json":              TypeJSON,
+	"jsonb":             TypeJSON,
+	"timestamp":         TypeTimestamp,
+	"timestamptz":       TypeTimestamp,
+	"interval":          TypeInterval,
+	"uuid":              TypeUUID,
+}
+
+func (p *parser) parseTypeName() (Type, error) {
+	t := p.next()
+	if t.kind != tokenIdent {
+		return 0, p.unexpected(t)
+	}
+	name := t.val
+	if (name == "double" && p.peek().is("precision")) || (name == "character" && p.peek().is("varying")) {
+		name += " " + p.next().val
+	}
+	typ, ok := typeNames[name]
+	if !ok {
+		return 0, errors.Wrapf(UnsupportedError, "type %s is not supported", name)
+	}
+	if name == "timestamp" && (p.peek().is("with") || p.peek().is("without")) {
+		p.next()
+		if err := p.expect("time"); err != nil {
+			return 0, err
+		}
+		if err := p.expect("zone"); err != nil {
+			return 0, err
+		}
+	}
+	// Skip type modifiers such as varchar(255).
+	if p.accept("(") {
+		for !p.accept(")") {
+			if p.peek().kind == tokenEOF {
+				return 0, p.unexpected(p.peek())
+			}
+			p.next()
+		}
+	}
+	if p.peek().is("[") {
+		return 0, errors.Wrap(UnsupportedError, "array types are not supported")
+	}
+	return typ, nil
+}
@@ -0,0 +1,447 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Query is a planned statement that can be executed any number of times.
+type Query struct {
+	// tag is the command tag of a statement that has no effect, or empty for a SELECT
+	// statement.
+	tag string
+	// table is the table the query reads from, or nil if the query has no FROM clause.
+	table   table
+	columns []Column
+	// items are the expressions of the query's output columns.
+	items []expr
+	where expr
+	// aggregate is true if the query computes aggregates. The items, having, and
+	// order of an aggregate query are evaluated over groups instead of rows.
+	aggregate bool
+	groups    []expr
+	aggs      []aggregate
+	having    expr
+	order     []orderItem
+	limit     expr
+	offset    expr
+	// stream is true if the query's rows can be emitted as they are read, without
+	// materializing and sorting them.
+	stream     bool
+	paramTypes []Type
+}
+
+// Columns returns the output columns of the query.
+func (q *Query) Columns() []Column { return q.columns }
+
+// ParamTypes returns the types of the parameters of the query.
+func (q *Query) ParamTypes() []Type { return q.paramTypes }
+
+// planner binds the expressions of a SELECT statement to the columns of its table.
+type planner struct {
+	stmt    *selectStmt
+	columns []Column
+	// names are the names of the statement's output columns.
+	names []string
+	// items are the bound expressions of the statement's output columns, before
+	// aggregates are extracted.
+	items []expr
+	q     *Query
+}
+
+func (s *Service) plan(ctx context.Context, stmt statement) (*Query, error) {
+	sel, ok := stmt.(*selectStmt)
+	if !ok {
+		return &Query{tag: stmt.(*commandStmt).tag}, nil
+	}
+	p := &planner{stmt: sel, q: &Query{}}
+	if sel.table != "" {
+		t, err := s.resolveTable(ctx, sel.table)
+		if err != nil {
+			return nil, err
+		}
+		p.q.table, p.columns = t, t.columns()
+	}
+	if err := p.plan(); err != nil {
+		return nil, err
+	}
+	return p.q, nil
+}
+
+func (p *planner) plan() (err error) {
+	if err = p.bindItems(); err != nil {
+		return err
+	}
+	if p.q.where, err = p.bind(p.stmt.where); err != nil {
+		return err
+	}
+	if hasAggregate(p.q.where) {
+		return errors.Wrap(validate.Error, "aggregate functions are not allowed in WHERE")
+	}
+	for _, g := range p.stmt.groupBy {
+		bound, err := p.bindOutputRef(g)
+		if err != nil {
+			return err
+		}
+		if hasAggregate(bound) {
+			return errors.Wrap(validate.Error, "aggregate functions are not allowed in GROUP BY")
+		}
+		p.q.groups = append(p.q.groups, bound)
+	}
+	order := make([]orderItem, len(p.stmt.orderBy))
+	for i, o := range p.stmt.orderBy {
+		order[i] = o
+		if order[i].e, err = p.bindOutputRef(o.e); err != nil {
+			return err
+		}
+	}
+	having, err := p.bind(p.stmt.having)
+	if err != nil {
+		return err
+	}
+	p.q.aggregate = len(p.q.groups) > 0 || having != nil ||
+		hasAggregate(p.items...) ||
+		hasAggregate(orderExprs(order)...)
+	p.q.items = p.items
+	if p.q.aggregate {
+		if p.q.items, err = p.extractAggregates(p.items...); err != nil {
+			return err
+		}
+		if having != nil {
+			h, err := p.extractAggregates(having)
+			if err != nil {
+				return err
+			}
+			p.q.having = h[0]
+		}
+		for i := range order {
+			e, err := p.extractAggregates(order[i].e)
+			if err != nil {
+				return err
+			}
+			order[i].e = e[0]
+		}
+	}
+	p.q.order = order
+	if p.q.limit, err = p.bindConst(p.stmt.limit, "LIMIT"); err != nil {
+		return err
+	}
+	if p.q.offset, err = p.bindConst(p.stmt.offset, "OFFSET"); err != nil {
+		return err
+	}
+	p.inferParamTypes()
+	p.q.columns = make([]Column, len(p.q.items))
+	for i, item := range p.q.items {
+		t := typeOf(item, p.q.paramTypes)
+		if t == TypeUnknown {
+			t = TypeText
+		}
+		p.q.columns[i] = Column{Name: p.names[i], Type: t}
+	}
+	p.q.stream = !p.q.aggregate && (len(order) == 0 || (len(order) == 1 &&
+		!order[0].desc && p.isOrderedColumn(order[0].e)))
+	return nil
+}
+
+// isOrderedColumn returns true if e is the column that the table scans rows in
+// ascending order of.
+func (p *planner) isOrderedColumn(e expr) bool {
+	c, ok := e.(*boundColumn)
+	return ok && p.q.table != nil && c.i == p.q.table.orderedBy()
+}
+
+func (p *planner) bindItems() error {
+	for _, item := range p.stmt.items {
+		if item.star {
+			if p.q.table == nil {
+				return errors.Wrap(validate.Error, "SELECT * with no tables specified is not valid")
+			}
+			for i, c := range p.columns {
+				p.items = append(p.items, &boundColumn{name: c.Name, i: i, t: c.Type})
+				p.names = append(p.names, c.Name)
+			}
+			continue
+		}
+		bound, err := p.bind(item.e)
+		if err != nil {
+			return err
+		}
+		p.items = append(p.items, bound)
+		name := item.alias
+		if name == "" {
+			name = outputName(item.e)
+		}
+		p.names = append(p.names, name)
+	}
+	return nil
+}
+
+// outputName returns the name PostgreSQL gives to an output column with no alias.
+func outputName(e expr) string {
+	switch n := e.(type) {
+	case *columnRef:
+		return n.name
+	case *callExpr:
+		return n.name
+	case *castExpr:
+		if name := outputName(n.x); name != "?column?" {
+			return name
+		}
+		return n.t.String()
+	}
+	return "?column?"
+}
+
+// bind replaces the column references in e with references to the columns of the
+// query's table.
+func (p *planner) bind(e expr) (expr, error) {
+	return rewrite(e, func(n expr) (expr, bool, error) {
+		switch c := n.(type) {
+		case *columnRef:
+			for i, col := range p.columns {
+				if col.Name == c.name {
+					return &boundColumn{name: col.Name, i: i, t: col.Type}, true, nil
+				}
+			}
+			// Some functions can be called without parentheses.
+			if c.name == "current_timestamp" || c.name == "current_database" || c.name == "current_schema" {
+				return &callExpr{name: c.name}, true, nil
+			}
+			return nil, true, errors.Wrapf(UndefinedColumnError, "column %q does not exist", c.name)
+		case *callExpr:
+			if err := checkCall(c); err != nil {
+				return nil, true, err
+			}
+		case *binaryExpr:
+			if c.op != "like" && c.op != "ilike" {
+				break
+			}
+			lit, ok := c.r.(*literal)
+			if !ok {
+				break
+			}
+			s, ok := lit.v.(string)
+			if !ok {
+				break
+			}
+			re, err := likeRegexp(s, c.op == "ilike")
+			if err != nil {
+				return nil, true, err
+			}
+			l, err := p.bind(c.l)
+			if err != nil {
+				return nil, true, err
+			}
+			return &binaryExpr{op: c.op, l: l, r: c.r, re: re}, true, nil
+		}
+		return n, false, nil
+	})
+}
+
+// bindOutputRef binds an expression in a GROUP BY or ORDER BY clause, which may refer
+// to an output column by its position or, if it isn't a column of the table, by its
+// name.
+func (p *planner) bindOutputRef(e expr) (expr, error) {
+	switch n := e.(type) {
+	case *literal:
+		pos, ok := n.v.(int64)
+		if !ok {
+			break
+		}
+		if pos < 1 || int(pos) > len(p.items) {
+			return nil, errors.Wrapf(validate.Error, "position %d is not in select list", pos)
+		}
+		return p.items[pos-1], nil
+	case *columnRef:
+		isColumn := false
+		for _, c := range p.columns {
+			isColumn = isColumn || c.Name == n.name
+		}
+		if isColumn {
+			break
+		}
+		for i, name := range p.names {
+			if name == n.name {
+				return p.items[i], nil
+			}
+		}
+	}
+	return p.bind(e)
+}
+
+// bindConst binds the expression of a LIMIT or OFFSET clause, which must not depend on
+// the rows of the query.
+func (p *planner) bindConst(e expr, clause string) (expr, error) {
+	if e == nil {
+		return nil, nil
+	}
+	if !isConst(e) || hasAggregate(e) {
+		return nil, errors.Wrapf(validate.Error, "argument of %s must not contain variables", clause)
+	}
+	return p.bind(e)
+}
+
+// extractAggregates rewrites expressions to be evaluated over the groups of an
+// aggregate query, replacing aggregate calls with references to their results and
+// GROUP BY expressions with references to their values.
+func (p *planner) extractAggregates(es ...expr) ([]expr, error) {
+	out := make([]expr, len(es))
+	for i, e := range es {
+		var err error
+		out[i], err = rewrite(e, func(n expr) (expr, bool, error) {
+			for j, g := range p.q.groups {
+				if reflect.DeepEqual(n, g) {
+					return &groupRef{i: j, t: typeOf(g, nil)}, true, nil
+				}
+			}
+			switch c := n.(type) {
+			case *callExpr:
+				if _, ok := aggregates[c.name]; !ok {
+					break
+				}
+				agg := aggregate{name: c.name}
+				if !c.star {
+					agg.arg = c.args[0]
+					if hasAggregate(agg.arg) {
+						return nil, true, errors.Wrap(validate.Error, "aggregate function calls cannot be nested")
+					}
+				}
+				agg.t = callType(c, nil)
+				if agg.t == TypeUnknown {
+					agg.t = TypeText
+				}
+				p.q.aggs = append(p.q.aggs, agg)
+				return &aggRef{i: len(p.q.aggs) - 1, t: agg.t}, true, nil
+			case *boundColumn:
+				return nil, true, errors.Wrapf(
+					validate.Error,
+					"column %q must appear in the GROUP BY clause or be used in an aggregate function",
+					c.name,
+				)
+			}
+			return n, false, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// hasAggregate returns true if any of the expressions contain an aggregate call.
+func hasAggregate(es ...expr) bool {
+	found := false
+	for _, e := range es {
+		walk(e, func(n expr) {
+			if c, ok := n.(*callExpr); ok {
+				if _, ok = aggregates[c.name]; ok {
+					found = true
+				}
+			}
+		})
+	}
+	return found
+}
+
+func orderExprs(order []orderItem) []expr {
+	es := make([]expr, len(order))
+	for i, o := range order {
+		es[i] = o.e
+	}
+	return es
+}
+
+// inferParamTypes infers the type of each parameter from the expressions it is
+// compared with or used in. Parameters whose type can't be inferred are text.
+func (p *planner) inferParamTypes() {
+	types := make([]Type, p.stmt.params)
+	set := func(e expr, t Type) {
+		if r, ok := e.(*paramRef); ok && t != TypeUnknown && types[r.i] == TypeUnknown {
+			types[r.i] = t
+		}
+	}
+	pair := func(l, r expr) {
+		set(l, typeOf(r, types))
+		set(r, typeOf(l, types))
+	}
+	visit := func(n expr) {
+		switch c := n.(type) {
+		case *binaryExpr:
+			switch c.op {
+			case "and", "or":
+				set(c.l, TypeBool)
+				set(c.r, TypeBool)
+			case "like", "ilike", "||":
+				set(c.l, TypeText)
+				set(c.r, TypeText)
+			case "+", "-":
+				// A parameter added to a timestamp is an interval.
+				if lt := typeOf(c.l, types); lt == TypeTimestamp {
+					set(c.r, TypeInterval)
+				}
+				pair(c.l, c.r)
+			default:
+				pair(c.l, c.r)
+			}
+		case *betweenExpr:
+			pair(c.x, c.lo)
+			pair(c.x, c.hi)
+		case *inExpr:
+			for _, item := range c.list {
+				pair(c.x, item)
+			}
+		case *castExpr:
+			set(c.x, c.t)
+		case *unaryExpr:
+			if c.op == "not" {
+				set(c.x, TypeBool)
+			}
+		case *callExpr:
+			switch c.name {
+			case "time_bucket", "date_bin":
+				set(c.args[0], TypeInterval)
+				set(c.args[1], TypeTimestamp)
+				if len(c.args) == 3 {
+					set(c.args[2], TypeTimestamp)
+				}
+			case "date_trunc":
+				set(c.args[0], TypeText)
+				set(c.args[1], TypeTimestamp)
+			}
+		}
+	}
+	exprs := append([]expr{p.q.where, p.q.having}, p.q.items...)
+	exprs = append(exprs, p.q.groups...)
+	exprs = append(exprs, orderExprs(p.q.order)...)
+	for _, a := range p.q.aggs {
+		exprs = append(exprs, a.arg)
+	}
+	set(p.q.limit, TypeInt)
+	set(p.q.offset, TypeInt)
+	exprs = append(exprs, p.q.limit, p.q.offset)
+	// Run twice so that types propagate through expressions that depend on the types
+	// of other parameters.
+	for range 2 {
+		for _, e := range exprs {
+			walk(e, visit)
+		}
+	}
+	for i, t := range types {
+		if t == TypeUnknown {
+			types[i] = TypeText
+		}
+	}
+	p.q.paramTypes = types
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package sql implements a read-only SQL query engine over channels and ranges. The
+// engine supports a subset of the PostgreSQL dialect, and exposes the following
+// virtual tables:
+//
+//   - Every channel with an index is a table named after the channel, with a time
+//     column holding the channel's index and a value column holding its samples. Index
+//     channels only have a time column.
+//   - The ranges table holds the key, name, start, end, color, and labels of every
+//     range.
+//   - The channels table holds the key, name, data type, index, and flags of every
+//     channel.
+//
+// Filters on the time column of a channel table and on the start and end columns of
+// the ranges table are pushed down into the reads of the underlying data, so queries
+// over small time ranges only read the data they need. Queries support the aggregates
+// count, sum, avg, min, max, first, and last, and grouping by time buckets with the
+// time_bucket function.
+package sql
+
+import (
+	"context"
+
+	"github.com/synnaxlabs/alamos"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/iterator"
+	"github.com/synnaxlabs/synnax/pkg/service/ranger"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var (
+	// SyntaxError is returned when a query can't be parsed.
+	SyntaxError = errors.Wrap(validate.Error, "syntax error")
+	// UnsupportedError is returned when a query uses a feature the engine doesn't
+	// support, including any statement that would modify data.
+	UnsupportedError = errors.Wrap(validate.Error, "unsupported")
+	// UndefinedColumnError is returned when a query references a column that doesn't
+	// exist.
+	UndefinedColumnError = errors.Wrap(validate.Error, "undefined column")
+)
+
+// Type is the type of a column or parameter.
+type Type uint8
+
+const (
+	// TypeUnknown is the type of values whose type can't be inferred, such as
+	// parameters that are only compared to other parameters. Values of an unknown
+	// type are treated as text.
+	TypeUnknown Type = iota
+	// TypeBool values are bools.
+	TypeBool
+	// TypeInt values are int64s.
+	TypeInt
+	// TypeNumeric values are uint64s.
+	TypeNumeric
+	// TypeFloat values are float64s.
+	TypeFloat
+	// TypeText values are strings.
+	TypeText
+	// TypeJSON values are strings holding JSON documents.
+	TypeJSON
+	// TypeTimestamp values are telem.TimeStamps.
+	TypeTimestamp
+	// TypeInterval values are telem.TimeSpans.
+	TypeInterval
+	// TypeUUID values are uuid.UUIDs.
+	TypeUUID
+	// TypeTextArray values are string slices.
+	TypeTextArray
+)
+
+// String implements fmt.Stringer.
+func (t Type) String() string {
+	switch t {
+	case TypeBool:
+		return "boolean"
+	case TypeInt:
+		return "bigint"
+	case TypeNumeric:
+		return "numeric"
+	case TypeFloat:
+		return "double precision"
+	case TypeText:
+		return "text"
+	case TypeJSON:
+		return "jsonb"
+	case TypeTimestamp:
+		return "timestamptz"
+	case TypeInterval:
+		return "interval"
+	case TypeUUID:
+		return "uuid"
+	case TypeTextArray:
+		return "text[]"
+	default:
+		return "unknown"
+	}
+}
+
+// Column is a column of a table or of the results of a query.
+type Column struct {
+	// Name is the name of the column.
+	Name string
+	// Type is the type of the values in the column.
+	Type Type
+}
+
+// Row is a row of the results of a query. Each value is either nil or a value of the
+// type of its column.
+type Row = []any
+
+// AuthorizeFunc is called with the ontology IDs of the channels and ranges a query
+// reads before their data is returned. If it returns an error, the query fails with
+// that error.
+type AuthorizeFunc = func(ctx context.Context, objects []ontology.ID) error
+
+// ServiceConfig is the configuration for opening a SQL service.
+type ServiceConfig struct {
+	alamos.Instrumentation
+	// Channel is used to resolve channel tables and to list channels.
+	// [REQUIRED]
+	Channel channel.Readable
+	// Iterator is used to read the data of channel tables.
+	// [REQUIRED]
+	Iterator *iterator.Service
+	// Ranger is used to list ranges.
+	// [REQUIRED]
+	Ranger *ranger.Service
+}
+
+var (
+	_ config.Config[ServiceConfig] = ServiceConfig{}
+	// DefaultServiceConfig is the default configuration for a SQL service.
+	DefaultServiceConfig = ServiceConfig{}
+)
+
+// Override implements config.Config.
+func (cfg ServiceConfig) Override(other ServiceConfig) ServiceConfig {
+	cfg.Instrumentation = override.Zero(cfg.Instrumentation, other.Instrumentation)
+	cfg.Channel = override.Nil(cfg.Channel, other.Channel)
+	cfg.Iterator = override.Nil(cfg.Iterator, other.Iterator)
+	cfg.Ranger = override.Nil(cfg.Ranger, other.Ranger)
+	return cfg
+}
+
+// Validate implements config.Config.
+func (cfg ServiceConfig) Validate() error {
+	v := validate.New("sql")
+	validate.NotNil(v, "channel", cfg.Channel)
+	validate.NotNil(v, "iterator", cfg.Iterator)
+	validate.NotNil(v, "ranger", cfg.Ranger)
+	return v.Error()
+}
+
+// Service prepares and executes SQL queries.
+type Service struct{ cfg ServiceConfig }
+
+// NewService opens a new SQL service using the provided configurations. Later
+// configurations override earlier ones.
+func NewService(cfgs ...ServiceConfig) (*Service, error) {
+	cfg, err := config.New(DefaultServiceConfig, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{cfg: cfg}, nil
+}
+
+// Prepare parses and plans the semicolon separated statements in text, returning a
+// query for each statement. Prepare returns an error without planning any statements
+// if the text can't be parsed.
+func (s *Service) Prepare(ctx context.Context, text string) ([]*Query, error) {
+	stmts, err := parse(text)
+	if err != nil {
+		return nil, err
+	}
+	queries := make([]*Query, len(stmts))
+	for i, stmt := range stmts {
+		if queries[i], err = s.plan(ctx, stmt); err != nil {
+			return nil, err
+		}
+	}
+	return queries, nil
+}
@@ -0,0 +1,299 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package sql_test
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/synnax/pkg/distribution/mock"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	svcframer "github.com/synnaxlabs/synnax/pkg/service/framer"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/iterator"
+	"github.com/synnaxlabs/synnax/pkg/service/label"
+	"github.com/synnaxlabs/synnax/pkg/service/ranger"
+	"github.com/synnaxlabs/synnax/pkg/service/sql"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+func allowAll(context.Context, []ontology.ID) error { return nil }
+
+func sec(s int) telem.TimeStamp { return telem.TimeStamp(s) * telem.SecondTS }
+
+var _ = Describe("SQL", Ordered, func() {
+	var (
+		builder     = mock.NewCluster()
+		dist        mock.Node
+		rangerSvc   *ranger.Service
+		labelSvc    *label.Service
+		svc         *sql.Service
+		idx         channel.Channel
+		temperature channel.Channel
+		pressure    channel.Channel
+	)
+	write := func(keys channel.Keys, series ...telem.Series) {
+		w := MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{
+			Start:            telem.SecondTS,
+			Keys:             keys,
+			EnableAutoCommit: config.True(),
+		}))
+		MustSucceed(w.Write(core.MultiFrame(keys, series)))
+		Expect(w.Close()).To(Succeed())
+	}
+	exec := func(text string, params ...any) ([]sql.Row, []sql.Column, error) {
+		queries, err := svc.Prepare(ctx, text)
+		if err != nil {
+			return nil, nil, err
+		}
+		Expect(queries).To(HaveLen(1))
+		var rows []sql.Row
+		_, err = queries[0].Exec(ctx, params, allowAll, func(r sql.Row) error {
+			rows = append(rows, r)
+			return nil
+		})
+		return rows, queries[0].Columns(), err
+	}
+	rows := func(text string, params ...any) []sql.Row {
+		r, _, err := exec(text, params...)
+		Expect(err).ToNot(HaveOccurred())
+		return r
+	}
+	BeforeAll(func() {
+		dist = builder.Provision(ctx)
+		labelSvc = MustSucceed(label.OpenService(ctx, label.Config{
+			DB:       dist.DB,
+			Ontology: dist.Ontology,
+			Group:    dist.Group,
+		}))
+		rangerSvc = MustSucceed(ranger.OpenService(ctx, ranger.Config{
+			DB:       dist.DB,
+			Ontology: dist.Ontology,
+			Group:    dist.Group,
+			Label:    labelSvc,
+		}))
+		iteratorSvc := MustSucceed(iterator.NewService(iterator.ServiceConfig{
+			DistFramer: dist.Framer,
+			Channel:    dist.Channel,
+		}))
+		svc = MustSucceed(sql.NewService(sql.ServiceConfig{
+			Channel:  dist.Channel,
+			Iterator: iteratorSvc,
+			Ranger:   rangerSvc,
+		}))
+		idx = channel.Channel{Name: "sql_time", DataType: telem.TimeStampT, IsIndex: true}
+		Expect(dist.Channel.Create(ctx, &idx)).To(Succeed())
+		temperature = channel.Channel{Name: "temperature", DataType: telem.Float64T, LocalIndex: idx.LocalKey}
+		Expect(dist.Channel.Create(ctx, &temperature)).To(Succeed())
+		pressure = channel.Channel{Name: "Pressure", DataType: telem.Int32T, LocalIndex: idx.LocalKey}
+		Expect(dist.Channel.Create(ctx, &pressure)).To(Succeed())
+		write(
+			channel.Keys{idx.Key(), temperature.Key(), pressure.Key()},
+			telem.NewSeriesSecondsTSV(1, 2, 3, 61, 62, 63),
+			telem.NewSeriesV(1.5, 2.5, 3.5, 4.5, 5.5, 6.5),
+			telem.NewSeriesV[int32](10, 20, 30, 40, 50, 60),
+		)
+	})
+	AfterAll(func() {
+		Expect(builder.Close()).To(Succeed())
+	})
+
+	Describe("Channel Tables", func() {
+		It("Should select every row of a channel", func() {
+			r, cols, err := exec("SELECT * FROM temperature")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cols).To(Equal([]sql.Column{
+				{Name: "time", Type: sql.TypeTimestamp},
+				{Name: "value", Type: sql.TypeFloat},
+			}))
+			Expect(r).To(HaveLen(6))
+			Expect(r[0]).To(Equal(sql.Row{sec(1), 1.5}))
+			Expect(r[5]).To(Equal(sql.Row{sec(63), 6.5}))
+		})
+
+		It("Should resolve channel names case-insensitively", func() {
+			r := rows("SELECT value FROM pressure LIMIT 1")
+			Expect(r).To(Equal([]sql.Row{{int64(10)}}))
+			Expect(rows(`SELECT value FROM "Pressure" LIMIT 1`)).To(Equal(r))
+		})
+
+		It("Should select only the time column of an index channel", func() {
+			_, cols, err := exec("SELECT * FROM sql_time")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cols).To(Equal([]sql.Column{{Name: "time", Type: sql.TypeTimestamp}}))
+		})
+
+		It("Should filter rows by time", func() {
+			r := rows(`
+				SELECT value FROM temperature
+				WHERE time >= '1970-01-01 00:00:02' AND time < $1`,
+				sec(62),
+			)
+			Expect(r).To(Equal([]sql.Row{{2.5}, {3.5}, {4.5}}))
+			Expect(rows("SELECT value FROM temperature WHERE time BETWEEN $1 AND $2", "1970-01-01T00:00:02Z", sec(3))).
+				To(Equal([]sql.Row{{2.5}, {3.5}}))
+		})
+
+		It("Should filter rows by value", func() {
+			r := rows("SELECT time, value * 2 AS doubled FROM temperature WHERE value > 5 OR value IN (1.5)")
+			Expect(r).To(Equal([]sql.Row{{sec(1), 3.0}, {sec(62), 11.0}, {sec(63), 13.0}}))
+		})
+
+		It("Should order, limit, and offset rows", func() {
+			Expect(rows("SELECT value FROM temperature ORDER BY time DESC LIMIT 2")).
+				To(Equal([]sql.Row{{6.5}, {5.5}}))
+			Expect(rows("SELECT value FROM temperature ORDER BY value LIMIT 2 OFFSET 1")).
+				To(Equal([]sql.Row{{2.5}, {3.5}}))
+			Expect(rows("SELECT value FROM temperature LIMIT $1", 1)).
+				To(Equal([]sql.Row{{1.5}}))
+		})
+
+		It("Should compute aggregates", func() {
+			r, cols, err := exec(`
+				SELECT count(*), avg(value), min(value), max(value), first(value), last(value)
+				FROM temperature`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cols[0]).To(Equal(sql.Column{Name: "count", Type: sql.TypeInt}))
+			Expect(r).To(Equal([]sql.Row{{int64(6), 4.0, 1.5, 6.5, 1.5, 6.5}}))
+			Expect(rows("SELECT sum(value) FROM pressure")).To(Equal([]sql.Row{{int64(210)}}))
+		})
+
+		It("Should group rows into time buckets", func() {
+			r := rows(`
+				SELECT time_bucket('1 minute', time) AS bucket, avg(value)
+				FROM temperature
+				GROUP BY bucket
+				ORDER BY 1 DESC`)
+			Expect(r).To(Equal([]sql.Row{{sec(60), 5.5}, {sec(0), 2.5}}))
+		})
+
+		It("Should filter groups with HAVING", func() {
+			r := rows(`
+				SELECT time_bucket('1m', time), count(*)
+				FROM temperature
+				GROUP BY 1
+				HAVING max(value) > 5`)
+			Expect(r).To(Equal([]sql.Row{{sec(60), int64(3)}}))
+		})
+
+		It("Should return a single row for an aggregate over no rows", func() {
+			Expect(rows("SELECT count(*), avg(value) FROM temperature WHERE time > now()")).
+				To(Equal([]sql.Row{{int64(0), nil}}))
+		})
+	})
+
+	Describe("Ranges", func() {
+		It("Should list ranges with their labels", func() {
+			l := label.Label{Name: "Hot Fire"}
+			Expect(labelSvc.NewWriter(dist.DB).Create(ctx, &l)).To(Succeed())
+			rng := ranger.Range{
+				Name:      "Test 1",
+				TimeRange: telem.TimeRange{Start: sec(1), End: sec(10)},
+				Color:     "#ff0000",
+			}
+			Expect(rangerSvc.NewWriter(dist.DB).Create(ctx, &rng)).To(Succeed())
+			Expect(labelSvc.NewWriter(dist.DB).Label(ctx, ranger.OntologyID(rng.Key), []uuid.UUID{l.Key})).To(Succeed())
+			other := ranger.Range{Name: "Test 2", TimeRange: telem.TimeRange{Start: sec(100), End: sec(200)}}
+			Expect(rangerSvc.NewWriter(dist.DB).Create(ctx, &other)).To(Succeed())
+			r := rows(`SELECT key, name, start, "end", color, labels FROM ranges WHERE "end" < $1`, sec(50))
+			Expect(r).To(Equal([]sql.Row{{rng.Key, "Test 1", sec(1), sec(10), "#ff0000", []string{"Hot Fire"}}}))
+			Expect(rows("SELECT name FROM ranges WHERE 'Hot Fire' = ANY(labels)")).
+				To(Equal([]sql.Row{{"Test 1"}}))
+			Expect(rows("SELECT name FROM ranges WHERE name ILIKE 'test%' ORDER BY start DESC")).
+				To(Equal([]sql.Row{{"Test 2"}, {"Test 1"}}))
+		})
+	})
+
+	Describe("Channels", func() {
+		It("Should list channels", func() {
+			r := rows("SELECT key, name, data_type, is_index, index FROM channels WHERE name LIKE 'temp%'")
+			Expect(r).To(Equal([]sql.Row{{
+				int64(temperature.Key()), "temperature", "float64", false, int64(idx.Key()),
+			}}))
+		})
+	})
+
+	Describe("Expressions", func() {
+		It("Should evaluate a query with no FROM clause", func() {
+			r, cols, err := exec(`SELECT 1 + 2 AS three, 'a' || 'b', 7 / 2, INTERVAL '1 hour' * 2, NULL IS NULL`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cols[0]).To(Equal(sql.Column{Name: "three", Type: sql.TypeInt}))
+			Expect(r).To(Equal([]sql.Row{{int64(3), "ab", int64(3), 2 * telem.Hour, true}}))
+		})
+
+		It("Should cast values", func() {
+			Expect(rows("SELECT '42'::int, CAST(1.5 AS text), '1970-01-01T00:00:01Z'::timestamptz")).
+				To(Equal([]sql.Row{{int64(42), "1.5", sec(1)}}))
+		})
+
+		It("Should infer the types of parameters", func() {
+			queries := MustSucceed(svc.Prepare(ctx, "SELECT value FROM temperature WHERE time > $1 AND value < $2 LIMIT $3"))
+			Expect(queries[0].ParamTypes()).To(Equal([]sql.Type{sql.TypeTimestamp, sql.TypeFloat, sql.TypeInt}))
+		})
+	})
+
+	Describe("Statements", func() {
+		It("Should accept session statements without effect", func() {
+			queries := MustSucceed(svc.Prepare(ctx, "SET client_encoding = 'UTF8'; BEGIN; COMMIT"))
+			Expect(queries).To(HaveLen(3))
+			tag := MustSucceed(queries[1].Exec(ctx, nil, allowAll, nil))
+			Expect(tag).To(Equal("BEGIN"))
+		})
+
+		It("Should return the number of rows in the command tag", func() {
+			q := MustSucceed(svc.Prepare(ctx, "SELECT * FROM temperature LIMIT 4"))[0]
+			Expect(q.Exec(ctx, nil, allowAll, func(sql.Row) error { return nil })).To(Equal("SELECT 4"))
+		})
+
+		It("Should not prepare any statements for an empty query", func() {
+			Expect(svc.Prepare(ctx, " ; -- comment")).To(BeEmpty())
+		})
+	})
+
+	Describe("Authorization", func() {
+		It("Should authorize reads of channel data before reading it", func() {
+			var objects []ontology.ID
+			denied := errors.New("denied")
+			q := MustSucceed(svc.Prepare(ctx, "SELECT * FROM temperature"))[0]
+			_, err := q.Exec(ctx, nil, func(_ context.Context, ids []ontology.ID) error {
+				objects = ids
+				return denied
+			}, func(sql.Row) error {
+				Fail("should not emit rows")
+				return nil
+			})
+			Expect(err).To(MatchError(denied))
+			Expect(objects).To(ConsistOf(svcframer.OntologyIDs(channel.Keys{idx.Key(), temperature.Key()})))
+		})
+	})
+
+	Describe("Errors", func() {
+		DescribeTable("Should reject invalid queries", func(text string, expected error) {
+			_, _, err := exec(text)
+			Expect(err).To(HaveOccurredAs(expected))
+		},
+			Entry("write statement", "INSERT INTO temperature VALUES (1, 2)", sql.UnsupportedError),
+			Entry("syntax error", "SELECT FROM WHERE", sql.SyntaxError),
+			Entry("unterminated string", "SELECT 'abc", sql.SyntaxError),
+			Entry("undefined table", "SELECT * FROM nope", query.NotFound),
+			Entry("undefined column", "SELECT nope FROM temperature", sql.UndefinedColumnError),
+			Entry("ungrouped column", "SELECT value, count(*) FROM temperature", validate.Error),
+		)
+	})
+})
@@ -0,0 +1,425 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/service/framer"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/iterator"
+	"github.com/synnaxlabs/synnax/pkg/service/ranger"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// table is a virtual table that a query reads from.
+type table interface {
+	columns() []Column
+	// scan calls emit with each row of the table that may satisfy where, stopping
+	// early if emit returns false. Rows that don't satisfy where may still be emitted,
+	// so callers must filter them.
+	scan(ctx context.Context, where expr, en *env, authorize AuthorizeFunc, emit func(Row) (bool, error)) error
+	// orderedBy returns the position of the column that scan emits rows in ascending
+	// order of, or -1 if rows are emitted in no particular order.
+	orderedBy() int
+}
+
+const (
+	// RangesTable is the name of the table holding every range.
+	RangesTable = "ranges"
+	// ChannelsTable is the name of the table holding every channel.
+	ChannelsTable = "channels"
+)
+
+// resolveTable returns the table with the given name.
+func (s *Service) resolveTable(ctx context.Context, name string) (table, error) {
+	switch name {
+	case RangesTable:
+		return &rangesTable{ranger: s.cfg.Ranger}, nil
+	case ChannelsTable:
+		return &channelsTable{channel: s.cfg.Channel}, nil
+	}
+	var matches []channel.Channel
+	if err := s.cfg.Channel.NewRetrieve().
+		WhereNames("(?i)"+regexp.QuoteMeta(name)).
+		Entries(&matches).
+		Exec(ctx, nil); err != nil {
+		return nil, err
+	}
+	// Prefer an exact match, falling back to a unique case-insensitive match so that
+	// channels with upper case names can be queried without quoting them.
+	ch, found := lo.Find(matches, func(ch channel.Channel) bool { return ch.Name == name })
+	if !found && len(matches) == 1 {
+		ch, found = matches[0], true
+	}
+	if !found {
+		if key, err := strconv.ParseUint(name, 10, 32); err == nil {
+			var byKey []channel.Channel
+			if err = s.cfg.Channel.NewRetrieve().
+				WhereKeys(channel.Key(key)).
+				Entries(&byKey).
+				Exec(ctx, nil); err == nil && len(byKey) == 1 {
+				ch, found = byKey[0], true
+			}
+		}
+	}
+	if !found {
+		if len(matches) > 1 {
+			return nil, errors.Wrapf(validate.Error, "table name %q is ambiguous, as it matches %d channels", name, len(matches))
+		}
+		return nil, errors.Wrapf(query.NotFound, "relation %q does not exist", name)
+	}
+	if ch.Virtual {
+		return nil, errors.Wrapf(UnsupportedError, "channel %s is virtual and has no stored data to query", ch.Name)
+	}
+	if !ch.IsIndex && ch.Index() == 0 {
+		return nil, errors.Wrapf(UnsupportedError, "channel %s has no index", ch.Name)
+	}
+	return &channelTable{ch: ch, iterator: s.cfg.Iterator}, nil
+}
+
+// channelTable is a table holding the data of a channel, with a time column holding
+// the channel's index and a value column holding its samples.
+type channelTable struct {
+	ch       channel.Channel
+	iterator *iterator.Service
+}
+
+func (t *channelTable) columns() []Column {
+	cols := []Column{{Name: "time", Type: TypeTimestamp}}
+	if !t.ch.IsIndex {
+		cols = append(cols, Column{Name: "value", Type: columnType(t.ch.DataType)})
+	}
+	return cols
+}
+
+func (t *channelTable) orderedBy() int { return 0 }
+
+func (t *channelTable) scan(
+	ctx context.Context,
+	where expr,
+	en *env,
+	authorize AuthorizeFunc,
+	emit func(Row) (bool, error),
+) (err error) {
+	tr, err := pushdownBounds(where, en, 0)
+	if err != nil || tr.Span() <= 0 {
+		return err
+	}
+	keys := channel.Keys{t.ch.Key()}
+	if !t.ch.IsIndex {
+		keys = channel.Keys{t.ch.Index(), t.ch.Key()}
+	}
+	if err = authorize(ctx, framer.OntologyIDs(keys)); err != nil {
+		return err
+	}
+	it, err := t.iterator.Open(ctx, iterator.Config{Keys: keys, Bounds: tr})
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Combine(err, it.Close()) }()
+	if !it.SeekFirst() {
+		return it.Error()
+	}
+	var (
+		stamps []telem.TimeStamp
+		values [][]byte
+	)
+	for it.Next(iterator.AutoSpan) {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		fr := it.Value()
+		stamps, values = stamps[:0], values[:0]
+		for _, s := range fr.Get(keys[0]).Series {
+			stamps = append(stamps, telem.UnmarshalSeries[telem.TimeStamp](s)...)
+		}
+		if !t.ch.IsIndex {
+			for _, s := range fr.Get(t.ch.Key()).Series {
+				for sample := range s.Samples() {
+					values = append(values, sample)
+				}
+			}
+		}
+		for i, ts := range stamps {
+			row := Row{ts}
+			if !t.ch.IsIndex {
+				// The channel may have fewer samples than its index if they were
+				// written separately, in which case the missing values are NULL.
+				var v any
+				if i < len(values) {
+					v = sampleValue(t.ch.DataType, values[i])
+				}
+				row = append(row, v)
+			}
+			if ok, err := emit(row); err != nil || !ok {
+				return err
+			}
+		}
+	}
+	return it.Error()
+}
+
+// columnType returns the type of the value column of a channel with the given data
+// type.
+func columnType(dt telem.DataType) Type {
+	switch dt {
+	case telem.Float64T, telem.Float32T:
+		return TypeFloat
+	case telem.Int64T, telem.Int32T, telem.Int16T, telem.Int8T,
+		telem.Uint32T, telem.Uint16T, telem.Uint8T:
+		return TypeInt
+	case telem.Uint64T:
+		return TypeNumeric
+	case telem.TimeStampT:
+		return TypeTimestamp
+	case telem.UUIDT:
+		return TypeUUID
+	case telem.JSONT:
+		return TypeJSON
+	}
+	return TypeText
+}
+
+// sampleValue converts the binary representation of a sample to a value of the
+// column type of its data type.
+func sampleValue(dt telem.DataType, sample []byte) any {
+	switch dt {
+	case telem.Float64T:
+		return telem.UnmarshalFloat64[float64](sample)
+	case telem.Float32T:
+		return telem.UnmarshalFloat32[float64](sample)
+	case telem.Int64T:
+		return telem.UnmarshalInt64[int64](sample)
+	case telem.Int32T:
+		return telem.UnmarshalInt32[int64](sample)
+	case telem.Int16T:
+		return telem.UnmarshalInt16[int64](sample)
+	case telem.Int8T:
+		return telem.UnmarshalInt8[int64](sample)
+	case telem.Uint64T:
+		return telem.UnmarshalUint64[uint64](sample)
+	case telem.Uint32T:
+		return telem.UnmarshalUint32[int64](sample)
+	case telem.Uint16T:
+		return telem.UnmarshalUint16[int64](sample)
+	case telem.Uint8T:
+		return telem.UnmarshalUint8[int64](sample)
+	case telem.TimeStampT:
+		return telem.UnmarshalInt64[telem.TimeStamp](sample)
+	case telem.UUIDT:
+		id, err := uuid.FromBytes(sample)
+		if err != nil {
+			return nil
+		}
+		return id
+	}
+	return string(sample)
+}
+
+// pushdownBounds returns a time range containing the values of the columns at the
+// given positions in every row that satisfies where, using the comparisons of the
+// columns with constants in the top-level conjuncts of where.
+func pushdownBounds(where expr, en *env, cols ...int) (telem.TimeRange, error) {
+	tr := telem.TimeRangeMax
+	bound := func(op string, e expr) error {
+		v, err := eval(e, en)
+		if err != nil {
+			return err
+		}
+		if v, err = coerce(v, TypeTimestamp); err != nil || v == nil {
+			return err
+		}
+		ts := v.(telem.TimeStamp)
+		switch op {
+		case ">=":
+			tr.Start = max(tr.Start, ts)
+		case ">":
+			tr.Start = max(tr.Start, ts+1)
+		case "<=":
+			tr.End = min(tr.End, ts+1)
+		case "<":
+			tr.End = min(tr.End, ts)
+		case "=":
+			tr.Start, tr.End = max(tr.Start, ts), min(tr.End, ts+1)
+		}
+		return nil
+	}
+	isCol := func(e expr) bool {
+		c, ok := e.(*boundColumn)
+		return ok && lo.Contains(cols, c.i)
+	}
+	var visit func(e expr) error
+	visit = func(e expr) error {
+		switch n := e.(type) {
+		case *binaryExpr:
+			switch {
+			case n.op == "and":
+				if err := visit(n.l); err != nil {
+					return err
+				}
+				return visit(n.r)
+			case !comparisonOps[n.op]:
+				return nil
+			case isCol(n.l) && isConst(n.r):
+				return bound(n.op, n.r)
+			case isCol(n.r) && isConst(n.l):
+				return bound(flipComparison(n.op), n.l)
+			}
+		case *betweenExpr:
+			if !n.not && isCol(n.x) && isConst(n.lo) && isConst(n.hi) {
+				if err := bound(">=", n.lo); err != nil {
+					return err
+				}
+				return bound("<=", n.hi)
+			}
+		}
+		return nil
+	}
+	if where == nil {
+		return tr, nil
+	}
+	return tr, visit(where)
+}
+
+// flipComparison returns the operator that gives the same result when the operands of
+// op are swapped.
+func flipComparison(op string) string {
+	switch op {
+	case "<":
+		return ">"
+	case "<=":
+		return ">="
+	case ">":
+		return "<"
+	case ">=":
+		return "<="
+	}
+	return op
+}
+
+// rangesTable is a table holding every range.
+type rangesTable struct{ ranger *ranger.Service }
+
+func (t *rangesTable) columns() []Column {
+	return []Column{
+		{Name: "key", Type: TypeUUID},
+		{Name: "name", Type: TypeText},
+		{Name: "start", Type: TypeTimestamp},
+		{Name: "end", Type: TypeTimestamp},
+		{Name: "color", Type: TypeText},
+		{Name: "labels", Type: TypeTextArray},
+	}
+}
+
+func (t *rangesTable) orderedBy() int { return -1 }
+
+func (t *rangesTable) scan(
+	ctx context.Context,
+	where expr,
+	en *env,
+	authorize AuthorizeFunc,
+	emit func(Row) (bool, error),
+) error {
+	// A range satisfies any bound on its start or end only if it overlaps with the
+	// bounds, so we can push both down as an overlap filter. The filter is widened to
+	// account for the exclusive ends of the bounds.
+	tr, err := pushdownBounds(where, en, 2, 3)
+	if err != nil {
+		return err
+	}
+	r := t.ranger.NewRetrieve()
+	if tr != telem.TimeRangeMax {
+		if tr.Start > telem.TimeStampMin {
+			tr.Start--
+		}
+		if tr.End < telem.TimeStampMax {
+			tr.End++
+		}
+		r = r.WhereOverlapsWith(tr)
+	}
+	var rngs []ranger.Range
+	if err = r.Entries(&rngs).Exec(ctx, nil); err != nil {
+		return err
+	}
+	if err = authorize(ctx, ranger.OntologyIDsFromRanges(rngs)); err != nil {
+		return err
+	}
+	for _, rng := range rngs {
+		labels, err := rng.RetrieveLabels(ctx)
+		if err != nil {
+			return err
+		}
+		names := make([]string, len(labels))
+		for i, l := range labels {
+			names[i] = l.Name
+		}
+		var color any
+		if rng.Color != "" {
+			color = rng.Color
+		}
+		row := Row{rng.Key, rng.Name, rng.TimeRange.Start, rng.TimeRange.End, color, names}
+		if ok, err := emit(row); err != nil || !ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// channelsTable is a table holding every channel.
+type channelsTable struct{ channel channel.Readable }
+
+func (t *channelsTable) columns() []Column {
+	return []Column{
+		{Name: "key", Type: TypeInt},
+		{Name: "name", Type: TypeText},
+		{Name: "data_type", Type: TypeText},
+		{Name: "is_index", Type: TypeBool},
+		{Name: "index", Type: TypeInt},
+		{Name: "virtual", Type: TypeBool},
+	}
+}
+
+func (t *channelsTable) orderedBy() int { return -1 }
+
+func (t *channelsTable) scan(
+	ctx context.Context,
+	_ expr,
+	_ *env,
+	authorize AuthorizeFunc,
+	emit func(Row) (bool, error),
+) error {
+	var chs []channel.Channel
+	if err := t.channel.NewRetrieve().WhereInternal(false).Entries(&chs).Exec(ctx, nil); err != nil {
+		return err
+	}
+	if err := authorize(ctx, channel.OntologyIDsFromChannels(chs)); err != nil {
+		return err
+	}
+	for _, ch := range chs {
+		var index any
+		if idx := ch.Index(); idx != 0 {
+			index = int64(idx)
+		}
+		row := Row{int64(ch.Key()), ch.Name, strings.ToLower(string(ch.DataType)), ch.IsIndex, index, ch.Virtual}
+		if ok, err := emit(row); err != nil || !ok {
+			return err
+		}
+	}
+	return nil
+}
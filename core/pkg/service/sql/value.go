@@ -0,0 +1,495 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// valueType returns the type of a value.
+func valueType(v any) Type {
+	switch v.(type) {
+	case bool:
+		return TypeBool
+	case int64:
+		return TypeInt
+	case uint64:
+		return TypeNumeric
+	case float64:
+		return TypeFloat
+	case string:
+		return TypeText
+	case telem.TimeStamp:
+		return TypeTimestamp
+	case telem.TimeSpan:
+		return TypeInterval
+	case uuid.UUID:
+		return TypeUUID
+	case []string:
+		return TypeTextArray
+	}
+	return TypeUnknown
+}
+
+func typeName(v any) string { return valueType(v).String() }
+
+func invalidSyntax(t Type, s string) error {
+	return errors.Wrapf(validate.Error, "invalid input syntax for type %s: %q", t, s)
+}
+
+func cannotCast(v any, t Type) error {
+	return errors.Wrapf(validate.Error, "cannot cast type %s to %s", typeName(v), t)
+}
+
+// normalize converts Go values of types that have natural SQL equivalents, such as
+// ints and time.Times, to the value types used by queries.
+func normalize(v any) any {
+	switch x := v.(type) {
+	case int:
+		return int64(x)
+	case int32:
+		return int64(x)
+	case int16:
+		return int64(x)
+	case int8:
+		return int64(x)
+	case uint:
+		return uint64(x)
+	case uint32:
+		return int64(x)
+	case uint16:
+		return int64(x)
+	case uint8:
+		return int64(x)
+	case float32:
+		return float64(x)
+	case time.Time:
+		return telem.NewTimeStamp(x)
+	case time.Duration:
+		return telem.TimeSpan(x)
+	case [16]byte:
+		return uuid.UUID(x)
+	case []byte:
+		return string(x)
+	}
+	return v
+}
+
+// coerce converts v to a value of type t.
+func coerce(v any, t Type) (any, error) {
+	if v == nil || t == TypeUnknown || valueType(v) == t {
+		return v, nil
+	}
+	switch t {
+	case TypeBool:
+		return toBool(v)
+	case TypeInt:
+		switch x := v.(type) {
+		case uint64:
+			if x > math.MaxInt64 {
+				return nil, errors.Wrap(validate.Error, "bigint out of range")
+			}
+			return int64(x), nil
+		case float64:
+			if math.IsNaN(x) || x >= math.MaxInt64 || x < math.MinInt64 {
+				return nil, errors.Wrap(validate.Error, "bigint out of range")
+			}
+			return int64(math.RoundToEven(x)), nil
+		case bool:
+			if x {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		case telem.TimeStamp:
+			return int64(x), nil
+		case telem.TimeSpan:
+			return int64(x), nil
+		case string:
+			s := strings.TrimSpace(x)
+			if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return i, nil
+			}
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				return coerce(f, TypeInt)
+			}
+			return nil, invalidSyntax(t, x)
+		}
+	case TypeNumeric:
+		switch x := v.(type) {
+		case int64:
+			if x < 0 {
+				return nil, errors.Wrap(validate.Error, "numeric out of range")
+			}
+			return uint64(x), nil
+		case float64:
+			if math.IsNaN(x) || x < 0 || x >= math.MaxUint64 {
+				return nil, errors.Wrap(validate.Error, "numeric out of range")
+			}
+			return uint64(math.RoundToEven(x)), nil
+		case string:
+			s := strings.TrimSpace(x)
+			if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+				return u, nil
+			}
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				return coerce(f, TypeNumeric)
+			}
+			return nil, invalidSyntax(t, x)
+		}
+	case TypeFloat:
+		if f, ok := toFloat(v); ok {
+			return f, nil
+		}
+		if s, ok := v.(string); ok {
+			f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+			if err != nil {
+				return nil, invalidSyntax(t, s)
+			}
+			return f, nil
+		}
+	case TypeText, TypeJSON:
+		return formatValue(v), nil
+	case TypeTimestamp:
+		switch x := v.(type) {
+		case int64:
+			return telem.TimeStamp(x), nil
+		case uint64:
+			return telem.TimeStamp(x), nil
+		case float64:
+			return telem.TimeStamp(x), nil
+		case string:
+			return parseTimestamp(x)
+		}
+	case TypeInterval:
+		switch x := v.(type) {
+		case int64:
+			return telem.TimeSpan(x), nil
+		case string:
+			return parseInterval(x)
+		}
+	case TypeUUID:
+		if s, ok := v.(string); ok {
+			id, err := uuid.Parse(strings.TrimSpace(s))
+			if err != nil {
+				return nil, invalidSyntax(t, s)
+			}
+			return id, nil
+		}
+	case TypeTextArray:
+		if s, ok := v.(string); ok {
+			return parseTextArray(s)
+		}
+	}
+	return nil, cannotCast(v, t)
+}
+
+func toBool(v any) (bool, error) {
+	switch x := v.(type) {
+	case bool:
+		return x, nil
+	case int64:
+		return x != 0, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(x)) {
+		case "t", "true", "y", "yes", "on", "1":
+			return true, nil
+		case "f", "false", "n", "no", "off", "0":
+			return false, nil
+		}
+		return false, invalidSyntax(TypeBool, x)
+	}
+	return false, errors.Wrapf(validate.Error, "argument of type %s must be type boolean", typeName(v))
+}
+
+func toFloat(v any) (float64, bool) {
+	switch x := v.(type) {
+	case int64:
+		return float64(x), true
+	case uint64:
+		return float64(x), true
+	case float64:
+		return x, true
+	}
+	return 0, false
+}
+
+// formatValue returns the text representation of v.
+func formatValue(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case bool:
+		return strconv.FormatBool(x)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case uint64:
+		return strconv.FormatUint(x, 10)
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64)
+	case telem.TimeStamp:
+		return x.Time().UTC().Format("2006-01-02 15:04:05.999999999-07")
+	case telem.TimeSpan:
+		return x.Duration().String()
+	case uuid.UUID:
+		return x.String()
+	case []string:
+		quoted := make([]string, len(x))
+		for i, s := range x {
+			quoted[i] = strconv.Quote(s)
+		}
+		return "{" + strings.Join(quoted, ",") + "}"
+	}
+	return ""
+}
+
+// compare returns a negative number if a is less than b, zero if they are equal, and a
+// positive number if a is greater than b. Untyped strings are coerced to the type of
+// the other value.
+func compare(a, b any) (int, error) {
+	ta, tb := valueType(a), valueType(b)
+	if ta != tb {
+		var err error
+		switch {
+		case ta == TypeText:
+			a, err = coerce(a, tb)
+		case tb == TypeText:
+			b, err = coerce(b, ta)
+		case isNumeric(ta) && isNumeric(tb):
+			return compareNumbers(a, b), nil
+		case ta == TypeTimestamp || ta == TypeInterval:
+			b, err = coerce(b, ta)
+		case tb == TypeTimestamp || tb == TypeInterval:
+			a, err = coerce(a, tb)
+		default:
+			err = errors.Wrapf(validate.Error, "operator does not exist: %s = %s", ta, tb)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	switch x := a.(type) {
+	case bool:
+		y := b.(bool)
+		switch {
+		case x == y:
+			return 0, nil
+		case !x:
+			return -1, nil
+		}
+		return 1, nil
+	case int64:
+		return cmp(x, b.(int64)), nil
+	case uint64:
+		return cmp(x, b.(uint64)), nil
+	case float64:
+		return cmp(x, b.(float64)), nil
+	case string:
+		return strings.Compare(x, b.(string)), nil
+	case telem.TimeStamp:
+		return cmp(x, b.(telem.TimeStamp)), nil
+	case telem.TimeSpan:
+		return cmp(x, b.(telem.TimeSpan)), nil
+	case uuid.UUID:
+		y := b.(uuid.UUID)
+		return bytes.Compare(x[:], y[:]), nil
+	}
+	return 0, errors.Wrapf(validate.Error, "could not compare values of type %s", ta)
+}
+
+func cmp[T int64 | uint64 | float64 | telem.TimeStamp | telem.TimeSpan](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareNumbers(a, b any) int {
+	// Compare int64s and uint64s exactly, as converting them to floats would lose
+	// precision.
+	if i, ok := a.(int64); ok {
+		if u, ok := b.(uint64); ok {
+			if i < 0 {
+				return -1
+			}
+			return cmp(uint64(i), u)
+		}
+	}
+	if _, ok := a.(uint64); ok {
+		if _, ok := b.(int64); ok {
+			return -compareNumbers(b, a)
+		}
+	}
+	fa, _ := toFloat(a)
+	fb, _ := toFloat(b)
+	return cmp(fa, fb)
+}
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05Z07",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// parseTimestamp parses a timestamp in RFC 3339 or PostgreSQL format, or as a number
+// of nanoseconds since the Unix epoch. Timestamps without a time zone are in UTC.
+func parseTimestamp(s string) (telem.TimeStamp, error) {
+	s = strings.TrimSpace(s)
+	if ns, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return telem.TimeStamp(ns), nil
+	}
+	switch strings.ToLower(s) {
+	case "now":
+		return telem.Now(), nil
+	case "epoch":
+		return 0, nil
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return telem.NewTimeStamp(t), nil
+		}
+	}
+	return 0, invalidSyntax(TypeTimestamp, s)
+}
+
+var intervalUnits = map[string]telem.TimeSpan{
+	"ns": telem.Nanosecond, "nanosecond": telem.Nanosecond, "nanoseconds": telem.Nanosecond,
+	"us": telem.Microsecond, "usec": telem.Microsecond, "usecs": telem.Microsecond,
+	"microsecond": telem.Microsecond, "microseconds": telem.Microsecond,
+	"ms": telem.Millisecond, "msec": telem.Millisecond, "msecs": telem.Millisecond,
+	"millisecond": telem.Millisecond, "milliseconds": telem.Millisecond,
+	"s": telem.Second, "sec": telem.Second, "secs": telem.Second,
+	"second": telem.Second, "seconds": telem.Second,
+	"m": telem.Minute, "min": telem.Minute, "mins": telem.Minute,
+	"minute": telem.Minute, "minutes": telem.Minute,
+	"h": telem.Hour, "hr": telem.Hour, "hrs": telem.Hour, "hour": telem.Hour, "hours": telem.Hour,
+	"d": telem.Day, "day": telem.Day, "days": telem.Day,
+	"w": 7 * telem.Day, "week": 7 * telem.Day, "weeks": 7 * telem.Day,
+}
+
+// parseInterval parses an interval such as '1 hour 30 minutes', '90m', or '01:30:00'.
+// Intervals in months or years are not supported, as their lengths vary.
+func parseInterval(s string) (telem.TimeSpan, error) {
+	s = strings.TrimSpace(s)
+	if d, err := time.ParseDuration(s); err == nil {
+		return telem.TimeSpan(d), nil
+	}
+	var (
+		total  telem.TimeSpan
+		fields = strings.Fields(strings.ToLower(s))
+	)
+	if len(fields) == 0 {
+		return 0, invalidSyntax(TypeInterval, s)
+	}
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if strings.Contains(f, ":") {
+			span, err := parseClock(f)
+			if err != nil {
+				return 0, invalidSyntax(TypeInterval, s)
+			}
+			total += span
+			continue
+		}
+		// Split the number from a unit that directly follows it, as in '5min'.
+		j := 0
+		for j < len(f) && (isDigit(f[j]) || f[j] == '.' || f[j] == '-' || f[j] == '+') {
+			j++
+		}
+		n, err := strconv.ParseFloat(f[:j], 64)
+		if err != nil {
+			return 0, invalidSyntax(TypeInterval, s)
+		}
+		unit := f[j:]
+		if unit == "" {
+			if i+1 == len(fields) {
+				// A bare number is a number of seconds.
+				unit = "s"
+			} else {
+				i++
+				unit = fields[i]
+			}
+		}
+		u, ok := intervalUnits[unit]
+		if !ok {
+			return 0, invalidSyntax(TypeInterval, s)
+		}
+		total += telem.TimeSpan(n * float64(u))
+	}
+	return total, nil
+}
+
+// parseClock parses an interval in the form hh:mm[:ss[.fff]].
+func parseClock(s string) (telem.TimeSpan, error) {
+	neg := strings.HasPrefix(s, "-")
+	parts := strings.Split(strings.TrimPrefix(s, "-"), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, errors.New("invalid clock")
+	}
+	units := []telem.TimeSpan{telem.Hour, telem.Minute, telem.Second}
+	var total telem.TimeSpan
+	for i, p := range parts {
+		n, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			return 0, err
+		}
+		total += telem.TimeSpan(n * float64(units[i]))
+	}
+	if neg {
+		total = -total
+	}
+	return total, nil
+}
+
+// parseTextArray parses a text array literal such as '{a,b,"c d"}'.
+func parseTextArray(s string) ([]string, error) {
+	t := strings.TrimSpace(s)
+	if len(t) < 2 || t[0] != '{' || t[len(t)-1] != '}' {
+		return nil, invalidSyntax(TypeTextArray, s)
+	}
+	t = t[1 : len(t)-1]
+	if t == "" {
+		return []string{}, nil
+	}
+	var (
+		out    []string
+		b      strings.Builder
+		quoted bool
+	)
+	for i := 0; i < len(t); i++ {
+		switch c := t[i]; {
+		case c == '"':
+			quoted = !quoted
+		case c == '\\' && quoted && i+1 < len(t):
+			i++
+			b.WriteByte(t[i])
+		case c == ',' && !quoted:
+			out = append(out, strings.TrimSpace(b.String()))
+			b.Reset()
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return append(out, strings.TrimSpace(b.String())), nil
+}
@@ -61,11 +61,12 @@ func KeyFromOntologyID(id ontology.ID) (uuid.UUID, error) {
 var OntologyTypeID = ontology.ID{Type: ontologyType, Key: ""}
 
 var schema = zyn.Object(map[string]zyn.Schema{
-	"key":        zyn.UUID(),
-	"username":   zyn.String(),
-	"first_name": zyn.String(),
-	"last_name":  zyn.String(),
-	"root_user":  zyn.Bool(),
+	"key":             zyn.UUID(),
+	"username":        zyn.String(),
+	"first_name":      zyn.String(),
+	"last_name":       zyn.String(),
+	"root_user":       zyn.Bool(),
+	"service_account": zyn.Bool(),
 })
 
 func (s *Service) Type() ontology.Type { return ontologyType }
@@ -32,6 +32,10 @@ type User struct {
 	// RootUser is a boolean that determines if the user is a root user. Root users are
 	// the users that configure the Synnax server, and have full access to the server.
 	RootUser bool `json:"root_user" msgpack:"root_user"`
+	// ServiceAccount is true if the user is a service account used by automation, such
+	// as CI pipelines and rack controllers. Service accounts have no password and
+	// authenticate with API keys.
+	ServiceAccount bool `json:"service_account" msgpack:"service_account"`
 }
 
 var _ gorp.Entry[uuid.UUID] = User{}
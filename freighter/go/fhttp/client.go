@@ -10,6 +10,9 @@
 package fhttp
 
 import (
+	"crypto/tls"
+	"net/http"
+
 	"github.com/synnaxlabs/freighter"
 	"github.com/synnaxlabs/x/config"
 	"github.com/synnaxlabs/x/httputil"
@@ -19,6 +22,9 @@ import (
 
 type ClientFactoryConfig struct {
 	Codec httputil.Codec
+	// TLS is the TLS configuration used to connect to servers. If nil, clients will
+	// connect over plain HTTP and websockets.
+	TLS *tls.Config
 }
 
 func (c ClientFactoryConfig) Validate() error {
@@ -29,6 +35,7 @@ func (c ClientFactoryConfig) Validate() error {
 
 func (c ClientFactoryConfig) Override(other ClientFactoryConfig) ClientFactoryConfig {
 	c.Codec = override.Nil(c.Codec, other.Codec)
+	c.TLS = override.Nil(c.TLS, other.TLS)
 	return c
 }
 
@@ -49,9 +56,19 @@ func NewClientFactory(configs ...ClientFactoryConfig) *ClientFactory {
 }
 
 func StreamClient[RQ, RS freighter.Payload](c *ClientFactory) freighter.StreamClient[RQ, RS] {
-	return &streamClient[RQ, RS]{codec: c.Codec}
+	sc := &streamClient[RQ, RS]{codec: c.Codec, scheme: "ws"}
+	if c.TLS != nil {
+		sc.scheme = "wss"
+		sc.dialer.TLSClientConfig = c.TLS
+	}
+	return sc
 }
 
 func UnaryClient[RQ, RS freighter.Payload](c *ClientFactory) freighter.UnaryClient[RQ, RS] {
-	return &unaryClient[RQ, RS]{codec: c.Codec}
+	uc := &unaryClient[RQ, RS]{codec: c.Codec, scheme: "http", client: &http.Client{}}
+	if c.TLS != nil {
+		uc.scheme = "https"
+		uc.client.Transport = &http.Transport{TLSClientConfig: c.TLS}
+	}
+	return uc
 }
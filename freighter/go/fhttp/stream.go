@@ -266,6 +266,7 @@ func (c *streamCore[I, O]) listenForContextCancellation() {
 type streamClient[RQ, RS freighter.Payload] struct {
 	alamos.Instrumentation
 	codec  httputil.Codec
+	scheme string
 	dialer ws.Dialer
 	freighter.Reporter
 	freighter.MiddlewareCollector
@@ -290,7 +291,7 @@ func (s *streamClient[RQ, RS]) Stream(
 		},
 		freighter.FinalizerFunc(func(ctx freighter.Context) (oCtx freighter.Context, err error) {
 			ctx.Params[fiber.HeaderContentType] = s.codec.ContentType()
-			conn, res, err := s.dialer.DialContext(ctx, "ws://"+target.String(), mdToHeaders(ctx))
+			conn, res, err := s.dialer.DialContext(ctx, s.scheme+"://"+target.String(), mdToHeaders(ctx))
 			oCtx = parseResponseCtx(res, target)
 			if err != nil {
 				return oCtx, err
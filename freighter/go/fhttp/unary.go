@@ -12,7 +12,6 @@ package fhttp
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"net/http"
 	"strings"
 
@@ -72,7 +71,9 @@ func (s *unaryServer[RQ, RS]) fiberHandler(fCtx *fiber.Ctx) error {
 type unaryClient[RQ, RS freighter.Payload] struct {
 	freighter.Reporter
 	freighter.MiddlewareCollector
-	codec httputil.Codec
+	codec  httputil.Codec
+	scheme string
+	client *http.Client
 }
 
 func (u *unaryClient[RQ, RS]) Send(
@@ -85,6 +86,7 @@ func (u *unaryClient[RQ, RS]) Send(
 			Context:  ctx,
 			Protocol: unaryReporter.Protocol,
 			Target:   target,
+			Params:   make(freighter.Params),
 		},
 		freighter.FinalizerFunc(func(inCtx freighter.Context) (outCtx freighter.Context, err error) {
 			b, err := u.codec.Encode(inCtx, req)
@@ -94,7 +96,7 @@ func (u *unaryClient[RQ, RS]) Send(
 			httpReq, err := http.NewRequestWithContext(
 				ctx,
 				"POST",
-				"http://"+target.String(),
+				u.scheme+"://"+target.String(),
 				bytes.NewReader(b),
 			)
 			if err != nil {
@@ -103,11 +105,11 @@ func (u *unaryClient[RQ, RS]) Send(
 			setRequestCtx(httpReq, inCtx)
 			httpReq.Header.Set(fiber.HeaderContentType, u.codec.ContentType())
 
-			httpRes, err := (&http.Client{}).Do(httpReq)
-			outCtx = parseResponseCtx(httpRes, target)
+			httpRes, err := u.client.Do(httpReq)
 			if err != nil {
 				return outCtx, err
 			}
+			outCtx = parseResponseCtx(httpRes, target)
 
 			if httpRes.StatusCode < 200 || httpRes.StatusCode >= 300 {
 				var pld errors.Payload
@@ -145,6 +147,11 @@ func parseRequestCtx(socketCtx context.Context, fiberCtx *fiber.Ctx, target addr
 	for k, v := range fiberCtx.GetReqHeaders() {
 		if len(v) > 0 {
 			md.Params[k] = v[0]
+			// Headers set by freighter clients are prefixed to avoid conflicts with
+			// standard headers, so we strip the prefix to restore the original key.
+			if isFreighterHeader(k) {
+				md.Params[k[len(freighterCtxPrefix):]] = v[0]
+			}
 		}
 	}
 	for k, v := range parseQueryString(fiberCtx) {
@@ -156,9 +163,11 @@ func parseRequestCtx(socketCtx context.Context, fiberCtx *fiber.Ctx, target addr
 }
 
 func parseSecurityInfo(c *fiber.Ctx) (info freighter.SecurityInfo) {
-	if c.Context().IsTLS() {
+	// TLSConnectionState also handles connections that wrap a TLS connection, such as
+	// those accepted from a connection multiplexer.
+	if state := c.Context().TLSConnectionState(); state != nil {
 		info.TLS.Used = true
-		info.TLS.ConnectionState = c.Context().Conn().(*tls.Conn).ConnectionState()
+		info.TLS.ConnectionState = *state
 	}
 	return info
 }
@@ -213,3 +222,9 @@ func isFreighterQueryStringParam(k string) bool {
 	// check if the key has the md prefix
 	return strings.HasPrefix(k, freighterCtxPrefix)
 }
+
+func isFreighterHeader(k string) bool {
+	// header keys are canonicalized, so we need a case-insensitive comparison.
+	return len(k) > len(freighterCtxPrefix) &&
+		strings.EqualFold(k[:len(freighterCtxPrefix)], freighterCtxPrefix)
+}
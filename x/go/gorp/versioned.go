@@ -0,0 +1,118 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package gorp
+
+import (
+	"context"
+
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/kv"
+	"github.com/synnaxlabs/x/query"
+	"github.com/synnaxlabs/x/version"
+)
+
+// ErrNotVersioned is returned when a versioned operation is executed against a DB
+// whose underlying key-value store does not implement kv.Versioned.
+var ErrNotVersioned = errors.New("[gorp] - underlying key-value store does not support versioned operations")
+
+// Versioned provides linearizable reads and compare-and-set writes on the entries of
+// a DB. It allows callers to implement optimistic concurrency control instead of
+// relying on last-writer-wins semantics. Versioned operations are executed directly
+// against the DB, and cannot be part of a Tx.
+type Versioned[K Key, E Entry[K]] struct {
+	*lazyPrefix[K, E]
+	db *DB
+}
+
+// WrapVersioned wraps the given DB to provide versioned operations on the entries
+// provided as the type arguments. The underlying key-value store of the DB must
+// implement kv.Versioned, otherwise all operations will return ErrNotVersioned.
+func WrapVersioned[K Key, E Entry[K]](db *DB) *Versioned[K, E] {
+	return &Versioned[K, E]{db: db, lazyPrefix: &lazyPrefix[K, E]{Tools: db}}
+}
+
+func (v Versioned[K, E]) base() (kv.Versioned, error) {
+	b, ok := v.db.DB.(kv.Versioned)
+	if !ok {
+		return nil, ErrNotVersioned
+	}
+	return b, nil
+}
+
+// Get retrieves the entry with the given key along with its current version. If the
+// entry does not exist, query.NotFound is returned.
+func (v Versioned[K, E]) Get(ctx context.Context, key K) (e E, ver version.Counter, err error) {
+	b, err := v.base()
+	if err != nil {
+		return e, ver, err
+	}
+	bKey, err := encodeKey(ctx, v.db, v.prefix(ctx), key)
+	if err != nil {
+		return e, ver, err
+	}
+	data, ver, err := b.GetVersioned(ctx, bKey)
+	if errors.Is(err, kv.NotFound) {
+		return e, ver, query.NotFound
+	}
+	if err != nil {
+		return e, ver, err
+	}
+	return e, ver, v.db.Decode(ctx, data, &e)
+}
+
+// CompareAndSet writes the entry if and only if the currently stored entry with the
+// same key is at the expected version. An expected version of zero requires that the
+// entry does not exist. If the versions do not match, kv.VersionMismatch is returned.
+func (v Versioned[K, E]) CompareAndSet(
+	ctx context.Context,
+	expected version.Counter,
+	entry E,
+) error {
+	b, err := v.base()
+	if err != nil {
+		return err
+	}
+	data, err := v.db.Encode(ctx, entry)
+	if err != nil {
+		return err
+	}
+	bKey, err := encodeKey(ctx, v.db, v.prefix(ctx), entry.GorpKey())
+	if err != nil {
+		return err
+	}
+	return b.CompareAndSet(ctx, bKey, expected, data, entry.SetOptions()...)
+}
+
+// Update reads the entry with the given key, applies the change function to it, and
+// writes it back using CompareAndSet. If another writer modifies the entry in between
+// the read and the write, the process is retried against the latest version of the
+// entry. The change function may therefore be called more than once, and should not
+// have side effects.
+func (v Versioned[K, E]) Update(
+	ctx context.Context,
+	key K,
+	f func(E) (E, error),
+) error {
+	for {
+		e, ver, err := v.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if e, err = f(e); err != nil {
+			return err
+		}
+		if err = v.CompareAndSet(ctx, ver, e); !errors.Is(err, kv.VersionMismatch) {
+			return err
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package gorp_test
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/x/binary"
+	"github.com/synnaxlabs/x/gorp"
+	xkv "github.com/synnaxlabs/x/kv"
+	"github.com/synnaxlabs/x/kv/memkv"
+	"github.com/synnaxlabs/x/query"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/version"
+)
+
+// versionedKV is a minimal, single-node implementation of xkv.Versioned used to
+// exercise gorp's versioned operations.
+type versionedKV struct {
+	xkv.DB
+	mu       sync.Mutex
+	versions map[string]version.Counter
+}
+
+var _ xkv.Versioned = (*versionedKV)(nil)
+
+func (v *versionedKV) GetVersioned(
+	ctx context.Context,
+	key []byte,
+	_ ...any,
+) ([]byte, version.Counter, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	b, closer, err := v.Get(ctx, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	b = binary.MakeCopy(b)
+	return b, v.versions[string(key)], closer.Close()
+}
+
+func (v *versionedKV) CompareAndSet(
+	ctx context.Context,
+	key []byte,
+	expected version.Counter,
+	value []byte,
+	_ ...any,
+) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.versions[string(key)] != expected {
+		return xkv.VersionMismatch
+	}
+	v.versions[string(key)]++
+	return v.Set(ctx, key, value)
+}
+
+var _ = Describe("Versioned", func() {
+	var (
+		kv *versionedKV
+		db *gorp.DB
+		v  *gorp.Versioned[int, entry]
+	)
+	BeforeEach(func() {
+		kv = &versionedKV{DB: memkv.New(), versions: make(map[string]version.Counter)}
+		db = gorp.Wrap(kv)
+		v = gorp.WrapVersioned[int, entry](db)
+	})
+	AfterEach(func() { Expect(kv.Close()).To(Succeed()) })
+
+	Describe("CompareAndSet", func() {
+		It("Should create an entry when the expected version is zero", func() {
+			Expect(v.CompareAndSet(ctx, 0, entry{ID: 1, Data: "a"})).To(Succeed())
+			e, ver := MustSucceed2(v.Get(ctx, 1))
+			Expect(e).To(Equal(entry{ID: 1, Data: "a"}))
+			Expect(ver).To(Equal(version.Counter(1)))
+		})
+
+		It("Should reject a write against a stale version", func() {
+			Expect(v.CompareAndSet(ctx, 0, entry{ID: 1, Data: "a"})).To(Succeed())
+			Expect(v.CompareAndSet(ctx, 1, entry{ID: 1, Data: "b"})).To(Succeed())
+			Expect(v.CompareAndSet(ctx, 1, entry{ID: 1, Data: "c"})).
+				To(HaveOccurredAs(xkv.VersionMismatch))
+			e, _ := MustSucceed2(v.Get(ctx, 1))
+			Expect(e.Data).To(Equal("b"))
+		})
+	})
+
+	Describe("Get", func() {
+		It("Should return query.NotFound when the entry does not exist", func() {
+			_, _, err := v.Get(ctx, 42)
+			Expect(err).To(HaveOccurredAs(query.NotFound))
+		})
+	})
+
+	Describe("Update", func() {
+		It("Should apply concurrent updates without losing any of them", func() {
+			Expect(v.CompareAndSet(ctx, 0, entry{ID: 1})).To(Succeed())
+			var wg sync.WaitGroup
+			for range 10 {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					Expect(v.Update(ctx, 1, func(e entry) (entry, error) {
+						e.Data += "x"
+						return e, nil
+					})).To(Succeed())
+				}()
+			}
+			wg.Wait()
+			e, _ := MustSucceed2(v.Get(ctx, 1))
+			Expect(e.Data).To(HaveLen(10))
+		})
+	})
+
+	It("Should return ErrNotVersioned when the store does not support it", func() {
+		plain := gorp.Wrap(memkv.New())
+		_, _, err := gorp.WrapVersioned[int, entry](plain).Get(ctx, 1)
+		Expect(err).To(HaveOccurredAs(gorp.ErrNotVersioned))
+		Expect(plain.Close()).To(Succeed())
+	})
+})
@@ -24,11 +24,16 @@ import (
 	"github.com/synnaxlabs/x/errors"
 	"github.com/synnaxlabs/x/iter"
 	"github.com/synnaxlabs/x/observe"
+	"github.com/synnaxlabs/x/version"
 )
 
 // NotFound is returned when a key is not found in the DB.
 var NotFound = errors.New("[kv] - not found")
 
+// VersionMismatch is returned when a conditional write is rejected because the
+// current version of a key does not match the version expected by the caller.
+var VersionMismatch = errors.New("[kv] - version mismatch")
+
 // Reader is a readable key-value store.
 type Reader interface {
 	// Get returns the value for the given key.
@@ -94,6 +99,28 @@ type DB interface {
 	io.Closer
 }
 
+// Versioned is a key-value store that can serve linearizable reads and conditional
+// writes on individual keys. Implementations that replicate data across multiple
+// nodes route these operations to the node responsible for the key, so they are
+// slower than their eventually consistent counterparts in Reader and Writer.
+type Versioned interface {
+	// GetVersioned returns the value for the given key along with its current
+	// version. The returned value is a copy and is safe to modify. If the key does
+	// not exist, GetVersioned returns NotFound.
+	GetVersioned(ctx context.Context, key []byte, opts ...any) ([]byte, version.Counter, error)
+	// CompareAndSet atomically sets the value for the given key if and only if its
+	// current version is equal to expected. An expected version of zero requires
+	// that the key does not exist. If the versions do not match, CompareAndSet
+	// returns VersionMismatch and leaves the key untouched.
+	CompareAndSet(
+		ctx context.Context,
+		key []byte,
+		expected version.Counter,
+		value []byte,
+		opts ...any,
+	) error
+}
+
 // Change represents a change to a key-value pair. The contents of Name and Value
 // should be considered read-only, and modifications to them may cause unexpected
 // behavior.
@@ -84,9 +84,14 @@ func (d db) Commit(ctx context.Context, opts ...any) error { return nil }
 // NewReader implement kv.DB.
 func (d db) NewReader() kv.TxReader { return d.OpenTx().NewReader() }
 
-// Set implement kv.DB.
-func (d db) Set(_ context.Context, key, value []byte, opts ...any) error {
-	return translateError(d.DB.Set(key, value, parseOpts(opts)))
+// Set implement kv.DB. The write is applied as a single operation transaction so that
+// observers are notified of it.
+func (d db) Set(ctx context.Context, key, value []byte, opts ...any) error {
+	txn := &tx{Batch: d.DB.NewBatch(), db: d}
+	if err := txn.Batch.Set(key, value, nil); err != nil {
+		return errors.Combine(translateError(err), txn.Close())
+	}
+	return d.applyWithOpts(ctx, txn, parseOpts(opts))
 }
 
 // Get implement kv.DB.
@@ -95,9 +100,14 @@ func (d db) Get(_ context.Context, key []byte, _ ...any) ([]byte, io.Closer, err
 	return b, c, translateError(err)
 }
 
-// Delete implement kv.DB.
+// Delete implement kv.DB. The delete is applied as a single operation transaction so
+// that observers are notified of it.
 func (d db) Delete(ctx context.Context, key []byte, opts ...any) error {
-	return translateError(d.DB.Delete(key, parseOpts(opts)))
+	txn := &tx{Batch: d.DB.NewBatch(), db: d}
+	if err := txn.Batch.Delete(key, nil); err != nil {
+		return errors.Combine(translateError(err), txn.Close())
+	}
+	return d.applyWithOpts(ctx, txn, parseOpts(opts))
 }
 
 // OpenIterator implement kv.DB.
@@ -106,7 +116,12 @@ func (d db) OpenIterator(opts kv.IteratorOptions) (kv.Iterator, error) {
 }
 
 func (d db) apply(ctx context.Context, txn *tx) error {
-	err := d.DB.Apply(txn.Batch, nil)
+	return d.applyWithOpts(ctx, txn, nil)
+}
+
+func (d db) applyWithOpts(ctx context.Context, txn *tx, opts *pebble.WriteOptions) error {
+	txn.committed = true
+	err := d.DB.Apply(txn.Batch, opts)
 	if err != nil {
 		return translateError(err)
 	}
@@ -162,7 +177,6 @@ func (txn *tx) OpenIterator(opts kv.IteratorOptions) (kv.Iterator, error) {
 
 // Commit implements kv.Writer.
 func (txn *tx) Commit(ctx context.Context, opts ...any) error {
-	txn.committed = true
 	return txn.db.apply(ctx, txn)
 }
 
@@ -166,6 +166,24 @@ var _ = Describe("Pebblekv", Ordered, func() {
 		Expect(tx.Close()).To(Succeed())
 	})
 
+	It("Should notify observers of writes made directly to the DB", func() {
+		var changes []kv.Change
+		disconnect := db.OnChange(func(ctx context.Context, reader kv.TxReader) {
+			for ch, ok := reader.Next(ctx); ok; ch, ok = reader.Next(ctx) {
+				changes = append(changes, ch)
+			}
+		})
+		defer disconnect()
+		Expect(db.Set(ctx, []byte("observed"), []byte("value"))).To(Succeed())
+		Expect(db.Delete(ctx, []byte("observed"))).To(Succeed())
+		Expect(changes).To(HaveLen(2))
+		Expect(changes[0].Variant).To(Equal(change.Set))
+		Expect(changes[0].Key).To(Equal([]byte("observed")))
+		Expect(changes[0].Value).To(Equal([]byte("value")))
+		Expect(changes[1].Variant).To(Equal(change.Delete))
+		Expect(changes[1].Key).To(Equal([]byte("observed")))
+	})
+
 	It("Should handle iterator bounds correctly", func() {
 		for i := byte(0); i < 5; i++ {
 			key := []byte{i}
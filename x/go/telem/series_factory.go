@@ -226,16 +226,16 @@ func MarshalF[T Sample](dt DataType) func(b []byte, v T) {
 }
 
 // UnmarshalInt8 unmarshals an 8-bit signed integer from a byte slice.
-func UnmarshalInt8[T Sample](b []byte) T { return T(b[0]) }
+func UnmarshalInt8[T Sample](b []byte) T { return T(int8(b[0])) }
 
 // UnmarshalInt16 unmarshals a 16-bit signed integer from a byte slice.
-func UnmarshalInt16[T Sample](b []byte) T { return T(ByteOrder.Uint16(b)) }
+func UnmarshalInt16[T Sample](b []byte) T { return T(int16(ByteOrder.Uint16(b))) }
 
 // UnmarshalInt32 unmarshals a 32-bit signed integer from a byte slice.
-func UnmarshalInt32[T Sample](b []byte) T { return T(ByteOrder.Uint32(b)) }
+func UnmarshalInt32[T Sample](b []byte) T { return T(int32(ByteOrder.Uint32(b))) }
 
 // UnmarshalInt64 unmarshals a 64-bit signed integer from a byte slice.
-func UnmarshalInt64[T Sample](b []byte) T { return T(ByteOrder.Uint64(b)) }
+func UnmarshalInt64[T Sample](b []byte) T { return T(int64(ByteOrder.Uint64(b))) }
 
 // UnmarshalUint8 unmarshals an 8-bit unsigned integer from a byte slice.
 func UnmarshalUint8[T Sample](b []byte) T { return T(b[0]) }
@@ -190,6 +190,13 @@ var _ = Describe("Series", func() {
 				marshalF(b, 12)
 				Expect(unmarshalF(b)).To(Equal(int8(12)))
 			})
+			Specify("Signed to Float64", func() {
+				for _, dt := range []telem.DataType{telem.Int64T, telem.Int32T, telem.Int16T, telem.Int8T} {
+					b := make([]byte, dt.Density())
+					telem.MarshalF[int64](dt)(b, -12)
+					Expect(telem.UnmarshalF[float64](dt)(b)).To(Equal(float64(-12)))
+				}
+			})
 			Specify("Uint8", func() {
 				dt := telem.Uint8T
 				marshalF, unmarshalF := telem.MarshalF[uint8](dt), telem.UnmarshalF[uint8](dt)